@@ -15,6 +15,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -39,24 +40,57 @@ It analyzes PR quality using configurable heuristics and maintains a portable bl
 
 	// Global flags
 	var configPath string
+	var assumeYes, noColor bool
 	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "config.yaml", "Path to configuration file")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Automatically answer yes to confirmation prompts")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also disabled by NO_COLOR or when output is not a terminal)")
+	rootCmd.PersistentPreRun = func(_ *cobra.Command, _ []string) {
+		if noColor {
+			commands.DisableColor()
+		}
+	}
 
 	// Add commands
-	rootCmd.AddCommand(commands.NewInitCommand(&configPath))
+	rootCmd.AddCommand(commands.NewInitCommand(&configPath, &assumeYes))
+	rootCmd.AddCommand(commands.NewConfigCommand(&configPath))
 	rootCmd.AddCommand(commands.NewMigrateCommand(&configPath))
-	rootCmd.AddCommand(commands.NewScanCommand(&configPath))
-	rootCmd.AddCommand(commands.NewScanAllCommand(&configPath))
-	rootCmd.AddCommand(commands.NewBlockCommand(&configPath))
-	rootCmd.AddCommand(commands.NewUnblockCommand(&configPath))
+	rootCmd.AddCommand(commands.NewScanCommand(&configPath, &assumeYes))
+	rootCmd.AddCommand(commands.NewScanPRCommand(&configPath, &assumeYes))
+	rootCmd.AddCommand(commands.NewScanIssuesCommand(&configPath, &assumeYes))
+	rootCmd.AddCommand(commands.NewScanAllCommand(&configPath, &assumeYes))
+	rootCmd.AddCommand(commands.NewBlockCommand(&configPath, &assumeYes))
+	rootCmd.AddCommand(commands.NewUnblockCommand(&configPath, &assumeYes))
+	rootCmd.AddCommand(commands.NewUndoCommand(&configPath, &assumeYes))
+	rootCmd.AddCommand(commands.NewPurgeCommand(&configPath))
+	rootCmd.AddCommand(commands.NewPruneDuplicatesCommand(&configPath, &assumeYes))
 	rootCmd.AddCommand(commands.NewCheckCommand(&configPath))
 	rootCmd.AddCommand(commands.NewListCommand(&configPath))
-	rootCmd.AddCommand(commands.NewExportCommand(&configPath))
+	rootCmd.AddCommand(commands.NewSearchCommand(&configPath))
+	rootCmd.AddCommand(commands.NewCountCommand(&configPath))
+	rootCmd.AddCommand(commands.NewExportCommand(&configPath, &assumeYes))
 	rootCmd.AddCommand(commands.NewImportCommand(&configPath))
+	rootCmd.AddCommand(commands.NewSyncCommand(&configPath))
+	rootCmd.AddCommand(commands.NewDiffCommand(&configPath))
+	rootCmd.AddCommand(commands.NewMergeCommand())
 	rootCmd.AddCommand(commands.NewClosePRCommand(&configPath))
+	rootCmd.AddCommand(commands.NewReopenPRCommand(&configPath))
 	rootCmd.AddCommand(commands.NewReviewCommand(&configPath))
+	rootCmd.AddCommand(commands.NewReportCommand(&configPath))
+	rootCmd.AddCommand(commands.NewRateLimitCommand(&configPath))
+	rootCmd.AddCommand(commands.NewWhoamiCommand(&configPath))
+	rootCmd.AddCommand(commands.NewDoctorCommand(&configPath))
+	rootCmd.AddCommand(commands.NewHistoryCommand(&configPath))
+	rootCmd.AddCommand(commands.NewServeCommand(&configPath))
+	rootCmd.AddCommand(commands.NewAPICommand(&configPath))
+	rootCmd.AddCommand(commands.NewWatchCommand(&configPath, &assumeYes))
+	rootCmd.AddCommand(commands.NewCompletionCommand())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		var exitErr *commands.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
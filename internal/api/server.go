@@ -0,0 +1,192 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package api serves the blocklist and on-demand scans over HTTP as JSON.
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+// maxRequestBytes bounds the size of request bodies
+const maxRequestBytes = 1 << 20
+
+// healthTimeout bounds the database ping made by /healthz
+const healthTimeout = 2 * time.Second
+
+// Pinger reports whether a dependency such as the database is reachable
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Server handles API requests using the blocklist manager and scanner
+type Server struct {
+	blManager blocklist.BlocklistManager
+	db        Pinger
+	scanner   scanner.PRScanner
+	ghClient  github.GitHubClient
+	token     string
+}
+
+// ScanRequest is the body accepted by POST /scan
+type ScanRequest struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+}
+
+// CheckResponse is returned by GET /check/{username}
+type CheckResponse struct {
+	Username string `json:"username"`
+	Blocked  bool   `json:"blocked"`
+}
+
+// HealthResponse is returned by GET /healthz
+type HealthResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// errorResponse is the body of every non-2xx response
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewServer creates an API server. Mutating routes require token as a bearer
+// token and are refused when it is empty.
+func NewServer(blManager blocklist.BlocklistManager, db Pinger, scan scanner.PRScanner, ghClient github.GitHubClient, token string) *Server {
+	return &Server{
+		blManager: blManager,
+		db:        db,
+		scanner:   scan,
+		ghClient:  ghClient,
+		token:     token,
+	}
+}
+
+// Handler returns the routes served by the API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealthz)
+	mux.HandleFunc("GET /blocklist", s.handleBlocklist)
+	mux.HandleFunc("GET /blocklist/{username}", s.handleBlocklistUser)
+	mux.HandleFunc("GET /check/{username}", s.handleCheck)
+	mux.Handle("POST /scan", s.requireToken(http.HandlerFunc(s.handleScan)))
+	return mux
+}
+
+// requireToken rejects requests without the configured bearer token
+func (s *Server) requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.token == "" {
+			writeError(w, http.StatusForbidden, "this route is disabled until api.token is configured")
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleHealthz reports 200 when the database answers a ping and 503 otherwise
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthTimeout)
+	defer cancel()
+
+	if err := s.db.Ping(ctx); err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, HealthResponse{Status: "unavailable", Error: fmt.Sprintf("database: %v", err)})
+		return
+	}
+	writeJSON(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+func (s *Server) handleBlocklist(w http.ResponseWriter, _ *http.Request) {
+	entries, err := s.blManager.List()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list blocklist: %v", err))
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func (s *Server) handleBlocklistUser(w http.ResponseWriter, r *http.Request) {
+	username := r.PathValue("username")
+	entries, err := s.blManager.GetByUsername(username)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get entries: %v", err))
+		return
+	}
+	if len(entries) == 0 {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no blocklist entries for %s", username))
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	username := r.PathValue("username")
+	blocked, err := s.blManager.IsBlocked(username)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to check block status: %v", err))
+		return
+	}
+	writeJSON(w, http.StatusOK, CheckResponse{Username: username, Blocked: blocked})
+}
+
+func (s *Server) handleScan(w http.ResponseWriter, r *http.Request) {
+	var req ScanRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if req.Owner == "" || req.Repo == "" {
+		writeError(w, http.StatusBadRequest, "owner and repo are required")
+		return
+	}
+
+	results, err := s.scanner.ScanRepository(s.ghClient.WithContext(r.Context()), req.Owner, req.Repo)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Sprintf("failed to scan %s/%s: %v", req.Owner, req.Repo, err))
+		return
+	}
+	writeJSON(w, http.StatusOK, scanner.NewReport(req.Owner, req.Repo, results))
+}
+
+// writeJSON writes v as the JSON response body with the given status
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v) //nolint:errcheck
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
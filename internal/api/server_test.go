@@ -0,0 +1,256 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/internal/database"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/mocks"
+	"github.com/prguard/prguard/internal/scanner"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+const testToken = "secret-token"
+
+// setupTestServer returns a server backed by an in-memory blocklist with one
+// blocked user
+func setupTestServer(t *testing.T, scan scanner.PRScanner) *Server {
+	t.Helper()
+
+	s, _ := setupTestServerDB(t, scan)
+	return s
+}
+
+// setupTestServerDB is setupTestServer that also returns the database
+func setupTestServerDB(t *testing.T, scan scanner.PRScanner) (*Server, *database.DB) {
+	t.Helper()
+
+	db, err := database.NewSQLiteDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() }) //nolint:errcheck
+
+	manager := blocklist.NewManager(db)
+	if _, err := manager.Block(blocklist.BlockRequest{Username: "spammer", Reason: "Spam PRs", EvidenceURL: "https://github.com/org/repo/pull/1", BlockedBy: "maintainer", Severity: models.SeverityHigh, Source: models.SourceManual}); err != nil {
+		t.Fatalf("failed to block user: %v", err)
+	}
+
+	return NewServer(manager, db, scan, &mocks.MockGitHubClient{}, testToken), db
+}
+
+// serve sends a request to the server's handler and returns the recorder
+func serve(s *Server, method, path, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var v T
+	if err := json.NewDecoder(rec.Body).Decode(&v); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return v
+}
+
+func TestHealthz(t *testing.T) {
+	s, db := setupTestServerDB(t, &mocks.MockScanner{})
+
+	rec := serve(s, "GET", "/healthz", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if resp := decode[HealthResponse](t, rec); resp.Status != "ok" {
+		t.Errorf("status = %q, want ok", resp.Status)
+	}
+
+	if err := db.Close(); err != nil {
+		t.Fatalf("failed to close database: %v", err)
+	}
+
+	rec = serve(s, "GET", "/healthz", "", "")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("closed database: status = %d, want 503", rec.Code)
+	}
+	resp := decode[HealthResponse](t, rec)
+	if resp.Status != "unavailable" || !strings.Contains(resp.Error, "database") {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestBlocklist(t *testing.T) {
+	s := setupTestServer(t, &mocks.MockScanner{})
+
+	rec := serve(s, "GET", "/blocklist", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	entries := decode[[]*models.BlocklistEntry](t, rec)
+	if len(entries) != 1 || entries[0].Username != "spammer" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestBlocklistUser(t *testing.T) {
+	s := setupTestServer(t, &mocks.MockScanner{})
+
+	rec := serve(s, "GET", "/blocklist/Spammer", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	entries := decode[[]*models.BlocklistEntry](t, rec)
+	if len(entries) != 1 || entries[0].Reason != "Spam PRs" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+
+	rec = serve(s, "GET", "/blocklist/someone-else", "", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+	if resp := decode[errorResponse](t, rec); resp.Error == "" {
+		t.Error("expected an error message")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	s := setupTestServer(t, &mocks.MockScanner{})
+
+	for username, want := range map[string]bool{"spammer": true, "contributor": false} {
+		rec := serve(s, "GET", "/check/"+username, "", "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", rec.Code)
+		}
+		resp := decode[CheckResponse](t, rec)
+		if resp.Username != username || resp.Blocked != want {
+			t.Errorf("check %s = %+v, want blocked=%v", username, resp, want)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	var scanned string
+	scan := &mocks.MockScanner{
+		ScanRepositoryFn: func(_ github.GitHubClient, owner, repo string) (*scanner.ScanResults, error) {
+			scanned = owner + "/" + repo
+			return &scanner.ScanResults{
+				Total: 1,
+				Spam: []*scanner.ScanResult{{
+					PR:      &github.PullRequest{Number: 7, Author: "spammer"},
+					IsSpam:  true,
+					Reasons: []string{"Contains spam phrases"},
+				}},
+			}, nil
+		},
+	}
+	s := setupTestServer(t, scan)
+
+	rec := serve(s, "POST", "/scan", `{"owner": "org", "repo": "app"}`, testToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	if scanned != "org/app" {
+		t.Errorf("scanned %q, want org/app", scanned)
+	}
+	report := decode[scanner.Report](t, rec)
+	if report.Repository != "org/app" || report.SpamCount != 1 || len(report.PullRequests) != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if report.PullRequests[0].Number != 7 {
+		t.Errorf("unexpected PR: %+v", report.PullRequests[0])
+	}
+}
+
+func TestScan_RequiresToken(t *testing.T) {
+	scanCalled := false
+	scan := &mocks.MockScanner{
+		ScanRepositoryFn: func(_ github.GitHubClient, _, _ string) (*scanner.ScanResults, error) {
+			scanCalled = true
+			return &scanner.ScanResults{}, nil
+		},
+	}
+	s := setupTestServer(t, scan)
+	body := `{"owner": "org", "repo": "app"}`
+
+	if rec := serve(s, "POST", "/scan", body, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("no token: status = %d, want 401", rec.Code)
+	}
+	if rec := serve(s, "POST", "/scan", body, "wrong"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("wrong token: status = %d, want 401", rec.Code)
+	}
+
+	s.token = ""
+	if rec := serve(s, "POST", "/scan", body, testToken); rec.Code != http.StatusForbidden {
+		t.Errorf("unconfigured token: status = %d, want 403", rec.Code)
+	}
+
+	if scanCalled {
+		t.Error("scan should not run without a valid token")
+	}
+}
+
+func TestScan_BadRequests(t *testing.T) {
+	scan := &mocks.MockScanner{
+		ScanRepositoryFn: func(_ github.GitHubClient, _, _ string) (*scanner.ScanResults, error) {
+			return nil, errors.New("repository not found")
+		},
+	}
+	s := setupTestServer(t, scan)
+
+	tests := []struct {
+		body string
+		want int
+	}{
+		{`not json`, http.StatusBadRequest},
+		{`{"owner": "org"}`, http.StatusBadRequest},
+		{`{"owner": "org", "repo": "app", "extra": true}`, http.StatusBadRequest},
+		{`{"owner": "org", "repo": "missing"}`, http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		rec := serve(s, "POST", "/scan", tt.body, testToken)
+		if rec.Code != tt.want {
+			t.Errorf("body %s: status = %d, want %d", tt.body, rec.Code, tt.want)
+		}
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	s := setupTestServer(t, &mocks.MockScanner{})
+
+	if rec := serve(s, "GET", "/scan", "", testToken); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /scan: status = %d, want 405", rec.Code)
+	}
+	if rec := serve(s, "DELETE", "/blocklist/spammer", "", testToken); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE /blocklist/spammer: status = %d, want 405", rec.Code)
+	}
+}
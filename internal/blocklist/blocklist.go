@@ -18,33 +18,209 @@ package blocklist
 import (
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/prguard/prguard/internal/database"
+	"github.com/prguard/prguard/internal/github"
 	"github.com/prguard/prguard/pkg/models"
+	"gopkg.in/yaml.v3"
 )
 
+// ErrNotModified is returned by ImportJSONFromURL when the remote list is
+// unchanged since the last import
+var ErrNotModified = errors.New("blocklist not modified since last import")
+
+// ErrEntryNotFound is returned by RemoveByID when no entry has the given ID
+var ErrEntryNotFound = errors.New("blocklist entry not found")
+
+// ErrResponseTooLarge is returned when a remote blocklist exceeds the maximum fetch size
+var ErrResponseTooLarge = errors.New("blocklist response too large")
+
+// Limits on fetching remote blocklists
+const (
+	DefaultFetchTimeout = 30 * time.Second
+	DefaultMaxFetchSize = 50 << 20 // 50 MiB
+)
+
+// defaultFetchClient is used by FetchJSON; its transport pools connections across fetches
+var defaultFetchClient = &http.Client{Timeout: DefaultFetchTimeout}
+
 // Manager handles blocklist operations
 type Manager struct {
-	db *database.DB
+	db           *database.DB
+	client       *http.Client // Reused for every remote import so connections are pooled
+	maxFetchSize int64        // Largest remote blocklist accepted, in bytes
 }
 
 // NewManager creates a new blocklist manager
 func NewManager(db *database.DB) *Manager {
-	return &Manager{db: db}
+	return &Manager{
+		db:           db,
+		client:       &http.Client{Timeout: DefaultFetchTimeout},
+		maxFetchSize: DefaultMaxFetchSize,
+	}
+}
+
+// SetFetchTimeout bounds each remote blocklist request, including reading the
+// body; zero or less keeps the default
+func (m *Manager) SetFetchTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		m.client.Timeout = timeout
+	}
+}
+
+// SetMaxFetchSize limits how many bytes a remote blocklist may have; zero or
+// less keeps the default
+func (m *Manager) SetMaxFetchSize(size int64) {
+	if size > 0 {
+		m.maxFetchSize = size
+	}
+}
+
+// BlockRequest describes a user to add to the blocklist
+type BlockRequest struct {
+	Username    string
+	Reason      string
+	EvidenceURL string
+	BlockedBy   string
+	Severity    string
+	Source      string
+	ExpiresAt   *time.Time // nil blocks permanently
+	Tags        []string   // Normalized before they are stored
+	Signals     []string   // Scanner signal codes recorded in the entry metadata
+
+	// Escalate raises the severity of repeat offenders: a user's second offense
+	// is at least medium and any later one is high
+	Escalate bool
+
+	// Snapshot, when set, is used to record the account's creation date,
+	// follower count and public repo count in the entry metadata. If the
+	// account can't be fetched the user is still blocked, just without one.
+	Snapshot github.GitHubClient
+
+	// UpdateExisting updates the user's entry with the same evidence URL, if
+	// there is one, instead of adding a duplicate. With Escalate set an update
+	// never lowers the existing severity.
+	UpdateExisting bool
 }
 
-// Block adds a user to the blocklist
-func (m *Manager) Block(username, reason, evidenceURL, blockedBy, severity, source string) (*models.BlocklistEntry, error) {
-	entry := models.NewBlocklistEntry(username, reason, evidenceURL, blockedBy, severity, source)
+// BlockResult reports the outcome of Block
+type BlockResult struct {
+	Entry   *models.BlocklistEntry
+	Offense int  // Which offense this is for the user when escalating; 0 otherwise and for updates
+	Updated bool // An existing entry was updated instead of adding one
+}
+
+// Block adds a user to the blocklist as described by req
+func (m *Manager) Block(req BlockRequest) (*BlockResult, error) {
+	if req.UpdateExisting {
+		existing, err := m.db.GetEntryByEvidence(req.Username, req.EvidenceURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up existing entry: %w", err)
+		}
+		if existing != nil {
+			return m.updateEntry(existing, req)
+		}
+	}
+
+	offense := 0
+	severity := req.Severity
+	if req.Escalate {
+		previous, err := m.db.CountOffenses(req.Username)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count offenses: %w", err)
+		}
+		offense = previous + 1
+		severity = escalatedSeverity(severity, offense)
+	}
+
+	entry := models.NewBlocklistEntry(req.Username, req.Reason, req.EvidenceURL, req.BlockedBy, severity, req.Source)
+	entry.ExpiresAt = req.ExpiresAt
+	entry.Tags = models.NormalizeTags(req.Tags)
+	metadata := models.EntryMetadata{Signals: req.Signals, Account: accountSnapshot(req.Snapshot, req.Username)}
+	if len(metadata.Signals) > 0 || metadata.Account != nil {
+		encoded, err := json.Marshal(metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode metadata: %w", err)
+		}
+		entry.Metadata = string(encoded)
+	}
 	if err := m.db.AddEntry(entry); err != nil {
 		return nil, fmt.Errorf("failed to add blocklist entry: %w", err)
 	}
-	return entry, nil
+	return &BlockResult{Entry: entry, Offense: offense}, nil
+}
+
+// updateEntry applies req's reason, severity, expiry and tags to an existing entry
+func (m *Manager) updateEntry(existing *models.BlocklistEntry, req BlockRequest) (*BlockResult, error) {
+	if !req.Escalate || models.SeverityRank(req.Severity) > models.SeverityRank(existing.Severity) {
+		existing.Severity = req.Severity
+	}
+	existing.Reason = req.Reason
+	existing.ExpiresAt = req.ExpiresAt
+	existing.Tags = models.NormalizeTags(append(existing.Tags, req.Tags...))
+	if err := m.db.UpdateEntry(existing); err != nil {
+		return nil, fmt.Errorf("failed to update blocklist entry: %w", err)
+	}
+	return &BlockResult{Entry: existing, Updated: true}, nil
+}
+
+// accountSnapshot fetches username's account details, or returns nil when
+// ghClient is nil or the lookup fails
+func accountSnapshot(ghClient github.GitHubClient, username string) *models.AccountSnapshot {
+	if ghClient == nil {
+		return nil
+	}
+	user, err := ghClient.GetUser(username)
+	if err != nil || user == nil {
+		return nil
+	}
+	return &models.AccountSnapshot{
+		CreatedAt:   user.CreatedAt.UTC(),
+		Followers:   user.Followers,
+		PublicRepos: user.PublicRepos,
+		CapturedAt:  time.Now().UTC(),
+	}
+}
+
+// escalatedSeverity raises severity to the minimum for the given offense number
+func escalatedSeverity(severity string, offense int) string {
+	floor := models.SeverityLow
+	switch {
+	case offense >= 3:
+		floor = models.SeverityHigh
+	case offense == 2:
+		floor = models.SeverityMedium
+	}
+	if models.SeverityRank(floor) > models.SeverityRank(severity) {
+		return floor
+	}
+	return severity
+}
+
+// BlockMany adds several users to the blocklist in one transaction with the
+// details in req; its Username is ignored. Users that are already blocked are skipped.
+func (m *Manager) BlockMany(usernames []string, req BlockRequest) (added, skipped int, err error) {
+	tags := models.NormalizeTags(req.Tags)
+	entries := make([]*models.BlocklistEntry, 0, len(usernames))
+	for _, username := range usernames {
+		entry := models.NewBlocklistEntry(username, req.Reason, req.EvidenceURL, req.BlockedBy, req.Severity, req.Source)
+		entry.ExpiresAt = req.ExpiresAt
+		entry.Tags = tags
+		entries = append(entries, entry)
+	}
+
+	added, err = m.db.AddEntries(entries)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to add blocklist entries: %w", err)
+	}
+	return added, len(usernames) - added, nil
 }
 
 // Unblock removes a user from the blocklist
@@ -52,43 +228,150 @@ func (m *Manager) Unblock(username string) error {
 	return m.db.RemoveByUsername(username)
 }
 
+// RemoveByID removes a single blocklist entry, leaving the user's other
+// entries in place, and returns the removed entry
+func (m *Manager) RemoveByID(id string) (*models.BlocklistEntry, error) {
+	entry, err := m.db.GetEntry(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entry: %w", err)
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEntryNotFound, id)
+	}
+	if err := m.db.RemoveEntry(id); err != nil {
+		return nil, fmt.Errorf("failed to remove entry: %w", err)
+	}
+	return entry, nil
+}
+
 // IsBlocked checks if a user is blocked
 func (m *Manager) IsBlocked(username string) (bool, error) {
 	return m.db.IsBlocked(username)
 }
 
+// MarkGitHubBlocked records that a user was blocked via the GitHub API
+func (m *Manager) MarkGitHubBlocked(username string) error {
+	return m.db.MarkGitHubBlocked(username)
+}
+
+// IsGitHubBlocked checks if a user was already blocked via the GitHub API
+func (m *Manager) IsGitHubBlocked(username string) (bool, error) {
+	return m.db.IsGitHubBlocked(username)
+}
+
+// PurgeExpired removes all expired blocklist entries and returns the number removed
+func (m *Manager) PurgeExpired() (int64, error) {
+	return m.db.PurgeExpired()
+}
+
+// FindDuplicateUsernames returns the usernames with more than one blocklist entry
+func (m *Manager) FindDuplicateUsernames() ([]string, error) {
+	return m.db.FindDuplicateUsernames()
+}
+
+// PruneDuplicates keeps one entry per username and returns the number removed
+func (m *Manager) PruneDuplicates() (int64, error) {
+	return m.db.PruneDuplicates()
+}
+
 // List returns all blocklist entries
 func (m *Manager) List() ([]*models.BlocklistEntry, error) {
 	return m.db.ListEntries()
 }
 
+// ListFiltered returns the blocklist entries matching filter
+func (m *Manager) ListFiltered(filter models.EntryFilter) ([]*models.BlocklistEntry, error) {
+	return m.db.ListEntriesFiltered(filter)
+}
+
+// ListPaged returns the page of entries matching filter given by its Limit and
+// Offset, and the total number of entries matching it
+func (m *Manager) ListPaged(filter models.EntryFilter) ([]*models.BlocklistEntry, int, error) {
+	return m.db.ListEntriesPaged(filter)
+}
+
+// ListSorted returns every entry ordered by sortField, optionally reversed
+func (m *Manager) ListSorted(sortField string, reverse bool) ([]*models.BlocklistEntry, error) {
+	return m.db.ListEntriesSorted(sortField, reverse)
+}
+
+// ListByTag returns every entry carrying tag, newest first
+func (m *Manager) ListByTag(tag string) ([]*models.BlocklistEntry, error) {
+	return m.db.GetEntriesByTag(strings.ToLower(strings.TrimSpace(tag)))
+}
+
+// Count returns the number of entries and distinct usernames, optionally within a severity
+func (m *Manager) Count(severity string) (entries, users int, err error) {
+	entries, err = m.db.CountEntries(severity)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count entries: %w", err)
+	}
+	users, err = m.db.CountUniqueUsernames(severity)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return entries, users, nil
+}
+
+// Search returns entries matching term, optionally restricted to a field and severity
+func (m *Manager) Search(term, field, severity string) ([]*models.BlocklistEntry, error) {
+	return m.db.SearchEntries(term, field, severity)
+}
+
 // GetByUsername returns all blocklist entries for a specific user
 func (m *Manager) GetByUsername(username string) ([]*models.BlocklistEntry, error) {
 	return m.db.GetEntriesByUsername(username)
 }
 
-// ExportJSON exports the blocklist to a JSON file
-func (m *Manager) ExportJSON(path string) error {
-	entries, err := m.db.ListEntries()
+// ExportJSON exports blocklist entries matching filter to a JSON file; the
+// zero filter exports every entry
+func (m *Manager) ExportJSON(path string, filter models.EntryFilter) error {
+	data, err := m.entriesJSON(filter)
 	if err != nil {
-		return fmt.Errorf("failed to get entries: %w", err)
+		return err
 	}
 
-	data, err := json.MarshalIndent(entries, "", "  ")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// entriesJSON returns the entries matching filter as indented JSON
+func (m *Manager) entriesJSON(filter models.EntryFilter) ([]byte, error) {
+	entries, err := m.db.ListEntriesFiltered(filter)
 	if err != nil {
-		return fmt.Errorf("failed to marshal JSON: %w", err)
+		return nil, fmt.Errorf("failed to get entries: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0600); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
 	}
+	return data, nil
+}
 
+// ExportJSONL streams entries matching filter to w as JSON Lines, one compact
+// entry per line. Entries are encoded as they are read so large blocklists are
+// never held in memory.
+func (m *Manager) ExportJSONL(w io.Writer, filter models.EntryFilter) error {
+	encoder := json.NewEncoder(w)
+	err := m.db.EachEntryFiltered(filter, func(entry *models.BlocklistEntry) error {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to write entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export entries: %w", err)
+	}
 	return nil
 }
 
-// ExportCSV exports the blocklist to a CSV file
-func (m *Manager) ExportCSV(path string) error {
-	entries, err := m.db.ListEntries()
+// ExportCSV exports blocklist entries matching filter to a CSV file
+func (m *Manager) ExportCSV(path string, filter models.EntryFilter) error {
+	entries, err := m.db.ListEntriesFiltered(filter)
 	if err != nil {
 		return fmt.Errorf("failed to get entries: %w", err)
 	}
@@ -99,11 +382,18 @@ func (m *Manager) ExportCSV(path string) error {
 	}
 	defer file.Close() //nolint:errcheck
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	return WriteCSV(file, entries)
+}
+
+// csvHeader lists the columns written by WriteCSV and read by ReadCSV
+var csvHeader = []string{"ID", "Username", "Reason", "EvidenceURL", "Timestamp", "BlockedBy", "Severity", "Source", "ExpiresAt", "Tags"}
+
+// WriteCSV writes entries as CSV, including a header row
+func WriteCSV(w io.Writer, entries []*models.BlocklistEntry) error {
+	writer := csv.NewWriter(w)
 
 	// Write header
-	if err := writer.Write([]string{"ID", "Username", "Reason", "EvidenceURL", "Timestamp", "BlockedBy", "Severity", "Source"}); err != nil {
+	if err := writer.Write(csvHeader); err != nil {
 		return fmt.Errorf("failed to write header: %w", err)
 	}
 
@@ -118,94 +408,355 @@ func (m *Manager) ExportCSV(path string) error {
 			entry.BlockedBy,
 			entry.Severity,
 			entry.Source,
+			"",
+			strings.Join(entry.Tags, ";"),
+		}
+		if entry.ExpiresAt != nil {
+			record[8] = entry.ExpiresAt.Format(time.RFC3339)
 		}
 		if err := writer.Write(record); err != nil {
 			return fmt.Errorf("failed to write record: %w", err)
 		}
 	}
 
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportYAML exports blocklist entries matching filter to a YAML file
+func (m *Manager) ExportYAML(path string, filter models.EntryFilter) error {
+	entries, err := m.db.ListEntriesFiltered(filter)
+	if err != nil {
+		return fmt.Errorf("failed to get entries: %w", err)
+	}
+
+	data, err := yaml.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
 	return nil
 }
 
 // ImportJSON imports blocklist entries from a JSON file
 func (m *Manager) ImportJSON(path string) (int, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // user-specified import path
+	entries, err := LoadJSON(path)
 	if err != nil {
-		return 0, fmt.Errorf("failed to read file: %w", err)
+		return 0, err
 	}
+	return m.importEntries(entries)
+}
 
-	var entries []*models.BlocklistEntry
-	if err := json.Unmarshal(data, &entries); err != nil {
-		return 0, fmt.Errorf("failed to unmarshal JSON: %w", err)
+// ImportYAML imports blocklist entries from a YAML file
+func (m *Manager) ImportYAML(path string) (int, error) {
+	entries, err := LoadYAML(path)
+	if err != nil {
+		return 0, err
 	}
+	return m.importEntries(entries)
+}
 
+// ImportCSV imports blocklist entries from a CSV file in the layout ExportCSV writes
+func (m *Manager) ImportCSV(path string) (int, error) {
+	entries, err := LoadCSV(path)
+	if err != nil {
+		return 0, err
+	}
 	return m.importEntries(entries)
 }
 
-// ImportJSONFromURL imports blocklist entries from a remote JSON URL
+// ImportJSONFromURL imports blocklist entries from a remote JSON URL. The
+// request is conditional on the ETag and Last-Modified values from the last
+// import; ErrNotModified is returned when the list has not changed since.
 func (m *Manager) ImportJSONFromURL(url string) (int, error) {
-	resp, err := http.Get(url) //nolint:gosec // user-configured blocklist URL
+	cache, err := m.db.GetSourceCache(url)
 	if err != nil {
-		return 0, fmt.Errorf("failed to fetch URL: %w", err)
+		return 0, fmt.Errorf("failed to read source cache: %w", err)
 	}
-	defer resp.Body.Close() //nolint:errcheck
 
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("HTTP error: %s", resp.Status)
+	entries, cache, err := fetchJSON(m.client, m.maxFetchSize, url, cache)
+	if err != nil {
+		return 0, err
 	}
 
-	data, err := io.ReadAll(resp.Body)
+	imported, err := m.importEntries(entries)
+	if err != nil {
+		return imported, err
+	}
+
+	// Only remember validators once the import has succeeded so a failed
+	// import is retried in full
+	if cache.ETag != "" || cache.LastModified != "" {
+		if err := m.db.SaveSourceCache(cache); err != nil {
+			return imported, fmt.Errorf("failed to save source cache: %w", err)
+		}
+	}
+	return imported, nil
+}
+
+// LoadJSON reads blocklist entries from a JSON file, either a plain array or a
+// registry envelope, without importing them
+func LoadJSON(path string) ([]*models.BlocklistEntry, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-specified import path
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return parseEntriesJSON(data)
+}
+
+// LoadYAML reads blocklist entries from a YAML file without importing them
+func LoadYAML(path string) ([]*models.BlocklistEntry, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-specified import path
 	if err != nil {
-		return 0, fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
 	var entries []*models.BlocklistEntry
-	if err := json.Unmarshal(data, &entries); err != nil {
-		return 0, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
 	}
+	return entries, nil
+}
 
-	return m.importEntries(entries)
+// LoadCSV reads blocklist entries from a CSV file without importing them
+func LoadCSV(path string) ([]*models.BlocklistEntry, error) {
+	file, err := os.Open(path) //nolint:gosec // user-specified import path
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	return ReadCSV(file)
 }
 
-// importEntries imports a slice of entries with deduplication
-func (m *Manager) importEntries(entries []*models.BlocklistEntry) (int, error) {
-	imported := 0
-	for _, entry := range entries {
-		// Check if entry already exists by ID
-		existing, err := m.db.GetEntry(entry.ID)
+// ReadCSV reads entries written by WriteCSV. Columns are matched by header
+// name, ignoring case, so they may be reordered or omitted apart from
+// Username. Entries without an ID get a new one, entries without a timestamp
+// are stamped with the current time, and without a Severity column entries are
+// medium severity. Values are not validated here; a blank or unknown severity
+// in a Severity column is rejected on import.
+func ReadCSV(r io.Reader) ([]*models.BlocklistEntry, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["username"]; !ok {
+		return nil, fmt.Errorf("missing Username column")
+	}
+
+	var entries []*models.BlocklistEntry
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
-			return imported, fmt.Errorf("failed to check for existing entry: %w", err)
+			return nil, fmt.Errorf("failed to read record: %w", err)
 		}
+		line, _ := reader.FieldPos(0)
 
-		if existing != nil {
-			// Entry exists, skip or update based on severity
-			if shouldUpdate(existing, entry) {
-				if err := m.db.UpdateEntry(entry); err != nil {
-					return imported, fmt.Errorf("failed to update entry: %w", err)
-				}
-				imported++
+		field := func(name string) string {
+			if i, ok := columns[strings.ToLower(name)]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		severity := models.SeverityMedium
+		if _, ok := columns["severity"]; ok {
+			severity = field("Severity")
+		}
+		entry := models.NewBlocklistEntry(field("Username"), field("Reason"), field("EvidenceURL"), field("BlockedBy"), severity, field("Source"))
+		if id := field("ID"); id != "" {
+			entry.ID = id
+		}
+		if value := field("Timestamp"); value != "" {
+			timestamp, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid timestamp %q: %w", line, value, err)
 			}
+			entry.Timestamp = timestamp
+		}
+		if value := field("ExpiresAt"); value != "" {
+			expiresAt, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid expiry %q: %w", line, value, err)
+			}
+			entry.ExpiresAt = &expiresAt
+		}
+		if value := field("Tags"); value != "" {
+			entry.Tags = models.NormalizeTags(strings.Split(value, ";"))
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// FetchJSON downloads blocklist entries from a remote JSON URL without
+// importing them, using the default timeout and size limit
+func FetchJSON(url string) ([]*models.BlocklistEntry, error) {
+	entries, _, err := fetchJSON(defaultFetchClient, DefaultMaxFetchSize, url, nil)
+	return entries, err
+}
+
+// fetchJSON downloads blocklist entries of at most maxSize bytes, sending
+// If-None-Match and If-Modified-Since from prev when set. It returns the
+// validators from the response, or ErrNotModified if the server answers 304.
+func fetchJSON(client *http.Client, maxSize int64, url string, prev *models.SourceCache) ([]*models.BlocklistEntry, *models.SourceCache, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if prev != nil {
+		if prev.ETag != "" {
+			req.Header.Set("If-None-Match", prev.ETag)
+		}
+		if prev.LastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.LastModified)
+		}
+	}
+
+	resp, err := client.Do(req) //nolint:gosec // user-configured blocklist URL
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, nil, ErrNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("HTTP error: %s", resp.Status)
+	}
+
+	if resp.ContentLength > maxSize {
+		return nil, nil, fmt.Errorf("%w: %d bytes exceeds the %d byte limit", ErrResponseTooLarge, resp.ContentLength, maxSize)
+	}
+	// Read one byte past the limit to detect bodies without a Content-Length that are too large
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, nil, fmt.Errorf("%w: exceeds the %d byte limit", ErrResponseTooLarge, maxSize)
+	}
+
+	entries, err := parseEntriesJSON(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cache := &models.SourceCache{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		FetchedAt:    time.Now(),
+	}
+	return entries, cache, nil
+}
+
+// Upgrade pairs a local entry with an incoming entry that would raise its severity on import
+type Upgrade struct {
+	Local    *models.BlocklistEntry
+	Incoming *models.BlocklistEntry
+}
+
+// Diff compares entries against the local blocklist using the same ID matching as
+// import. It returns entries an import would add, entries whose severity an import
+// would upgrade, and local entries absent from the given set. Nil entries are skipped.
+func (m *Manager) Diff(entries []*models.BlocklistEntry) (added []*models.BlocklistEntry, upgraded []Upgrade, localOnly []*models.BlocklistEntry, err error) {
+	local, err := m.db.ListEntries()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get entries: %w", err)
+	}
+
+	localByID := make(map[string]*models.BlocklistEntry, len(local))
+	for _, entry := range local {
+		localByID[entry.ID] = entry
+	}
+
+	incomingIDs := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if entry == nil {
 			continue
 		}
+		incomingIDs[entry.ID] = true
+		existing, ok := localByID[entry.ID]
+		switch {
+		case !ok:
+			added = append(added, entry)
+		case shouldUpdate(existing, entry):
+			upgraded = append(upgraded, Upgrade{Local: existing, Incoming: entry})
+		}
+	}
 
-		// Add new entry
-		entry.Source = models.SourceImported
-		if err := m.db.AddEntry(entry); err != nil {
-			return imported, fmt.Errorf("failed to add entry: %w", err)
+	for _, entry := range local {
+		if !incomingIDs[entry.ID] {
+			localOnly = append(localOnly, entry)
 		}
-		imported++
 	}
 
+	return added, upgraded, localOnly, nil
+}
+
+// importEntries validates entries and imports them with deduplication in a
+// single transaction, so nothing is written if any entry is rejected
+func (m *Manager) importEntries(entries []*models.BlocklistEntry) (int, error) {
+	if problems := ValidateEntries(entries); len(problems) > 0 {
+		return 0, &ValidationError{Problems: problems}
+	}
+
+	imported := 0
+	err := m.db.WithTransaction(func(tx *database.Tx) error {
+		for _, entry := range entries {
+			// Check if entry already exists by ID
+			existing, err := tx.GetEntry(entry.ID)
+			if err != nil {
+				return fmt.Errorf("failed to check for existing entry: %w", err)
+			}
+
+			if existing != nil {
+				// Entry exists, skip or update based on severity
+				if shouldUpdate(existing, entry) {
+					if err := tx.UpdateEntry(entry); err != nil {
+						return fmt.Errorf("failed to update entry: %w", err)
+					}
+					imported++
+				}
+				continue
+			}
+
+			// Add new entry
+			entry.Source = models.SourceImported
+			if err := tx.AddEntry(entry); err != nil {
+				return fmt.Errorf("failed to add entry for %s: %w", entry.Username, err)
+			}
+			imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
 	return imported, nil
 }
 
 // shouldUpdate determines if an existing entry should be updated with new data
 func shouldUpdate(existing, incoming *models.BlocklistEntry) bool {
 	// Update if new entry has higher severity
-	severityMap := map[string]int{
-		models.SeverityLow:    1,
-		models.SeverityMedium: 2,
-		models.SeverityHigh:   3,
-	}
-	return severityMap[incoming.Severity] > severityMap[existing.Severity]
+	return models.SeverityRank(incoming.Severity) > models.SeverityRank(existing.Severity)
 }
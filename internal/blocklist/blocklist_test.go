@@ -15,12 +15,20 @@
 package blocklist
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/prguard/prguard/internal/database"
+	"github.com/prguard/prguard/internal/github"
 	"github.com/prguard/prguard/pkg/models"
 )
 
@@ -36,18 +44,19 @@ func TestBlock(t *testing.T) {
 	manager, db := setupTestManager(t)
 	defer db.Close() //nolint:errcheck
 
-	entry, err := manager.Block(
-		"spammer",
-		"Multiple spam PRs",
-		"https://github.com/org/repo/pull/123",
-		"maintainer",
-		models.SeverityHigh,
-		models.SourceManual,
-	)
+	result, err := manager.Block(BlockRequest{
+		Username:    "spammer",
+		Reason:      "Multiple spam PRs",
+		EvidenceURL: "https://github.com/org/repo/pull/123",
+		BlockedBy:   "maintainer",
+		Severity:    models.SeverityHigh,
+		Source:      models.SourceManual,
+	})
 
 	if err != nil {
 		t.Fatalf("Block failed: %v", err)
 	}
+	entry := result.Entry
 
 	if entry.Username != "spammer" {
 		t.Errorf("Expected username 'spammer', got '%s'", entry.Username)
@@ -67,13 +76,44 @@ func TestBlock(t *testing.T) {
 	}
 }
 
+func TestRemoveByID(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	mistake, err := manager.Block(BlockRequest{Username: "user", Reason: "imported by mistake", EvidenceURL: "https://example.com/1", BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceImported})
+	if err != nil {
+		t.Fatalf("Block failed: %v", err)
+	}
+	legitimate, err := manager.Block(BlockRequest{Username: "user", Reason: "spam", EvidenceURL: "https://example.com/2", BlockedBy: "admin", Severity: models.SeverityHigh, Source: models.SourceManual})
+	if err != nil {
+		t.Fatalf("Block failed: %v", err)
+	}
+
+	removed, err := manager.RemoveByID(mistake.Entry.ID)
+	if err != nil {
+		t.Fatalf("RemoveByID failed: %v", err)
+	}
+	if removed.ID != mistake.Entry.ID || removed.Username != "user" {
+		t.Errorf("Expected the removed entry to be returned, got %+v", removed)
+	}
+
+	entries, _ := manager.GetByUsername("user")
+	if len(entries) != 1 || entries[0].ID != legitimate.Entry.ID {
+		t.Errorf("Expected only the legitimate entry to remain, got %d entries", len(entries))
+	}
+
+	if _, err := manager.RemoveByID(mistake.Entry.ID); !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("Expected ErrEntryNotFound for a removed entry, got %v", err)
+	}
+}
+
 func TestUnblock(t *testing.T) {
 	manager, db := setupTestManager(t)
 	defer db.Close() //nolint:errcheck
 
 	// Block a user
 	//nolint:errcheck
-	_, _ = manager.Block("toremove", "test", "https://example.com", "admin", models.SeverityLow, models.SourceManual)
+	_, _ = manager.Block(BlockRequest{Username: "toremove", Reason: "test", EvidenceURL: "https://example.com", BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual})
 
 	// Verify blocked
 	blocked, _ := manager.IsBlocked("toremove")
@@ -104,12 +144,12 @@ func TestExportJSON(t *testing.T) {
 
 	// Add test entries
 	//nolint:errcheck
-	_, _ = manager.Block("user1", "reason1", "https://example.com/1", "admin", models.SeverityHigh, models.SourceManual)
+	_, _ = manager.Block(BlockRequest{Username: "user1", Reason: "reason1", EvidenceURL: "https://example.com/1", BlockedBy: "admin", Severity: models.SeverityHigh, Source: models.SourceManual})
 	//nolint:errcheck
-	_, _ = manager.Block("user2", "reason2", "https://example.com/2", "admin", models.SeverityMedium, models.SourceImported)
+	_, _ = manager.Block(BlockRequest{Username: "user2", Reason: "reason2", EvidenceURL: "https://example.com/2", BlockedBy: "admin", Severity: models.SeverityMedium, Source: models.SourceImported})
 
 	// Export
-	err := manager.ExportJSON(exportPath)
+	err := manager.ExportJSON(exportPath, models.EntryFilter{})
 	if err != nil {
 		t.Fatalf("ExportJSON failed: %v", err)
 	}
@@ -135,6 +175,63 @@ func TestExportJSON(t *testing.T) {
 	}
 }
 
+func TestExportJSONL(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	usernames := []string{"user1", "user2", "user3"}
+	for _, username := range usernames {
+		if _, err := manager.Block(BlockRequest{Username: username, Reason: "spam", EvidenceURL: "https://example.com", BlockedBy: "admin", Severity: models.SeverityHigh, Source: models.SourceManual}); err != nil {
+			t.Fatalf("Block failed: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := manager.ExportJSONL(&buf, models.EntryFilter{}); err != nil {
+		t.Fatalf("ExportJSONL failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != len(usernames) {
+		t.Fatalf("Expected %d lines, got %d:\n%s", len(usernames), len(lines), buf.String())
+	}
+
+	seen := map[string]bool{}
+	for _, line := range lines {
+		var entry models.BlocklistEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Line is not a valid entry: %v\n%s", err, line)
+		}
+		if entry.ID == "" || entry.Severity != models.SeverityHigh {
+			t.Errorf("Unexpected entry: %+v", entry)
+		}
+		seen[entry.Username] = true
+	}
+	for _, username := range usernames {
+		if !seen[username] {
+			t.Errorf("Expected %s in export", username)
+		}
+	}
+}
+
+func TestExportJSONL_Filtered(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	//nolint:errcheck
+	_, _ = manager.Block(BlockRequest{Username: "low", Reason: "spam", BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual})
+	//nolint:errcheck
+	_, _ = manager.Block(BlockRequest{Username: "high", Reason: "spam", BlockedBy: "admin", Severity: models.SeverityHigh, Source: models.SourceManual})
+
+	var buf bytes.Buffer
+	if err := manager.ExportJSONL(&buf, models.EntryFilter{MinSeverity: models.SeverityMedium}); err != nil {
+		t.Fatalf("ExportJSONL failed: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 1 || !strings.Contains(buf.String(), `"username":"high"`) {
+		t.Errorf("Expected only the high entry, got:\n%s", buf.String())
+	}
+}
+
 func TestExportCSV(t *testing.T) {
 	manager, db := setupTestManager(t)
 	defer db.Close() //nolint:errcheck
@@ -144,10 +241,10 @@ func TestExportCSV(t *testing.T) {
 
 	// Add test entries
 	//nolint:errcheck
-	_, _ = manager.Block("csvuser1", "test reason", "https://example.com", "admin", models.SeverityLow, models.SourceManual)
+	_, _ = manager.Block(BlockRequest{Username: "csvuser1", Reason: "test reason", EvidenceURL: "https://example.com", BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual})
 
 	// Export
-	err := manager.ExportCSV(exportPath)
+	err := manager.ExportCSV(exportPath, models.EntryFilter{})
 	if err != nil {
 		t.Fatalf("ExportCSV failed: %v", err)
 	}
@@ -174,6 +271,159 @@ func TestExportCSV(t *testing.T) {
 	}
 }
 
+func TestImportCSV_RoundTrip(t *testing.T) {
+	source, sourceDB := setupTestManager(t)
+	defer sourceDB.Close() //nolint:errcheck
+
+	expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	permanent, err := source.Block(BlockRequest{Username: "csvuser1", Reason: "spam, with a comma", EvidenceURL: "https://example.com/1", BlockedBy: "admin", Severity: models.SeverityHigh, Source: models.SourceManual, Tags: []string{"crypto-spam", "readme-only"}})
+	if err != nil {
+		t.Fatalf("Block failed: %v", err)
+	}
+	if _, err := source.Block(BlockRequest{Username: "csvuser2", Reason: "spam", EvidenceURL: "https://example.com/2", BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual, ExpiresAt: &expiresAt}); err != nil {
+		t.Fatalf("Block failed: %v", err)
+	}
+
+	exportPath := filepath.Join(t.TempDir(), "blocklist.csv")
+	if err := source.ExportCSV(exportPath, models.EntryFilter{}); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	imported, err := manager.ImportCSV(exportPath)
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if imported != 2 {
+		t.Fatalf("Expected 2 imported entries, got %d", imported)
+	}
+
+	entries, _ := manager.GetByUsername("csvuser1")
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry for csvuser1, got %d", len(entries))
+	}
+	got := entries[0]
+	if got.ID != permanent.Entry.ID || got.Reason != "spam, with a comma" || got.Severity != models.SeverityHigh || len(got.Tags) != 2 || got.ExpiresAt != nil {
+		t.Errorf("Entry did not round-trip: %+v", got)
+	}
+
+	entries, _ = manager.GetByUsername("csvuser2")
+	if len(entries) != 1 || entries[0].ExpiresAt == nil || !entries[0].ExpiresAt.Equal(expiresAt) {
+		t.Errorf("Expected csvuser2 to keep its expiry, got %+v", entries)
+	}
+
+	// Re-importing the same export adds nothing
+	if imported, err := manager.ImportCSV(exportPath); err != nil || imported != 0 {
+		t.Errorf("Expected re-import to be deduplicated, got %d, %v", imported, err)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	entries, err := ReadCSV(strings.NewReader("Severity,Username\nhigh,no-id\n"))
+	if err != nil {
+		t.Fatalf("ReadCSV failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Username != "no-id" || entries[0].Severity != models.SeverityHigh {
+		t.Fatalf("Expected reordered columns to be read, got %+v", entries)
+	}
+	if entries[0].ID == "" || entries[0].Timestamp.IsZero() {
+		t.Errorf("Expected a generated ID and timestamp, got %+v", entries[0])
+	}
+
+	// Omitted columns are defaulted so the entries pass import validation
+	entries, err = ReadCSV(strings.NewReader("Username\nspammer\n"))
+	if err != nil {
+		t.Fatalf("ReadCSV failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Severity != models.SeverityMedium {
+		t.Fatalf("Expected a medium severity entry without a Severity column, got %+v", entries)
+	}
+	if problems := ValidateEntries(entries); len(problems) > 0 {
+		t.Errorf("Expected a Username-only row to be valid, got %v", problems)
+	}
+
+	if _, err := ReadCSV(strings.NewReader("ID,Reason\n1,spam\n")); err == nil {
+		t.Error("Expected an error without a Username column")
+	}
+	if _, err := ReadCSV(strings.NewReader("Username,Timestamp\nuser,yesterday\n")); err == nil {
+		t.Error("Expected an error for an invalid timestamp")
+	}
+}
+
+// addAgedEntry adds an entry whose timestamp is age in the past
+func addAgedEntry(t *testing.T, db *database.DB, username, severity string, age time.Duration) {
+	t.Helper()
+	entry := models.NewBlocklistEntry(username, "spam", "https://example.com", "admin", severity, models.SourceManual)
+	entry.Timestamp = time.Now().Add(-age)
+	if err := db.AddEntry(entry); err != nil {
+		t.Fatalf("Failed to add entry: %v", err)
+	}
+}
+
+func TestExportFiltered(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	day := 24 * time.Hour
+	addAgedEntry(t, db, "recent-high", models.SeverityHigh, 1*day)
+	addAgedEntry(t, db, "recent-medium", models.SeverityMedium, 2*day)
+	addAgedEntry(t, db, "recent-low", models.SeverityLow, 3*day)
+	addAgedEntry(t, db, "stale-high", models.SeverityHigh, 400*day)
+
+	filter := models.EntryFilter{
+		MinSeverity: models.SeverityMedium,
+		Since:       time.Now().Add(-30 * day),
+	}
+
+	tmpDir := t.TempDir()
+	jsonPath := filepath.Join(tmpDir, "blocklist.json")
+	if err := manager.ExportJSON(jsonPath, filter); err != nil {
+		t.Fatalf("ExportJSON failed: %v", err)
+	}
+
+	data, err := os.ReadFile(jsonPath) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("Failed to read JSON file: %v", err)
+	}
+	var entries []*models.BlocklistEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	var usernames []string
+	for _, entry := range entries {
+		usernames = append(usernames, entry.Username)
+	}
+	if strings.Join(usernames, ",") != "recent-high,recent-medium" {
+		t.Errorf("Expected recent-high,recent-medium, got %v", usernames)
+	}
+
+	csvPath := filepath.Join(tmpDir, "blocklist.csv")
+	until := models.EntryFilter{Until: time.Now().Add(-30 * day)}
+	if err := manager.ExportCSV(csvPath, until); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	data, err = os.ReadFile(csvPath) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("Failed to read CSV file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], "stale-high") {
+		t.Errorf("Expected header and stale-high only, got:\n%s", data)
+	}
+}
+
+func TestExportFiltered_InvalidSeverity(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	path := filepath.Join(t.TempDir(), "blocklist.json")
+	if err := manager.ExportJSON(path, models.EntryFilter{MinSeverity: "critical"}); err == nil {
+		t.Error("Expected error for invalid severity")
+	}
+}
+
 func TestImportJSON(t *testing.T) {
 	manager, db := setupTestManager(t)
 	defer db.Close() //nolint:errcheck
@@ -314,6 +564,92 @@ func TestImportJSON_LowerSeverityIgnored(t *testing.T) {
 	}
 }
 
+func TestExportImportYAML_RoundTrip(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	tmpDir := t.TempDir()
+	exportPath := filepath.Join(tmpDir, "blocklist.yaml")
+
+	//nolint:errcheck
+	_, _ = manager.Block(BlockRequest{Username: "yamluser1", Reason: "reason: with colon", EvidenceURL: "https://example.com/1", BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual})
+	//nolint:errcheck
+	_, _ = manager.Block(BlockRequest{Username: "yamluser2", Reason: "reason2", EvidenceURL: "https://example.com/2", BlockedBy: "admin", Severity: models.SeverityMedium, Source: models.SourceManual})
+
+	if err := manager.ExportYAML(exportPath, models.EntryFilter{}); err != nil {
+		t.Fatalf("ExportYAML failed: %v", err)
+	}
+
+	data, err := os.ReadFile(exportPath) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("Failed to read YAML file: %v", err)
+	}
+	if !contains(string(data), "username: yamluser1") {
+		t.Errorf("YAML export missing snake_case fields:\n%s", data)
+	}
+
+	// Re-importing into the same database deduplicates by ID
+	count, err := manager.ImportYAML(exportPath)
+	if err != nil {
+		t.Fatalf("ImportYAML failed: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected 0 entries imported on re-import, got %d", count)
+	}
+
+	// Importing into a fresh database restores all entries
+	freshManager, freshDB := setupTestManager(t)
+	defer freshDB.Close() //nolint:errcheck
+
+	count, err = freshManager.ImportYAML(exportPath)
+	if err != nil {
+		t.Fatalf("ImportYAML failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 entries imported, got %d", count)
+	}
+
+	entries, _ := freshManager.GetByUsername("yamluser1")
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry for yamluser1, got %d", len(entries))
+	}
+	if entries[0].Reason != "reason: with colon" {
+		t.Errorf("Expected reason to round-trip, got '%s'", entries[0].Reason)
+	}
+}
+
+func TestImportYAML_HigherSeverityUpdates(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	tmpDir := t.TempDir()
+	exportPath := filepath.Join(tmpDir, "blocklist.yaml")
+
+	original, _ := manager.Block(BlockRequest{Username: "yamldup", Reason: "original", EvidenceURL: "https://example.com", BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual})
+	if err := manager.ExportYAML(exportPath, models.EntryFilter{}); err != nil {
+		t.Fatalf("ExportYAML failed: %v", err)
+	}
+
+	// Bump severity in the exported file
+	data, _ := os.ReadFile(exportPath) //nolint:gosec // test file
+	updated := strings.Replace(string(data), "severity: low", "severity: high", 1)
+	_ = os.WriteFile( //nolint:errcheck,gosec // test file
+		exportPath, []byte(updated), 0644)
+
+	count, err := manager.ImportYAML(exportPath)
+	if err != nil {
+		t.Fatalf("ImportYAML failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 entry updated, got %d", count)
+	}
+
+	entry, _ := db.GetEntry(original.Entry.ID)
+	if entry.Severity != models.SeverityHigh {
+		t.Errorf("Expected severity to be updated to 'high', got '%s'", entry.Severity)
+	}
+}
+
 func TestGetByUsername(t *testing.T) {
 	manager, db := setupTestManager(t)
 	defer db.Close() //nolint:errcheck
@@ -322,9 +658,9 @@ func TestGetByUsername(t *testing.T) {
 
 	// Add multiple entries for same user
 	//nolint:errcheck
-	_, _ = manager.Block(username, "reason1", "https://example.com/1", "admin", models.SeverityLow, models.SourceManual)
+	_, _ = manager.Block(BlockRequest{Username: username, Reason: "reason1", EvidenceURL: "https://example.com/1", BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual})
 	//nolint:errcheck
-	_, _ = manager.Block(username, "reason2", "https://example.com/2", "admin", models.SeverityHigh, models.SourceImported)
+	_, _ = manager.Block(BlockRequest{Username: username, Reason: "reason2", EvidenceURL: "https://example.com/2", BlockedBy: "admin", Severity: models.SeverityHigh, Source: models.SourceImported})
 
 	entries, err := manager.GetByUsername(username)
 	if err != nil {
@@ -342,11 +678,11 @@ func TestList(t *testing.T) {
 
 	// Add multiple users
 	//nolint:errcheck
-	_, _ = manager.Block("user1", "reason1", "https://example.com/1", "admin", models.SeverityLow, models.SourceManual)
+	_, _ = manager.Block(BlockRequest{Username: "user1", Reason: "reason1", EvidenceURL: "https://example.com/1", BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual})
 	//nolint:errcheck
-	_, _ = manager.Block("user2", "reason2", "https://example.com/2", "admin", models.SeverityMedium, models.SourceManual)
+	_, _ = manager.Block(BlockRequest{Username: "user2", Reason: "reason2", EvidenceURL: "https://example.com/2", BlockedBy: "admin", Severity: models.SeverityMedium, Source: models.SourceManual})
 	//nolint:errcheck
-	_, _ = manager.Block("user3", "reason3", "https://example.com/3", "admin", models.SeverityHigh, models.SourceManual)
+	_, _ = manager.Block(BlockRequest{Username: "user3", Reason: "reason3", EvidenceURL: "https://example.com/3", BlockedBy: "admin", Severity: models.SeverityHigh, Source: models.SourceManual})
 
 	entries, err := manager.List()
 	if err != nil {
@@ -371,3 +707,468 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestDiff(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	unchanged := models.NewBlocklistEntry("same", "spam", "", "admin", models.SeverityHigh, models.SourceManual)
+	upgradable := models.NewBlocklistEntry("upgrade", "spam", "", "admin", models.SeverityLow, models.SourceManual)
+	localOnly := models.NewBlocklistEntry("localonly", "spam", "", "admin", models.SeverityMedium, models.SourceManual)
+	for _, entry := range []*models.BlocklistEntry{unchanged, upgradable, localOnly} {
+		if err := db.AddEntry(entry); err != nil {
+			t.Fatalf("AddEntry failed: %v", err)
+		}
+	}
+
+	sameCopy := *unchanged
+	sameCopy.Severity = models.SeverityLow // lower severity is not an upgrade
+	upgradeCopy := *upgradable
+	upgradeCopy.Severity = models.SeverityHigh
+	newEntry := models.NewBlocklistEntry("newuser", "abuse", "", "community", models.SeverityMedium, models.SourceImported)
+
+	added, upgraded, onlyLocal, err := manager.Diff([]*models.BlocklistEntry{&sameCopy, &upgradeCopy, newEntry})
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+
+	if len(added) != 1 || added[0].Username != "newuser" {
+		t.Errorf("Expected newuser to be added, got %v", added)
+	}
+	if len(upgraded) != 1 {
+		t.Fatalf("Expected 1 upgrade, got %d", len(upgraded))
+	}
+	if upgraded[0].Local.Severity != models.SeverityLow || upgraded[0].Incoming.Severity != models.SeverityHigh {
+		t.Errorf("Expected low → high upgrade, got %s → %s", upgraded[0].Local.Severity, upgraded[0].Incoming.Severity)
+	}
+	if len(onlyLocal) != 1 || onlyLocal[0].Username != "localonly" {
+		t.Errorf("Expected localonly to be local-only, got %v", onlyLocal)
+	}
+
+	// Diff must not modify the database
+	entries, _ := manager.GetByUsername("upgrade")
+	if len(entries) != 1 || entries[0].Severity != models.SeverityLow {
+		t.Errorf("Expected upgrade entry to remain low severity")
+	}
+}
+
+func TestDiff_SkipsNilEntries(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	newEntry := models.NewBlocklistEntry("newuser", "abuse", "", "community", models.SeverityMedium, models.SourceImported)
+
+	added, _, _, err := manager.Diff([]*models.BlocklistEntry{nil, newEntry})
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if len(added) != 1 || added[0].Username != "newuser" {
+		t.Errorf("Expected only newuser to be added, got %v", added)
+	}
+}
+
+func TestIsBlocked_CaseInsensitive(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	if _, err := manager.Block(BlockRequest{Username: "Spammer", Reason: "spam", BlockedBy: "admin", Severity: models.SeverityHigh, Source: models.SourceManual}); err != nil {
+		t.Fatalf("Block failed: %v", err)
+	}
+
+	blocked, err := manager.IsBlocked("spammer")
+	if err != nil {
+		t.Fatalf("IsBlocked failed: %v", err)
+	}
+	if !blocked {
+		t.Error("spammer should be blocked after blocking Spammer")
+	}
+}
+
+func TestBlockWithTags(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	result, err := manager.Block(BlockRequest{Username: "spammer", Reason: "airdrop", BlockedBy: "admin", Severity: models.SeverityHigh, Source: models.SourceManual, Tags: []string{"Crypto-Spam", " link-spam ", "crypto-spam", ""}})
+	if err != nil {
+		t.Fatalf("Block failed: %v", err)
+	}
+	if entry := result.Entry; len(entry.Tags) != 2 || entry.Tags[0] != "crypto-spam" || entry.Tags[1] != "link-spam" {
+		t.Errorf("Expected normalized tags [crypto-spam link-spam], got %v", entry.Tags)
+	}
+
+	if _, _, err := manager.BlockMany([]string{"bulk1", "bulk2"}, BlockRequest{Reason: "wave", BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual, Tags: []string{"link-spam"}}); err != nil {
+		t.Fatalf("BlockMany failed: %v", err)
+	}
+
+	tagged, err := manager.ListByTag("CRYPTO-SPAM")
+	if err != nil {
+		t.Fatalf("ListByTag failed: %v", err)
+	}
+	if len(tagged) != 1 || tagged[0].Username != "spammer" {
+		t.Errorf("Expected only spammer tagged crypto-spam, got %d entries", len(tagged))
+	}
+
+	tagged, err = manager.ListByTag("link-spam")
+	if err != nil {
+		t.Fatalf("ListByTag failed: %v", err)
+	}
+	if len(tagged) != 3 {
+		t.Errorf("Expected 3 entries tagged link-spam, got %d", len(tagged))
+	}
+}
+
+func TestBlock_RecordsSignals(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	if _, err := manager.Block(BlockRequest{Username: "spammer", Reason: "Auto-detected spam", BlockedBy: "admin", Severity: models.SeverityHigh, Source: models.SourceAutoDetected, Signals: []string{"readme_only", "new_account"}, Escalate: true}); err != nil {
+		t.Fatalf("Block failed: %v", err)
+	}
+
+	entries, err := manager.GetByUsername("spammer")
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("GetByUsername returned %d entries, err %v", len(entries), err)
+	}
+	var metadata models.EntryMetadata
+	if err := json.Unmarshal([]byte(entries[0].Metadata), &metadata); err != nil {
+		t.Fatalf("Failed to decode metadata %q: %v", entries[0].Metadata, err)
+	}
+	if len(metadata.Signals) != 2 || metadata.Signals[0] != "readme_only" || metadata.Signals[1] != "new_account" {
+		t.Errorf("Expected stored signals [readme_only new_account], got %v", metadata.Signals)
+	}
+}
+
+func TestBlock_EscalationSeverityClimbs(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	for i, want := range []string{models.SeverityLow, models.SeverityMedium, models.SeverityHigh, models.SeverityHigh} {
+		// Alternate case to check offenses are counted per GitHub user
+		username := "repeat"
+		if i%2 == 1 {
+			username = "Repeat"
+		}
+		// Each block cites a different PR, so each is a new offense
+		result, err := manager.Block(BlockRequest{Username: username, Reason: "spam", EvidenceURL: fmt.Sprintf("https://github.com/org/repo/pull/%d", i), BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual, Escalate: true})
+		if err != nil {
+			t.Fatalf("Block failed: %v", err)
+		}
+		if result.Offense != i+1 {
+			t.Errorf("Expected offense #%d, got #%d", i+1, result.Offense)
+		}
+		if result.Entry.Severity != want {
+			t.Errorf("Offense #%d: expected severity %s, got %s", i+1, want, result.Entry.Severity)
+		}
+	}
+}
+
+// userClient is a GitHub client that only answers GetUser
+type userClient struct {
+	github.GitHubClient
+	user *github.User
+	err  error
+}
+
+func (c *userClient) GetUser(string) (*github.User, error) {
+	return c.user, c.err
+}
+
+func TestBlock_SnapshotRecordsAccount(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	client := &userClient{user: &github.User{Login: "spammer", CreatedAt: created, Followers: 2, PublicRepos: 1}}
+	result, err := manager.Block(BlockRequest{Username: "spammer", Reason: "spam", BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual, Signals: []string{"new_account"}, Snapshot: client})
+	if err != nil {
+		t.Fatalf("Block failed: %v", err)
+	}
+	if result.Offense != 0 {
+		t.Errorf("Expected no offense count without escalation, got %d", result.Offense)
+	}
+	entry := result.Entry
+
+	entries, err := manager.GetByUsername("spammer")
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("GetByUsername returned %d entries, err %v", len(entries), err)
+	}
+	if entries[0].ID != entry.ID {
+		t.Errorf("Expected stored entry %s, got %s", entry.ID, entries[0].ID)
+	}
+	metadata, err := entries[0].ParseMetadata()
+	if err != nil {
+		t.Fatalf("Failed to parse metadata %q: %v", entries[0].Metadata, err)
+	}
+	if metadata.Account == nil {
+		t.Fatalf("Expected an account snapshot in %q", entries[0].Metadata)
+	}
+	if !metadata.Account.CreatedAt.Equal(created) || metadata.Account.Followers != 2 || metadata.Account.PublicRepos != 1 {
+		t.Errorf("Unexpected snapshot %+v", metadata.Account)
+	}
+	if metadata.Account.CapturedAt.IsZero() {
+		t.Error("Expected the capture time to be set")
+	}
+	if len(metadata.Signals) != 1 || metadata.Signals[0] != "new_account" {
+		t.Errorf("Expected signals to be kept alongside the snapshot, got %v", metadata.Signals)
+	}
+}
+
+func TestBlock_SnapshotBlocksWhenUserLookupFails(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	client := &userClient{err: errors.New("not found")}
+	result, err := manager.Block(BlockRequest{Username: "ghost", Reason: "spam", BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual, Escalate: true, Snapshot: client})
+	if err != nil {
+		t.Fatalf("Block failed: %v", err)
+	}
+	if result.Offense != 1 {
+		t.Errorf("Expected first offense with escalation, got %d", result.Offense)
+	}
+	if result.Entry.Metadata != "{}" && result.Entry.Metadata != "" {
+		t.Errorf("Expected empty metadata without a snapshot, got %q", result.Entry.Metadata)
+	}
+}
+
+func TestBlock_UpdateExistingSameEvidence(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	evidence := "https://github.com/org/repo/pull/1"
+	first, err := manager.Block(BlockRequest{Username: "spammer", Reason: "spam", EvidenceURL: evidence, BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual, Tags: []string{"crypto-spam"}, UpdateExisting: true})
+	if err != nil || first.Updated {
+		t.Fatalf("Expected a new entry, got %+v, err %v", first, err)
+	}
+
+	second, err := manager.Block(BlockRequest{Username: "Spammer", Reason: "more spam", EvidenceURL: evidence, BlockedBy: "admin", Severity: models.SeverityHigh, Source: models.SourceManual, Tags: []string{"phishing"}, UpdateExisting: true})
+	if err != nil {
+		t.Fatalf("Block failed: %v", err)
+	}
+	if !second.Updated || second.Offense != 0 || second.Entry.ID != first.Entry.ID {
+		t.Errorf("Expected entry %s to be updated, got %s (updated %v, offense %d)", first.Entry.ID, second.Entry.ID, second.Updated, second.Offense)
+	}
+
+	entries, err := manager.GetByUsername("spammer")
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d (err %v)", len(entries), err)
+	}
+	if entries[0].Reason != "more spam" || entries[0].Severity != models.SeverityHigh {
+		t.Errorf("Expected the reason and severity to be updated, got %q %s", entries[0].Reason, entries[0].Severity)
+	}
+	if len(entries[0].Tags) != 2 {
+		t.Errorf("Expected tags to be merged, got %v", entries[0].Tags)
+	}
+}
+
+func TestBlock_UpdateExistingEscalationNeverLowersSeverity(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	evidence := "https://github.com/org/repo/pull/1"
+	if _, err := manager.Block(BlockRequest{Username: "spammer", Reason: "spam", EvidenceURL: evidence, BlockedBy: "admin", Severity: models.SeverityHigh, Source: models.SourceManual, UpdateExisting: true}); err != nil {
+		t.Fatalf("Block failed: %v", err)
+	}
+	result, err := manager.Block(BlockRequest{Username: "spammer", Reason: "spam", EvidenceURL: evidence, BlockedBy: "admin", Severity: models.SeverityMedium, Source: models.SourceManual, Escalate: true, UpdateExisting: true})
+	if err != nil || !result.Updated {
+		t.Fatalf("Expected an update, got %+v, err %v", result, err)
+	}
+	if result.Entry.Severity != models.SeverityHigh {
+		t.Errorf("Expected severity to stay high, got %s", result.Entry.Severity)
+	}
+}
+
+func TestBlock_UpdateExistingNewEvidenceAddsEntry(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	for i := 1; i <= 2; i++ {
+		result, err := manager.Block(BlockRequest{Username: "spammer", Reason: "spam", EvidenceURL: fmt.Sprintf("https://github.com/org/repo/pull/%d", i), BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual, Escalate: true, UpdateExisting: true})
+		if err != nil || result.Updated {
+			t.Fatalf("Block %d: expected a new entry, got %+v, err %v", i, result, err)
+		}
+		if result.Offense != i {
+			t.Errorf("Block %d: expected offense #%d, got #%d", i, i, result.Offense)
+		}
+	}
+}
+
+func TestEscalatedSeverity_NeverLowers(t *testing.T) {
+	if got := escalatedSeverity(models.SeverityHigh, 2); got != models.SeverityHigh {
+		t.Errorf("Expected high to stay high on a second offense, got %s", got)
+	}
+	if got := escalatedSeverity(models.SeverityMedium, 1); got != models.SeverityMedium {
+		t.Errorf("Expected first offense to keep its severity, got %s", got)
+	}
+}
+
+// conditionalServer serves entries with the given validator headers and answers
+// 304 when the request carries a matching If-None-Match or If-Modified-Since
+func conditionalServer(t *testing.T, etag, lastModified string, entries []*models.BlocklistEntry, requests *int) *httptest.Server {
+	t.Helper()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatalf("Failed to marshal entries: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if (etag != "" && r.Header.Get("If-None-Match") == etag) ||
+			(lastModified != "" && r.Header.Get("If-Modified-Since") == lastModified) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+		}
+		if lastModified != "" {
+			w.Header().Set("Last-Modified", lastModified)
+		}
+		_, _ = w.Write(data) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestImportJSONFromURL_NotModified(t *testing.T) {
+	tests := []struct {
+		name         string
+		etag         string
+		lastModified string
+	}{
+		{name: "ETag", etag: `"v1"`},
+		{name: "Last-Modified", lastModified: "Mon, 03 Mar 2025 12:00:00 GMT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, db := setupTestManager(t)
+			defer db.Close() //nolint:errcheck
+
+			entry := models.NewBlocklistEntry("remote-spammer", "spam", "", "upstream", models.SeverityHigh, models.SourceManual)
+			requests := 0
+			server := conditionalServer(t, tt.etag, tt.lastModified, []*models.BlocklistEntry{entry}, &requests)
+
+			imported, err := manager.ImportJSONFromURL(server.URL)
+			if err != nil {
+				t.Fatalf("First import failed: %v", err)
+			}
+			if imported != 1 {
+				t.Fatalf("Expected 1 imported entry, got %d", imported)
+			}
+
+			// Remove the entry locally; an unconditional re-import would restore it
+			if err := manager.Unblock("remote-spammer"); err != nil {
+				t.Fatalf("Unblock failed: %v", err)
+			}
+
+			imported, err = manager.ImportJSONFromURL(server.URL)
+			if !errors.Is(err, ErrNotModified) {
+				t.Fatalf("Expected ErrNotModified, got %v", err)
+			}
+			if imported != 0 {
+				t.Errorf("Expected 0 imported entries, got %d", imported)
+			}
+			if requests != 2 {
+				t.Errorf("Expected 2 requests, got %d", requests)
+			}
+			if blocked, _ := manager.IsBlocked("remote-spammer"); blocked {
+				t.Error("Entry should not be re-imported after a 304")
+			}
+		})
+	}
+}
+
+func TestImportJSONFromURL_Timeout(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		_, _ = w.Write([]byte("[]")) //nolint:errcheck
+	}))
+	// Unblock the handler before Close waits for it to finish
+	defer server.Close()
+	defer close(release)
+
+	manager.SetFetchTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	if _, err := manager.ImportJSONFromURL(server.URL); err == nil {
+		t.Fatal("Expected a slow server to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the request to give up quickly, took %v", elapsed)
+	}
+}
+
+func TestImportJSONFromURL_TooLarge(t *testing.T) {
+	entries := []*models.BlocklistEntry{
+		models.NewBlocklistEntry("remote-spammer", strings.Repeat("spam ", 100), "", "upstream", models.SeverityHigh, models.SourceManual),
+	}
+	body, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatalf("Failed to encode entries: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{name: "Content-Length", handler: func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(body) //nolint:errcheck
+		}},
+		{name: "chunked", handler: func(w http.ResponseWriter, _ *http.Request) {
+			// Flushing before the body is written forces chunked encoding without a length
+			w.(http.Flusher).Flush()
+			_, _ = w.Write(body) //nolint:errcheck
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, db := setupTestManager(t)
+			defer db.Close() //nolint:errcheck
+
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			manager.SetMaxFetchSize(int64(len(body) - 1))
+			if _, err := manager.ImportJSONFromURL(server.URL); !errors.Is(err, ErrResponseTooLarge) {
+				t.Fatalf("Expected ErrResponseTooLarge, got %v", err)
+			}
+			if blocked, _ := manager.IsBlocked("remote-spammer"); blocked {
+				t.Error("Entries from an oversized response should not be imported")
+			}
+
+			manager.SetMaxFetchSize(int64(len(body)))
+			if imported, err := manager.ImportJSONFromURL(server.URL); err != nil || imported != 1 {
+				t.Errorf("Expected a response at the limit to import, got %d, %v", imported, err)
+			}
+		})
+	}
+}
+
+func TestImportJSONFromURL_WithoutValidators(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	entry := models.NewBlocklistEntry("remote-spammer", "spam", "", "upstream", models.SeverityHigh, models.SourceManual)
+	requests := 0
+	server := conditionalServer(t, "", "", []*models.BlocklistEntry{entry}, &requests)
+
+	for i := 0; i < 2; i++ {
+		if _, err := manager.ImportJSONFromURL(server.URL); err != nil {
+			t.Fatalf("Import %d failed: %v", i+1, err)
+		}
+	}
+
+	cache, err := db.GetSourceCache(server.URL)
+	if err != nil {
+		t.Fatalf("GetSourceCache failed: %v", err)
+	}
+	if cache != nil {
+		t.Errorf("Expected no cache entry without validators, got %+v", cache)
+	}
+}
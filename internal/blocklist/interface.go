@@ -14,22 +14,52 @@
 
 package blocklist
 
-import "github.com/prguard/prguard/pkg/models"
+import (
+	"io"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/pkg/models"
+)
 
 // BlocklistManager defines the interface for managing the blocklist
 type BlocklistManager interface {
 	// Block operations
-	Block(username, reason, evidenceURL, blockedBy, severity, source string) (*models.BlocklistEntry, error)
+	Block(req BlockRequest) (*BlockResult, error)
+	BlockMany(usernames []string, req BlockRequest) (added, skipped int, err error)
 	Unblock(username string) error
+	RemoveByID(id string) (*models.BlocklistEntry, error)
 	IsBlocked(username string) (bool, error)
+	MarkGitHubBlocked(username string) error
+	IsGitHubBlocked(username string) (bool, error)
+	PurgeExpired() (int64, error)
+	FindDuplicateUsernames() ([]string, error)
+	PruneDuplicates() (int64, error)
 
 	// Query operations
 	List() ([]*models.BlocklistEntry, error)
+	ListFiltered(filter models.EntryFilter) ([]*models.BlocklistEntry, error)
+	ListPaged(filter models.EntryFilter) ([]*models.BlocklistEntry, int, error)
+	ListSorted(sortField string, reverse bool) ([]*models.BlocklistEntry, error)
+	ListByTag(tag string) ([]*models.BlocklistEntry, error)
 	GetByUsername(username string) ([]*models.BlocklistEntry, error)
+	Search(term, field, severity string) ([]*models.BlocklistEntry, error)
+	Count(severity string) (entries, users int, err error)
 
 	// Import/Export operations
-	ExportJSON(path string) error
-	ExportCSV(path string) error
+	ExportJSON(path string, filter models.EntryFilter) error
+	ExportCSV(path string, filter models.EntryFilter) error
+	ExportYAML(path string, filter models.EntryFilter) error
+	ExportRegistry(path string, filter models.EntryFilter) error
+	ExportJSONL(w io.Writer, filter models.EntryFilter) error
+	ExportSignedJSON(path, privKeyPath string, filter models.EntryFilter) error
 	ImportJSON(path string) (int, error)
+	ImportYAML(path string) (int, error)
+	ImportCSV(path string) (int, error)
 	ImportJSONFromURL(url string) (int, error)
+	ImportSignedJSON(path, pubKeyPath string) (int, error)
+	Diff(entries []*models.BlocklistEntry) (added []*models.BlocklistEntry, upgraded []Upgrade, localOnly []*models.BlocklistEntry, err error)
+
+	// Source sync operations
+	SyncSources(sources []config.BlocklistSource) []*SyncResult
+	MergeStaged(entries []*models.BlocklistEntry) (int, error)
 }
@@ -0,0 +1,65 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blocklist
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/prguard/prguard/pkg/models"
+)
+
+// Merge combines entry sets in memory, deduplicating by ID with the same
+// severity-upgrade rule as import: a later entry replaces an earlier one with
+// the same ID only when its severity is higher. Entries keep the order in which
+// their ID first appears. Null entries are skipped.
+func Merge(sets ...[]*models.BlocklistEntry) []*models.BlocklistEntry {
+	var merged []*models.BlocklistEntry
+	index := make(map[string]int)
+	for _, entries := range sets {
+		for _, entry := range entries {
+			if entry == nil {
+				continue
+			}
+			i, ok := index[entry.ID]
+			if !ok {
+				index[entry.ID] = len(merged)
+				merged = append(merged, entry)
+				continue
+			}
+			if shouldUpdate(merged[i], entry) {
+				merged[i] = entry
+			}
+		}
+	}
+	return merged
+}
+
+// SaveJSON writes entries to a JSON file in the same format as ExportJSON
+func SaveJSON(path string, entries []*models.BlocklistEntry) error {
+	if entries == nil {
+		entries = []*models.BlocklistEntry{}
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
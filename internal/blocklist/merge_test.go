@@ -0,0 +1,82 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blocklist
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/prguard/prguard/pkg/models"
+)
+
+func mergeEntry(id, username, severity string) *models.BlocklistEntry {
+	entry := models.NewBlocklistEntry(username, "spam", "", "admin", severity, models.SourceManual)
+	entry.ID = id
+	return entry
+}
+
+func TestMerge_HighestSeverityWins(t *testing.T) {
+	first := []*models.BlocklistEntry{
+		mergeEntry("a", "alice", models.SeverityLow),
+		mergeEntry("b", "bob", models.SeverityHigh),
+	}
+	second := []*models.BlocklistEntry{
+		mergeEntry("b", "bob", models.SeverityMedium),
+		mergeEntry("a", "alice", models.SeverityMedium),
+		mergeEntry("c", "carol", models.SeverityLow),
+	}
+	third := []*models.BlocklistEntry{
+		mergeEntry("a", "alice", models.SeverityHigh),
+	}
+
+	merged := Merge(first, second, third)
+
+	want := map[string]string{"a": models.SeverityHigh, "b": models.SeverityHigh, "c": models.SeverityLow}
+	if len(merged) != len(want) {
+		t.Fatalf("Expected %d merged entries, got %d", len(want), len(merged))
+	}
+	for i, id := range []string{"a", "b", "c"} {
+		if merged[i].ID != id {
+			t.Errorf("Expected entry %d to be %s, got %s", i, id, merged[i].ID)
+		}
+		if merged[i].Severity != want[id] {
+			t.Errorf("Expected %s to have severity %s, got %s", id, want[id], merged[i].Severity)
+		}
+	}
+}
+
+func TestMerge_SkipsNullEntries(t *testing.T) {
+	merged := Merge([]*models.BlocklistEntry{nil, mergeEntry("a", "alice", models.SeverityLow)}, []*models.BlocklistEntry{nil})
+
+	if len(merged) != 1 || merged[0].ID != "a" {
+		t.Errorf("Expected only entry a, got %v", merged)
+	}
+}
+
+func TestSaveJSON_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "merged.json")
+	entries := []*models.BlocklistEntry{mergeEntry("a", "alice", models.SeverityLow)}
+
+	if err := SaveJSON(path, entries); err != nil {
+		t.Fatalf("SaveJSON failed: %v", err)
+	}
+	loaded, err := LoadJSON(path)
+	if err != nil {
+		t.Fatalf("LoadJSON failed: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].ID != "a" || loaded[0].Username != "alice" {
+		t.Errorf("Expected the saved entry back, got %v", loaded)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blocklist
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/prguard/prguard/pkg/models"
+)
+
+// RegistryVersion is the version of the registry envelope written by this build
+const RegistryVersion = 1
+
+// registryGenerator identifies prguard as the producer of a registry export
+const registryGenerator = "prguard"
+
+// Registry is the envelope used to exchange blocklists through a shared
+// registry. Unknown top-level keys are ignored when reading so newer
+// producers can add metadata without breaking older consumers.
+type Registry struct {
+	Version     int                      `json:"version"`
+	GeneratedAt time.Time                `json:"generated_at"`
+	Generator   string                   `json:"generator"`
+	Entries     []*models.BlocklistEntry `json:"entries"`
+}
+
+// NewRegistry wraps entries in a registry envelope generated at now
+func NewRegistry(entries []*models.BlocklistEntry, now time.Time) *Registry {
+	if entries == nil {
+		entries = []*models.BlocklistEntry{}
+	}
+	return &Registry{
+		Version:     RegistryVersion,
+		GeneratedAt: now.UTC(),
+		Generator:   registryGenerator,
+		Entries:     entries,
+	}
+}
+
+// ExportRegistry exports blocklist entries matching filter to a JSON file
+// wrapped in a registry envelope
+func (m *Manager) ExportRegistry(path string, filter models.EntryFilter) error {
+	entries, err := m.db.ListEntriesFiltered(filter)
+	if err != nil {
+		return fmt.Errorf("failed to get entries: %w", err)
+	}
+
+	data, err := json.MarshalIndent(NewRegistry(entries, time.Now()), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// parseEntriesJSON decodes blocklist entries from either a plain JSON array or
+// a registry envelope
+func parseEntriesJSON(data []byte) ([]*models.BlocklistEntry, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		var entries []*models.BlocklistEntry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+		}
+		return entries, nil
+	}
+
+	var registry Registry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal registry JSON: %w", err)
+	}
+	if registry.Version < 1 {
+		return nil, fmt.Errorf("registry is missing a version")
+	}
+	if registry.Version > RegistryVersion {
+		return nil, fmt.Errorf("registry version %d is newer than supported version %d; upgrade prguard", registry.Version, RegistryVersion)
+	}
+	return registry.Entries, nil
+}
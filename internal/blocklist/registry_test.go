@@ -0,0 +1,138 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blocklist
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/pkg/models"
+)
+
+func TestExportRegistry_RoundTrip(t *testing.T) {
+	source, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	//nolint:errcheck
+	_, _ = source.Block(BlockRequest{Username: "registry1", Reason: "spam", EvidenceURL: "https://example.com/1", BlockedBy: "admin", Severity: models.SeverityHigh, Source: models.SourceManual})
+	//nolint:errcheck
+	_, _ = source.Block(BlockRequest{Username: "registry2", Reason: "abuse", EvidenceURL: "https://example.com/2", BlockedBy: "admin", Severity: models.SeverityLow, Source: models.SourceManual})
+
+	path := filepath.Join(t.TempDir(), "blocklist.registry.json")
+	if err := source.ExportRegistry(path, models.EntryFilter{}); err != nil {
+		t.Fatalf("ExportRegistry failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	var registry Registry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		t.Fatalf("export is not a registry envelope: %v", err)
+	}
+	if registry.Version != RegistryVersion || registry.Generator != "prguard" || registry.GeneratedAt.IsZero() {
+		t.Errorf("unexpected envelope metadata: version=%d generator=%q generated_at=%v", registry.Version, registry.Generator, registry.GeneratedAt)
+	}
+	if len(registry.Entries) != 2 {
+		t.Fatalf("expected 2 entries in envelope, got %d", len(registry.Entries))
+	}
+
+	target, targetDB := setupTestManager(t)
+	defer targetDB.Close() //nolint:errcheck
+
+	count, err := target.ImportJSON(path)
+	if err != nil {
+		t.Fatalf("ImportJSON failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 entries imported, got %d", count)
+	}
+	for _, username := range []string{"registry1", "registry2"} {
+		if blocked, _ := target.IsBlocked(username); !blocked {
+			t.Errorf("%s should be blocked after import", username)
+		}
+	}
+}
+
+func TestExportRegistry_EmptyHasEntriesArray(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	path := filepath.Join(t.TempDir(), "empty.json")
+	if err := manager.ExportRegistry(path, models.EntryFilter{}); err != nil {
+		t.Fatalf("ExportRegistry failed: %v", err)
+	}
+	data, _ := os.ReadFile(path) //nolint:gosec // test file
+	if !strings.Contains(string(data), `"entries": []`) {
+		t.Errorf("expected an empty entries array, got:\n%s", data)
+	}
+}
+
+func TestParseEntriesJSON_Formats(t *testing.T) {
+	entry := models.NewBlocklistEntry("user", "spam", "", "admin", models.SeverityMedium, models.SourceManual)
+	entryJSON, _ := json.Marshal(entry)
+
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr string
+	}{
+		{"plain array", "[" + string(entryJSON) + "]", 1, ""},
+		{"envelope", `{"version": 1, "generator": "other-tool", "entries": [` + string(entryJSON) + `]}`, 1, ""},
+		{"unknown top-level keys", `{"version": 1, "coalition": "maintainers", "signature": {"alg": "ed25519"}, "entries": [` + string(entryJSON) + `]}`, 1, ""},
+		{"missing version", `{"entries": []}`, 0, "missing a version"},
+		{"newer version", `{"version": 99, "entries": []}`, 0, "newer than supported"},
+		{"malformed", `{"version": 1, "entries": `, 0, "failed to unmarshal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := parseEntriesJSON([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseEntriesJSON failed: %v", err)
+			}
+			if len(entries) != tt.want {
+				t.Errorf("expected %d entries, got %d", tt.want, len(entries))
+			}
+		})
+	}
+}
+
+func TestImportJSON_PlainArrayStillImports(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	entries := []*models.BlocklistEntry{
+		models.NewBlocklistEntry("legacy", "spam", "https://example.com", "admin", models.SeverityHigh, models.SourceManual),
+	}
+	count, err := manager.ImportJSON(writeEntriesJSON(t, entries))
+	if err != nil {
+		t.Fatalf("ImportJSON failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 entry imported, got %d", count)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blocklist
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/prguard/prguard/pkg/models"
+)
+
+// ErrInvalidSignature is returned when a signed export does not verify against the public key
+var ErrInvalidSignature = errors.New("invalid blocklist signature")
+
+// SignaturePath returns the path of the detached signature for an export
+func SignaturePath(path string) string {
+	return path + ".sig"
+}
+
+// ExportSignedJSON exports blocklist entries matching filter to a JSON file and
+// writes a detached ed25519 signature of the file to SignaturePath(path)
+func (m *Manager) ExportSignedJSON(path, privKeyPath string, filter models.EntryFilter) error {
+	privKey, err := LoadPrivateKey(privKeyPath)
+	if err != nil {
+		return err
+	}
+
+	data, err := m.entriesJSON(filter)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privKey, data)) + "\n"
+	if err := os.WriteFile(SignaturePath(path), []byte(signature), 0600); err != nil {
+		return fmt.Errorf("failed to write signature: %w", err)
+	}
+
+	return nil
+}
+
+// ImportSignedJSON verifies a JSON export against its detached signature and
+// imports it. Nothing is imported if the signature is missing or invalid.
+func (m *Manager) ImportSignedJSON(path, pubKeyPath string) (int, error) {
+	pubKey, err := LoadPublicKey(pubKeyPath)
+	if err != nil {
+		return 0, err
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // user-specified import path
+	if err != nil {
+		return 0, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	encoded, err := os.ReadFile(SignaturePath(path)) //nolint:gosec // derived from the import path
+	if err != nil {
+		return 0, fmt.Errorf("failed to read signature: %w", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed signature file", ErrInvalidSignature)
+	}
+
+	if !ed25519.Verify(pubKey, data, signature) {
+		return 0, ErrInvalidSignature
+	}
+
+	entries, err := parseEntriesJSON(data)
+	if err != nil {
+		return 0, err
+	}
+	return m.importEntries(entries)
+}
+
+// LoadPrivateKey reads a PEM-encoded PKCS #8 ed25519 private key, as written by
+// `openssl genpkey -algorithm ed25519`
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	privKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is %T, not ed25519", key)
+	}
+	return privKey, nil
+}
+
+// LoadPublicKey reads a PEM-encoded PKIX ed25519 public key, as written by
+// `openssl pkey -pubout`
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	pubKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is %T, not ed25519", key)
+	}
+	return pubKey, nil
+}
+
+// readPEM reads the first PEM block from a key file
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-specified key path
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	return block, nil
+}
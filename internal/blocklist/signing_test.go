@@ -0,0 +1,164 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blocklist
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/pkg/models"
+)
+
+// writeKeyPair generates an ed25519 key pair and writes it as PEM files
+func writeKeyPair(t *testing.T, dir, name string) (privPath, pubPath string) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("Failed to marshal private key: %v", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+
+	privPath = filepath.Join(dir, name+".pem")
+	pubPath = filepath.Join(dir, name+".pub.pem")
+	if err := os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600); err != nil {
+		t.Fatalf("Failed to write private key: %v", err)
+	}
+	if err := os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0600); err != nil {
+		t.Fatalf("Failed to write public key: %v", err)
+	}
+	return privPath, pubPath
+}
+
+// signedExport writes a signed export of one entry and returns its path and the public key path
+func signedExport(t *testing.T) (exportPath, pubPath string) {
+	t.Helper()
+
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	if _, err := manager.Block(BlockRequest{Username: "spammer", Reason: "spam", BlockedBy: "maintainer", Severity: models.SeverityHigh, Source: models.SourceManual}); err != nil {
+		t.Fatalf("Block failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	privPath, pubPath := writeKeyPair(t, dir, "signing")
+	exportPath = filepath.Join(dir, "blocklist.json")
+	if err := manager.ExportSignedJSON(exportPath, privPath, models.EntryFilter{}); err != nil {
+		t.Fatalf("ExportSignedJSON failed: %v", err)
+	}
+	return exportPath, pubPath
+}
+
+func TestSignedJSON_RoundTrip(t *testing.T) {
+	exportPath, pubPath := signedExport(t)
+
+	if _, err := os.Stat(SignaturePath(exportPath)); err != nil {
+		t.Fatalf("Expected signature file: %v", err)
+	}
+
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	imported, err := manager.ImportSignedJSON(exportPath, pubPath)
+	if err != nil {
+		t.Fatalf("ImportSignedJSON failed: %v", err)
+	}
+	if imported != 1 {
+		t.Errorf("Expected 1 imported entry, got %d", imported)
+	}
+}
+
+func TestSignedJSON_RejectsTampering(t *testing.T) {
+	exportPath, pubPath := signedExport(t)
+
+	data, err := os.ReadFile(exportPath) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+	tampered := strings.Replace(string(data), `"severity": "high"`, `"severity": "low"`, 1)
+	if tampered == string(data) {
+		t.Fatal("Tampering did not change the export")
+	}
+	if err := os.WriteFile(exportPath, []byte(tampered), 0600); err != nil {
+		t.Fatalf("Failed to write tampered export: %v", err)
+	}
+
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	imported, err := manager.ImportSignedJSON(exportPath, pubPath)
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("Expected ErrInvalidSignature, got %v", err)
+	}
+	if imported != 0 {
+		t.Errorf("Expected nothing imported, got %d", imported)
+	}
+	if blocked, _ := manager.IsBlocked("spammer"); blocked {
+		t.Error("Tampered entries must not be imported")
+	}
+}
+
+func TestSignedJSON_RejectsWrongKey(t *testing.T) {
+	exportPath, _ := signedExport(t)
+	_, otherPub := writeKeyPair(t, t.TempDir(), "other")
+
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	if _, err := manager.ImportSignedJSON(exportPath, otherPub); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestSignedJSON_MissingSignature(t *testing.T) {
+	exportPath, pubPath := signedExport(t)
+	if err := os.Remove(SignaturePath(exportPath)); err != nil {
+		t.Fatalf("Failed to remove signature: %v", err)
+	}
+
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	if _, err := manager.ImportSignedJSON(exportPath, pubPath); err == nil {
+		t.Error("Expected an error when the signature file is missing")
+	}
+}
+
+func TestLoadKeys_RejectsWrongKind(t *testing.T) {
+	privPath, pubPath := writeKeyPair(t, t.TempDir(), "key")
+
+	if _, err := LoadPrivateKey(pubPath); err == nil {
+		t.Error("Expected an error loading a public key as a private key")
+	}
+	if _, err := LoadPublicKey(privPath); err == nil {
+		t.Error("Expected an error loading a private key as a public key")
+	}
+}
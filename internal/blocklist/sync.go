@@ -0,0 +1,112 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blocklist
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+// SyncResult reports the outcome of syncing one blocklist source
+type SyncResult struct {
+	Source      config.BlocklistSource
+	Imported    int                      // Entries added or upgraded (trusted sources)
+	Staged      []*models.BlocklistEntry // Entries awaiting confirmation (untrusted sources)
+	Reviewed    bool                     // Staged matches the entries listed by the previous sync
+	NotModified bool                     // The source was unchanged since the last sync
+	Err         error
+}
+
+// SyncSources syncs every source with AutoSync enabled. Trusted sources are
+// imported directly. Untrusted sources are only fetched: the entries an import
+// would add or upgrade are returned in Staged for MergeStaged after review,
+// with Reviewed reporting whether the previous sync staged the same entries.
+// A failing source is reported in its result without stopping the others.
+func (m *Manager) SyncSources(sources []config.BlocklistSource) []*SyncResult {
+	var results []*SyncResult
+	for _, source := range sources {
+		if !source.AutoSync {
+			continue
+		}
+
+		result := &SyncResult{Source: source}
+		if source.Trusted {
+			result.Imported, result.Err = m.ImportJSONFromURL(source.URL)
+			if errors.Is(result.Err, ErrNotModified) {
+				result.NotModified = true
+				result.Err = nil
+			}
+		} else {
+			result.Staged, result.Reviewed, result.Err = m.stage(source.URL)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// stage fetches and validates a remote list and returns the entries an import
+// would change. The staged set is recorded so the next sync can tell whether
+// the source changed since these entries were listed.
+func (m *Manager) stage(url string) ([]*models.BlocklistEntry, bool, error) {
+	entries, _, err := fetchJSON(m.client, m.maxFetchSize, url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if problems := ValidateEntries(entries); len(problems) > 0 {
+		return nil, false, &ValidationError{Problems: problems}
+	}
+
+	added, upgraded, _, err := m.Diff(entries)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, upgrade := range upgraded {
+		added = append(added, upgrade.Incoming)
+	}
+
+	digest, err := stagedDigest(added)
+	if err != nil {
+		return nil, false, err
+	}
+	previous, err := m.db.GetStagedDigest(url)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get staged entries: %w", err)
+	}
+	if err := m.db.SaveStagedDigest(url, digest); err != nil {
+		return nil, false, fmt.Errorf("failed to record staged entries: %w", err)
+	}
+	return added, previous == digest, nil
+}
+
+// stagedDigest hashes a staged entry set so an unchanged source yields the same digest
+func stagedDigest(entries []*models.BlocklistEntry) (string, error) {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash staged entries: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// MergeStaged imports entries staged by SyncSources with deduplication
+func (m *Manager) MergeStaged(entries []*models.BlocklistEntry) (int, error) {
+	return m.importEntries(entries)
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blocklist
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+// sourceServer serves usernames as a JSON blocklist
+func sourceServer(t *testing.T, usernames ...string) *httptest.Server {
+	t.Helper()
+
+	var entries []*models.BlocklistEntry
+	for _, username := range usernames {
+		entries = append(entries, models.NewBlocklistEntry(username, "spam", "", "upstream", models.SeverityHigh, models.SourceManual))
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatalf("Failed to marshal entries: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(data) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSyncSources(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	trusted := sourceServer(t, "trusted-1", "trusted-2")
+	untrusted := sourceServer(t, "untrusted-1")
+	manual := sourceServer(t, "manual-1")
+	broken := httptest.NewServer(http.NotFoundHandler())
+	defer broken.Close()
+
+	results := manager.SyncSources([]config.BlocklistSource{
+		{Name: "trusted", URL: trusted.URL, Trusted: true, AutoSync: true},
+		{Name: "untrusted", URL: untrusted.URL, AutoSync: true},
+		{Name: "manual", URL: manual.URL, Trusted: true},
+		{Name: "broken", URL: broken.URL, Trusted: true, AutoSync: true},
+	})
+
+	if len(results) != 3 {
+		t.Fatalf("Expected results for the 3 auto-sync sources, got %d", len(results))
+	}
+
+	if results[0].Err != nil || results[0].Imported != 2 {
+		t.Errorf("Trusted source: imported %d, err %v; want 2, nil", results[0].Imported, results[0].Err)
+	}
+	if results[1].Err != nil || results[1].Imported != 0 || len(results[1].Staged) != 1 || results[1].Reviewed {
+		t.Errorf("Untrusted source: imported %d, staged %d, reviewed %v, err %v; want 0, 1, false, nil", results[1].Imported, len(results[1].Staged), results[1].Reviewed, results[1].Err)
+	}
+	if results[2].Err == nil {
+		t.Error("Broken source should report an error")
+	}
+
+	for username, want := range map[string]bool{"trusted-1": true, "trusted-2": true, "untrusted-1": false, "manual-1": false} {
+		if blocked, _ := manager.IsBlocked(username); blocked != want {
+			t.Errorf("IsBlocked(%s) = %v, want %v", username, blocked, want)
+		}
+	}
+
+	// Staging the same entries again marks them as reviewed
+	restaged := manager.SyncSources([]config.BlocklistSource{{Name: "untrusted", URL: untrusted.URL, AutoSync: true}})
+	if !restaged[0].Reviewed {
+		t.Error("Expected an unchanged source to be marked reviewed")
+	}
+
+	merged, err := manager.MergeStaged(results[1].Staged)
+	if err != nil {
+		t.Fatalf("MergeStaged failed: %v", err)
+	}
+	if merged != 1 {
+		t.Errorf("Expected 1 merged entry, got %d", merged)
+	}
+	if blocked, _ := manager.IsBlocked("untrusted-1"); !blocked {
+		t.Error("Staged entry should be blocked after merging")
+	}
+
+	// Once merged, the untrusted source has nothing left to stage
+	results = manager.SyncSources([]config.BlocklistSource{{Name: "untrusted", URL: untrusted.URL, AutoSync: true}})
+	if len(results[0].Staged) != 0 {
+		t.Errorf("Expected nothing staged after merging, got %d", len(results[0].Staged))
+	}
+}
+
+func TestSyncSources_ChangedSourceIsNotReviewed(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	body := []byte(`[{"id":"id-1","username":"untrusted-1","reason":"spam","severity":"high","source":"manual"}]`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(body) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	sources := []config.BlocklistSource{{Name: "untrusted", URL: server.URL, AutoSync: true}}
+	manager.SyncSources(sources)
+
+	// The source adds an entry after the first sync listed it
+	body = []byte(`[{"id":"id-1","username":"untrusted-1","reason":"spam","severity":"high","source":"manual"},` +
+		`{"id":"id-2","username":"untrusted-2","reason":"spam","severity":"high","source":"manual"}]`)
+	results := manager.SyncSources(sources)
+	if results[0].Err != nil {
+		t.Fatalf("SyncSources failed: %v", results[0].Err)
+	}
+	if results[0].Reviewed {
+		t.Error("Expected a changed source not to be marked reviewed")
+	}
+	if len(results[0].Staged) != 2 {
+		t.Errorf("Expected 2 staged entries, got %d", len(results[0].Staged))
+	}
+}
+
+func TestSyncSources_RejectsInvalidEntries(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[null]`)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	results := manager.SyncSources([]config.BlocklistSource{{Name: "untrusted", URL: server.URL, AutoSync: true}})
+	var validationErr *ValidationError
+	if !errors.As(results[0].Err, &validationErr) {
+		t.Errorf("Expected a validation error, got %v", results[0].Err)
+	}
+	if len(results[0].Staged) != 0 {
+		t.Errorf("Expected nothing staged from an invalid source, got %d", len(results[0].Staged))
+	}
+}
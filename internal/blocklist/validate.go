@@ -0,0 +1,98 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blocklist
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/prguard/prguard/pkg/models"
+)
+
+// ImportProblem describes an entry that cannot be imported
+type ImportProblem struct {
+	Index    int    // Zero-based position of the entry in the file
+	Username string // Username of the entry, if any
+	Problem  string
+}
+
+func (p ImportProblem) String() string {
+	if p.Username == "" {
+		return fmt.Sprintf("entry %d: %s", p.Index+1, p.Problem)
+	}
+	return fmt.Sprintf("entry %d (%s): %s", p.Index+1, p.Username, p.Problem)
+}
+
+// ValidationError is returned by imports when entries fail validation; nothing
+// is written when it is returned
+type ValidationError struct {
+	Problems []ImportProblem
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		problems[i] = p.String()
+	}
+	return fmt.Sprintf("%d invalid %s: %s", len(e.Problems), pluralEntries(len(e.Problems)), strings.Join(problems, "; "))
+}
+
+// ValidateEntries checks that every entry has an ID, a username, a known
+// severity, and a known source (or none, since new entries are marked imported)
+func ValidateEntries(entries []*models.BlocklistEntry) []ImportProblem {
+	var problems []ImportProblem
+	for i, entry := range entries {
+		if entry == nil {
+			problems = append(problems, ImportProblem{Index: i, Problem: "entry is null"})
+			continue
+		}
+
+		add := func(format string, args ...any) {
+			problems = append(problems, ImportProblem{Index: i, Username: entry.Username, Problem: fmt.Sprintf(format, args...)})
+		}
+		if strings.TrimSpace(entry.Username) == "" {
+			add("missing username")
+		}
+		if entry.ID == "" {
+			add("missing id")
+		}
+		if models.SeverityRank(entry.Severity) == 0 {
+			add("invalid severity %q (must be low, medium, or high)", entry.Severity)
+		}
+		switch entry.Source {
+		case "", models.SourceManual, models.SourceImported, models.SourceAutoDetected:
+		default:
+			add("invalid source %q (must be manual, imported, or auto-detected)", entry.Source)
+		}
+	}
+	return problems
+}
+
+// ImportJSONValidate reads a JSON file and reports every entry that would fail
+// to import, without writing anything
+func ImportJSONValidate(path string) ([]ImportProblem, error) {
+	entries, err := LoadJSON(path)
+	if err != nil {
+		return nil, err
+	}
+	return ValidateEntries(entries), nil
+}
+
+func pluralEntries(count int) string {
+	if count == 1 {
+		return "entry"
+	}
+	return "entries"
+}
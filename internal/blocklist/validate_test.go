@@ -0,0 +1,122 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blocklist
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/pkg/models"
+)
+
+// writeEntriesJSON writes entries to a JSON file in a temp directory
+func writeEntriesJSON(t *testing.T, entries []*models.BlocklistEntry) string {
+	t.Helper()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatalf("failed to marshal entries: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "import.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("failed to write import file: %v", err)
+	}
+	return path
+}
+
+// entriesWithInvalidSeverity returns two valid entries around one whose
+// severity the database would reject
+func entriesWithInvalidSeverity() []*models.BlocklistEntry {
+	return []*models.BlocklistEntry{
+		models.NewBlocklistEntry("valid1", "spam", "https://example.com/1", "admin", models.SeverityHigh, models.SourceImported),
+		models.NewBlocklistEntry("badseverity", "spam", "https://example.com/2", "admin", "critical", models.SourceImported),
+		models.NewBlocklistEntry("valid2", "spam", "https://example.com/3", "admin", models.SeverityLow, models.SourceImported),
+	}
+}
+
+func TestValidateEntries(t *testing.T) {
+	valid := models.NewBlocklistEntry("user", "spam", "", "admin", models.SeverityMedium, models.SourceManual)
+	noSource := models.NewBlocklistEntry("user2", "spam", "", "admin", models.SeverityMedium, "")
+	badSource := models.NewBlocklistEntry("user3", "spam", "", "admin", models.SeverityMedium, "scraped")
+	noUsername := models.NewBlocklistEntry(" ", "spam", "", "admin", models.SeverityLow, models.SourceManual)
+	noID := models.NewBlocklistEntry("user4", "spam", "", "admin", models.SeverityLow, models.SourceManual)
+	noID.ID = ""
+
+	problems := ValidateEntries([]*models.BlocklistEntry{valid, noSource, badSource, noUsername, noID, nil})
+
+	want := []string{
+		`entry 3 (user3): invalid source "scraped"`,
+		"entry 4 ( ): missing username",
+		"entry 5 (user4): missing id",
+		"entry 6: entry is null",
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("expected %d problems, got %d: %v", len(want), len(problems), problems)
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(problems[i].String(), prefix) {
+			t.Errorf("problem %d = %q, want prefix %q", i, problems[i], prefix)
+		}
+	}
+}
+
+func TestImportJSONValidate(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	problems, err := ImportJSONValidate(writeEntriesJSON(t, entriesWithInvalidSeverity()))
+	if err != nil {
+		t.Fatalf("ImportJSONValidate failed: %v", err)
+	}
+
+	if len(problems) != 1 {
+		t.Fatalf("expected 1 problem, got %d: %v", len(problems), problems)
+	}
+	if problems[0].Index != 1 || problems[0].Username != "badseverity" || !strings.Contains(problems[0].Problem, `"critical"`) {
+		t.Errorf("unexpected problem: %+v", problems[0])
+	}
+
+	entries, _ := manager.List()
+	if len(entries) != 0 {
+		t.Errorf("validation should not write entries, found %d", len(entries))
+	}
+}
+
+func TestImportJSON_InvalidEntryRejectsImport(t *testing.T) {
+	manager, db := setupTestManager(t)
+	defer db.Close() //nolint:errcheck
+
+	count, err := manager.ImportJSON(writeEntriesJSON(t, entriesWithInvalidSeverity()))
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+	if len(validationErr.Problems) != 1 {
+		t.Errorf("expected 1 problem, got %v", validationErr.Problems)
+	}
+	if count != 0 {
+		t.Errorf("expected 0 entries imported, got %d", count)
+	}
+
+	entries, _ := manager.List()
+	if len(entries) != 0 {
+		t.Errorf("expected nothing written, found %d entries", len(entries))
+	}
+}
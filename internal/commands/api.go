@@ -0,0 +1,121 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prguard/prguard/internal/api"
+	"github.com/prguard/prguard/internal/scanner"
+
+	"github.com/spf13/cobra"
+)
+
+// NewAPICommand creates the api command
+func NewAPICommand(configPath *string) *cobra.Command {
+	var addr string
+	var shutdownGrace time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Serve the blocklist and on-demand scans as a JSON API",
+		Long: `Runs an HTTP server exposing the blocklist and scanner as JSON:
+
+  GET  /healthz                200 when the database is reachable, 503 otherwise
+  GET  /blocklist              All blocklist entries
+  GET  /blocklist/{username}   Entries for one user (404 if none)
+  GET  /check/{username}       Whether a user is blocked
+  POST /scan                   Scan {"owner": ..., "repo": ...} and return the report
+
+POST /scan requires "Authorization: Bearer <token>" matching api.token in the
+config (or PRGUARD_API_TOKEN) and is disabled when no token is configured.
+Scans only report findings; no automated actions are taken.
+
+On Ctrl-C or SIGTERM the server stops accepting connections and waits up to
+--shutdown-timeout for in-flight requests to finish.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runAPI(*configPath, addr, shutdownGrace)
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", ":8080", "Address to listen on")
+	cmd.Flags().DurationVar(&shutdownGrace, "shutdown-timeout", 30*time.Second, "Time to wait for in-flight requests on shutdown")
+
+	return cmd
+}
+
+func runAPI(configPath, addr string, shutdownGrace time.Duration) error {
+	cfg, ghClient, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	scan, err := scanner.NewScannerE(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.API.Token == "" {
+		fmt.Println("Warning: api.token is not set; POST /scan is disabled")
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           api.NewServer(blManager, db, scan, ghClient, cfg.API.Token).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Serving API on %s\n", addr)
+	return serveUntilDone(ctx, server, shutdownGrace)
+}
+
+// serveUntilDone runs server until ctx is done, then shuts it down gracefully,
+// waiting up to grace for in-flight requests before closing remaining connections
+func serveUntilDone(ctx context.Context, server *http.Server, grace time.Duration) error {
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	fmt.Println("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		_ = server.Close() //nolint:errcheck
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("in-flight requests did not finish within %s", grace)
+		}
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// freeAddr returns a localhost address with a port that is currently unused
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	addr := l.Addr().String()
+	_ = l.Close() //nolint:errcheck
+	return addr
+}
+
+func TestServeUntilDone_WaitsForInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := &http.Server{
+		Addr: freeAddr(t),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			close(started)
+			<-release
+			w.WriteHeader(http.StatusNoContent)
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- serveUntilDone(ctx, server, 5*time.Second) }()
+
+	status := make(chan int, 1)
+	go func() {
+		var resp *http.Response
+		var err error
+		for range 50 {
+			if resp, err = http.Get("http://" + server.Addr); err == nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if err != nil {
+			status <- 0
+			return
+		}
+		_ = resp.Body.Close() //nolint:errcheck
+		status <- resp.StatusCode
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-done:
+		t.Fatalf("server stopped before the in-flight request finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if code := <-status; code != http.StatusNoContent {
+		t.Errorf("in-flight request status = %d, want 204", code)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("serveUntilDone() error = %v", err)
+	}
+}
+
+func TestServeUntilDone_ShutdownTimeout(t *testing.T) {
+	started := make(chan struct{})
+	server := &http.Server{
+		Addr: freeAddr(t),
+		Handler: http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-r.Context().Done()
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- serveUntilDone(ctx, server, 50*time.Millisecond) }()
+
+	go func() {
+		for range 50 {
+			if resp, err := http.Get("http://" + server.Addr); err == nil {
+				_ = resp.Body.Close() //nolint:errcheck
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	<-started
+	cancel()
+
+	if err := <-done; err == nil {
+		t.Error("expected an error when in-flight requests outlive the timeout")
+	}
+}
@@ -17,17 +17,36 @@ package commands
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/internal/config"
 	"github.com/prguard/prguard/pkg/models"
 	"github.com/spf13/cobra"
 )
 
+// blockOptions holds the command-line options for a block
+type blockOptions struct {
+	reason           string
+	evidenceURL      string
+	severity         string
+	expires          string   // Duration after which the block expires; permanent when empty
+	tags             []string // Reason codes attached to the entry
+	escalate         bool     // Raise the severity of repeat offenders
+	githubBlock      bool
+	allowAnyEvidence bool
+	fromFile         string // File listing usernames to block, one per line
+	yes              bool
+}
+
 // NewBlockCommand creates the block command
-func NewBlockCommand(configPath *string) *cobra.Command {
-	var reason, evidenceURL, severity string
-	var githubBlock bool
+func NewBlockCommand(configPath *string, assumeYes *bool) *cobra.Command {
+	var opts blockOptions
+	var interactive bool
 
 	cmd := &cobra.Command{
 		Use:   "block <username>",
@@ -35,34 +54,275 @@ func NewBlockCommand(configPath *string) *cobra.Command {
 		Long: `Blocks a GitHub user by adding them to the local blocklist.
 
 Optionally blocks them via GitHub API using --github-block flag.
+The evidence must be a GitHub pull request or issue URL; use --allow-any-evidence
+to record some other link.
+Use --tag (repeatable) to attach reason codes such as crypto-spam for filtering.
+Use --expires to make the block temporary (e.g. 30d, 12h).
+Blocking a user again with the same evidence updates their existing entry
+instead of adding a duplicate.
+Repeat offenders are escalated: a user's second block is at least medium severity
+and later blocks are high, unless --severity is given explicitly.
+Use --from-file to block every username listed in a file (one per line; blank
+lines and lines starting with # are ignored) with the same reason, evidence and
+severity.
+Use --interactive to be prompted for the reason, evidence and severity when they
+are not given as flags.
 Note: GitHub blocking works at organization or personal account level, not per-repository.`,
-		Args: cobra.ExactArgs(1),
-		RunE: func(_ *cobra.Command, args []string) error {
-			return runBlock(*configPath, args[0], reason, evidenceURL, severity, githubBlock)
+		Args: func(_ *cobra.Command, args []string) error {
+			if opts.fromFile != "" {
+				if len(args) != 0 {
+					return fmt.Errorf("cannot pass a username together with --from-file")
+				}
+				return nil
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("accepts 1 arg(s), received %d", len(args))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.fromFile != "" && opts.githubBlock {
+				return fmt.Errorf("--github-block cannot be used with --from-file")
+			}
+			// An explicit --severity overrides repeat-offense escalation
+			opts.escalate = !cmd.Flags().Changed("severity")
+			if interactive {
+				chosen, err := promptBlockDetails(bufio.NewReader(os.Stdin), &opts.reason, &opts.evidenceURL, &opts.severity, opts.escalate, opts.allowAnyEvidence)
+				if err != nil {
+					return err
+				}
+				opts.escalate = opts.escalate && !chosen
+			}
+			if err := requireBlockDetails(opts.reason, opts.evidenceURL); err != nil {
+				return err
+			}
+
+			if opts.fromFile != "" {
+				return runBlockFromFile(*configPath, opts)
+			}
+			opts.yes = *assumeYes
+			return runBlock(*configPath, args[0], opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&reason, "reason", "r", "", "Reason for blocking (required)")
-	cmd.Flags().StringVarP(&evidenceURL, "evidence", "e", "", "URL to evidence (PR/issue link, required)")
-	cmd.Flags().StringVarP(&severity, "severity", "s", "medium", "Severity level (low/medium/high)")
-	cmd.Flags().BoolVar(&githubBlock, "github-block", false, "Also block user via GitHub API (affects ALL repos in org/account)")
-	_ = cmd.MarkFlagRequired("reason")
-	_ = cmd.MarkFlagRequired("evidence")
+	cmd.Flags().StringVarP(&opts.reason, "reason", "r", "", "Reason for blocking (required unless --interactive)")
+	cmd.Flags().StringVarP(&opts.evidenceURL, "evidence", "e", "", "URL to evidence (PR/issue link, required unless --interactive)")
+	cmd.Flags().StringVarP(&opts.severity, "severity", "s", "medium", "Severity level (low/medium/high)")
+	cmd.Flags().StringArrayVarP(&opts.tags, "tag", "t", nil, "Reason code to attach to the entry (repeatable)")
+	cmd.Flags().StringVar(&opts.expires, "expires", "", "Expire the block after a duration (e.g. 30d, 12h); permanent if unset")
+	cmd.Flags().BoolVar(&opts.githubBlock, "github-block", false, "Also block user via GitHub API (affects ALL repos in org/account)")
+	cmd.Flags().BoolVar(&opts.allowAnyEvidence, "allow-any-evidence", false, "Accept an evidence value that is not a GitHub PR/issue URL")
+	cmd.Flags().StringVar(&opts.fromFile, "from-file", "", "Block every username listed in a file (one per line)")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Prompt for the reason, evidence and severity when not given as flags")
+	_ = cmd.RegisterFlagCompletionFunc("severity", completeSeverity)
 
 	return cmd
 }
 
-func runBlock(configPath, username, reason, evidenceURL, severity string, githubBlock bool) error {
-	cfg, ghClient, blManager, db, err := initClients(configPath)
+// parseExpiry converts a duration such as "30d" or "12h" into an absolute expiry time
+func parseExpiry(value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	d, err := parseDays(value)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("invalid expiry %q: %w", value, err)
 	}
-	defer db.Close() //nolint:errcheck
 
-	// Validate severity
+	if d <= 0 {
+		return nil, fmt.Errorf("invalid expiry %q: must be positive", value)
+	}
+
+	expiresAt := now.Add(d).UTC()
+	return &expiresAt, nil
+}
+
+// parseDays parses a Go duration, also accepting a day suffix such as "30d"
+func parseDays(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// validateSeverity checks that severity is one of low/medium/high
+func validateSeverity(severity string) error {
 	if severity != models.SeverityLow && severity != models.SeverityMedium && severity != models.SeverityHigh {
 		return fmt.Errorf("invalid severity, must be low/medium/high")
 	}
+	return nil
+}
+
+// validateEvidence checks the evidence URL unless the caller opted out with --allow-any-evidence
+func validateEvidence(evidenceURL string, allowAny bool) error {
+	if allowAny {
+		return nil
+	}
+	if err := models.ValidateEvidenceURL(evidenceURL); err != nil {
+		return fmt.Errorf("%w (use --allow-any-evidence to skip this check)", err)
+	}
+	return nil
+}
+
+// requireBlockDetails checks that a reason and evidence were provided
+func requireBlockDetails(reason, evidenceURL string) error {
+	var missing []string
+	if reason == "" {
+		missing = append(missing, `"reason"`)
+	}
+	if evidenceURL == "" {
+		missing = append(missing, `"evidence"`)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("required flag(s) %s not set (or use --interactive)", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// promptBlockDetails prompts for the reason and evidence when they are empty,
+// and for a severity when askSeverity is set, re-asking until each answer is
+// valid. It reports whether a severity was chosen; an empty answer keeps the
+// current one.
+func promptBlockDetails(reader *bufio.Reader, reason, evidenceURL, severity *string, askSeverity, allowAnyEvidence bool) (bool, error) {
+	var err error
+	if *reason == "" {
+		*reason, err = promptUntilValid(reader, "Reason: ", func(answer string) error {
+			if answer == "" {
+				return fmt.Errorf("a reason is required")
+			}
+			return nil
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	if *evidenceURL == "" {
+		*evidenceURL, err = promptUntilValid(reader, "Evidence URL: ", func(answer string) error {
+			if answer == "" {
+				return fmt.Errorf("an evidence URL is required")
+			}
+			return validateEvidence(answer, allowAnyEvidence)
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	if !askSeverity {
+		return false, nil
+	}
+
+	answer, err := promptUntilValid(reader, fmt.Sprintf("Severity (low/medium/high) [%s]: ", *severity), func(answer string) error {
+		if answer == "" {
+			return nil
+		}
+		return validateSeverity(answer)
+	})
+	if err != nil || answer == "" {
+		return false, err
+	}
+	*severity = answer
+	return true, nil
+}
+
+// readUsernames reads one username per line, ignoring blank lines and # comments
+func readUsernames(r io.Reader) ([]string, error) {
+	var usernames []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		usernames = append(usernames, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usernames: %w", err)
+	}
+	return usernames, nil
+}
+
+func runBlockFromFile(configPath string, opts blockOptions) error {
+	if err := validateSeverity(opts.severity); err != nil {
+		return err
+	}
+	if err := validateEvidence(opts.evidenceURL, opts.allowAnyEvidence); err != nil {
+		return err
+	}
+
+	expiresAt, err := parseExpiry(opts.expires, time.Now())
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(opts.fromFile) //nolint:gosec // path is provided by the user
+	if err != nil {
+		return fmt.Errorf("failed to open username file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	usernames, err := readUsernames(f)
+	if err != nil {
+		return err
+	}
+	if len(usernames) == 0 {
+		return fmt.Errorf("no usernames found in %s", opts.fromFile)
+	}
+
+	cfg, _, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	blockedBy := cfg.GitHub.User
+	if blockedBy == "" {
+		blockedBy = cfg.GitHub.Org
+	}
+
+	added, skipped, err := blManager.BlockMany(usernames, blocklist.BlockRequest{
+		Reason:      opts.reason,
+		EvidenceURL: opts.evidenceURL,
+		BlockedBy:   blockedBy,
+		Severity:    opts.severity,
+		Source:      models.SourceManual,
+		ExpiresAt:   expiresAt,
+		Tags:        opts.tags,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to block users: %w", err)
+	}
+
+	fmt.Printf("✓ Added %d user(s) to local blocklist\n", added)
+	if skipped > 0 {
+		fmt.Printf("  Skipped %d already blocked or duplicate user(s)\n", skipped)
+	}
+
+	return nil
+}
+
+func runBlock(configPath, username string, opts blockOptions) error {
+	if err := validateSeverity(opts.severity); err != nil {
+		return err
+	}
+	if err := validateEvidence(opts.evidenceURL, opts.allowAnyEvidence); err != nil {
+		return err
+	}
+
+	expiresAt, err := parseExpiry(opts.expires, time.Now())
+	if err != nil {
+		return err
+	}
+
+	cfg, ghClient, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
 
 	// Determine who is blocking
 	blockedBy := cfg.GitHub.User
@@ -70,70 +330,92 @@ func runBlock(configPath, username, reason, evidenceURL, severity string, github
 		blockedBy = cfg.GitHub.Org
 	}
 
-	// Add to local blocklist
-	entry, err := blManager.Block(username, reason, evidenceURL, blockedBy, severity, models.SourceManual)
+	// Add to local blocklist, or update the entry already recorded for this evidence
+	result, err := blManager.Block(blocklist.BlockRequest{
+		Username:       username,
+		Reason:         opts.reason,
+		EvidenceURL:    opts.evidenceURL,
+		BlockedBy:      blockedBy,
+		Severity:       opts.severity,
+		Source:         models.SourceManual,
+		ExpiresAt:      expiresAt,
+		Tags:           opts.tags,
+		Escalate:       opts.escalate,
+		Snapshot:       ghClient,
+		UpdateExisting: true,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to block user: %w", err)
 	}
+	entry := result.Entry
 
-	fmt.Printf("✓ User %s added to local blocklist\n", username)
+	if result.Updated {
+		fmt.Printf("✓ Updated existing blocklist entry for %s with the same evidence\n", username)
+	} else {
+		fmt.Printf("✓ User %s added to local blocklist\n", username)
+	}
 	fmt.Printf("  ID: %s\n", entry.ID)
 	fmt.Printf("  Reason: %s\n", entry.Reason)
 	fmt.Printf("  Evidence: %s\n", entry.EvidenceURL)
 	fmt.Printf("  Severity: %s\n", entry.Severity)
+	if result.Offense > 1 {
+		fmt.Printf("  Repeat offense: #%d\n", result.Offense)
+	}
+	if len(entry.Tags) > 0 {
+		fmt.Printf("  Tags: %s\n", strings.Join(entry.Tags, ", "))
+	}
+	if entry.ExpiresAt != nil {
+		fmt.Printf("  Expires: %s\n", entry.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	}
 
 	// GitHub API blocking (optional)
-	if githubBlock {
+	if opts.githubBlock {
 		fmt.Println()
-		switch {
-		case cfg.GitHub.Org != "":
-			// Organization-level blocking
-			fmt.Printf("⚠️  WARNING: This will block %s from ALL repositories in the '%s' organization.\n", username, cfg.GitHub.Org)
-			fmt.Print("Continue? (y/N): ")
-
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(strings.ToLower(response))
-
-			if response != "y" && response != "yes" {
-				fmt.Println("GitHub blocking cancelled. User remains in local blocklist.")
-				return nil
-			}
+		return executeGitHubBlock(cfg, newGitHubBlocker(cfg, ghClient, blManager, os.Stdout, 0), bufio.NewReader(os.Stdin), username, opts.yes)
+	}
 
-			if err := ghClient.BlockUserOrg(cfg.GitHub.Org, username); err != nil {
-				return fmt.Errorf("failed to block user via GitHub API: %w", err)
-			}
-			fmt.Printf("✓ User %s blocked at organization level via GitHub API\n", username)
-			fmt.Printf("  Scope: ALL repositories in '%s' organization\n", cfg.GitHub.Org)
-			fmt.Println("  Required permission: admin:org")
-		case cfg.GitHub.User != "":
-			// Personal account-level blocking
-			fmt.Printf("⚠️  WARNING: This will block %s from ALL repositories owned by your personal account (%s).\n", username, cfg.GitHub.User)
-			fmt.Print("Continue? (y/N): ")
-
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(strings.ToLower(response))
-
-			if response != "y" && response != "yes" {
-				fmt.Println("GitHub blocking cancelled. User remains in local blocklist.")
-				return nil
-			}
+	fmt.Println("\nNote: User is only blocked in PRGuard's local database.")
+	fmt.Println("To also block via GitHub API, use: --github-block flag")
+	fmt.Println("(This will block them from ALL repos in your org/account)")
 
-			if err := ghClient.BlockUserPersonal(username); err != nil {
-				return fmt.Errorf("failed to block user via GitHub API: %w", err)
-			}
-			fmt.Printf("✓ User %s blocked at personal account level via GitHub API\n", username)
-			fmt.Printf("  Scope: ALL repositories owned by '%s'\n", cfg.GitHub.User)
-			fmt.Println("  Required permission: user")
-		default:
-			return fmt.Errorf("cannot use --github-block: neither github.org nor github.user is configured")
-		}
-	} else {
-		fmt.Println("\nNote: User is only blocked in PRGuard's local database.")
-		fmt.Println("To also block via GitHub API, use: --github-block flag")
-		fmt.Println("(This will block them from ALL repos in your org/account)")
+	return nil
+}
+
+// executeGitHubBlock blocks a user via the GitHub API after confirming the
+// org- or account-wide scope, skipping users blocker has already blocked
+func executeGitHubBlock(cfg *config.Config, blocker *githubBlocker, reader *bufio.Reader, username string, assumeYes bool) error {
+	switch {
+	case cfg.GitHub.Org != "":
+		// Organization-level blocking
+		fmt.Printf("⚠️  WARNING: This will block %s from ALL repositories in the '%s' organization.\n", username, cfg.GitHub.Org)
+	case cfg.GitHub.User != "":
+		// Personal account-level blocking
+		fmt.Printf("⚠️  WARNING: This will block %s from ALL repositories owned by your personal account (%s).\n", username, cfg.GitHub.User)
+	default:
+		return fmt.Errorf("cannot use --github-block: neither github.org nor github.user is configured")
+	}
+	if !confirmPrompt(reader, assumeYes) {
+		fmt.Println("GitHub blocking cancelled. User remains in local blocklist.")
+		return nil
+	}
+
+	skipped, err := blocker.block(username)
+	if err != nil {
+		return fmt.Errorf("failed to block user via GitHub API: %w", err)
+	}
+	if skipped {
+		fmt.Printf("- User %s is already blocked via GitHub API\n", username)
+		return nil
 	}
 
+	if cfg.GitHub.Org != "" {
+		fmt.Printf("✓ User %s blocked at organization level via GitHub API\n", username)
+		fmt.Printf("  Scope: ALL repositories in '%s' organization\n", cfg.GitHub.Org)
+		fmt.Println("  Required permission: admin:org")
+	} else {
+		fmt.Printf("✓ User %s blocked at personal account level via GitHub API\n", username)
+		fmt.Printf("  Scope: ALL repositories owned by '%s'\n", cfg.GitHub.User)
+		fmt.Println("  Required permission: user")
+	}
 	return nil
 }
@@ -15,18 +15,23 @@
 package commands
 
 import (
+	"bufio"
+	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/prguard/prguard/internal/config"
 	"github.com/prguard/prguard/internal/database"
+	"github.com/prguard/prguard/internal/mocks"
 	"github.com/prguard/prguard/pkg/models"
 )
 
 func TestBlockCommand_Flags(t *testing.T) {
 	configPath := "config.yaml"
-	cmd := NewBlockCommand(&configPath)
+	cmd := NewBlockCommand(&configPath, new(bool))
 
 	// Test required flags are set
 	reasonFlag := cmd.Flags().Lookup("reason")
@@ -155,7 +160,7 @@ func TestBlockCommand_Integration(t *testing.T) {
 	defer db.Close() //nolint:errcheck
 
 	// Test blocking a user (without GitHub API)
-	err = runBlock(configPath, "testuser", "spam", "https://github.com/test/repo/pull/1", models.SeverityMedium, false)
+	err = runBlock(configPath, "testuser", blockOptions{reason: "spam", evidenceURL: "https://github.com/test/repo/pull/1", severity: models.SeverityMedium})
 	if err != nil {
 		t.Errorf("runBlock failed: %v", err)
 	}
@@ -227,13 +232,13 @@ func TestBlockCommand_DuplicateUser(t *testing.T) {
 	defer db.Close() //nolint:errcheck
 
 	// Block user first time
-	err = runBlock(configPath, "spammer", "spam", "https://github.com/test/repo/pull/1", models.SeverityLow, false)
+	err = runBlock(configPath, "spammer", blockOptions{reason: "spam", evidenceURL: "https://github.com/test/repo/pull/1", severity: models.SeverityLow})
 	if err != nil {
 		t.Fatalf("first block failed: %v", err)
 	}
 
 	// Block same user again with higher severity
-	err = runBlock(configPath, "spammer", "more spam", "https://github.com/test/repo/pull/2", models.SeverityHigh, false)
+	err = runBlock(configPath, "spammer", blockOptions{reason: "more spam", evidenceURL: "https://github.com/test/repo/pull/2", severity: models.SeverityHigh})
 	if err != nil {
 		t.Fatalf("second block failed: %v", err)
 	}
@@ -248,15 +253,266 @@ func TestBlockCommand_DuplicateUser(t *testing.T) {
 	}
 }
 
+func TestBlockCommand_SameEvidenceUpdates(t *testing.T) {
+	configPath, db := setupTestConfig(t)
+
+	evidence := "https://github.com/test/repo/pull/1"
+	if err := runBlock(configPath, "spammer", blockOptions{reason: "spam", evidenceURL: evidence, severity: models.SeverityLow}); err != nil {
+		t.Fatalf("first block failed: %v", err)
+	}
+	if err := runBlock(configPath, "spammer", blockOptions{reason: "crypto spam", evidenceURL: evidence, severity: models.SeverityHigh}); err != nil {
+		t.Fatalf("second block failed: %v", err)
+	}
+
+	entries, err := db.GetEntriesByUsername("spammer")
+	if err != nil {
+		t.Fatalf("failed to get entries: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected the repeat block to update the entry, got %d entries", len(entries))
+	}
+	if entries[0].Reason != "crypto spam" || entries[0].Severity != models.SeverityHigh {
+		t.Errorf("expected reason and severity to be updated, got %q %s", entries[0].Reason, entries[0].Severity)
+	}
+}
+
+func TestBlockCommand_EscalatesRepeatOffenders(t *testing.T) {
+	configPath, db := setupTestConfig(t)
+
+	for i := 1; i <= 3; i++ {
+		evidence := fmt.Sprintf("https://github.com/test/repo/pull/%d", i)
+		if err := runBlock(configPath, "repeat", blockOptions{reason: "spam", evidenceURL: evidence, severity: models.SeverityLow, escalate: true}); err != nil {
+			t.Fatalf("block #%d failed: %v", i, err)
+		}
+	}
+	// An explicit severity is kept as given
+	if err := runBlock(configPath, "repeat", blockOptions{reason: "spam", evidenceURL: "https://github.com/test/repo/pull/4", severity: models.SeverityLow}); err != nil {
+		t.Fatalf("explicit block failed: %v", err)
+	}
+
+	entries, err := db.GetEntriesByUsername("repeat")
+	if err != nil {
+		t.Fatalf("failed to get entries: %v", err)
+	}
+	severities := map[string]string{}
+	for _, entry := range entries {
+		severities[entry.EvidenceURL] = entry.Severity
+	}
+	want := map[string]string{
+		"https://github.com/test/repo/pull/1": models.SeverityLow,
+		"https://github.com/test/repo/pull/2": models.SeverityMedium,
+		"https://github.com/test/repo/pull/3": models.SeverityHigh,
+		"https://github.com/test/repo/pull/4": models.SeverityLow,
+	}
+	for evidence, severity := range want {
+		if severities[evidence] != severity {
+			t.Errorf("%s: expected severity %s, got %s", evidence, severity, severities[evidence])
+		}
+	}
+}
+
 func TestBlockCommand_MissingConfig(t *testing.T) {
 	configPath := "/nonexistent/config.yaml"
-	err := runBlock(configPath, "testuser", "spam", "https://github.com/test/repo/pull/1", models.SeverityMedium, false)
+	err := runBlock(configPath, "testuser", blockOptions{reason: "spam", evidenceURL: "https://github.com/test/repo/pull/1", severity: models.SeverityMedium})
 	if err == nil {
 		t.Error("expected error with missing config")
 	}
 }
 
+func TestBlockCommand_InvalidEvidence(t *testing.T) {
+	// Evidence is validated before the config is loaded
+	err := runBlock("/nonexistent/config.yaml", "testuser", blockOptions{reason: "spam", evidenceURL: "see slack", severity: models.SeverityMedium})
+	if err == nil || !strings.Contains(err.Error(), "--allow-any-evidence") {
+		t.Errorf("expected evidence validation error, got %v", err)
+	}
+
+	err = runBlock("/nonexistent/config.yaml", "testuser", blockOptions{reason: "spam", evidenceURL: "see slack", severity: models.SeverityMedium, allowAnyEvidence: true})
+	if err == nil || strings.Contains(err.Error(), "evidence") {
+		t.Errorf("expected --allow-any-evidence to skip validation, got %v", err)
+	}
+}
+
 // Cleanup helper
+func TestReadUsernames(t *testing.T) {
+	input := "# spam wave 2025-03\nspammer1\n\n  spammer2  \n# reviewed later\nspammer3\n"
+
+	usernames, err := readUsernames(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("readUsernames failed: %v", err)
+	}
+
+	want := []string{"spammer1", "spammer2", "spammer3"}
+	if len(usernames) != len(want) {
+		t.Fatalf("expected %v, got %v", want, usernames)
+	}
+	for i := range want {
+		if usernames[i] != want[i] {
+			t.Errorf("expected %q at %d, got %q", want[i], i, usernames[i])
+		}
+	}
+}
+
+func TestBlockCommand_FromFile(t *testing.T) {
+	configPath, db := setupTestConfig(t)
+
+	existing := models.NewBlocklistEntry("spammer2", "earlier", "https://github.com/test/repo/pull/9", "testowner", models.SeverityLow, models.SourceManual)
+	if err := db.AddEntry(existing); err != nil {
+		t.Fatalf("failed to add existing entry: %v", err)
+	}
+
+	listPath := filepath.Join(t.TempDir(), "users.txt")
+	if err := os.WriteFile(listPath, []byte("spammer1\nspammer2\n# comment\n\nspammer3\nspammer1\n"), 0600); err != nil {
+		t.Fatalf("failed to write username file: %v", err)
+	}
+
+	if err := runBlockFromFile(configPath, blockOptions{reason: "offsite review", evidenceURL: "https://example.com/review", severity: models.SeverityHigh, allowAnyEvidence: true, fromFile: listPath}); err != nil {
+		t.Fatalf("runBlockFromFile failed: %v", err)
+	}
+
+	for _, username := range []string{"spammer1", "spammer2", "spammer3"} {
+		entries, err := db.GetEntriesByUsername(username)
+		if err != nil {
+			t.Fatalf("failed to get entries: %v", err)
+		}
+		if len(entries) != 1 {
+			t.Errorf("expected 1 entry for %s, got %d", username, len(entries))
+		}
+	}
+
+	entries, _ := db.GetEntriesByUsername("spammer3")
+	if len(entries) == 1 && (entries[0].Reason != "offsite review" || entries[0].Severity != models.SeverityHigh) {
+		t.Errorf("shared reason/severity not applied: %+v", entries[0])
+	}
+}
+
+func TestBlockCommand_FromFileErrors(t *testing.T) {
+	configPath, _ := setupTestConfig(t)
+
+	emptyPath := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(emptyPath, []byte("# nothing here\n"), 0600); err != nil {
+		t.Fatalf("failed to write username file: %v", err)
+	}
+
+	if err := runBlockFromFile(configPath, blockOptions{reason: "spam", evidenceURL: "https://example.com", severity: models.SeverityLow, allowAnyEvidence: true, fromFile: emptyPath}); err == nil {
+		t.Error("expected error for file without usernames")
+	}
+	if err := runBlockFromFile(configPath, blockOptions{reason: "spam", evidenceURL: "https://example.com", severity: models.SeverityLow, allowAnyEvidence: true, fromFile: filepath.Join(t.TempDir(), "missing.txt")}); err == nil {
+		t.Error("expected error for missing file")
+	}
+	if err := runBlockFromFile(configPath, blockOptions{reason: "spam", evidenceURL: "https://example.com", severity: "extreme", allowAnyEvidence: true, fromFile: emptyPath}); err == nil {
+		t.Error("expected error for invalid severity")
+	}
+
+	cmd := NewBlockCommand(&configPath, new(bool))
+	cmd.SetArgs([]string{"someone", "--from-file", emptyPath, "-r", "spam", "-e", "https://example.com"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error when passing a username with --from-file")
+	}
+}
+
+func TestBlockCommand_RequiresReasonAndEvidence(t *testing.T) {
+	configPath := "config.yaml"
+	cmd := NewBlockCommand(&configPath, new(bool))
+	cmd.SetArgs([]string{"someone", "-r", "spam"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `"evidence"`) {
+		t.Errorf("expected a missing evidence error, got %v", err)
+	}
+}
+
+func TestPromptBlockDetails(t *testing.T) {
+	// An empty reason and an invalid URL are re-asked; Enter keeps the severity
+	reader := bufio.NewReader(strings.NewReader("\nspam links\nnot-a-url\nhttps://github.com/org/repo/pull/7\nextreme\nhigh\n"))
+	reason, evidenceURL, severity := "", "", models.SeverityMedium
+
+	chosen, err := promptBlockDetails(reader, &reason, &evidenceURL, &severity, true, false)
+	if err != nil {
+		t.Fatalf("promptBlockDetails failed: %v", err)
+	}
+	if reason != "spam links" || evidenceURL != "https://github.com/org/repo/pull/7" {
+		t.Errorf("unexpected answers: reason %q, evidence %q", reason, evidenceURL)
+	}
+	if !chosen || severity != models.SeverityHigh {
+		t.Errorf("expected severity high to be chosen, got %q (chosen %v)", severity, chosen)
+	}
+}
+
+func TestPromptBlockDetails_KeepsFlagValues(t *testing.T) {
+	reason, evidenceURL, severity := "from flag", "https://github.com/org/repo/pull/1", models.SeverityMedium
+
+	// Only the severity is asked for, and Enter keeps the default
+	chosen, err := promptBlockDetails(bufio.NewReader(strings.NewReader("\n")), &reason, &evidenceURL, &severity, true, false)
+	if err != nil {
+		t.Fatalf("promptBlockDetails failed: %v", err)
+	}
+	if chosen || severity != models.SeverityMedium || reason != "from flag" {
+		t.Errorf("expected flag values to be kept, got reason %q severity %q (chosen %v)", reason, severity, chosen)
+	}
+
+	// Nothing is missing and severity was given, so nothing is read
+	if _, err := promptBlockDetails(noStdin(t), &reason, &evidenceURL, &severity, false, false); err != nil {
+		t.Errorf("expected no prompts, got %v", err)
+	}
+}
+
+func TestPromptBlockDetails_InputRunsOut(t *testing.T) {
+	reason, evidenceURL, severity := "", "", models.SeverityMedium
+	if _, err := promptBlockDetails(bufio.NewReader(strings.NewReader("spam\n")), &reason, &evidenceURL, &severity, true, false); err == nil {
+		t.Error("expected an error when input ends before the evidence is given")
+	}
+}
+
+func TestExecuteGitHubBlock(t *testing.T) {
+	tests := []struct {
+		name         string
+		github       config.GitHubConfig
+		reader       func(t *testing.T) *bufio.Reader
+		assumeYes    bool
+		marked       bool // Already blocked via the GitHub API by an earlier run
+		wantOrg      int
+		wantPersonal int
+	}{
+		{"org with --yes", config.GitHubConfig{Org: "test-org"}, noStdin, true, false, 1, 0},
+		{"personal with --yes", config.GitHubConfig{User: "testowner"}, noStdin, true, false, 0, 1},
+		{"org declined", config.GitHubConfig{Org: "test-org"}, func(*testing.T) *bufio.Reader {
+			return bufio.NewReader(strings.NewReader("n\n"))
+		}, false, false, 0, 0},
+		{"org already blocked", config.GitHubConfig{Org: "test-org"}, noStdin, true, true, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var orgCalls, personalCalls int
+			mockGH := &mocks.MockGitHubClient{
+				BlockUserOrgFn: func(string, string) error {
+					orgCalls++
+					return nil
+				},
+				BlockUserPersonalFn: func(string) error {
+					personalCalls++
+					return nil
+				},
+			}
+
+			cfg := &config.Config{GitHub: tt.github}
+			marked := map[string]bool{"spammer": tt.marked}
+			blocker := newGitHubBlocker(cfg, mockGH, trackingManager(marked), &bytes.Buffer{}, 0)
+			if err := executeGitHubBlock(cfg, blocker, tt.reader(t), "spammer", tt.assumeYes); err != nil {
+				t.Fatalf("executeGitHubBlock failed: %v", err)
+			}
+			if calls := tt.wantOrg + tt.wantPersonal; calls > 0 && !marked["spammer"] {
+				t.Error("expected the GitHub block to be recorded")
+			}
+			if orgCalls != tt.wantOrg {
+				t.Errorf("expected %d org block calls, got %d", tt.wantOrg, orgCalls)
+			}
+			if personalCalls != tt.wantPersonal {
+				t.Errorf("expected %d personal block calls, got %d", tt.wantPersonal, personalCalls)
+			}
+		})
+	}
+}
+
 func TestMain(m *testing.M) {
 	code := m.Run()
 	os.Exit(code)
@@ -16,6 +16,10 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"os"
+
+	"github.com/prguard/prguard/pkg/models"
 
 	"github.com/spf13/cobra"
 )
@@ -35,7 +39,7 @@ func NewCheckCommand(configPath *string) *cobra.Command {
 }
 
 func runCheck(configPath, username string) error {
-	_, _, blManager, db, err := initClients(configPath)
+	cfg, _, blManager, db, err := initClients(configPath)
 	if err != nil {
 		return err
 	}
@@ -56,6 +60,7 @@ func runCheck(configPath, username string) error {
 			return fmt.Errorf("failed to get entries: %w", err)
 		}
 
+		times := newTimeFormatter(cfg.Display)
 		for _, entry := range entries {
 			fmt.Printf("Entry ID: %s\n", entry.ID)
 			fmt.Printf("  Reason: %s\n", entry.Reason)
@@ -63,7 +68,12 @@ func runCheck(configPath, username string) error {
 			fmt.Printf("  Severity: %s\n", entry.Severity)
 			fmt.Printf("  Blocked by: %s\n", entry.BlockedBy)
 			fmt.Printf("  Source: %s\n", entry.Source)
-			fmt.Printf("  Date: %s\n\n", entry.Timestamp.Format("2006-01-02 15:04:05"))
+			if entry.ExpiresAt != nil {
+				fmt.Printf("  Expires: %s\n", times.format(*entry.ExpiresAt))
+			}
+			fmt.Printf("  Date: %s\n", times.format(entry.Timestamp))
+			printAccountSnapshot(os.Stdout, entry, times)
+			fmt.Println()
 		}
 	} else {
 		fmt.Printf("User %s is NOT blocked\n", username)
@@ -71,3 +81,18 @@ func runCheck(configPath, username string) error {
 
 	return nil
 }
+
+// printAccountSnapshot writes the account details captured when the entry was
+// created. Entries without a snapshot, or with unreadable metadata, print nothing.
+func printAccountSnapshot(w io.Writer, entry *models.BlocklistEntry, times timeFormatter) {
+	metadata, err := entry.ParseMetadata()
+	if err != nil || metadata.Account == nil {
+		return
+	}
+	account := metadata.Account
+	fmt.Fprintln(w, "  Account at block time:")
+	fmt.Fprintf(w, "    Created: %s\n", account.CreatedAt.Format("2006-01-02"))
+	fmt.Fprintf(w, "    Followers: %d\n", account.Followers)
+	fmt.Fprintf(w, "    Public repos: %d\n", account.PublicRepos)
+	fmt.Fprintf(w, "    Captured: %s\n", times.format(account.CapturedAt))
+}
@@ -15,7 +15,9 @@
 package commands
 
 import (
+	"bytes"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/prguard/prguard/internal/blocklist"
@@ -59,7 +61,7 @@ func TestCheckCommand_UserBlocked(t *testing.T) {
 
 	// Add a blocked user
 	manager := blocklist.NewManager(db)
-	_, err = manager.Block("blockeduser", "spam", "https://github.com/test/repo/pull/1", "test-org", models.SeverityHigh, models.SourceManual)
+	_, err = manager.Block(blocklist.BlockRequest{Username: "blockeduser", Reason: "spam", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "test-org", Severity: models.SeverityHigh, Source: models.SourceManual})
 	if err != nil {
 		t.Fatalf("failed to block user: %v", err)
 	}
@@ -146,12 +148,12 @@ func TestCheckCommand_MultipleEntries(t *testing.T) {
 
 	// Add multiple entries for the same user
 	manager := blocklist.NewManager(db)
-	_, err = manager.Block("repeatoffender", "spam PR 1", "https://github.com/test/repo/pull/1", "test-org", models.SeverityLow, models.SourceManual)
+	_, err = manager.Block(blocklist.BlockRequest{Username: "repeatoffender", Reason: "spam PR 1", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "test-org", Severity: models.SeverityLow, Source: models.SourceManual})
 	if err != nil {
 		t.Fatalf("failed to block user first time: %v", err)
 	}
 
-	_, err = manager.Block("repeatoffender", "spam PR 2", "https://github.com/test/repo/pull/2", "test-org", models.SeverityHigh, models.SourceManual)
+	_, err = manager.Block(blocklist.BlockRequest{Username: "repeatoffender", Reason: "spam PR 2", EvidenceURL: "https://github.com/test/repo/pull/2", BlockedBy: "test-org", Severity: models.SeverityHigh, Source: models.SourceManual})
 	if err != nil {
 		t.Fatalf("failed to block user second time: %v", err)
 	}
@@ -172,3 +174,29 @@ func TestCheckCommand_MultipleEntries(t *testing.T) {
 		t.Errorf("expected 2 entries, got %d", len(entries))
 	}
 }
+
+func TestPrintAccountSnapshot(t *testing.T) {
+	entry := models.NewBlocklistEntry("spammer", "spam", "", "admin", models.SeverityLow, models.SourceManual)
+	entry.Metadata = `{"account":{"created_at":"2025-03-01T12:00:00Z","followers":2,"public_repos":7,"captured_at":"2025-03-02T08:00:00Z"}}`
+
+	var out bytes.Buffer
+	printAccountSnapshot(&out, entry, newTimeFormatter(config.DisplayConfig{}))
+	for _, want := range []string{"Account at block time:", "Created: 2025-03-01", "Followers: 2", "Public repos: 7", "Captured: 2025-03-02T08:00:00Z"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestPrintAccountSnapshot_NoSnapshot(t *testing.T) {
+	for _, metadata := range []string{"", "{}", `{"signals":["readme_only"]}`, "not json"} {
+		entry := models.NewBlocklistEntry("spammer", "spam", "", "admin", models.SeverityLow, models.SourceManual)
+		entry.Metadata = metadata
+
+		var out bytes.Buffer
+		printAccountSnapshot(&out, entry, newTimeFormatter(config.DisplayConfig{}))
+		if out.Len() != 0 {
+			t.Errorf("Metadata %q: expected no output, got %q", metadata, out.String())
+		}
+	}
+}
@@ -17,7 +17,9 @@ package commands
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/prguard/prguard/internal/github"
 	"github.com/spf13/cobra"
 )
 
@@ -27,10 +29,11 @@ func NewClosePRCommand(configPath *string) *cobra.Command {
 	var addLabel bool
 
 	cmd := &cobra.Command{
-		Use:   "close-pr <owner>/<repo> <pr-number>...",
-		Short: "Close one or more spam pull requests",
-		Long:  `Closes pull requests and optionally adds a spam label`,
-		Args:  cobra.MinimumNArgs(2),
+		Use:               "close-pr <owner>/<repo> <pr-number>...",
+		Short:             "Close one or more spam pull requests",
+		Long:              `Closes pull requests and optionally adds the spam labels from actions.spam_label and actions.labels`,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeRepositories(configPath),
 		RunE: func(_ *cobra.Command, args []string) error {
 			repo = args[0]
 			prNumbers := args[1:]
@@ -39,7 +42,7 @@ func NewClosePRCommand(configPath *string) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&comment, "comment", "c", "", "Comment to add (uses config default if not specified)")
-	cmd.Flags().BoolVarP(&addLabel, "label", "l", false, "Add 'spam' label to the PR")
+	cmd.Flags().BoolVarP(&addLabel, "label", "l", false, "Add the configured spam labels to the PR")
 
 	return cmd
 }
@@ -62,6 +65,14 @@ func runClosePR(configPath, repo string, prNumbers []string, comment string, add
 		comment = cfg.Actions.CommentTemplate
 	}
 
+	// Create missing labels once for the repository
+	labelPRs := addLabel || cfg.Actions.AddSpamLabel
+	if labelPRs {
+		if err := ensureSpamLabels(ghClient, cfg.Actions, owner, repoName); err != nil {
+			fmt.Printf("Warning: failed to create label: %v\n", err)
+		}
+	}
+
 	// Close each PR
 	for _, prNumStr := range prNumbers {
 		prNum, err := strconv.Atoi(prNumStr)
@@ -72,14 +83,14 @@ func runClosePR(configPath, repo string, prNumbers []string, comment string, add
 		fmt.Printf("Closing PR #%d...\n", prNum)
 
 		// Add label if requested
-		if addLabel || cfg.Actions.AddSpamLabel {
-			if err := ghClient.AddLabel(owner, repoName, prNum, "spam"); err != nil {
+		if labelPRs {
+			if err := addSpamLabels(ghClient, cfg.Actions, owner, repoName, prNum); err != nil {
 				fmt.Printf("  Warning: failed to add label: %v\n", err)
 			}
 		}
 
 		// Close the PR
-		if err := ghClient.ClosePullRequest(owner, repoName, prNum, comment); err != nil {
+		if err := ghClient.ClosePullRequest(owner, repoName, prNum, renderCloseComment(ghClient, owner, repoName, prNum, comment)); err != nil {
 			return fmt.Errorf("failed to close PR #%d: %w", prNum, err)
 		}
 
@@ -88,3 +99,25 @@ func runClosePR(configPath, repo string, prNumbers []string, comment string, add
 
 	return nil
 }
+
+// renderCloseComment renders a comment template for a PR closed by hand. The
+// PR is only fetched when the template needs it; there are no scan reasons.
+// A template that fails to render is replaced by the default comment.
+func renderCloseComment(ghClient github.GitHubClient, owner, repoName string, prNum int, comment string) string {
+	data := templateData{PRNumber: prNum, Repo: owner + "/" + repoName}
+	if strings.Contains(comment, ".Author") {
+		pr, err := ghClient.GetPullRequest(owner, repoName, prNum)
+		if err != nil {
+			fmt.Printf("  Warning: failed to fetch PR author: %v\n", err)
+		} else {
+			data.Author = pr.Author
+		}
+	}
+
+	rendered, err := renderTemplate("comment_template", comment, data)
+	if err != nil {
+		fmt.Printf("  Warning: %v; using default comment\n", err)
+		return defaultPRComment
+	}
+	return rendered
+}
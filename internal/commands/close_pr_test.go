@@ -178,3 +178,31 @@ func executeClosePR(ghClient github.GitHubClient, owner, repo string, prNumbers
 
 	return nil
 }
+
+func TestRenderCloseComment(t *testing.T) {
+	fetched := 0
+	mockClient := &mocks.MockGitHubClient{
+		GetPullRequestFn: func(_, _ string, number int) (*github.PullRequest, error) {
+			fetched++
+			return &github.PullRequest{Number: number, Author: "spammer"}, nil
+		},
+	}
+
+	got := renderCloseComment(mockClient, "owner", "repo", 7, "@{{.Author}}: #{{.PRNumber}} in {{.Repo}}")
+	if got != "@spammer: #7 in owner/repo" {
+		t.Errorf("renderCloseComment() = %q", got)
+	}
+
+	got = renderCloseComment(mockClient, "owner", "repo", 7, "Closing #{{.PRNumber}}")
+	if got != "Closing #7" {
+		t.Errorf("renderCloseComment() = %q", got)
+	}
+	if fetched != 1 {
+		t.Errorf("expected the PR to be fetched only when .Author is used, got %d fetches", fetched)
+	}
+
+	got = renderCloseComment(mockClient, "owner", "repo", 7, "Hi {{.Autor}}")
+	if got != defaultPRComment {
+		t.Errorf("renderCloseComment() = %q, want the default comment for a broken template", got)
+	}
+}
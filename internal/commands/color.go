@@ -0,0 +1,58 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"os"
+)
+
+// ANSI escape codes used to highlight scan output
+const (
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorReset  = "\033[0m"
+)
+
+// colorDisabled is set by the --no-color flag
+var colorDisabled bool
+
+// stdoutIsTerminal reports whether stdout is a terminal; replaced in tests
+var stdoutIsTerminal = func() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// DisableColor turns off colored output, as requested by --no-color
+func DisableColor() {
+	colorDisabled = true
+}
+
+// colorEnabled reports whether output should be colored: stdout must be a
+// terminal and neither --no-color nor the NO_COLOR environment variable set
+func colorEnabled() bool {
+	if colorDisabled || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return stdoutIsTerminal()
+}
+
+// colorize wraps s in the given color when colored output is enabled
+func colorize(color, s string) string {
+	if !colorEnabled() {
+		return s
+	}
+	return color + s + colorReset
+}
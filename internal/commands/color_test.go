@@ -0,0 +1,108 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+// withTerminal pretends stdout is (or is not) a terminal for the test
+func withTerminal(t *testing.T, terminal bool) {
+	t.Helper()
+	original := stdoutIsTerminal
+	stdoutIsTerminal = func() bool { return terminal }
+	t.Cleanup(func() { stdoutIsTerminal = original })
+}
+
+// withColorDisabled sets the --no-color state for the test
+func withColorDisabled(t *testing.T, disabled bool) {
+	t.Helper()
+	original := colorDisabled
+	colorDisabled = disabled
+	t.Cleanup(func() { colorDisabled = original })
+}
+
+func colorTestResults() *scanner.ScanResults {
+	return &scanner.ScanResults{
+		Total: 3,
+		Spam: []*scanner.ScanResult{
+			{PR: &github.PullRequest{Number: 1, Author: "spammer"}, IsSpam: true, Severity: "high"},
+		},
+		Uncertain: []*scanner.ScanResult{
+			{PR: &github.PullRequest{Number: 2, Author: "newbie"}, IsUncertain: true},
+		},
+		Clean: []*scanner.ScanResult{
+			{PR: &github.PullRequest{Number: 3, Author: "regular"}},
+		},
+	}
+}
+
+func renderScanOutput() string {
+	var out bytes.Buffer
+	results := colorTestResults()
+	displayScanSummary(&out, results)
+	displaySpamResults(&out, results)
+	displayUncertainResults(&out, results, false)
+	return out.String()
+}
+
+func TestColorize_Terminal(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	withTerminal(t, true)
+	withColorDisabled(t, false)
+
+	out := renderScanOutput()
+	for _, want := range []string{
+		colorRed + "Spam detected: 1" + colorReset,
+		colorYellow + "Uncertain: 1" + colorReset,
+		colorGreen + "Clean: 1" + colorReset,
+		colorRed + "=== SPAM DETECTED ===" + colorReset,
+		colorYellow + "=== MANUAL REVIEW NEEDED ===" + colorReset,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%q", want, out)
+		}
+	}
+}
+
+func TestColorize_Disabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		terminal bool
+		noColor  bool
+		envVar   string
+	}{
+		{name: "--no-color", terminal: true, noColor: true},
+		{name: "NO_COLOR", terminal: true, envVar: "1"},
+		{name: "not a terminal", terminal: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.envVar)
+			withTerminal(t, tt.terminal)
+			withColorDisabled(t, tt.noColor)
+
+			if out := renderScanOutput(); strings.Contains(out, "\033[") {
+				t.Errorf("expected no escape codes, got:\n%q", out)
+			}
+		})
+	}
+}
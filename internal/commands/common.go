@@ -15,7 +15,11 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"time"
 
 	"github.com/prguard/prguard/internal/blocklist"
 	"github.com/prguard/prguard/internal/config"
@@ -57,8 +61,77 @@ func initClients(configPath string) (*config.Config, github.GitHubClient, blockl
 		return nil, nil, nil, nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
 
-	ghClient := github.NewClient(cfg.GitHub.Token)
+	ghClient, err := newGitHubClient(cfg)
+	if err != nil {
+		_ = db.Close() //nolint:errcheck
+		return nil, nil, nil, nil, fmt.Errorf("failed to initialize GitHub client: %w", err)
+	}
 	blManager := blocklist.NewManager(db)
+	blManager.SetFetchTimeout(cfg.Blocklist.FetchTimeout)
 
 	return cfg, ghClient, blManager, db, nil
 }
+
+// newGitHubClient creates a GitHub client using App authentication when configured,
+// falling back to the personal access token
+func newGitHubClient(cfg *config.Config) (github.GitHubClient, error) {
+	if cfg.GitHub.UsesApp() {
+		client, err := github.NewClientFromApp(cfg.GitHub.AppID, cfg.GitHub.InstallationID, cfg.GitHub.PrivateKeyPath, cfg.GitHub.Retries())
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+	return github.NewClient(cfg.GitHub.Token, cfg.GitHub.Retries()), nil
+}
+
+// timeFormatter renders timestamps in the configured display layout and time zone
+type timeFormatter struct {
+	layout   string
+	location *time.Location
+}
+
+// newTimeFormatter returns a formatter for the display settings. Config
+// validation rejects unknown time zones, so one that fails to load falls back to UTC.
+func newTimeFormatter(display config.DisplayConfig) timeFormatter {
+	location, err := display.Location()
+	if err != nil {
+		location = time.UTC
+	}
+	return timeFormatter{layout: display.Layout(), location: location}
+}
+
+// format renders t in the display time zone and layout
+func (f timeFormatter) format(t time.Time) string {
+	return t.In(f.location).Format(f.layout)
+}
+
+// scanContext returns a context that is cancelled on Ctrl-C or once the configured
+// GitHub timeout elapses. The returned stop function must be called to release it.
+func scanContext(cfg *config.Config) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	if cfg.GitHub.Timeout <= 0 {
+		return ctx, stop
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.GitHub.Timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// ExitError is returned by commands that should exit with a specific status
+// code, such as scan --fail-on
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
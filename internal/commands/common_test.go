@@ -0,0 +1,52 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+)
+
+func TestTimeFormatter(t *testing.T) {
+	instant := time.Date(2025, 3, 2, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		display config.DisplayConfig
+		want    string
+	}{
+		{"defaults", config.DisplayConfig{}, "2025-03-02T08:30:00Z"},
+		{"UTC RFC3339", config.DisplayConfig{TimeFormat: "RFC3339", TimeZone: "UTC"}, "2025-03-02T08:30:00Z"},
+		{"named zone", config.DisplayConfig{TimeFormat: "RFC3339", TimeZone: "America/New_York"}, "2025-03-02T03:30:00-05:00"},
+		{"named layout", config.DisplayConfig{TimeFormat: "DateTime", TimeZone: "Asia/Tokyo"}, "2025-03-02 17:30:00"},
+		{"custom layout", config.DisplayConfig{TimeFormat: "02 Jan 2006 15:04 MST", TimeZone: "Europe/Berlin"}, "02 Mar 2025 09:30 CET"},
+		{"unknown zone", config.DisplayConfig{TimeZone: "Mars/Olympus"}, "2025-03-02T08:30:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.display.TimeZone != "" && tt.display.TimeZone != "Mars/Olympus" {
+				if _, err := time.LoadLocation(tt.display.TimeZone); err != nil {
+					t.Skipf("time zone database unavailable: %v", err)
+				}
+			}
+			if got := newTimeFormatter(tt.display).format(instant); got != tt.want {
+				t.Errorf("format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
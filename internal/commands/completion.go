@@ -0,0 +1,94 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// NewCompletionCommand creates the completion command
+func NewCompletionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Generate a shell completion script",
+		Long: `Prints a completion script for the given shell.
+
+To load completions for the current shell session:
+
+  bash:        source <(prguard completion bash)
+  zsh:         source <(prguard completion zsh)
+  fish:        prguard completion fish | source
+  powershell:  prguard completion powershell | Out-String | Invoke-Expression
+
+To load them in every session, write the script to your shell's completion
+directory, e.g. prguard completion bash > /etc/bash_completion.d/prguard`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCompletion(cmd.Root(), os.Stdout, args[0])
+		},
+	}
+	return cmd
+}
+
+func runCompletion(root *cobra.Command, w io.Writer, shell string) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(w, true)
+	case "zsh":
+		return root.GenZshCompletion(w)
+	case "fish":
+		return root.GenFishCompletion(w, true)
+	case "powershell":
+		return root.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell %q, must be bash, zsh, fish, or powershell", shell)
+	}
+}
+
+// completeSeverity completes severity flag values
+func completeSeverity(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return []string{"low", "medium", "high"}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRepositories returns a completion function offering the configured
+// repositories as owner/repo for a command's first argument. A missing or
+// unreadable config simply offers nothing.
+func completeRepositories(configPath *string) cobra.CompletionFunc {
+	return func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		cfg, _, err := config.Read(*configPath)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var repos []string
+		for _, repo := range cfg.Repositories {
+			if name := repo.FullName(); strings.HasPrefix(name, toComplete) {
+				repos = append(repos, name)
+			}
+		}
+		return repos, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestRunCompletion_Bash(t *testing.T) {
+	configPath := "config.yaml"
+	root := &cobra.Command{Use: "prguard"}
+	root.AddCommand(NewScanCommand(&configPath, new(bool)))
+	root.AddCommand(NewCompletionCommand())
+
+	var out bytes.Buffer
+	if err := runCompletion(root, &out, "bash"); err != nil {
+		t.Fatalf("runCompletion failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "__start_prguard") {
+		t.Errorf("Expected a bash completion script for prguard, got:\n%s", out.String())
+	}
+}
+
+func TestRunCompletion_UnknownShell(t *testing.T) {
+	if err := runCompletion(&cobra.Command{Use: "prguard"}, &bytes.Buffer{}, "tcsh"); err == nil {
+		t.Error("Expected an error for an unsupported shell")
+	}
+}
+
+func TestCompleteSeverity(t *testing.T) {
+	got, directive := completeSeverity(nil, nil, "")
+	if !slices.Equal(got, []string{"low", "medium", "high"}) {
+		t.Errorf("Expected low, medium, high, got %v", got)
+	}
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("Expected file completion to be disabled, got %v", directive)
+	}
+}
+
+func TestCompleteRepositories(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	cfg := &config.Config{
+		GitHub: config.GitHubConfig{Token: "test-token", User: "testowner"},
+		Repositories: []config.Repository{
+			{Owner: "org", Name: "api"},
+			{Owner: "org", Name: "web"},
+			{Owner: "other", Name: "tools"},
+		},
+	}
+	if err := config.Save(cfg, configPath); err != nil {
+		t.Fatalf("failed to save test config: %v", err)
+	}
+	complete := completeRepositories(&configPath)
+
+	got, _ := complete(nil, nil, "")
+	if !slices.Equal(got, []string{"org/api", "org/web", "other/tools"}) {
+		t.Errorf("Expected every configured repository, got %v", got)
+	}
+	got, _ = complete(nil, nil, "org/")
+	if !slices.Equal(got, []string{"org/api", "org/web"}) {
+		t.Errorf("Expected repositories matching org/, got %v", got)
+	}
+	if got, _ := complete(nil, []string{"org/api"}, ""); len(got) != 0 {
+		t.Errorf("Expected no completions after the repository argument, got %v", got)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+	if got, _ := completeRepositories(&missing)(nil, nil, ""); len(got) != 0 {
+		t.Errorf("Expected no completions without a config, got %v", got)
+	}
+}
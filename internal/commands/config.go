@@ -0,0 +1,107 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// NewConfigCommand creates the config command
+func NewConfigCommand(configPath *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the configuration file",
+	}
+
+	cmd.AddCommand(newConfigValidateCommand(configPath))
+	cmd.AddCommand(newConfigSchemaCommand())
+
+	return cmd
+}
+
+func newConfigValidateCommand(configPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration file for problems",
+		Long: `Loads the configuration file, reports which file was used, and lists every
+problem that would stop PRGuard from running. Suspicious but valid settings are
+reported as warnings.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runConfigValidate(os.Stdout, *configPath)
+		},
+	}
+}
+
+func runConfigValidate(w io.Writer, configPath string) error {
+	cfg, path, err := config.Read(configPath)
+	if path != "" {
+		fmt.Fprintf(w, "Config file: %s\n", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	if problems := cfg.Problems(); len(problems) > 0 {
+		fmt.Fprintf(w, "\n✗ Found %d %s:\n", len(problems), pluralize("problem", "problems", len(problems)))
+		for _, problem := range problems {
+			fmt.Fprintf(w, "  - %v\n", problem)
+		}
+		return fmt.Errorf("invalid configuration")
+	}
+
+	cfg.SetDefaults()
+
+	if warnings := cfg.Warnings(); len(warnings) > 0 {
+		fmt.Fprintf(w, "\n⚠ %d %s:\n", len(warnings), pluralize("warning", "warnings", len(warnings)))
+		for _, warning := range warnings {
+			fmt.Fprintf(w, "  - %s\n", warning)
+		}
+	}
+
+	fmt.Fprintln(w, "\n✓ Configuration is valid")
+	return nil
+}
+
+func newConfigSchemaCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print a JSON Schema for the configuration file",
+		Long: `Prints a JSON Schema describing the configuration file so editors can offer
+completion and validation, e.g.:
+
+  prguard config schema > prguard.schema.json`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runConfigSchema(os.Stdout)
+		},
+	}
+}
+
+func runConfigSchema(w io.Writer) error {
+	schema, err := config.SchemaJSON()
+	if err != nil {
+		return fmt.Errorf("failed to generate schema: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, string(schema)); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	return nil
+}
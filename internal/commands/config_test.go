@@ -0,0 +1,158 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "prguard.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestRunConfigValidate_Valid(t *testing.T) {
+	path := writeTestConfig(t, `github:
+  token: test-token
+  org: test-org
+database:
+  type: sqlite
+  path: /tmp/prguard.db
+repositories:
+  - owner: test-org
+    name: repo
+filters:
+  whitelist:
+    - dependabot[bot]
+`)
+
+	var out bytes.Buffer
+	if err := runConfigValidate(&out, path); err != nil {
+		t.Fatalf("runConfigValidate failed: %v", err)
+	}
+
+	output := out.String()
+	if !strings.Contains(output, "Config file: "+path) {
+		t.Errorf("expected config path in output, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Configuration is valid") {
+		t.Errorf("expected OK message, got:\n%s", output)
+	}
+	if strings.Contains(output, "warning") {
+		t.Errorf("expected no warnings, got:\n%s", output)
+	}
+}
+
+func TestRunConfigValidate_Warnings(t *testing.T) {
+	path := writeTestConfig(t, `github:
+  token: test-token
+  org: test-org
+database:
+  type: sqlite
+  path: /tmp/prguard.db
+filters:
+  min_files: -1
+`)
+
+	var out bytes.Buffer
+	if err := runConfigValidate(&out, path); err != nil {
+		t.Fatalf("runConfigValidate failed: %v", err)
+	}
+
+	output := out.String()
+	for _, want := range []string{"filters.min_files is -1", "filters.whitelist is empty", "no repositories configured", "Configuration is valid"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunConfigValidate_Broken(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "malformed yaml",
+			content: "github:\n  token: [unterminated\n",
+			want:    []string{"failed to parse config file"},
+		},
+		{
+			name:    "missing required fields",
+			content: "filters:\n  min_files: 3\n",
+			want:    []string{"github.token is required", "either github.org or github.user", "database.type is required"},
+		},
+		{
+			name:    "unsupported database",
+			content: "github:\n  token: t\n  user: u\ndatabase:\n  type: postgres\n",
+			want:    []string{"database.type must be 'sqlite' or 'turso'"},
+		},
+		{
+			name:    "turso without url",
+			content: "github:\n  token: t\n  user: u\ndatabase:\n  type: turso\n",
+			want:    []string{"database.url is required for turso"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := runConfigValidate(&out, writeTestConfig(t, tt.content))
+			if err == nil {
+				t.Fatal("expected validation to fail")
+			}
+
+			combined := out.String() + err.Error()
+			for _, want := range tt.want {
+				if !strings.Contains(combined, want) {
+					t.Errorf("expected %q in output, got:\n%s", want, combined)
+				}
+			}
+		})
+	}
+}
+
+func TestRunConfigValidate_MissingFile(t *testing.T) {
+	var out bytes.Buffer
+	if err := runConfigValidate(&out, filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected error for missing config file")
+	}
+}
+
+func TestRunConfigSchema(t *testing.T) {
+	var out bytes.Buffer
+	if err := runConfigSchema(&out); err != nil {
+		t.Fatalf("runConfigSchema failed: %v", err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	if schema["type"] != "object" {
+		t.Errorf("expected an object schema, got %v", schema["type"])
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/prguard/prguard/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// NewCountCommand creates the count command
+func NewCountCommand(configPath *string) *cobra.Command {
+	var severity string
+
+	cmd := &cobra.Command{
+		Use:   "count",
+		Short: "Count blocklist entries",
+		Long:  `Prints the number of blocklist entries and distinct blocked users without listing them`,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runCount(*configPath, severity)
+		},
+	}
+
+	cmd.Flags().StringVarP(&severity, "severity", "s", "", "Only count entries with this severity (low/medium/high)")
+	_ = cmd.RegisterFlagCompletionFunc("severity", completeSeverity)
+
+	return cmd
+}
+
+func runCount(configPath, severity string) error {
+	if severity != "" && severity != models.SeverityLow && severity != models.SeverityMedium && severity != models.SeverityHigh {
+		return fmt.Errorf("invalid severity, must be low/medium/high")
+	}
+
+	_, _, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	entries, users, err := blManager.Count(severity)
+	if err != nil {
+		return err
+	}
+
+	if severity != "" {
+		fmt.Printf("Severity: %s\n", severity)
+	}
+	fmt.Printf("Entries: %d\n", entries)
+	fmt.Printf("Unique users: %d\n", users)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+func TestCountCommand_WithEntries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+	manager := blocklist.NewManager(db)
+	for _, username := range []string{"spammer", "spammer", "other"} {
+		if _, err := manager.Block(blocklist.BlockRequest{Username: username, Reason: "spam", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "testowner", Severity: models.SeverityLow, Source: models.SourceManual}); err != nil {
+			t.Fatalf("failed to add test user %s: %v", username, err)
+		}
+	}
+
+	if err := runCount(configPath, ""); err != nil {
+		t.Errorf("runCount failed: %v", err)
+	}
+	if err := runCount(configPath, models.SeverityHigh); err != nil {
+		t.Errorf("runCount with severity failed: %v", err)
+	}
+
+	entries, users, err := manager.Count("")
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if entries != 3 || users != 2 {
+		t.Errorf("expected 3 entries and 2 users, got %d and %d", entries, users)
+	}
+}
+
+func TestCountCommand_InvalidSeverity(t *testing.T) {
+	if err := runCount("config.yaml", "critical"); err == nil {
+		t.Error("expected error with invalid severity")
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// NewDiffCommand creates the diff command
+func NewDiffCommand(configPath *string) *cobra.Command {
+	var against, url string
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare the local blocklist against a file or URL",
+		Long: `Shows what importing a blocklist would change without modifying the local database.
+Lists entries that would be added, entries whose severity would be upgraded, and
+entries that exist only locally. Files ending in .yaml or .yml are read as YAML.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runDiff(*configPath, against, url)
+		},
+	}
+
+	cmd.Flags().StringVarP(&against, "against", "a", "", "Path to JSON or YAML file to compare against")
+	cmd.Flags().StringVarP(&url, "url", "u", "", "URL to JSON file to compare against")
+
+	return cmd
+}
+
+func runDiff(configPath, against, url string) error {
+	if against == "" && url == "" {
+		return fmt.Errorf("either --against or --url must be specified")
+	}
+	if against != "" && url != "" {
+		return fmt.Errorf("cannot specify both --against and --url")
+	}
+
+	entries, err := loadDiffEntries(against, url)
+	if err != nil {
+		return err
+	}
+
+	_, _, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	added, upgraded, localOnly, err := blManager.Diff(entries)
+	if err != nil {
+		return fmt.Errorf("diff failed: %w", err)
+	}
+
+	printDiff(added, upgraded, localOnly)
+	return nil
+}
+
+// loadDiffEntries reads and validates the remote entry set from a file or URL
+func loadDiffEntries(file, url string) ([]*models.BlocklistEntry, error) {
+	var (
+		entries []*models.BlocklistEntry
+		err     error
+	)
+
+	switch {
+	case url != "":
+		entries, err = blocklist.FetchJSON(url)
+	case isYAMLFile(file):
+		entries, err = blocklist.LoadYAML(file)
+	default:
+		entries, err = blocklist.LoadJSON(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load entries: %w", err)
+	}
+	if problems := blocklist.ValidateEntries(entries); len(problems) > 0 {
+		return nil, &blocklist.ValidationError{Problems: problems}
+	}
+	return entries, nil
+}
+
+func printDiff(added []*models.BlocklistEntry, upgraded []blocklist.Upgrade, localOnly []*models.BlocklistEntry) {
+	if len(added) == 0 && len(upgraded) == 0 && len(localOnly) == 0 {
+		fmt.Println("No differences found")
+		return
+	}
+
+	fmt.Printf("New (%d):\n", len(added))
+	for _, entry := range added {
+		fmt.Printf("  + %s [%s] %s\n", entry.Username, entry.Severity, entry.Reason)
+	}
+
+	fmt.Printf("\nSeverity upgrades (%d):\n", len(upgraded))
+	for _, u := range upgraded {
+		fmt.Printf("  ↑ %s: %s → %s\n", u.Incoming.Username, u.Local.Severity, u.Incoming.Severity)
+	}
+
+	fmt.Printf("\nOnly local (%d):\n", len(localOnly))
+	for _, entry := range localOnly {
+		fmt.Printf("  - %s [%s] %s\n", entry.Username, entry.Severity, entry.Reason)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+func TestDiffCommand_Flags(t *testing.T) {
+	cmd := NewDiffCommand(new(string))
+
+	for _, name := range []string{"against", "url"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+}
+
+func TestRunDiff_RequiresSource(t *testing.T) {
+	err := runDiff("unused", "", "")
+	if err == nil || !strings.Contains(err.Error(), "either --against or --url") {
+		t.Errorf("expected missing source error, got %v", err)
+	}
+
+	err = runDiff("unused", "a.json", "https://example.com/b.json")
+	if err == nil || !strings.Contains(err.Error(), "cannot specify both") {
+		t.Errorf("expected mutually exclusive error, got %v", err)
+	}
+}
+
+func TestRunDiff_FromFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+
+	local := models.NewBlocklistEntry("localuser", "spam", "", "admin", models.SeverityLow, models.SourceManual)
+	if err := db.AddEntry(local); err != nil {
+		t.Fatalf("failed to add entry: %v", err)
+	}
+
+	remote := models.NewBlocklistEntry("remoteuser", "spam", "", "community", models.SeverityHigh, models.SourceImported)
+	data, err := json.Marshal([]*models.BlocklistEntry{remote})
+	if err != nil {
+		t.Fatalf("failed to marshal entries: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "remote.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	if err := runDiff(configPath, path, ""); err != nil {
+		t.Fatalf("runDiff failed: %v", err)
+	}
+
+	entries, err := db.GetEntriesByUsername("remoteuser")
+	if err != nil {
+		t.Fatalf("failed to query entries: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected diff not to import entries, found %d", len(entries))
+	}
+}
+
+func TestRunDiff_RejectsInvalidEntries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, _ := setupTestConfig(t)
+
+	path := filepath.Join(t.TempDir(), "remote.json")
+	if err := os.WriteFile(path, []byte(`[null]`), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	err := runDiff(configPath, path, "")
+	var validationErr *blocklist.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/database"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/spf13/cobra"
+)
+
+// NewDoctorCommand creates the doctor command
+func NewDoctorCommand(configPath *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check that PRGuard is set up correctly",
+		Long: `Runs a checklist over the local setup: the config file is found and valid, the
+database is reachable and fully migrated, and the GitHub credentials authenticate.
+Each failed check prints a hint on how to fix it. Nothing is changed.
+
+Migrations are embedded in the binary, so no separate migration tool is needed.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runDoctor(os.Stdout, *configPath, newGitHubClient)
+		},
+	}
+	return cmd
+}
+
+// doctorCheck is the outcome of one doctor check
+type doctorCheck struct {
+	name   string
+	ok     bool
+	detail string
+	hint   string // How to fix a failed check
+}
+
+// runDoctor runs every check, printing each as it completes. Checks that need
+// a valid config are skipped when it is missing or invalid.
+func runDoctor(w io.Writer, configPath string, newClient func(*config.Config) (github.GitHubClient, error)) error {
+	var checks []doctorCheck
+	report := func(check doctorCheck) {
+		checks = append(checks, check)
+		printDoctorCheck(w, check)
+	}
+
+	cfg, check := checkConfig(configPath)
+	report(check)
+	if cfg != nil {
+		report(checkDatabase(cfg))
+		report(checkGitHubAuth(cfg, newClient))
+	} else {
+		fmt.Fprintln(w, "  Database and GitHub checks skipped until the config is fixed")
+	}
+
+	failed := 0
+	for _, check := range checks {
+		if !check.ok {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d %s failed", failed, pluralize("check", "checks", failed))
+	}
+	fmt.Fprintln(w, "\nAll checks passed")
+	return nil
+}
+
+// printDoctorCheck writes a check as a checklist line, with the hint for failures
+func printDoctorCheck(w io.Writer, check doctorCheck) {
+	mark := "✓"
+	if !check.ok {
+		mark = "✗"
+	}
+	fmt.Fprintf(w, "%s %s: %s\n", mark, check.name, check.detail)
+	if !check.ok && check.hint != "" {
+		fmt.Fprintf(w, "    → %s\n", check.hint)
+	}
+}
+
+// checkConfig finds and validates the config file, returning it with defaults
+// applied when it is usable
+func checkConfig(configPath string) (*config.Config, doctorCheck) {
+	check := doctorCheck{name: "Config"}
+
+	cfg, path, err := config.Read(configPath)
+	if err != nil {
+		check.detail = err.Error()
+		check.hint = "run 'prguard init' to create a config, or pass --config"
+		return nil, check
+	}
+	if problems := cfg.Problems(); len(problems) > 0 {
+		check.detail = fmt.Sprintf("%s has %d %s, first: %v", path, len(problems), pluralize("problem", "problems", len(problems)), problems[0])
+		check.hint = "run 'prguard config validate' to list every problem"
+		return nil, check
+	}
+
+	cfg.SetDefaults()
+	check.ok = true
+	check.detail = path
+	return cfg, check
+}
+
+// checkDatabase opens the database without migrating it and compares its
+// schema version with the newest embedded migration
+func checkDatabase(cfg *config.Config) doctorCheck {
+	check := doctorCheck{name: "Database"}
+
+	if cfg.Database.Type == "sqlite" {
+		if _, err := os.Stat(cfg.Database.Path); err != nil {
+			check.detail = fmt.Sprintf("%s not found", cfg.Database.Path)
+			check.hint = "run 'prguard migrate up' to create it"
+			return check
+		}
+	}
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		check.detail = err.Error()
+		check.hint = "check the database settings in your config"
+		return check
+	}
+	defer db.Close() //nolint:errcheck
+
+	current, err := database.MigrationStatus(db)
+	if err != nil {
+		check.detail = fmt.Sprintf("failed to read migration status: %v", err)
+		check.hint = "run 'prguard migrate status' for details"
+		return check
+	}
+	latest, err := database.LatestMigrationVersion()
+	if err != nil {
+		check.detail = err.Error()
+		return check
+	}
+	if current < latest {
+		check.detail = fmt.Sprintf("schema at version %d, %d available", current, latest)
+		check.hint = "run 'prguard migrate up'"
+		return check
+	}
+
+	check.ok = true
+	check.detail = fmt.Sprintf("%s reachable, schema at version %d", cfg.Database.Type, current)
+	return check
+}
+
+// checkGitHubAuth makes an authenticated request with the configured credentials.
+// App installation tokens can't read /user, so apps are checked with the rate limit.
+func checkGitHubAuth(cfg *config.Config, newClient func(*config.Config) (github.GitHubClient, error)) doctorCheck {
+	check := doctorCheck{name: "GitHub"}
+	hint := "check github.token, or create a new token at https://github.com/settings/tokens"
+	if cfg.GitHub.UsesApp() {
+		hint = "check github.app_id, github.installation_id and github.private_key_path"
+	}
+
+	ghClient, err := newClient(cfg)
+	if err != nil {
+		check.detail = err.Error()
+		check.hint = hint
+		return check
+	}
+	ctx, stop := scanContext(cfg)
+	defer stop()
+	ghClient = ghClient.WithContext(ctx)
+
+	if cfg.GitHub.UsesApp() {
+		if _, err := ghClient.GetRateLimit(); err != nil {
+			check.detail = err.Error()
+			check.hint = hint
+			return check
+		}
+		check.ok = true
+		check.detail = "GitHub App credentials authenticate"
+		return check
+	}
+
+	user, _, err := ghClient.GetAuthenticatedUser()
+	if err != nil {
+		check.detail = err.Error()
+		check.hint = hint
+		return check
+	}
+	check.ok = true
+	check.detail = fmt.Sprintf("token authenticates as %s", user.Login)
+	return check
+}
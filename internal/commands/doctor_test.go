@@ -0,0 +1,136 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/mocks"
+)
+
+// doctorClient returns a client factory whose token check succeeds or fails
+func doctorClient(err error) func(*config.Config) (github.GitHubClient, error) {
+	return func(*config.Config) (github.GitHubClient, error) {
+		return &mocks.MockGitHubClient{
+			GetAuthenticatedUserFn: func() (*github.User, []string, error) {
+				if err != nil {
+					return nil, nil, err
+				}
+				return &github.User{Login: "maintainer"}, []string{"repo"}, nil
+			},
+		}, nil
+	}
+}
+
+func TestRunDoctor_AllPass(t *testing.T) {
+	configPath, _ := setupTestConfig(t)
+
+	var out bytes.Buffer
+	if err := runDoctor(&out, configPath, doctorClient(nil)); err != nil {
+		t.Fatalf("runDoctor failed: %v\n%s", err, out.String())
+	}
+	for _, want := range []string{"✓ Config: " + configPath, "✓ Database: sqlite reachable", "✓ GitHub: token authenticates as maintainer", "All checks passed"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunDoctor_MissingConfig(t *testing.T) {
+	var out bytes.Buffer
+	err := runDoctor(&out, filepath.Join(t.TempDir(), "missing.yaml"), doctorClient(nil))
+	if err == nil {
+		t.Fatal("expected an error for a missing config")
+	}
+	for _, want := range []string{"✗ Config:", "prguard init", "checks skipped"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunDoctor_InvalidConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("database:\n  type: sqlite\n"), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runDoctor(&out, configPath, doctorClient(nil)); err == nil {
+		t.Fatal("expected an error for an invalid config")
+	}
+	if !strings.Contains(out.String(), "prguard config validate") {
+		t.Errorf("expected a hint to run config validate, got:\n%s", out.String())
+	}
+}
+
+func TestRunDoctor_MissingDatabase(t *testing.T) {
+	configPath, _ := setupTestConfig(t)
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if err := os.Remove(cfg.Database.Path); err != nil {
+		t.Fatalf("failed to remove database: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runDoctor(&out, configPath, doctorClient(nil)); err == nil || err.Error() != "1 check failed" {
+		t.Fatalf("expected 1 failed check, got %v", err)
+	}
+	if !strings.Contains(out.String(), "✗ Database:") || !strings.Contains(out.String(), "prguard migrate up") {
+		t.Errorf("expected a database failure with a migrate hint, got:\n%s", out.String())
+	}
+	if _, err := os.Stat(cfg.Database.Path); err == nil {
+		t.Error("expected doctor not to create the database")
+	}
+}
+
+func TestRunDoctor_PendingMigrations(t *testing.T) {
+	configPath, _ := setupTestConfig(t)
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	// An empty file is a valid SQLite database with no schema
+	cfg.Database.Path = filepath.Join(t.TempDir(), "empty.db")
+	if err := os.WriteFile(cfg.Database.Path, nil, 0600); err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+
+	check := checkDatabase(cfg)
+	if check.ok || !strings.Contains(check.detail, "schema at version 0") || check.hint != "run 'prguard migrate up'" {
+		t.Errorf("expected a pending migrations failure, got %+v", check)
+	}
+}
+
+func TestRunDoctor_BadToken(t *testing.T) {
+	configPath, _ := setupTestConfig(t)
+
+	var out bytes.Buffer
+	if err := runDoctor(&out, configPath, doctorClient(errors.New("401 Bad credentials"))); err == nil {
+		t.Fatal("expected an error for a rejected token")
+	}
+	if !strings.Contains(out.String(), "✗ GitHub: 401 Bad credentials") || !strings.Contains(out.String(), "github.token") {
+		t.Errorf("expected a token failure with a hint, got:\n%s", out.String())
+	}
+}
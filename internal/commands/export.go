@@ -16,31 +16,130 @@ package commands
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/pkg/models"
 	"github.com/spf13/cobra"
 )
 
+// exportOptions holds the command-line options for an export
+type exportOptions struct {
+	format  string // json, jsonl, csv, yaml, or registry
+	output  string // File written to, or - for stdout with jsonl; defaults by format
+	signKey string // ed25519 private key a JSON export is signed with
+	filter  models.EntryFilter
+	force   bool
+}
+
 // NewExportCommand creates the export command
-func NewExportCommand(configPath *string) *cobra.Command {
-	var format, output string
+func NewExportCommand(configPath *string, assumeYes *bool) *cobra.Command {
+	var opts exportOptions
+	var minSeverity, since, until string
+	var toGitHubList, applyGitHub bool
+	var delay time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export the blocklist to a file",
-		Long:  `Exports the blocklist to JSON or CSV format`,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			return runExport(*configPath, format, output)
+		Long: `Exports the blocklist to JSON, JSON Lines, CSV, YAML, or registry format.
+
+The registry format is JSON wrapped in a versioned envelope
+({version, generated_at, generator, entries}) for sharing through a common
+registry; 'prguard import' reads it as well as plain JSON arrays.
+
+The jsonl format streams one entry per line without loading the whole blocklist
+into memory; use --output - to write it to stdout for piping.
+
+Use --min-severity to leave out lower-severity entries, and --since/--until to
+export only entries added within a time window. Both accept a duration ago
+(e.g. 30d) or a date (YYYY-MM-DD).
+
+Use --sign-key with a PEM ed25519 private key to write a detached signature
+next to a JSON export (<output>.sig) that consumers can check with
+'prguard import --verify-key'.
+
+Use --to-github-list to write the active usernames one per line (default
+blocklist.txt, or - for stdout), the format read by 'prguard block --from-file'.
+Use --apply-github to block every active user on GitHub at the org or personal
+level, waiting --delay (default github.block_delay) between API calls. Users
+already blocked on GitHub by an earlier run are skipped, so an interrupted run
+can simply be repeated.
+
+Missing parent directories of --output are created. An existing output file is
+never replaced unless --force is given.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var err error
+			opts.filter, err = parseExportFilter(minSeverity, since, until, time.Now())
+			if err != nil {
+				return err
+			}
+			if toGitHubList || applyGitHub {
+				if toGitHubList && applyGitHub {
+					return fmt.Errorf("cannot use --to-github-list together with --apply-github")
+				}
+				if cmd.Flags().Changed("format") || opts.signKey != "" {
+					return fmt.Errorf("--format and --sign-key cannot be used with --to-github-list or --apply-github")
+				}
+				if applyGitHub {
+					var delayOverride *time.Duration
+					if cmd.Flags().Changed("delay") {
+						delayOverride = &delay
+					}
+					return runApplyGitHub(*configPath, opts.filter, delayOverride, *assumeYes)
+				}
+				return runExportGitHubList(*configPath, opts.output, opts.filter, opts.force)
+			}
+			return runExport(*configPath, opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&format, "format", "f", "json", "Export format (json or csv)")
-	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file path (default: blocklist.json or blocklist.csv)")
+	cmd.Flags().StringVarP(&opts.format, "format", "f", "json", "Export format (json, jsonl, csv, yaml, or registry)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Output file path, or - for stdout with jsonl (default: blocklist.<format>)")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Overwrite the output file if it already exists")
+	cmd.Flags().StringVar(&minSeverity, "min-severity", "", "Only export entries at or above this severity (low/medium/high)")
+	cmd.Flags().StringVar(&since, "since", "", "Only export entries added at or after this time (e.g. 30d or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&until, "until", "", "Only export entries added before this time (e.g. 7d or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&opts.signKey, "sign-key", "", "Sign a JSON export with this ed25519 private key (PEM)")
+	cmd.Flags().BoolVar(&toGitHubList, "to-github-list", false, "Write active usernames one per line for 'prguard block --from-file'")
+	cmd.Flags().BoolVar(&applyGitHub, "apply-github", false, "Block every active user via GitHub API (affects ALL repos in org/account)")
+	cmd.Flags().DurationVar(&delay, "delay", 0, "Time to wait between GitHub API calls with --apply-github (default github.block_delay)")
+	_ = cmd.RegisterFlagCompletionFunc("min-severity", completeSeverity)
 
 	return cmd
 }
 
-func runExport(configPath, format, output string) error {
+// parseExportFilter builds an entry filter from the export flags
+func parseExportFilter(minSeverity, since, until string, now time.Time) (models.EntryFilter, error) {
+	filter := models.EntryFilter{MinSeverity: minSeverity}
+
+	if minSeverity != "" {
+		if err := validateSeverity(minSeverity); err != nil {
+			return filter, err
+		}
+	}
+
+	var err error
+	if filter.Since, err = parseSince(since, now); err != nil {
+		return filter, fmt.Errorf("invalid --since: %w", err)
+	}
+	if filter.Until, err = parseSince(until, now); err != nil {
+		return filter, fmt.Errorf("invalid --until: %w", err)
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		return filter, fmt.Errorf("--since must be before --until")
+	}
+
+	return filter, nil
+}
+
+func runExport(configPath string, opts exportOptions) error {
+	if opts.signKey != "" && (opts.format != "json" || opts.output == "-") {
+		return fmt.Errorf("--sign-key is only supported for JSON file exports")
+	}
+
 	_, _, blManager, db, err := initClients(configPath)
 	if err != nil {
 		return err
@@ -48,33 +147,103 @@ func runExport(configPath, format, output string) error {
 	defer db.Close() //nolint:errcheck
 
 	// Determine output path
-	if output == "" {
-		switch format {
+	if opts.output == "" {
+		switch opts.format {
 		case "json":
-			output = "blocklist.json"
+			opts.output = "blocklist.json"
+		case "jsonl":
+			opts.output = "blocklist.jsonl"
 		case "csv":
-			output = "blocklist.csv"
+			opts.output = "blocklist.csv"
+		case "yaml":
+			opts.output = "blocklist.yaml"
+		case "registry":
+			opts.output = "blocklist.registry.json"
 		default:
-			return fmt.Errorf("invalid format, must be json or csv")
+			return fmt.Errorf("invalid format, must be json, jsonl, csv, yaml, or registry")
+		}
+	}
+
+	if opts.output == "-" {
+		if opts.format != "jsonl" {
+			return fmt.Errorf("--output - is only supported with --format jsonl")
+		}
+		if err := blManager.ExportJSONL(os.Stdout, opts.filter); err != nil {
+			return fmt.Errorf("export failed: %w", err)
 		}
+		return nil
+	}
+
+	if err := prepareOutputPath(opts.output, opts.force); err != nil {
+		return err
 	}
 
 	// Export
-	switch format {
+	switch opts.format {
 	case "json":
-		if err := blManager.ExportJSON(output); err != nil {
+		if opts.signKey != "" {
+			err = blManager.ExportSignedJSON(opts.output, opts.signKey, opts.filter)
+		} else {
+			err = blManager.ExportJSON(opts.output, opts.filter)
+		}
+		if err != nil {
+			return fmt.Errorf("export failed: %w", err)
+		}
+	case "jsonl":
+		if err := exportJSONLFile(blManager, opts.output, opts.filter); err != nil {
 			return fmt.Errorf("export failed: %w", err)
 		}
 	case "csv":
-		if err := blManager.ExportCSV(output); err != nil {
+		if err := blManager.ExportCSV(opts.output, opts.filter); err != nil {
+			return fmt.Errorf("export failed: %w", err)
+		}
+	case "yaml":
+		if err := blManager.ExportYAML(opts.output, opts.filter); err != nil {
+			return fmt.Errorf("export failed: %w", err)
+		}
+	case "registry":
+		if err := blManager.ExportRegistry(opts.output, opts.filter); err != nil {
 			return fmt.Errorf("export failed: %w", err)
 		}
 	default:
-		return fmt.Errorf("invalid format, must be json or csv")
+		return fmt.Errorf("invalid format, must be json, jsonl, csv, yaml, or registry")
 	}
 
-	absPath, _ := filepath.Abs(output)
+	absPath, _ := filepath.Abs(opts.output)
 	fmt.Printf("✓ Blocklist exported to %s\n", absPath)
+	if opts.signKey != "" {
+		fmt.Printf("✓ Signature written to %s\n", blocklist.SignaturePath(absPath))
+	}
+
+	return nil
+}
 
+// prepareOutputPath refuses to replace an existing file at path unless force is
+// set and creates the missing parent directories of path
+func prepareOutputPath(path string, force bool) error {
+	if !force {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("output file %s already exists; use --force to overwrite it", path)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to check output file: %w", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
 	return nil
 }
+
+// exportJSONLFile streams a JSON Lines export to the file at path
+func exportJSONLFile(blManager blocklist.BlocklistManager, path string, filter models.EntryFilter) error {
+	file, err := os.Create(path) //nolint:gosec // user-specified export path
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if err := blManager.ExportJSONL(file, filter); err != nil {
+		_ = file.Close() //nolint:errcheck
+		return err
+	}
+	return file.Close()
+}
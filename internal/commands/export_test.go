@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/prguard/prguard/internal/blocklist"
 	"github.com/prguard/prguard/internal/config"
@@ -65,14 +66,14 @@ func TestExportCommand_JSON(t *testing.T) {
 	manager := blocklist.NewManager(db)
 	testUsers := []string{"user1", "user2", "user3"}
 	for _, username := range testUsers {
-		_, err := manager.Block(username, "spam", "https://github.com/test/repo/pull/1", "test-org", models.SeverityMedium, models.SourceManual)
+		_, err := manager.Block(blocklist.BlockRequest{Username: username, Reason: "spam", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "test-org", Severity: models.SeverityMedium, Source: models.SourceManual})
 		if err != nil {
 			t.Fatalf("failed to block user %s: %v", username, err)
 		}
 	}
 
 	// Export to JSON
-	err = runExport(configPath, "json", exportPath)
+	err = runExport(configPath, exportOptions{format: "json", output: exportPath})
 	if err != nil {
 		t.Errorf("runExport failed: %v", err)
 	}
@@ -146,13 +147,13 @@ func TestExportCommand_CSV(t *testing.T) {
 
 	// Add test entry
 	manager := blocklist.NewManager(db)
-	_, err = manager.Block("csvuser", "spam", "https://github.com/test/repo/pull/1", "testowner", models.SeverityHigh, models.SourceManual)
+	_, err = manager.Block(blocklist.BlockRequest{Username: "csvuser", Reason: "spam", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "testowner", Severity: models.SeverityHigh, Source: models.SourceManual})
 	if err != nil {
 		t.Fatalf("failed to block user: %v", err)
 	}
 
 	// Export to CSV
-	err = runExport(configPath, "csv", exportPath)
+	err = runExport(configPath, exportOptions{format: "csv", output: exportPath})
 	if err != nil {
 		t.Errorf("runExport failed: %v", err)
 	}
@@ -216,7 +217,7 @@ func TestExportCommand_DefaultPath(t *testing.T) {
 	defer db.Close() //nolint:errcheck
 
 	// Export with default path (empty string)
-	err = runExport(configPath, "json", "")
+	err = runExport(configPath, exportOptions{format: "json"})
 	if err != nil {
 		t.Errorf("runExport with default path failed: %v", err)
 	}
@@ -262,7 +263,7 @@ func TestExportCommand_InvalidFormat(t *testing.T) {
 	defer db.Close() //nolint:errcheck
 
 	// Try invalid format
-	err = runExport(configPath, "xml", "")
+	err = runExport(configPath, exportOptions{format: "xml"})
 	if err == nil {
 		t.Error("expected error with invalid format")
 	}
@@ -303,7 +304,7 @@ func TestExportCommand_EmptyBlocklist(t *testing.T) {
 	defer db.Close() //nolint:errcheck
 
 	// Export empty blocklist
-	err = runExport(configPath, "json", exportPath)
+	err = runExport(configPath, exportOptions{format: "json", output: exportPath})
 	if err != nil {
 		t.Errorf("runExport with empty blocklist failed: %v", err)
 	}
@@ -323,3 +324,237 @@ func TestExportCommand_EmptyBlocklist(t *testing.T) {
 		t.Errorf("expected 0 entries in empty export, got %d", len(entries))
 	}
 }
+
+func TestExportCommand_YAMLRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+	exportPath := filepath.Join(t.TempDir(), "export.yml")
+
+	manager := blocklist.NewManager(db)
+	_, err := manager.Block(blocklist.BlockRequest{Username: "yamluser", Reason: "spam", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "testowner", Severity: models.SeverityHigh, Source: models.SourceManual})
+	if err != nil {
+		t.Fatalf("failed to block user: %v", err)
+	}
+
+	if err := runExport(configPath, exportOptions{format: "yaml", output: exportPath}); err != nil {
+		t.Fatalf("runExport failed: %v", err)
+	}
+
+	data, err := os.ReadFile(exportPath) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("failed to read export file: %v", err)
+	}
+	if !strings.Contains(string(data), "username: yamluser") {
+		t.Errorf("YAML export missing user:\n%s", data)
+	}
+
+	// Re-import is detected as YAML by extension and deduplicated by ID
+	if err := runImport(configPath, importOptions{file: exportPath}); err != nil {
+		t.Fatalf("runImport failed: %v", err)
+	}
+
+	entries, err := manager.List()
+	if err != nil {
+		t.Fatalf("failed to list entries: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected 1 entry after re-import, got %d", len(entries))
+	}
+}
+
+func TestParseExportFilter(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	filter, err := parseExportFilter("medium", "30d", "2025-03-01", now)
+	if err != nil {
+		t.Fatalf("parseExportFilter failed: %v", err)
+	}
+	if filter.MinSeverity != models.SeverityMedium {
+		t.Errorf("expected min severity medium, got %q", filter.MinSeverity)
+	}
+	if !filter.Since.Equal(now.Add(-30 * 24 * time.Hour)) {
+		t.Errorf("unexpected since: %v", filter.Since)
+	}
+	if !filter.Until.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected until: %v", filter.Until)
+	}
+
+	for _, tt := range []struct{ severity, since, until string }{
+		{"critical", "", ""},
+		{"", "soon", ""},
+		{"", "", "later"},
+		{"", "2025-03-01", "2025-02-01"},
+	} {
+		if _, err := parseExportFilter(tt.severity, tt.since, tt.until, now); err == nil {
+			t.Errorf("expected error for %+v", tt)
+		}
+	}
+}
+
+func TestExportCommand_Filtered(t *testing.T) {
+	configPath, db := setupTestConfig(t)
+
+	for _, e := range []struct {
+		username, severity string
+		age                time.Duration
+	}{
+		{"fresh-high", models.SeverityHigh, time.Hour},
+		{"fresh-low", models.SeverityLow, time.Hour},
+		{"old-high", models.SeverityHigh, 90 * 24 * time.Hour},
+	} {
+		entry := models.NewBlocklistEntry(e.username, "spam", "https://example.com", "testowner", e.severity, models.SourceManual)
+		entry.Timestamp = time.Now().Add(-e.age)
+		if err := db.AddEntry(entry); err != nil {
+			t.Fatalf("failed to add entry: %v", err)
+		}
+	}
+
+	filter, err := parseExportFilter("high", "30d", "", time.Now())
+	if err != nil {
+		t.Fatalf("parseExportFilter failed: %v", err)
+	}
+
+	exportPath := filepath.Join(t.TempDir(), "public.csv")
+	if err := runExport(configPath, exportOptions{format: "csv", output: exportPath, filter: filter}); err != nil {
+		t.Fatalf("runExport failed: %v", err)
+	}
+
+	data, err := os.ReadFile(exportPath) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	content := string(data)
+	if !strings.Contains(content, "fresh-high") {
+		t.Error("expected fresh-high in export")
+	}
+	for _, excluded := range []string{"fresh-low", "old-high"} {
+		if strings.Contains(content, excluded) {
+			t.Errorf("expected %s to be filtered out", excluded)
+		}
+	}
+}
+
+func TestExportCommand_JSONL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+	for _, username := range []string{"user1", "user2"} {
+		entry := models.NewBlocklistEntry(username, "spam", "https://github.com/test/repo/pull/1", "admin", models.SeverityMedium, models.SourceManual)
+		if err := db.AddEntry(entry); err != nil {
+			t.Fatalf("failed to add entry: %v", err)
+		}
+	}
+
+	exportPath := filepath.Join(t.TempDir(), "blocklist.jsonl")
+	if err := runExport(configPath, exportOptions{format: "jsonl", output: exportPath}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	data, err := os.ReadFile(exportPath) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	for _, line := range lines {
+		var entry models.BlocklistEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Errorf("invalid JSON line %q: %v", line, err)
+		}
+	}
+}
+
+func TestExportCommand_StdoutRequiresJSONL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, _ := setupTestConfig(t)
+	if err := runExport(configPath, exportOptions{format: "csv", output: "-"}); err == nil {
+		t.Error("expected error for --output - with csv")
+	}
+}
+
+func TestRunExport_SignKeyRequiresJSONFile(t *testing.T) {
+	if err := runExport("config.yaml", exportOptions{format: "csv", output: "out.csv", signKey: "key.pem"}); err == nil {
+		t.Error("expected error signing a CSV export")
+	}
+	if err := runExport("config.yaml", exportOptions{format: "jsonl", output: "-", signKey: "key.pem"}); err == nil {
+		t.Error("expected error signing a stdout export")
+	}
+}
+
+func TestExportCommand_RefusesExistingFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, _ := setupTestConfig(t)
+	exportPath := filepath.Join(t.TempDir(), "blocklist.json")
+	if err := os.WriteFile(exportPath, []byte("keep me"), 0600); err != nil {
+		t.Fatalf("failed to write existing file: %v", err)
+	}
+
+	err := runExport(configPath, exportOptions{format: "json", output: exportPath})
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected an error suggesting --force, got %v", err)
+	}
+	data, err := os.ReadFile(exportPath) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if string(data) != "keep me" {
+		t.Errorf("expected the existing file to be untouched, got %q", data)
+	}
+}
+
+func TestExportCommand_ForceOverwrites(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+	entry := models.NewBlocklistEntry("spammer", "spam", "https://github.com/test/repo/pull/1", "admin", models.SeverityHigh, models.SourceManual)
+	if err := db.AddEntry(entry); err != nil {
+		t.Fatalf("failed to add entry: %v", err)
+	}
+	exportPath := filepath.Join(t.TempDir(), "blocklist.json")
+	if err := os.WriteFile(exportPath, []byte("stale"), 0600); err != nil {
+		t.Fatalf("failed to write existing file: %v", err)
+	}
+
+	if err := runExport(configPath, exportOptions{format: "json", output: exportPath, force: true}); err != nil {
+		t.Fatalf("export with --force failed: %v", err)
+	}
+	data, err := os.ReadFile(exportPath) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	if !strings.Contains(string(data), "spammer") {
+		t.Errorf("expected the file to be overwritten with the export, got %q", data)
+	}
+}
+
+func TestExportCommand_CreatesParentDirectories(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, _ := setupTestConfig(t)
+	for _, format := range []string{"json", "jsonl", "csv"} {
+		exportPath := filepath.Join(t.TempDir(), "dir", "sub", "blocklist."+format)
+		if err := runExport(configPath, exportOptions{format: format, output: exportPath}); err != nil {
+			t.Fatalf("%s export to a nested path failed: %v", format, err)
+		}
+		if _, err := os.Stat(exportPath); err != nil {
+			t.Errorf("expected %s to be created: %v", exportPath, err)
+		}
+	}
+}
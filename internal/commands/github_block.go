@@ -0,0 +1,101 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+)
+
+// githubBlockTracker records which users were blocked via the GitHub API;
+// implemented by blocklist.BlocklistManager
+type githubBlockTracker interface {
+	MarkGitHubBlocked(username string) error
+	IsGitHubBlocked(username string) (bool, error)
+}
+
+// githubBlocker blocks users via the GitHub API at the org or personal level.
+// It waits delay between API calls to stay clear of secondary rate limits and,
+// with a tracker, records each block so a re-run skips users already done.
+// It is safe for concurrent use; concurrent blocks are made one at a time.
+type githubBlocker struct {
+	cfg      *config.Config
+	ghClient github.GitHubClient
+	tracker  githubBlockTracker // nil disables resuming
+	out      io.Writer          // Destination for warnings
+	delay    time.Duration
+	wait     func(time.Duration)
+
+	mu    sync.Mutex // Serializes API calls so the delay holds across goroutines
+	calls int        // API calls made so far; the first call does not wait
+}
+
+// newGitHubBlocker creates a githubBlocker that sleeps between API calls
+func newGitHubBlocker(cfg *config.Config, ghClient github.GitHubClient, tracker githubBlockTracker, out io.Writer, delay time.Duration) *githubBlocker {
+	return &githubBlocker{
+		cfg:      cfg,
+		ghClient: ghClient,
+		tracker:  tracker,
+		out:      out,
+		delay:    delay,
+		wait:     time.Sleep,
+	}
+}
+
+// block blocks username on GitHub unless the tracker shows it was already
+// blocked, in which case it returns skipped without calling the API
+func (b *githubBlocker) block(username string) (skipped bool, err error) {
+	if b.tracker != nil {
+		done, err := b.tracker.IsGitHubBlocked(username)
+		if err != nil {
+			return false, fmt.Errorf("failed to check GitHub block state: %w", err)
+		}
+		if done {
+			return true, nil
+		}
+	}
+
+	if err := b.call(username); err != nil {
+		return false, err
+	}
+
+	if b.tracker != nil {
+		if err := b.tracker.MarkGitHubBlocked(username); err != nil {
+			fmt.Fprintf(b.out, "    ⚠ Blocked %s on GitHub but failed to record it: %v\n", username, err)
+		}
+	}
+	return false, nil
+}
+
+// call makes the block API call, waiting delay after any previous call
+func (b *githubBlocker) call(username string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.calls > 0 && b.delay > 0 {
+		b.wait(b.delay)
+	}
+	b.calls++
+
+	if b.cfg.GitHub.Org != "" {
+		return b.ghClient.BlockUserOrg(b.cfg.GitHub.Org, username)
+	}
+	return b.ghClient.BlockUserPersonal(username)
+}
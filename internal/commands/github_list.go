@@ -0,0 +1,175 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/prguard/prguard/pkg/models"
+)
+
+// githubListUsernames returns the usernames of entries that are still active at
+// now, once each in the order they first appear; usernames match case-insensitively
+func githubListUsernames(entries []*models.BlocklistEntry, now time.Time) []string {
+	seen := make(map[string]bool, len(entries))
+	var usernames []string
+	for _, entry := range entries {
+		if entry.ExpiresAt != nil && !entry.ExpiresAt.After(now) {
+			continue
+		}
+		key := strings.ToLower(entry.Username)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		usernames = append(usernames, entry.Username)
+	}
+	return usernames
+}
+
+// writeGitHubList writes one username per line, the format read by
+// 'prguard block --from-file'
+func writeGitHubList(w io.Writer, usernames []string) error {
+	for _, username := range usernames {
+		if _, err := fmt.Fprintln(w, username); err != nil {
+			return fmt.Errorf("failed to write username: %w", err)
+		}
+	}
+	return nil
+}
+
+func runExportGitHubList(configPath, output string, filter models.EntryFilter, force bool) error {
+	_, _, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	entries, err := blManager.ListFiltered(filter)
+	if err != nil {
+		return fmt.Errorf("failed to get entries: %w", err)
+	}
+	usernames := githubListUsernames(entries, time.Now())
+
+	if output == "-" {
+		return writeGitHubList(os.Stdout, usernames)
+	}
+	if output == "" {
+		output = "blocklist.txt"
+	}
+	if err := prepareOutputPath(output, force); err != nil {
+		return err
+	}
+
+	file, err := os.Create(output) //nolint:gosec // user-specified export path
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	if err := writeGitHubList(file, usernames); err != nil {
+		_ = file.Close() //nolint:errcheck
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	absPath, _ := filepath.Abs(output)
+	fmt.Printf("✓ %d %s written to %s\n", len(usernames), pluralize("username", "usernames", len(usernames)), absPath)
+	return nil
+}
+
+// runApplyGitHub blocks every active user on GitHub, waiting delay between API
+// calls or github.block_delay when delay is nil
+func runApplyGitHub(configPath string, filter models.EntryFilter, delay *time.Duration, assumeYes bool) error {
+	if delay != nil && *delay < 0 {
+		return fmt.Errorf("--delay cannot be negative")
+	}
+
+	cfg, ghClient, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	if cfg.GitHub.Org == "" && cfg.GitHub.User == "" {
+		return fmt.Errorf("cannot use --apply-github: neither github.org nor github.user is configured")
+	}
+
+	entries, err := blManager.ListFiltered(filter)
+	if err != nil {
+		return fmt.Errorf("failed to get entries: %w", err)
+	}
+	usernames := githubListUsernames(entries, time.Now())
+	if len(usernames) == 0 {
+		fmt.Println("No active blocklist entries to apply")
+		return nil
+	}
+
+	if cfg.GitHub.Org != "" {
+		fmt.Printf("⚠️  This will block %d %s for ALL repositories in the '%s' organization.\n", len(usernames), pluralize("user", "users", len(usernames)), cfg.GitHub.Org)
+	} else {
+		fmt.Printf("⚠️  This will block %d %s for ALL repositories owned by your personal account (%s).\n", len(usernames), pluralize("user", "users", len(usernames)), cfg.GitHub.User)
+	}
+	if !confirmPrompt(bufio.NewReader(os.Stdin), assumeYes) {
+		fmt.Println("GitHub blocking cancelled.")
+		return nil
+	}
+
+	wait := cfg.GitHub.BlockDelay
+	if delay != nil {
+		wait = *delay
+	}
+	blocker := newGitHubBlocker(cfg, ghClient, blManager, os.Stdout, wait)
+	blocked, skipped, failed := applyGitHubBlocks(os.Stdout, blocker, usernames)
+	fmt.Printf("\n✓ Blocked %d %s on GitHub", blocked, pluralize("user", "users", blocked))
+	if skipped > 0 {
+		fmt.Printf(", %d already blocked", skipped)
+	}
+	if failed > 0 {
+		fmt.Printf(", %d failed", failed)
+	}
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("failed to block %d %s on GitHub", failed, pluralize("user", "users", failed))
+	}
+	return nil
+}
+
+// applyGitHubBlocks blocks each username with blocker. Users the blocker has
+// already blocked are skipped, and failures are reported and skipped so one
+// bad account does not stop the run.
+func applyGitHubBlocks(w io.Writer, blocker *githubBlocker, usernames []string) (blocked, skipped, failed int) {
+	for i, username := range usernames {
+		done, err := blocker.block(username)
+		switch {
+		case err != nil:
+			fmt.Fprintf(w, "[%d/%d] ✗ %s: %v\n", i+1, len(usernames), username, err)
+			failed++
+		case done:
+			fmt.Fprintf(w, "[%d/%d] - %s: already blocked on GitHub\n", i+1, len(usernames), username)
+			skipped++
+		default:
+			fmt.Fprintf(w, "[%d/%d] ✓ %s\n", i+1, len(usernames), username)
+			blocked++
+		}
+	}
+	return blocked, skipped, failed
+}
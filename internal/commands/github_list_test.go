@@ -0,0 +1,253 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/mocks"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+func TestGitHubListUsernames(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	entry := func(username string, expiresAt *time.Time) *models.BlocklistEntry {
+		e := models.NewBlocklistEntry(username, "spam", "", "admin", models.SeverityHigh, models.SourceManual)
+		e.ExpiresAt = expiresAt
+		return e
+	}
+	entries := []*models.BlocklistEntry{
+		entry("spammer1", nil),
+		entry("expired", &past),
+		entry("Spammer1", nil),
+		entry("temporary", &future),
+		entry("spammer2", nil),
+	}
+
+	got := githubListUsernames(entries, now)
+	want := []string{"spammer1", "temporary", "spammer2"}
+	if !slices.Equal(got, want) {
+		t.Errorf("githubListUsernames() = %v, want %v", got, want)
+	}
+}
+
+func TestWriteGitHubList_ReadableByBlockFromFile(t *testing.T) {
+	usernames := []string{"spammer1", "spammer2", "bot-account"}
+
+	var buf bytes.Buffer
+	if err := writeGitHubList(&buf, usernames); err != nil {
+		t.Fatalf("writeGitHubList() error = %v", err)
+	}
+	if buf.String() != "spammer1\nspammer2\nbot-account\n" {
+		t.Errorf("unexpected list:\n%s", buf.String())
+	}
+
+	read, err := readUsernames(&buf)
+	if err != nil {
+		t.Fatalf("readUsernames() error = %v", err)
+	}
+	if !slices.Equal(read, usernames) {
+		t.Errorf("readUsernames() = %v, want %v", read, usernames)
+	}
+}
+
+func TestApplyGitHubBlocks_ThrottlesAndContinues(t *testing.T) {
+	var blocked []string
+	gh := &mocks.MockGitHubClient{
+		BlockUserOrgFn: func(org, username string) error {
+			if org != "test-org" {
+				t.Errorf("unexpected org %q", org)
+			}
+			if username == "already-gone" {
+				return errors.New("user not found")
+			}
+			blocked = append(blocked, username)
+			return nil
+		},
+		BlockUserPersonalFn: func(string) error {
+			t.Error("personal block should not be used when an org is configured")
+			return nil
+		},
+	}
+	var waits []time.Duration
+	wait := func(d time.Duration) { waits = append(waits, d) }
+
+	out := &bytes.Buffer{}
+	cfg := &config.Config{GitHub: config.GitHubConfig{Org: "test-org"}}
+	blocker := newGitHubBlocker(cfg, gh, nil, out, 2*time.Second)
+	blocker.wait = wait
+	ok, skipped, failed := applyGitHubBlocks(out, blocker, []string{"spammer1", "already-gone", "spammer2"})
+
+	if ok != 2 || skipped != 0 || failed != 1 {
+		t.Errorf("applyGitHubBlocks() = (%d, %d, %d), want (2, 0, 1)", ok, skipped, failed)
+	}
+	if !slices.Equal(blocked, []string{"spammer1", "spammer2"}) {
+		t.Errorf("blocked %v", blocked)
+	}
+	if !slices.Equal(waits, []time.Duration{2 * time.Second, 2 * time.Second}) {
+		t.Errorf("expected a wait between each of the 3 calls, got %v", waits)
+	}
+	for _, want := range []string{"[1/3] ✓ spammer1", "[2/3] ✗ already-gone: user not found", "[3/3] ✓ spammer2"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected progress %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestApplyGitHubBlocks_Personal(t *testing.T) {
+	var blocked []string
+	gh := &mocks.MockGitHubClient{
+		BlockUserPersonalFn: func(username string) error {
+			blocked = append(blocked, username)
+			return nil
+		},
+	}
+	waited := false
+
+	cfg := &config.Config{GitHub: config.GitHubConfig{User: "maintainer"}}
+	blocker := newGitHubBlocker(cfg, gh, nil, &bytes.Buffer{}, 0)
+	blocker.wait = func(time.Duration) { waited = true }
+	ok, _, failed := applyGitHubBlocks(&bytes.Buffer{}, blocker, []string{"spammer1", "spammer2"})
+
+	if ok != 2 || failed != 0 || len(blocked) != 2 {
+		t.Errorf("applyGitHubBlocks() = (%d, %d), blocked %v", ok, failed, blocked)
+	}
+	if waited {
+		t.Error("no wait expected with a zero delay")
+	}
+}
+
+// trackingManager returns a blocklist mock that remembers GitHub blocks in marked
+func trackingManager(marked map[string]bool) *mocks.MockBlocklistManager {
+	return &mocks.MockBlocklistManager{
+		MarkGitHubBlockedFn: func(username string) error {
+			marked[username] = true
+			return nil
+		},
+		IsGitHubBlockedFn: func(username string) (bool, error) {
+			return marked[username], nil
+		},
+	}
+}
+
+func TestGitHubBlocker_SharedAcrossGoroutines(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	gh := &mocks.MockGitHubClient{
+		BlockUserOrgFn: func(_, _ string) error {
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			return nil
+		},
+	}
+	cfg := &config.Config{GitHub: config.GitHubConfig{Org: "test-org"}}
+	blocker := newGitHubBlocker(cfg, gh, nil, &bytes.Buffer{}, time.Second)
+	var waits int32
+	blocker.wait = func(time.Duration) { atomic.AddInt32(&waits, 1) }
+
+	// Parallel repository scans block through the same blocker
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := blocker.block(fmt.Sprintf("spammer%d", i)); err != nil {
+				t.Errorf("block failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if maxInFlight != 1 {
+		t.Errorf("expected blocks to be made one at a time, got %d in flight", maxInFlight)
+	}
+	if waits != 4 {
+		t.Errorf("expected a delay before every block after the first, got %d", waits)
+	}
+}
+
+func TestApplyGitHubBlocks_ResumesAfterInterruption(t *testing.T) {
+	usernames := []string{"spammer1", "spammer2", "spammer3", "spammer4"}
+	cfg := &config.Config{GitHub: config.GitHubConfig{Org: "test-org"}}
+	marked := map[string]bool{}
+
+	// The first run is cut off after two blocks
+	var firstRun []string
+	interrupted := &mocks.MockGitHubClient{
+		BlockUserOrgFn: func(_, username string) error {
+			if len(firstRun) == 2 {
+				return errors.New("context canceled")
+			}
+			firstRun = append(firstRun, username)
+			return nil
+		},
+	}
+	blocker := newGitHubBlocker(cfg, interrupted, trackingManager(marked), &bytes.Buffer{}, 0)
+	if ok, _, failed := applyGitHubBlocks(&bytes.Buffer{}, blocker, usernames); ok != 2 || failed != 2 {
+		t.Fatalf("first run = (%d ok, %d failed), want (2, 2)", ok, failed)
+	}
+
+	// The re-run only calls the API for the users that were not blocked
+	var secondRun []string
+	gh := &mocks.MockGitHubClient{
+		BlockUserOrgFn: func(_, username string) error {
+			secondRun = append(secondRun, username)
+			return nil
+		},
+	}
+	var waits int
+	out := &bytes.Buffer{}
+	blocker = newGitHubBlocker(cfg, gh, trackingManager(marked), out, time.Second)
+	blocker.wait = func(time.Duration) { waits++ }
+	ok, skipped, failed := applyGitHubBlocks(out, blocker, usernames)
+
+	if ok != 2 || skipped != 2 || failed != 0 {
+		t.Errorf("resumed run = (%d, %d, %d), want (2, 2, 0)", ok, skipped, failed)
+	}
+	if !slices.Equal(secondRun, []string{"spammer3", "spammer4"}) {
+		t.Errorf("expected only the remaining users to be blocked, got %v", secondRun)
+	}
+	if waits != 1 {
+		t.Errorf("expected skipped users not to wait, got %d waits", waits)
+	}
+	if !strings.Contains(out.String(), "[1/4] - spammer1: already blocked on GitHub") {
+		t.Errorf("expected skip progress, got:\n%s", out.String())
+	}
+	for _, username := range usernames {
+		if !marked[username] {
+			t.Errorf("expected %s to be recorded as GitHub-blocked", username)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// NewHistoryCommand creates the history command
+func NewHistoryCommand(configPath *string) *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:               "history <owner>/<repo>",
+		Short:             "Show past scan results for a repository",
+		Long:              `Displays the most recent scans recorded for a repository to track spam trends over time`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeRepositories(configPath),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runHistory(*configPath, args[0], limit)
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 10, "Number of scans to show")
+
+	return cmd
+}
+
+func runHistory(configPath, repo string, limit int) error {
+	if limit <= 0 {
+		return fmt.Errorf("--limit must be greater than 0")
+	}
+
+	owner, repoName, err := parseRepo(repo)
+	if err != nil {
+		return err
+	}
+
+	_, _, _, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	entries, err := db.ListScanHistory(owner+"/"+repoName, limit)
+	if err != nil {
+		return fmt.Errorf("failed to list scan history: %w", err)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No scan history for %s/%s\n", owner, repoName)
+		return nil
+	}
+
+	fmt.Printf("Last %d %s of %s/%s:\n\n", len(entries), pluralize("scan", "scans", len(entries)), owner, repoName)
+	fmt.Printf("%-19s  %6s  %6s  %9s  %6s\n", "DATE", "TOTAL", "SPAM", "UNCERTAIN", "CLEAN")
+	for _, entry := range entries {
+		fmt.Printf("%-19s  %6d  %6d  %9d  %6d\n",
+			entry.ScannedAt.Format("2006-01-02 15:04:05"),
+			entry.TotalPRs,
+			entry.SpamCount,
+			entry.UncertainCount,
+			entry.CleanCount,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/database"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+// setupTestConfig writes a minimal sqlite config and opens its database
+func setupTestConfig(t *testing.T) (string, *database.DB) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "test.db")
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	cfg := &config.Config{
+		GitHub: config.GitHubConfig{
+			Token: "test-token",
+			User:  "testowner",
+		},
+		Database: config.DatabaseConfig{
+			Type: "sqlite",
+			Path: dbPath,
+		},
+	}
+
+	if err := config.Save(cfg, configPath); err != nil {
+		t.Fatalf("failed to save test config: %v", err)
+	}
+
+	db, err := database.NewSQLiteDB(dbPath)
+	if err != nil {
+		t.Fatalf("failed to initialize database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() }) //nolint:errcheck
+
+	return configPath, db
+}
+
+func TestHistoryCommand_Flags(t *testing.T) {
+	configPath := "config.yaml"
+	cmd := NewHistoryCommand(&configPath)
+
+	limitFlag := cmd.Flags().Lookup("limit")
+	if limitFlag == nil {
+		t.Fatal("limit flag not found")
+	}
+	if limitFlag.DefValue != "10" {
+		t.Errorf("limit default should be '10', got %s", limitFlag.DefValue)
+	}
+
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error with no args")
+	}
+}
+
+func TestHistoryCommand_WithEntries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+
+	if err := db.RecordScan(models.NewScanHistoryEntry("org/repo", 5, 2, 1, 2)); err != nil {
+		t.Fatalf("failed to record scan: %v", err)
+	}
+
+	if err := runHistory(configPath, "org/repo", 10); err != nil {
+		t.Errorf("runHistory failed: %v", err)
+	}
+	if err := runHistory(configPath, "org/empty", 10); err != nil {
+		t.Errorf("runHistory failed for repo without history: %v", err)
+	}
+}
+
+func TestHistoryCommand_InvalidArgs(t *testing.T) {
+	if err := runHistory("config.yaml", "org/repo", 0); err == nil {
+		t.Error("expected error with zero limit")
+	}
+	if err := runHistory("config.yaml", "invalid", 10); err == nil {
+		t.Error("expected error with invalid repo format")
+	}
+}
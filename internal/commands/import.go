@@ -15,37 +15,69 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/pkg/models"
 	"github.com/spf13/cobra"
 )
 
+// importOptions holds the command-line options for an import
+type importOptions struct {
+	file      string
+	url       string
+	verifyKey string // ed25519 public key a signed JSON file must verify against
+}
+
 // NewImportCommand creates the import command
 func NewImportCommand(configPath *string) *cobra.Command {
-	var file, url string
+	var opts importOptions
+	var validate bool
 
 	cmd := &cobra.Command{
 		Use:   "import",
 		Short: "Import blocklist entries from a file or URL",
-		Long:  `Imports blocklist entries from a JSON file or remote URL`,
+		Long: `Imports blocklist entries from a JSON, YAML, or CSV file, or a remote JSON URL.
+Files ending in .yaml or .yml are read as YAML and files ending in .csv as CSV
+in the layout written by export --format csv.
+
+Use --verify-key with a PEM ed25519 public key to require a valid detached
+signature (<file>.sig) for a JSON file; nothing is imported if it fails.
+
+Every entry is checked before anything is written; if any entry has a missing
+username or an unknown severity or source, the whole import is rejected. Use
+--validate to report those problems without importing.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			return runImport(*configPath, file, url)
+			if validate {
+				return runImportValidate(os.Stdout, opts.file, opts.url)
+			}
+			return runImport(*configPath, opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&file, "file", "f", "", "Path to JSON file to import")
-	cmd.Flags().StringVarP(&url, "url", "u", "", "URL to JSON file to import")
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Path to JSON, YAML, or CSV file to import")
+	cmd.Flags().StringVarP(&opts.url, "url", "u", "", "URL to JSON file to import")
+	cmd.Flags().StringVar(&opts.verifyKey, "verify-key", "", "Verify a signed JSON file with this ed25519 public key (PEM)")
+	cmd.Flags().BoolVar(&validate, "validate", false, "Check the file for invalid entries without importing")
 
 	return cmd
 }
 
-func runImport(configPath, file, url string) error {
-	if file == "" && url == "" {
+func runImport(configPath string, opts importOptions) error {
+	if opts.file == "" && opts.url == "" {
 		return fmt.Errorf("either --file or --url must be specified")
 	}
-	if file != "" && url != "" {
+	if opts.file != "" && opts.url != "" {
 		return fmt.Errorf("cannot specify both --file and --url")
 	}
+	if opts.verifyKey != "" && (opts.file == "" || isYAMLFile(opts.file) || isCSVFile(opts.file)) {
+		return fmt.Errorf("--verify-key is only supported for JSON files")
+	}
 
 	_, _, blManager, db, err := initClients(configPath)
 	if err != nil {
@@ -55,12 +87,28 @@ func runImport(configPath, file, url string) error {
 
 	var imported int
 
-	if file != "" {
-		fmt.Printf("Importing from file: %s\n", file)
-		imported, err = blManager.ImportJSON(file)
+	if opts.file != "" {
+		fmt.Printf("Importing from file: %s\n", opts.file)
+		switch {
+		case opts.verifyKey != "":
+			imported, err = blManager.ImportSignedJSON(opts.file, opts.verifyKey)
+			if err == nil {
+				fmt.Println("✓ Signature verified")
+			}
+		case isYAMLFile(opts.file):
+			imported, err = blManager.ImportYAML(opts.file)
+		case isCSVFile(opts.file):
+			imported, err = blManager.ImportCSV(opts.file)
+		default:
+			imported, err = blManager.ImportJSON(opts.file)
+		}
 	} else {
-		fmt.Printf("Importing from URL: %s\n", url)
-		imported, err = blManager.ImportJSONFromURL(url)
+		fmt.Printf("Importing from URL: %s\n", opts.url)
+		imported, err = blManager.ImportJSONFromURL(opts.url)
+		if errors.Is(err, blocklist.ErrNotModified) {
+			fmt.Println("✓ No changes since last import")
+			return nil
+		}
 	}
 
 	if err != nil {
@@ -72,6 +120,53 @@ func runImport(configPath, file, url string) error {
 	return nil
 }
 
+// runImportValidate reports every entry in file that would fail to import
+func runImportValidate(w io.Writer, file, url string) error {
+	if file == "" || url != "" {
+		return fmt.Errorf("--validate requires --file")
+	}
+
+	var problems []blocklist.ImportProblem
+	var err error
+	switch {
+	case isYAMLFile(file):
+		var entries []*models.BlocklistEntry
+		entries, err = blocklist.LoadYAML(file)
+		problems = blocklist.ValidateEntries(entries)
+	case isCSVFile(file):
+		var entries []*models.BlocklistEntry
+		entries, err = blocklist.LoadCSV(file)
+		problems = blocklist.ValidateEntries(entries)
+	default:
+		problems, err = blocklist.ImportJSONValidate(file)
+	}
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if len(problems) == 0 {
+		fmt.Fprintf(w, "✓ %s is valid\n", file)
+		return nil
+	}
+
+	fmt.Fprintf(w, "Found %d %s in %s:\n", len(problems), pluralize("problem", "problems", len(problems)), file)
+	for _, p := range problems {
+		fmt.Fprintf(w, "  - %s\n", p)
+	}
+	return fmt.Errorf("%s is not valid; nothing was imported", file)
+}
+
+// isYAMLFile reports whether a path has a YAML extension
+func isYAMLFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// isCSVFile reports whether a path has a CSV extension
+func isCSVFile(path string) bool {
+	return strings.ToLower(filepath.Ext(path)) == ".csv"
+}
+
 func pluralize(singular, plural string, count int) string {
 	if count == 1 {
 		return singular
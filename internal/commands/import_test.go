@@ -15,9 +15,11 @@
 package commands
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/prguard/prguard/internal/blocklist"
@@ -92,7 +94,7 @@ func TestImportCommand_FromFile(t *testing.T) {
 	}
 
 	// Import from file
-	err = runImport(configPath, importPath, "")
+	err = runImport(configPath, importOptions{file: importPath})
 	if err != nil {
 		t.Errorf("runImport failed: %v", err)
 	}
@@ -157,7 +159,7 @@ func TestImportCommand_Deduplication(t *testing.T) {
 
 	// Add an existing entry
 	manager := blocklist.NewManager(db)
-	_, err = manager.Block("existinguser", "spam", "https://github.com/test/repo/pull/1", "testowner", models.SeverityLow, models.SourceManual)
+	_, err = manager.Block(blocklist.BlockRequest{Username: "existinguser", Reason: "spam", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "testowner", Severity: models.SeverityLow, Source: models.SourceManual})
 	if err != nil {
 		t.Fatalf("failed to add existing entry: %v", err)
 	}
@@ -187,7 +189,7 @@ func TestImportCommand_Deduplication(t *testing.T) {
 	}
 
 	// Import (should deduplicate)
-	err = runImport(configPath, importPath, "")
+	err = runImport(configPath, importOptions{file: importPath})
 	if err != nil {
 		t.Errorf("runImport failed: %v", err)
 	}
@@ -211,7 +213,7 @@ func TestImportCommand_MissingFlags(t *testing.T) {
 	configPath := "config.yaml"
 
 	// No file or URL specified
-	err := runImport(configPath, "", "")
+	err := runImport(configPath, importOptions{})
 	if err == nil {
 		t.Error("expected error when neither file nor URL specified")
 	}
@@ -221,7 +223,7 @@ func TestImportCommand_BothFlags(t *testing.T) {
 	configPath := "config.yaml"
 
 	// Both file and URL specified
-	err := runImport(configPath, "file.json", "http://example.com/blocklist.json")
+	err := runImport(configPath, importOptions{file: "file.json", url: "http://example.com/blocklist.json"})
 	if err == nil {
 		t.Error("expected error when both file and URL specified")
 	}
@@ -261,7 +263,7 @@ func TestImportCommand_NonexistentFile(t *testing.T) {
 	defer db.Close() //nolint:errcheck
 
 	// Try to import from nonexistent file
-	err = runImport(configPath, "/nonexistent/file.json", "")
+	err = runImport(configPath, importOptions{file: "/nonexistent/file.json"})
 	if err == nil {
 		t.Error("expected error with nonexistent file")
 	}
@@ -307,7 +309,7 @@ func TestImportCommand_InvalidJSON(t *testing.T) {
 	}
 
 	// Try to import invalid JSON
-	err = runImport(configPath, importPath, "")
+	err = runImport(configPath, importOptions{file: importPath})
 	if err == nil {
 		t.Error("expected error with invalid JSON")
 	}
@@ -353,7 +355,7 @@ func TestImportCommand_EmptyFile(t *testing.T) {
 	}
 
 	// Import empty file
-	err = runImport(configPath, importPath, "")
+	err = runImport(configPath, importOptions{file: importPath})
 	if err != nil {
 		t.Errorf("runImport with empty file failed: %v", err)
 	}
@@ -393,3 +395,70 @@ func TestPluralize(t *testing.T) {
 		}
 	}
 }
+
+func TestRunImport_VerifyKeyRequiresJSONFile(t *testing.T) {
+	if err := runImport("config.yaml", importOptions{url: "https://example.com/blocklist.json", verifyKey: "key.pub.pem"}); err == nil {
+		t.Error("expected error verifying a URL import")
+	}
+	if err := runImport("config.yaml", importOptions{file: "blocklist.yaml", verifyKey: "key.pub.pem"}); err == nil {
+		t.Error("expected error verifying a YAML import")
+	}
+	if err := runImport("config.yaml", importOptions{file: "blocklist.csv", verifyKey: "key.pub.pem"}); err == nil {
+		t.Error("expected error verifying a CSV import")
+	}
+}
+
+func TestRunImportValidate(t *testing.T) {
+	configPath, db := setupTestConfig(t)
+	tempDir := t.TempDir()
+
+	entries := []*models.BlocklistEntry{
+		models.NewBlocklistEntry("validuser", "spam", "https://github.com/test/repo/pull/1", "admin", models.SeverityHigh, models.SourceImported),
+		models.NewBlocklistEntry("baduser", "spam", "https://github.com/test/repo/pull/2", "admin", "critical", models.SourceImported),
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatalf("failed to marshal test data: %v", err)
+	}
+	invalidPath := filepath.Join(tempDir, "invalid.json")
+	if err := os.WriteFile(invalidPath, data, 0600); err != nil {
+		t.Fatalf("failed to write import file: %v", err)
+	}
+
+	out := &bytes.Buffer{}
+	if err := runImportValidate(out, invalidPath, ""); err == nil {
+		t.Error("expected validation to fail")
+	}
+	if !strings.Contains(out.String(), `entry 2 (baduser): invalid severity "critical"`) {
+		t.Errorf("expected the invalid entry to be reported, got:\n%s", out.String())
+	}
+
+	// A real import of the same file is rejected without writing anything
+	if err := runImport(configPath, importOptions{file: invalidPath}); err == nil {
+		t.Error("expected import of an invalid file to fail")
+	}
+	if blocked, _ := blocklist.NewManager(db).IsBlocked("validuser"); blocked {
+		t.Error("no entries should be imported when one is invalid")
+	}
+
+	data, err = json.Marshal(entries[:1])
+	if err != nil {
+		t.Fatalf("failed to marshal test data: %v", err)
+	}
+	validPath := filepath.Join(tempDir, "valid.json")
+	if err := os.WriteFile(validPath, data, 0600); err != nil {
+		t.Fatalf("failed to write import file: %v", err)
+	}
+
+	out.Reset()
+	if err := runImportValidate(out, validPath, ""); err != nil {
+		t.Errorf("expected valid file to pass, got %v", err)
+	}
+	if !strings.Contains(out.String(), "is valid") {
+		t.Errorf("unexpected output: %s", out.String())
+	}
+
+	if err := runImportValidate(out, "", "https://example.com/blocklist.json"); err == nil {
+		t.Error("expected --validate to require --file")
+	}
+}
@@ -26,7 +26,7 @@ import (
 )
 
 // NewInitCommand creates the init command
-func NewInitCommand(_ *string) *cobra.Command {
+func NewInitCommand(_ *string, assumeYes *bool) *cobra.Command {
 	var global bool
 
 	cmd := &cobra.Command{
@@ -34,7 +34,7 @@ func NewInitCommand(_ *string) *cobra.Command {
 		Short: "Initialize PRGuard configuration",
 		Long:  `Creates a new configuration file with interactive prompts`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			return runInit(global)
+			return runInit(global, *assumeYes)
 		},
 	}
 
@@ -43,7 +43,7 @@ func NewInitCommand(_ *string) *cobra.Command {
 	return cmd
 }
 
-func runInit(global bool) error {
+func runInit(global, assumeYes bool) error {
 	reader := bufio.NewReader(os.Stdin)
 
 	// Determine config path
@@ -53,7 +53,7 @@ func runInit(global bool) error {
 	}
 
 	// Check if config already exists and prompt for overwrite
-	shouldProceed, err := promptOverwriteExisting(configPath, reader)
+	shouldProceed, err := promptOverwriteExisting(configPath, reader, assumeYes)
 	if err != nil {
 		return err
 	}
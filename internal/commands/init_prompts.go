@@ -35,8 +35,39 @@ func determineConfigPath(global bool) (string, error) {
 	return "./config.yaml", nil
 }
 
-// promptOverwriteExisting checks if config exists and prompts for overwrite
-func promptOverwriteExisting(path string, reader *bufio.Reader) (bool, error) {
+// readLine reads one line from reader with surrounding whitespace trimmed
+func readLine(reader *bufio.Reader) string {
+	line, _ := reader.ReadString('\n')
+	return strings.TrimSpace(line)
+}
+
+// promptLine prints prompt and returns the trimmed answer
+func promptLine(reader *bufio.Reader, prompt string) string {
+	fmt.Print(prompt)
+	return readLine(reader)
+}
+
+// promptUntilValid repeats prompt until validate accepts the trimmed answer,
+// printing why each rejected answer was invalid. It fails once input runs out.
+func promptUntilValid(reader *bufio.Reader, prompt string, validate func(string) error) (string, error) {
+	for {
+		fmt.Print(prompt)
+		line, readErr := reader.ReadString('\n')
+		answer := strings.TrimSpace(line)
+		err := validate(answer)
+		if err == nil {
+			return answer, nil
+		}
+		if readErr != nil {
+			return "", err
+		}
+		fmt.Printf("  %v\n", err)
+	}
+}
+
+// promptOverwriteExisting checks if config exists and prompts for overwrite;
+// with assumeYes an existing config is overwritten without asking
+func promptOverwriteExisting(path string, reader *bufio.Reader, assumeYes bool) (bool, error) {
 	if _, err := os.Stat(path); err != nil {
 		// File doesn't exist, proceed
 		return true, nil
@@ -44,8 +75,11 @@ func promptOverwriteExisting(path string, reader *bufio.Reader) (bool, error) {
 
 	fmt.Printf("Config file already exists at: %s\n", path)
 	fmt.Print("Overwrite? (y/N): ")
-	response, _ := reader.ReadString('\n')
-	response = strings.TrimSpace(strings.ToLower(response))
+	if assumeYes {
+		fmt.Println("y (--yes)")
+		return true, nil
+	}
+	response := strings.ToLower(readLine(reader))
 
 	if response != "y" && response != "yes" {
 		fmt.Println("Initialization cancelled.")
@@ -60,9 +94,7 @@ func promptGitHubToken(reader *bufio.Reader) (string, error) {
 	fmt.Println("GitHub Personal Access Token:")
 	fmt.Println("  Create one at: https://github.com/settings/tokens")
 	fmt.Println("  Required scopes: repo, write:discussion")
-	fmt.Print("Token: ")
-	token, _ := reader.ReadString('\n')
-	token = strings.TrimSpace(token)
+	token := promptLine(reader, "Token: ")
 
 	if token == "" {
 		return "", fmt.Errorf("GitHub token is required")
@@ -77,9 +109,7 @@ func promptOrgOrUser(reader *bufio.Reader, gitUser string) (org, user string, er
 	if gitUser != "" {
 		fmt.Printf("  Detected from git config: %s\n", gitUser)
 	}
-	fmt.Print("Enter org name (or press Enter for user mode): ")
-	org, _ = reader.ReadString('\n')
-	org = strings.TrimSpace(org)
+	org = promptLine(reader, "Enter org name (or press Enter for user mode): ")
 
 	// If no org, we need a username
 	if org == "" {
@@ -97,18 +127,14 @@ func promptUsername(reader *bufio.Reader, gitUser string) (string, error) {
 	var user string
 
 	if gitUser != "" {
-		fmt.Printf("Use '%s' as username? (Y/n): ", gitUser)
-		response, _ := reader.ReadString('\n')
-		response = strings.TrimSpace(strings.ToLower(response))
+		response := strings.ToLower(promptLine(reader, fmt.Sprintf("Use '%s' as username? (Y/n): ", gitUser)))
 		if response == "" || response == "y" || response == "yes" {
 			user = gitUser
 		}
 	}
 
 	if user == "" {
-		fmt.Print("GitHub username: ")
-		user, _ = reader.ReadString('\n')
-		user = strings.TrimSpace(user)
+		user = promptLine(reader, "GitHub username: ")
 	}
 
 	if user == "" {
@@ -126,9 +152,7 @@ func promptRepositories(reader *bufio.Reader) ([]string, error) {
 
 	var repos []string
 	for {
-		fmt.Print("Repository (or Enter to skip): ")
-		repo, _ := reader.ReadString('\n')
-		repo = strings.TrimSpace(repo)
+		repo := promptLine(reader, "Repository (or Enter to skip): ")
 
 		if repo == "" {
 			break
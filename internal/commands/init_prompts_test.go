@@ -0,0 +1,61 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPromptOverwriteExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	// Missing config never prompts
+	proceed, err := promptOverwriteExisting(path, noStdin(t), false)
+	if err != nil || !proceed {
+		t.Fatalf("expected to proceed for missing config, got %v, %v", proceed, err)
+	}
+
+	if err := os.WriteFile(path, []byte("github: {}\n"), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	proceed, err = promptOverwriteExisting(path, noStdin(t), true)
+	if err != nil || !proceed {
+		t.Errorf("expected --yes to overwrite without prompting, got %v, %v", proceed, err)
+	}
+
+	proceed, err = promptOverwriteExisting(path, bufio.NewReader(strings.NewReader("n\n")), false)
+	if err != nil || proceed {
+		t.Errorf("expected declined overwrite, got %v, %v", proceed, err)
+	}
+}
+
+func TestPromptUntilValid(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("bad\ngood\n"))
+	answer, err := promptUntilValid(reader, "> ", func(answer string) error {
+		if answer != "good" {
+			return fmt.Errorf("not good")
+		}
+		return nil
+	})
+	if err != nil || answer != "good" {
+		t.Errorf("expected the second answer, got %q, %v", answer, err)
+	}
+}
@@ -15,53 +15,251 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/internal/database"
+	"github.com/prguard/prguard/pkg/models"
 	"github.com/spf13/cobra"
 )
 
+// listOptions holds the command-line options for a list
+type listOptions struct {
+	limit       int
+	offset      int
+	tag         string // Only list entries carrying this tag
+	minSeverity string // Only list entries at or above this severity
+	output      string // verbose, table, json, or csv
+	sortField   string // Field to order by instead of newest first
+	reverse     bool
+}
+
 // NewListCommand creates the list command
 func NewListCommand(configPath *string) *cobra.Command {
+	var opts listOptions
+
 	cmd := &cobra.Command{
 		Use:   "list",
-		Short: "List all blocklist entries",
-		Long:  `Displays all users in the blocklist with their details`,
+		Short: "List blocklist entries",
+		Long: `Displays users in the blocklist with their details, one page at a time. Use --tag to show only entries with a reason code
+and --min-severity to hide entries below a severity.
+
+--output table prints one aligned row per entry; json and csv print only the
+entries on the page, for piping into other tools.
+
+--sort orders entries by username, severity, timestamp, or source instead of
+newest first; --reverse flips the order.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			return runList(*configPath)
+			return runList(*configPath, opts)
 		},
 	}
+
+	cmd.Flags().IntVarP(&opts.limit, "limit", "n", 50, "Maximum number of entries to show")
+	cmd.Flags().IntVar(&opts.offset, "offset", 0, "Number of entries to skip")
+	cmd.Flags().StringVarP(&opts.tag, "tag", "t", "", "Only list entries with this tag")
+	cmd.Flags().StringVar(&opts.minSeverity, "min-severity", "", "Only list entries at or above this severity (low, medium, or high)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "verbose", "Output format (verbose, table, json, or csv)")
+	cmd.Flags().StringVar(&opts.sortField, "sort", "", "Sort by username, severity, timestamp, or source")
+	cmd.Flags().BoolVar(&opts.reverse, "reverse", false, "Reverse the sort order")
+
 	return cmd
 }
 
-func runList(configPath string) error {
-	_, _, blManager, db, err := initClients(configPath)
+func runList(configPath string, opts listOptions) error {
+	switch opts.output {
+	case "verbose", "table", "json", "csv":
+	default:
+		return fmt.Errorf("invalid output format, must be verbose, table, json, or csv")
+	}
+	if opts.limit < 1 {
+		return fmt.Errorf("limit must be at least 1")
+	}
+	if opts.offset < 0 {
+		return fmt.Errorf("offset must not be negative")
+	}
+	if opts.minSeverity != "" && models.SeverityRank(opts.minSeverity) == 0 {
+		return fmt.Errorf("invalid severity, must be low, medium, or high")
+	}
+	if opts.sortField != "" && !slices.Contains(database.SortFields, opts.sortField) {
+		return fmt.Errorf("invalid sort field, must be %s", strings.Join(database.SortFields, ", "))
+	}
+	if opts.reverse && opts.sortField == "" {
+		opts.sortField = "timestamp"
+	}
+
+	cfg, _, blManager, db, err := initClients(configPath)
 	if err != nil {
 		return err
 	}
 	defer db.Close() //nolint:errcheck
 
-	entries, err := blManager.List()
+	var entries []*models.BlocklistEntry
+	var total int
+	switch {
+	case opts.sortField != "":
+		entries, total, err = listSortedPage(blManager, opts.sortField, opts.reverse, opts.tag, opts.minSeverity, opts.limit, opts.offset)
+	case opts.minSeverity != "":
+		entries, total, err = blManager.ListPaged(models.EntryFilter{
+			MinSeverity: opts.minSeverity,
+			Tag:         strings.ToLower(strings.TrimSpace(opts.tag)),
+			Limit:       opts.limit,
+			Offset:      opts.offset,
+		})
+	case opts.tag != "":
+		entries, total, err = listByTagPage(blManager, opts.tag, opts.limit, opts.offset)
+	default:
+		entries, total, err = blManager.ListPaged(models.EntryFilter{Limit: opts.limit, Offset: opts.offset})
+	}
 	if err != nil {
 		return fmt.Errorf("failed to list entries: %w", err)
 	}
 
-	if len(entries) == 0 {
+	if opts.output != "verbose" {
+		return writeEntries(os.Stdout, opts.output, entries)
+	}
+
+	if total == 0 {
+		if opts.minSeverity != "" {
+			fmt.Printf("No entries at or above severity %s\n", opts.minSeverity)
+			return nil
+		}
+		if opts.tag != "" {
+			fmt.Printf("No entries tagged %q\n", opts.tag)
+			return nil
+		}
 		fmt.Println("Blocklist is empty")
 		return nil
 	}
 
-	fmt.Printf("Total blocked users: %d\n\n", len(entries))
+	if len(entries) == 0 {
+		fmt.Printf("No entries at offset %d (total %d)\n", opts.offset, total)
+		return nil
+	}
+
+	fmt.Printf("Total blocked users: %d\n\n", total)
+
+	printEntries(entries, opts.offset+1, newTimeFormatter(cfg.Display))
 
+	fmt.Printf("Showing %d–%d of %d\n", opts.offset+1, opts.offset+len(entries), total)
+
+	return nil
+}
+
+// listByTagPage returns one page of the entries carrying tag and their total
+func listByTagPage(blManager blocklist.BlocklistManager, tag string, limit, offset int) ([]*models.BlocklistEntry, int, error) {
+	entries, err := blManager.ListByTag(tag)
+	if err != nil {
+		return nil, 0, err
+	}
+	return pageEntries(entries, limit, offset)
+}
+
+// listSortedPage returns one page of the entries ordered by sortField, keeping
+// only those carrying tag and at or above minSeverity when set, and their total
+func listSortedPage(blManager blocklist.BlocklistManager, sortField string, reverse bool, tag, minSeverity string, limit, offset int) ([]*models.BlocklistEntry, int, error) {
+	entries, err := blManager.ListSorted(sortField, reverse)
+	if err != nil {
+		return nil, 0, err
+	}
+	return pageEntries(filterEntries(entries, tag, minSeverity), limit, offset)
+}
+
+// filterEntries keeps the entries carrying tag and at or above minSeverity;
+// empty values disable each condition
+func filterEntries(entries []*models.BlocklistEntry, tag, minSeverity string) []*models.BlocklistEntry {
+	if tag == "" && minSeverity == "" {
+		return entries
+	}
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	minRank := models.SeverityRank(minSeverity)
+	kept := entries[:0]
+	for _, entry := range entries {
+		if tag != "" && !slices.Contains(entry.Tags, tag) {
+			continue
+		}
+		if models.SeverityRank(entry.Severity) < minRank {
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	return kept
+}
+
+// pageEntries slices one page out of entries and returns it with the total
+func pageEntries(entries []*models.BlocklistEntry, limit, offset int) ([]*models.BlocklistEntry, int, error) {
+	total := len(entries)
+	if offset >= total {
+		return nil, total, nil
+	}
+	end := min(offset+limit, total)
+	return entries[offset:end], total, nil
+}
+
+// listReasonWidth is the width at which reasons are truncated in table output
+const listReasonWidth = 50
+
+// writeEntries writes entries to w in a table, json, or csv format
+func writeEntries(w io.Writer, format string, entries []*models.BlocklistEntry) error {
+	switch format {
+	case "json":
+		if entries == nil {
+			entries = []*models.BlocklistEntry{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	case "csv":
+		return blocklist.WriteCSV(w, entries)
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "USERNAME\tSEVERITY\tSOURCE\tDATE\tREASON")
+		for _, entry := range entries {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+				entry.Username,
+				entry.Severity,
+				entry.Source,
+				entry.Timestamp.Format("2006-01-02"),
+				truncateReason(entry.Reason, listReasonWidth),
+			)
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown output format: %s", format)
+	}
+}
+
+// truncateReason shortens reason to at most width runes, marking the cut with an ellipsis
+func truncateReason(reason string, width int) string {
+	reason = strings.Join(strings.Fields(reason), " ")
+	runes := []rune(reason)
+	if len(runes) <= width {
+		return reason
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// printEntries prints blocklist entries numbered from start, with timestamps rendered by times
+func printEntries(entries []*models.BlocklistEntry, start int, times timeFormatter) {
 	for i, entry := range entries {
-		fmt.Printf("%d. %s\n", i+1, entry.Username)
+		fmt.Printf("%d. %s\n", start+i, entry.Username)
 		fmt.Printf("   ID: %s\n", entry.ID)
 		fmt.Printf("   Reason: %s\n", entry.Reason)
 		fmt.Printf("   Evidence: %s\n", entry.EvidenceURL)
 		fmt.Printf("   Severity: %s\n", entry.Severity)
 		fmt.Printf("   Blocked by: %s\n", entry.BlockedBy)
 		fmt.Printf("   Source: %s\n", entry.Source)
-		fmt.Printf("   Date: %s\n\n", entry.Timestamp.Format("2006-01-02 15:04:05"))
+		if len(entry.Tags) > 0 {
+			fmt.Printf("   Tags: %s\n", strings.Join(entry.Tags, ", "))
+		}
+		if entry.ExpiresAt != nil {
+			fmt.Printf("   Expires: %s\n", times.format(*entry.ExpiresAt))
+		}
+		fmt.Printf("   Date: %s\n\n", times.format(entry.Timestamp))
 	}
-
-	return nil
 }
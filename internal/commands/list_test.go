@@ -15,12 +15,17 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/prguard/prguard/internal/blocklist"
 	"github.com/prguard/prguard/internal/config"
 	"github.com/prguard/prguard/internal/database"
+	"github.com/prguard/prguard/internal/mocks"
 	"github.com/prguard/prguard/pkg/models"
 )
 
@@ -58,7 +63,7 @@ func TestListCommand_Empty(t *testing.T) {
 	defer db.Close() //nolint:errcheck
 
 	// List should succeed with empty database
-	err = runList(configPath)
+	err = runList(configPath, listOptions{limit: 50, output: "verbose"})
 	if err != nil {
 		t.Errorf("runList failed: %v", err)
 	}
@@ -110,14 +115,14 @@ func TestListCommand_WithEntries(t *testing.T) {
 	}
 
 	for _, u := range testUsers {
-		_, err := manager.Block(u.username, u.reason, "https://github.com/test/repo/pull/1", "testowner", u.severity, models.SourceManual)
+		_, err := manager.Block(blocklist.BlockRequest{Username: u.username, Reason: u.reason, EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "testowner", Severity: u.severity, Source: models.SourceManual})
 		if err != nil {
 			t.Fatalf("failed to add test user %s: %v", u.username, err)
 		}
 	}
 
 	// List should succeed and show all entries
-	err = runList(configPath)
+	err = runList(configPath, listOptions{limit: 50, output: "verbose"})
 	if err != nil {
 		t.Errorf("runList failed: %v", err)
 	}
@@ -145,10 +150,280 @@ func TestListCommand_WithEntries(t *testing.T) {
 	}
 }
 
+func TestListCommand_Pagination(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+	manager := blocklist.NewManager(db)
+	for _, username := range []string{"spammer1", "spammer2", "spammer3"} {
+		if _, err := manager.Block(blocklist.BlockRequest{Username: username, Reason: "spam", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "testowner", Severity: models.SeverityLow, Source: models.SourceManual}); err != nil {
+			t.Fatalf("failed to add test user %s: %v", username, err)
+		}
+	}
+
+	if err := runList(configPath, listOptions{limit: 2, output: "verbose"}); err != nil {
+		t.Errorf("runList first page failed: %v", err)
+	}
+	if err := runList(configPath, listOptions{limit: 2, offset: 2, output: "verbose"}); err != nil {
+		t.Errorf("runList last page failed: %v", err)
+	}
+	if err := runList(configPath, listOptions{limit: 2, offset: 10, output: "verbose"}); err != nil {
+		t.Errorf("runList past the end failed: %v", err)
+	}
+	if err := runList(configPath, listOptions{limit: 0, output: "verbose"}); err == nil {
+		t.Error("expected error with zero limit")
+	}
+	if err := runList(configPath, listOptions{limit: 2, offset: -1, output: "verbose"}); err == nil {
+		t.Error("expected error with negative offset")
+	}
+}
+
 func TestListCommand_MissingConfig(t *testing.T) {
 	configPath := "/nonexistent/config.yaml"
-	err := runList(configPath)
+	err := runList(configPath, listOptions{limit: 50, output: "verbose"})
 	if err == nil {
 		t.Error("expected error with missing config")
 	}
 }
+
+func TestListByTagPage(t *testing.T) {
+	entries := []*models.BlocklistEntry{{Username: "a"}, {Username: "b"}, {Username: "c"}}
+	var requested string
+	blManager := &mocks.MockBlocklistManager{
+		ListByTagFn: func(tag string) ([]*models.BlocklistEntry, error) {
+			requested = tag
+			return entries, nil
+		},
+	}
+
+	page, total, err := listByTagPage(blManager, "crypto-spam", 2, 1)
+	if err != nil {
+		t.Fatalf("listByTagPage failed: %v", err)
+	}
+	if requested != "crypto-spam" {
+		t.Errorf("expected tag crypto-spam to be requested, got %q", requested)
+	}
+	if total != 3 || len(page) != 2 || page[0].Username != "b" || page[1].Username != "c" {
+		t.Errorf("expected page [b c] of 3, got %d entries of %d", len(page), total)
+	}
+
+	page, total, err = listByTagPage(blManager, "crypto-spam", 2, 5)
+	if err != nil {
+		t.Fatalf("listByTagPage failed: %v", err)
+	}
+	if total != 3 || len(page) != 0 {
+		t.Errorf("expected empty page past the end, got %d entries of %d", len(page), total)
+	}
+}
+
+func TestListCommand_Tag(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+	manager := blocklist.NewManager(db)
+	if _, err := manager.Block(blocklist.BlockRequest{Username: "crypto", Reason: "airdrop", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "testowner", Severity: models.SeverityHigh, Source: models.SourceManual, Tags: []string{"crypto-spam"}}); err != nil {
+		t.Fatalf("failed to block user: %v", err)
+	}
+
+	if err := runList(configPath, listOptions{limit: 50, tag: "crypto-spam", output: "verbose"}); err != nil {
+		t.Errorf("runList with tag failed: %v", err)
+	}
+	if err := runList(configPath, listOptions{limit: 50, tag: "unknown", output: "verbose"}); err != nil {
+		t.Errorf("runList with unused tag failed: %v", err)
+	}
+}
+
+func TestListCommand_MinSeverity(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+	manager := blocklist.NewManager(db)
+	for username, severity := range map[string]string{
+		"low-spammer":    models.SeverityLow,
+		"medium-spammer": models.SeverityMedium,
+		"high-spammer":   models.SeverityHigh,
+		"worst-spammer":  models.SeverityHigh,
+	} {
+		if _, err := manager.Block(blocklist.BlockRequest{Username: username, Reason: "spam", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "testowner", Severity: severity, Source: models.SourceManual}); err != nil {
+			t.Fatalf("failed to add test user %s: %v", username, err)
+		}
+	}
+
+	page, total, err := manager.ListPaged(models.EntryFilter{MinSeverity: models.SeverityHigh, Limit: 50})
+	if err != nil {
+		t.Fatalf("ListPaged failed: %v", err)
+	}
+	if total != 2 || len(page) != 2 {
+		t.Fatalf("expected 2 high-severity entries, got %d entries of %d", len(page), total)
+	}
+	for _, entry := range page {
+		if entry.Severity != models.SeverityHigh {
+			t.Errorf("expected only high-severity entries, got %s (%s)", entry.Username, entry.Severity)
+		}
+	}
+
+	if err := runList(configPath, listOptions{limit: 50, minSeverity: models.SeverityHigh, output: "verbose"}); err != nil {
+		t.Errorf("runList with min severity failed: %v", err)
+	}
+	if err := runList(configPath, listOptions{limit: 50, minSeverity: models.SeverityMedium, output: "table", sortField: "severity"}); err != nil {
+		t.Errorf("runList with min severity and sort failed: %v", err)
+	}
+}
+
+func TestRunList_InvalidMinSeverity(t *testing.T) {
+	if err := runList("config.yaml", listOptions{limit: 50, minSeverity: "critical", output: "verbose"}); err == nil {
+		t.Error("expected error for invalid severity")
+	}
+}
+
+func listFormatEntries() []*models.BlocklistEntry {
+	timestamp := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	short := models.NewBlocklistEntry("a", "spam", "", "maintainer", models.SeverityHigh, models.SourceManual)
+	short.Timestamp = timestamp
+	long := models.NewBlocklistEntry("much-longer-username", strings.Repeat("very long reason ", 10), "", "maintainer", models.SeverityLow, models.SourceAutoDetected)
+	long.Timestamp = timestamp
+	return []*models.BlocklistEntry{short, long}
+}
+
+func TestWriteEntries_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeEntries(&buf, "json", listFormatEntries()); err != nil {
+		t.Fatalf("writeEntries() error = %v", err)
+	}
+
+	var decoded []*models.BlocklistEntry
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if len(decoded) != 2 || decoded[1].Username != "much-longer-username" {
+		t.Errorf("unexpected decoded entries: %+v", decoded)
+	}
+
+	// An empty page is an empty array rather than null
+	buf.Reset()
+	if err := writeEntries(&buf, "json", nil); err != nil {
+		t.Fatalf("writeEntries() error = %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("expected [], got %q", buf.String())
+	}
+}
+
+func TestWriteEntries_Table(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeEntries(&buf, "table", listFormatEntries()); err != nil {
+		t.Fatalf("writeEntries() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d lines:\n%s", len(lines), buf.String())
+	}
+
+	// Every column starts at the same offset on every line
+	for _, column := range []struct{ header, first, second string }{
+		{"SEVERITY", models.SeverityHigh, models.SeverityLow},
+		{"SOURCE", models.SourceManual, models.SourceAutoDetected},
+		{"DATE", "2025-03-01", "2025-03-01"},
+		{"REASON", "spam", "very long reason"},
+	} {
+		offset := strings.Index(lines[0], column.header)
+		if offset < 0 {
+			t.Fatalf("header %q missing: %q", column.header, lines[0])
+		}
+		if got := strings.Index(lines[1], column.first); got != offset {
+			t.Errorf("%s column misaligned in row 1: at %d, header at %d", column.header, got, offset)
+		}
+		if got := strings.Index(lines[2], column.second); got != offset {
+			t.Errorf("%s column misaligned in row 2: at %d, header at %d", column.header, got, offset)
+		}
+	}
+
+	if !strings.HasSuffix(lines[2], "…") {
+		t.Errorf("expected long reason to be truncated: %q", lines[2])
+	}
+}
+
+func TestWriteEntries_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeEntries(&buf, "csv", listFormatEntries()); err != nil {
+		t.Fatalf("writeEntries() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "ID,Username,Reason") {
+		t.Errorf("unexpected CSV output:\n%s", buf.String())
+	}
+}
+
+func TestTruncateReason(t *testing.T) {
+	if got := truncateReason("short", 10); got != "short" {
+		t.Errorf("truncateReason() = %q, want %q", got, "short")
+	}
+	if got := truncateReason("line one\nline two", 50); got != "line one line two" {
+		t.Errorf("truncateReason() = %q, want newlines collapsed", got)
+	}
+	if got := truncateReason("abcdefghij", 5); got != "abcd…" {
+		t.Errorf("truncateReason() = %q, want %q", got, "abcd…")
+	}
+}
+
+func TestRunList_InvalidOutput(t *testing.T) {
+	if err := runList("config.yaml", listOptions{limit: 50, output: "xml"}); err == nil {
+		t.Error("expected error for invalid output format")
+	}
+}
+
+func TestListSortedPage(t *testing.T) {
+	entries := []*models.BlocklistEntry{
+		{Username: "a", Tags: []string{"crypto-spam"}},
+		{Username: "b"},
+		{Username: "c", Severity: models.SeverityHigh, Tags: []string{"crypto-spam"}},
+	}
+	var gotField string
+	var gotReverse bool
+	blManager := &mocks.MockBlocklistManager{
+		ListSortedFn: func(sortField string, reverse bool) ([]*models.BlocklistEntry, error) {
+			gotField, gotReverse = sortField, reverse
+			return append([]*models.BlocklistEntry(nil), entries...), nil
+		},
+	}
+
+	page, total, err := listSortedPage(blManager, "username", true, "", "", 2, 1)
+	if err != nil {
+		t.Fatalf("listSortedPage failed: %v", err)
+	}
+	if gotField != "username" || !gotReverse {
+		t.Errorf("expected sort by username reversed, got %q reverse=%v", gotField, gotReverse)
+	}
+	if total != 3 || len(page) != 2 || page[0].Username != "b" || page[1].Username != "c" {
+		t.Errorf("expected page [b c] of 3, got %d entries of %d", len(page), total)
+	}
+
+	page, total, err = listSortedPage(blManager, "username", false, "Crypto-Spam", "", 50, 0)
+	if err != nil {
+		t.Fatalf("listSortedPage failed: %v", err)
+	}
+	if total != 2 || len(page) != 2 || page[0].Username != "a" || page[1].Username != "c" {
+		t.Errorf("expected tagged entries [a c], got %d entries of %d", len(page), total)
+	}
+	page, total, err = listSortedPage(blManager, "username", false, "", models.SeverityMedium, 50, 0)
+	if err != nil {
+		t.Fatalf("listSortedPage failed: %v", err)
+	}
+	if total != 1 || len(page) != 1 || page[0].Username != "c" {
+		t.Errorf("expected entries at or above medium [c], got %d entries of %d", len(page), total)
+	}
+}
+
+func TestRunList_InvalidSort(t *testing.T) {
+	if err := runList("config.yaml", listOptions{limit: 50, output: "verbose", sortField: "reason"}); err == nil {
+		t.Error("expected error for invalid sort field")
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// NewMergeCommand creates the merge command
+func NewMergeCommand() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "merge <file>...",
+		Short: "Merge several blocklist files into one",
+		Long: `Combines blocklist files into a single JSON file without touching the local database.
+Entries are deduplicated by ID; when the same ID appears more than once the entry with
+the highest severity is kept. Files ending in .yaml or .yml are read as YAML.
+Every entry is checked as for import; if any file has a null entry or one with a
+missing ID or username, or an unknown severity or source, nothing is written.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runMerge(args, output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Path of the merged JSON file (required)")
+	_ = cmd.MarkFlagRequired("output")
+
+	return cmd
+}
+
+func runMerge(files []string, output string) error {
+	if output == "" {
+		return fmt.Errorf("--output is required")
+	}
+
+	merged, total, err := mergeFiles(files)
+	if err != nil {
+		return err
+	}
+
+	if err := blocklist.SaveJSON(output, merged); err != nil {
+		return err
+	}
+
+	fmt.Printf("Merged %d entries from %d %s into %d unique entries in %s\n",
+		total, len(files), pluralize("file", "files", len(files)), len(merged), output)
+	return nil
+}
+
+// mergeFiles loads every file and merges their entries, returning the merged
+// entries and the number of entries read
+func mergeFiles(files []string) ([]*models.BlocklistEntry, int, error) {
+	sets := make([][]*models.BlocklistEntry, 0, len(files))
+	total := 0
+	for _, file := range files {
+		var entries []*models.BlocklistEntry
+		var err error
+		if isYAMLFile(file) {
+			entries, err = blocklist.LoadYAML(file)
+		} else {
+			entries, err = blocklist.LoadJSON(file)
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to load %s: %w", file, err)
+		}
+		if problems := blocklist.ValidateEntries(entries); len(problems) > 0 {
+			return nil, 0, fmt.Errorf("invalid entries in %s: %w", file, &blocklist.ValidationError{Problems: problems})
+		}
+		sets = append(sets, entries)
+		total += len(entries)
+	}
+	return blocklist.Merge(sets...), total, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+func TestRunMerge_OverlappingFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	shared := models.NewBlocklistEntry("spammer", "spam", "", "admin", models.SeverityLow, models.SourceManual)
+	upgraded := *shared
+	upgraded.Severity = models.SeverityHigh
+	other := models.NewBlocklistEntry("bot", "spam", "", "admin", models.SeverityMedium, models.SourceManual)
+
+	file1 := filepath.Join(dir, "one.json")
+	file2 := filepath.Join(dir, "two.json")
+	if err := blocklist.SaveJSON(file1, []*models.BlocklistEntry{shared}); err != nil {
+		t.Fatalf("SaveJSON failed: %v", err)
+	}
+	if err := blocklist.SaveJSON(file2, []*models.BlocklistEntry{&upgraded, other}); err != nil {
+		t.Fatalf("SaveJSON failed: %v", err)
+	}
+
+	output := filepath.Join(dir, "merged.json")
+	if err := runMerge([]string{file1, file2}, output); err != nil {
+		t.Fatalf("runMerge failed: %v", err)
+	}
+
+	merged, err := blocklist.LoadJSON(output)
+	if err != nil {
+		t.Fatalf("LoadJSON failed: %v", err)
+	}
+	if len(merged) != 2 {
+		t.Fatalf("Expected 2 unique entries, got %d", len(merged))
+	}
+	if merged[0].ID != shared.ID || merged[0].Severity != models.SeverityHigh {
+		t.Errorf("Expected %s upgraded to high, got %s %s", shared.ID, merged[0].ID, merged[0].Severity)
+	}
+}
+
+func TestRunMerge_MissingFile(t *testing.T) {
+	dir := t.TempDir()
+	err := runMerge([]string{filepath.Join(dir, "missing.json")}, filepath.Join(dir, "out.json"))
+	if err == nil || !strings.Contains(err.Error(), "missing.json") {
+		t.Errorf("Expected an error naming the missing file, got %v", err)
+	}
+}
+
+func TestRunMerge_RejectsNullEntry(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "null.json")
+	if err := os.WriteFile(file, []byte(`[null]`), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	output := filepath.Join(dir, "merged.json")
+	err := runMerge([]string{file}, output)
+	if err == nil || !strings.Contains(err.Error(), "entry is null") {
+		t.Errorf("Expected a null entry error, got %v", err)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Errorf("Expected no merged file to be written, got %v", err)
+	}
+}
@@ -91,16 +91,7 @@ func runMigrateUp(configPath string) error {
 	}
 	defer db.Close() //nolint:errcheck
 
-	// Get database URL and auth token based on type
-	var dbURL, authToken string
-	if cfg.Database.Type == "sqlite" {
-		dbURL = cfg.Database.Path
-	} else {
-		dbURL = cfg.Database.URL
-		authToken = cfg.Database.AuthToken
-	}
-
-	if err := database.RunMigrations(db, cfg.Database.Type, dbURL, authToken); err != nil {
+	if err := database.RunMigrations(db); err != nil {
 		return fmt.Errorf("migration failed: %w", err)
 	}
 
@@ -139,16 +130,7 @@ func runMigrateDown(configPath string) error {
 		return nil
 	}
 
-	// Get database URL and auth token based on type
-	var dbURL, authToken string
-	if cfg.Database.Type == "sqlite" {
-		dbURL = cfg.Database.Path
-	} else {
-		dbURL = cfg.Database.URL
-		authToken = cfg.Database.AuthToken
-	}
-
-	if err := database.Rollback(db, cfg.Database.Type, dbURL, authToken); err != nil {
+	if err := database.Rollback(db); err != nil {
 		return fmt.Errorf("rollback failed: %w", err)
 	}
 
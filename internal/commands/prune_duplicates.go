@@ -0,0 +1,92 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/spf13/cobra"
+)
+
+// NewPruneDuplicatesCommand creates the prune-duplicates command
+func NewPruneDuplicatesCommand(configPath *string, assumeYes *bool) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "prune-duplicates",
+		Short: "Collapse duplicate blocklist entries for the same user",
+		Long: `Finds users with more than one blocklist entry and keeps only the
+highest-severity, most recent one. The kept entry takes the union of the tags
+and the longest expiry, and records the removed entries' reasons and evidence
+in its metadata.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runPruneDuplicates(*configPath, dryRun, *assumeYes)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List users with duplicate entries without changing anything")
+
+	return cmd
+}
+
+func runPruneDuplicates(configPath string, dryRun, assumeYes bool) error {
+	_, _, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	return executePruneDuplicates(blManager, bufio.NewReader(os.Stdin), dryRun, assumeYes)
+}
+
+// executePruneDuplicates lists the users with duplicate entries and prunes them after confirmation
+func executePruneDuplicates(blManager blocklist.BlocklistManager, reader *bufio.Reader, dryRun, assumeYes bool) error {
+	usernames, err := blManager.FindDuplicateUsernames()
+	if err != nil {
+		return fmt.Errorf("failed to find duplicate entries: %w", err)
+	}
+	if len(usernames) == 0 {
+		fmt.Println("No duplicate blocklist entries found")
+		return nil
+	}
+
+	fmt.Printf("Found %d %s with duplicate entries:\n", len(usernames), pluralize("user", "users", len(usernames)))
+	for _, username := range usernames {
+		fmt.Printf("  - %s\n", username)
+	}
+	fmt.Println()
+
+	if dryRun {
+		fmt.Println("Dry run: no entries removed")
+		return nil
+	}
+
+	if !confirmPrompt(reader, assumeYes) {
+		fmt.Println("Prune cancelled.")
+		return nil
+	}
+
+	removed, err := blManager.PruneDuplicates()
+	if err != nil {
+		return fmt.Errorf("failed to prune duplicate entries: %w", err)
+	}
+
+	fmt.Printf("✓ Removed %d duplicate blocklist %s\n", removed, pluralize("entry", "entries", int(removed)))
+	return nil
+}
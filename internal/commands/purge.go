@@ -0,0 +1,51 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// NewPurgeCommand creates the purge command
+func NewPurgeCommand(configPath *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Remove expired blocklist entries",
+		Long:  `Deletes all blocklist entries whose expiry time has passed`,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runPurge(*configPath)
+		},
+	}
+	return cmd
+}
+
+func runPurge(configPath string) error {
+	_, _, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	removed, err := blManager.PurgeExpired()
+	if err != nil {
+		return fmt.Errorf("failed to purge expired entries: %w", err)
+	}
+
+	fmt.Printf("✓ Removed %d expired blocklist entries\n", removed)
+	return nil
+}
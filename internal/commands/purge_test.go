@@ -0,0 +1,149 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/mocks"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+func TestPurgeCommand_RemovesExpired(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+
+	expired := models.NewBlocklistEntry("expired", "spam", "https://github.com/test/repo/pull/1", "testowner", models.SeverityLow, models.SourceManual)
+	past := time.Now().Add(-time.Hour)
+	expired.ExpiresAt = &past
+	if err := db.AddEntry(expired); err != nil {
+		t.Fatalf("failed to add expired entry: %v", err)
+	}
+
+	permanent := models.NewBlocklistEntry("permanent", "spam", "https://github.com/test/repo/pull/2", "testowner", models.SeverityHigh, models.SourceManual)
+	if err := db.AddEntry(permanent); err != nil {
+		t.Fatalf("failed to add permanent entry: %v", err)
+	}
+
+	if err := runPurge(configPath); err != nil {
+		t.Fatalf("runPurge failed: %v", err)
+	}
+
+	entries, err := db.ListEntries()
+	if err != nil {
+		t.Fatalf("failed to list entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Username != "permanent" {
+		t.Errorf("expected only the permanent entry to remain, got %d entries", len(entries))
+	}
+}
+
+func TestPruneDuplicatesCommand_KeepsHighestSeverity(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+
+	for _, severity := range []string{models.SeverityLow, models.SeverityHigh} {
+		entry := models.NewBlocklistEntry("spammer", "spam", "https://github.com/test/repo/pull/1", "testowner", severity, models.SourceManual)
+		if err := db.AddEntry(entry); err != nil {
+			t.Fatalf("failed to add entry: %v", err)
+		}
+	}
+
+	if err := runPruneDuplicates(configPath, true, true); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if entries, _ := db.GetEntriesByUsername("spammer"); len(entries) != 2 {
+		t.Fatalf("dry run should not remove entries, got %d", len(entries))
+	}
+
+	if err := runPruneDuplicates(configPath, false, true); err != nil {
+		t.Fatalf("runPruneDuplicates failed: %v", err)
+	}
+
+	entries, err := db.GetEntriesByUsername("spammer")
+	if err != nil {
+		t.Fatalf("failed to get entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Severity != models.SeverityHigh {
+		t.Errorf("expected only the high severity entry to remain, got %d entries", len(entries))
+	}
+}
+
+func TestExecutePruneDuplicates_Declined(t *testing.T) {
+	pruned := false
+	blManager := &mocks.MockBlocklistManager{
+		FindDuplicateUsernamesFn: func() ([]string, error) { return []string{"spammer"}, nil },
+		PruneDuplicatesFn: func() (int64, error) {
+			pruned = true
+			return 1, nil
+		},
+	}
+
+	if err := executePruneDuplicates(blManager, bufio.NewReader(strings.NewReader("n\n")), false, false); err != nil {
+		t.Fatalf("executePruneDuplicates failed: %v", err)
+	}
+	if pruned {
+		t.Error("expected no pruning when the prompt is declined")
+	}
+}
+
+func TestParseExpiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "30d", want: 30 * 24 * time.Hour},
+		{value: "12h", want: 12 * time.Hour},
+		{value: "90m", want: 90 * time.Minute},
+		{value: "xd", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseExpiry(tt.value, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error for %q", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(now.Add(tt.want)) {
+				t.Errorf("parseExpiry(%q) = %v, want %v", tt.value, got, now.Add(tt.want))
+			}
+		})
+	}
+
+	got, err := parseExpiry("", now)
+	if err != nil || got != nil {
+		t.Errorf("empty expiry should be permanent, got %v, %v", got, err)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/prguard/prguard/internal/github"
+	"github.com/spf13/cobra"
+)
+
+// NewRateLimitCommand creates the rate-limit command
+func NewRateLimitCommand(configPath *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rate-limit",
+		Short: "Show remaining GitHub API budget",
+		Long:  `Prints the remaining core and search GitHub API requests and when each budget resets`,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runRateLimit(*configPath)
+		},
+	}
+
+	return cmd
+}
+
+func runRateLimit(configPath string) error {
+	_, ghClient, _, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	limits, err := ghClient.GetRateLimit()
+	if err != nil {
+		return err
+	}
+
+	printRateLimits(os.Stdout, limits, time.Now())
+	return nil
+}
+
+// printRateLimits writes the core and search budgets with their reset times
+func printRateLimits(w io.Writer, limits *github.RateLimits, now time.Time) {
+	for _, category := range []struct {
+		name  string
+		limit github.RateLimit
+	}{
+		{"Core", limits.Core},
+		{"Search", limits.Search},
+	} {
+		fmt.Fprintf(w, "%-7s %d/%d remaining, resets %s (in %s)\n",
+			category.name+":",
+			category.limit.Remaining,
+			category.limit.Limit,
+			category.limit.Reset.Local().Format("15:04:05"),
+			max(category.limit.Reset.Sub(now), 0).Round(time.Second))
+	}
+}
+
+// waitForRateLimit pauses until the core budget resets when fewer than threshold
+// requests remain. A threshold of 0 disables the check; a failed check is
+// reported and does not block the scan.
+func waitForRateLimit(ctx context.Context, w io.Writer, ghClient github.GitHubClient, threshold int) error {
+	if threshold <= 0 {
+		return nil
+	}
+
+	limits, err := ghClient.GetRateLimit()
+	if err != nil {
+		fmt.Fprintf(w, "⚠ Could not check rate limit: %v\n", err)
+		return nil
+	}
+	if limits.Core.Remaining >= threshold {
+		return nil
+	}
+
+	wait := time.Until(limits.Core.Reset)
+	if wait <= 0 {
+		return nil
+	}
+	fmt.Fprintf(w, "⚠ Only %d API requests remaining (threshold %d); pausing %s until reset\n",
+		limits.Core.Remaining, threshold, wait.Round(time.Second))
+
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
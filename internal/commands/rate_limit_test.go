@@ -0,0 +1,98 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/mocks"
+)
+
+func TestPrintRateLimits(t *testing.T) {
+	now := time.Now()
+	limits := &github.RateLimits{
+		Core:   github.RateLimit{Limit: 5000, Remaining: 4321, Reset: now.Add(30 * time.Minute)},
+		Search: github.RateLimit{Limit: 30, Remaining: 29, Reset: now.Add(time.Minute)},
+	}
+
+	var out bytes.Buffer
+	printRateLimits(&out, limits, now)
+
+	for _, want := range []string{"Core:   4321/5000 remaining", "(in 30m0s)", "Search: 29/30 remaining", "(in 1m0s)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestWaitForRateLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		remaining int
+		threshold int
+		err       error
+		wantOut   string
+	}{
+		{name: "disabled", remaining: 0, threshold: 0},
+		{name: "above threshold", remaining: 500, threshold: 100},
+		{name: "below threshold", remaining: 10, threshold: 100, wantOut: "Only 10 API requests remaining"},
+		{name: "check fails", threshold: 100, err: fmt.Errorf("boom"), wantOut: "Could not check rate limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mocks.MockGitHubClient{
+				GetRateLimitFn: func() (*github.RateLimits, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &github.RateLimits{Core: github.RateLimit{Remaining: tt.remaining, Reset: time.Now().Add(20 * time.Millisecond)}}, nil
+				},
+			}
+
+			var out bytes.Buffer
+			if err := waitForRateLimit(context.Background(), &out, client, tt.threshold); err != nil {
+				t.Fatalf("waitForRateLimit() error = %v", err)
+			}
+			if tt.wantOut == "" && out.Len() != 0 {
+				t.Errorf("expected no output, got %q", out.String())
+			}
+			if tt.wantOut != "" && !strings.Contains(out.String(), tt.wantOut) {
+				t.Errorf("output missing %q:\n%s", tt.wantOut, out.String())
+			}
+		})
+	}
+}
+
+func TestWaitForRateLimit_Cancelled(t *testing.T) {
+	client := &mocks.MockGitHubClient{
+		GetRateLimitFn: func() (*github.RateLimits, error) {
+			return &github.RateLimits{Core: github.RateLimit{Remaining: 1, Reset: time.Now().Add(time.Hour)}}, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := waitForRateLimit(ctx, &bytes.Buffer{}, client, 100); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
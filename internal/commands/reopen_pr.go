@@ -0,0 +1,83 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/prguard/prguard/internal/github"
+	"github.com/spf13/cobra"
+)
+
+// NewReopenPRCommand creates the reopen-pr command
+func NewReopenPRCommand(configPath *string) *cobra.Command {
+	var comment string
+
+	cmd := &cobra.Command{
+		Use:               "reopen-pr <owner>/<repo> <pr-number>...",
+		Short:             "Reopen one or more pull requests closed by mistake",
+		Long:              `Reopens pull requests and optionally posts a comment, e.g. an apology for a mistaken auto-close`,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeRepositories(configPath),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runReopenPR(*configPath, args[0], args[1:], comment)
+		},
+	}
+
+	cmd.Flags().StringVarP(&comment, "comment", "c", "", "Comment to add after reopening")
+
+	return cmd
+}
+
+func runReopenPR(configPath, repo string, prNumbers []string, comment string) error {
+	// Parse arguments before touching the API so a typo doesn't leave a partial run
+	owner, repoName, err := parseRepo(repo)
+	if err != nil {
+		return err
+	}
+
+	numbers := make([]int, 0, len(prNumbers))
+	for _, prNumStr := range prNumbers {
+		prNum, err := strconv.Atoi(prNumStr)
+		if err != nil {
+			return fmt.Errorf("invalid PR number: %s", prNumStr)
+		}
+		numbers = append(numbers, prNum)
+	}
+
+	_, ghClient, _, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	return reopenPRs(ghClient, owner, repoName, numbers, comment)
+}
+
+// reopenPRs reopens each pull request, stopping at the first failure
+func reopenPRs(ghClient github.GitHubClient, owner, repoName string, numbers []int, comment string) error {
+	for _, prNum := range numbers {
+		fmt.Printf("Reopening PR #%d...\n", prNum)
+
+		if err := ghClient.ReopenPullRequest(owner, repoName, prNum, comment); err != nil {
+			return fmt.Errorf("failed to reopen PR #%d: %w", prNum, err)
+		}
+
+		fmt.Printf("  ✓ PR #%d reopened\n", prNum)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prguard/prguard/internal/mocks"
+)
+
+func TestReopenPRCommand_Flags(t *testing.T) {
+	configPath := "config.yaml"
+	cmd := NewReopenPRCommand(&configPath)
+
+	cmd.SetArgs([]string{"owner/repo"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error with only 1 arg")
+	}
+
+	if cmd.Flags().Lookup("comment") == nil {
+		t.Error("comment flag not found")
+	}
+}
+
+func TestReopenPR_InvalidNumber(t *testing.T) {
+	err := runReopenPR("does-not-exist.yaml", "owner/repo", []string{"12", "abc"}, "")
+	if err == nil || err.Error() != "invalid PR number: abc" {
+		t.Errorf("expected invalid PR number error, got %v", err)
+	}
+}
+
+func TestReopenPR_Success(t *testing.T) {
+	var reopened []int
+	var comments []string
+
+	mockClient := &mocks.MockGitHubClient{
+		ReopenPullRequestFn: func(owner, repo string, number int, comment string) error {
+			if owner != "testowner" || repo != "testrepo" {
+				t.Errorf("unexpected owner/repo: %s/%s", owner, repo)
+			}
+			reopened = append(reopened, number)
+			comments = append(comments, comment)
+			return nil
+		},
+	}
+
+	if err := reopenPRs(mockClient, "testowner", "testrepo", []int{123, 456}, "Sorry, closed by mistake"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(reopened) != 2 || reopened[0] != 123 || reopened[1] != 456 {
+		t.Errorf("unexpected PRs reopened: %v", reopened)
+	}
+	for _, comment := range comments {
+		if comment != "Sorry, closed by mistake" {
+			t.Errorf("unexpected comment: %q", comment)
+		}
+	}
+}
+
+func TestReopenPR_StopsOnFailure(t *testing.T) {
+	calls := 0
+	mockClient := &mocks.MockGitHubClient{
+		ReopenPullRequestFn: func(_, _ string, _ int, _ string) error {
+			calls++
+			return errors.New("API error")
+		},
+	}
+
+	err := reopenPRs(mockClient, "testowner", "testrepo", []int{123, 456}, "")
+	if err == nil || err.Error() != "failed to reopen PR #123: API error" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected to stop after the first failure, got %d calls", calls)
+	}
+}
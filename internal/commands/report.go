@@ -0,0 +1,102 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/prguard/prguard/internal/report"
+	"github.com/prguard/prguard/internal/scanner"
+	"github.com/spf13/cobra"
+)
+
+// NewReportCommand creates the report command
+func NewReportCommand(configPath *string) *cobra.Command {
+	var format, output string
+
+	cmd := &cobra.Command{
+		Use:   "report <owner>/<repo>",
+		Short: "Scan a repository and write a Markdown or HTML report",
+		Long: `Scans a repository and renders a shareable report with summary counts,
+a table of spam PRs with reasons and links, and the PRs needing manual review.
+
+The report is written to stdout unless --output is given.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeRepositories(configPath),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runReport(*configPath, args[0], format, output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", report.FormatMarkdown, "Report format (markdown/html)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the report to a file instead of stdout")
+
+	return cmd
+}
+
+func runReport(configPath, repo, format, output string) error {
+	if format != report.FormatMarkdown && format != report.FormatHTML {
+		return fmt.Errorf("invalid format, must be %s or %s", report.FormatMarkdown, report.FormatHTML)
+	}
+
+	cfg, ghClient, _, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	owner, repoName, err := parseRepo(repo)
+	if err != nil {
+		return err
+	}
+
+	// Progress goes to stderr so stdout holds only the report
+	fmt.Fprintf(os.Stderr, "Scanning repository %s/%s...\n", owner, repoName)
+
+	scan, err := scanner.NewScannerE(cfg)
+	if err != nil {
+		return err
+	}
+	results, err := scan.ScanRepository(ghClient, owner, repoName)
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	return writeReport(output, format, scanner.NewReport(owner, repoName, results))
+}
+
+// writeReport renders a report to the output file, or to stdout when output is empty
+func writeReport(output, format string, r *scanner.Report) error {
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output) //nolint:gosec // path is provided by the user
+		if err != nil {
+			return fmt.Errorf("failed to create report file: %w", err)
+		}
+		defer f.Close() //nolint:errcheck
+		w = f
+	}
+
+	if err := report.Render(w, format, r); err != nil {
+		return err
+	}
+
+	if output != "" {
+		fmt.Fprintf(os.Stderr, "✓ Report written to %s\n", output)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+func TestReportCommand_Flags(t *testing.T) {
+	configPath := "config.yaml"
+	cmd := NewReportCommand(&configPath)
+
+	if cmd.Use != "report <owner>/<repo>" {
+		t.Errorf("expected Use 'report <owner>/<repo>', got '%s'", cmd.Use)
+	}
+	formatFlag := cmd.Flags().Lookup("format")
+	if formatFlag == nil || formatFlag.DefValue != "markdown" {
+		t.Error("expected format flag defaulting to markdown")
+	}
+	if cmd.Flags().Lookup("output") == nil {
+		t.Error("output flag not found")
+	}
+}
+
+func TestRunReport_InvalidFormat(t *testing.T) {
+	if err := runReport("config.yaml", "org/repo", "pdf", ""); err == nil {
+		t.Error("expected error for invalid format")
+	}
+}
+
+func TestWriteReport_File(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "report.html")
+	r := &scanner.Report{
+		Repository: "org/repo",
+		SpamCount:  1,
+		PullRequests: []scanner.ReportPR{
+			{Number: 7, Title: "Spam", Author: "spammer", Classification: scanner.ClassificationSpam},
+		},
+	}
+
+	if err := writeReport(output, "html", r); err != nil {
+		t.Fatalf("writeReport failed: %v", err)
+	}
+
+	data, err := os.ReadFile(output) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if !strings.Contains(string(data), "#7") || !strings.Contains(string(data), "spammer") {
+		t.Errorf("report missing PR details:\n%s", data)
+	}
+}
@@ -15,28 +15,48 @@
 package commands
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/prguard/prguard/internal/scanner"
+	"github.com/prguard/prguard/pkg/models"
 	"github.com/spf13/cobra"
 )
 
 // NewReviewCommand creates the review command
 func NewReviewCommand(configPath *string) *cobra.Command {
+	var interactive, githubBlock bool
+	var author string
+
 	cmd := &cobra.Command{
 		Use:   "review <owner>/<repo>",
 		Short: "Show PRs that need manual review",
-		Long:  `Displays pull requests that have suspicious indicators but are not definitively spam`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Displays pull requests that have suspicious indicators but are not definitively spam.
+
+With --interactive, walks through each PR and prompts to (b)lock the author,
+(c)lose the PR, (s)kip it, or (q)uit.
+
+Use --author to only review PRs opened by one user.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeRepositories(configPath),
 		RunE: func(_ *cobra.Command, args []string) error {
-			return runReview(*configPath, args[0])
+			return runReview(*configPath, args[0], author, interactive, githubBlock)
 		},
 	}
+
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Prompt for an action on each PR")
+	cmd.Flags().BoolVar(&githubBlock, "github-block", false, "Also block users via GitHub API when blocking interactively")
+	cmd.Flags().StringVar(&author, "author", "", "Only review PRs opened by this user")
+
 	return cmd
 }
 
-func runReview(configPath, repo string) error {
-	cfg, ghClient, _, db, err := initClients(configPath)
+func runReview(configPath, repo, author string, interactive, githubBlock bool) error {
+	cfg, ghClient, blManager, db, err := initClients(configPath)
 	if err != nil {
 		return err
 	}
@@ -51,7 +71,11 @@ func runReview(configPath, repo string) error {
 	fmt.Printf("Scanning repository %s/%s for PRs needing review...\n\n", owner, repoName)
 
 	// Create scanner
-	scan := scanner.NewScanner(cfg)
+	scan, err := scanner.NewScannerE(cfg)
+	if err != nil {
+		return err
+	}
+	scan.SetAuthor(author)
 
 	// Scan repository
 	results, err := scan.ScanRepository(ghClient, owner, repoName)
@@ -66,18 +90,21 @@ func runReview(configPath, repo string) error {
 
 	fmt.Printf("Found %d PR(s) needing manual review:\n\n", len(results.Uncertain))
 
-	for i, result := range results.Uncertain {
-		fmt.Printf("%d. PR #%d: %s\n", i+1, result.PR.Number, result.PR.Title)
-		fmt.Printf("   Author: %s\n", result.PR.Author)
-		fmt.Printf("   URL: %s\n", result.PR.HTMLURL)
-		fmt.Printf("   Files changed: %d\n", result.PR.FilesCount)
-		fmt.Printf("   Lines: +%d -%d\n", result.PR.Additions, result.PR.Deletions)
-		fmt.Printf("   Suspicious indicators:\n")
-		for _, reason := range result.Reasons {
-			fmt.Printf("     - %s\n", reason)
+	if interactive {
+		ctx := &ActionContext{
+			cfg:       cfg,
+			ghClient:  ghClient,
+			blManager: blManager,
+			out:       os.Stdout,
+			actions:   db,
+			batchID:   uuid.New().String(),
 		}
-		fmt.Printf("   Recommendation: %s\n", result.RecommendAction)
-		fmt.Println()
+		reviewInteractively(ctx, bufio.NewReader(os.Stdin), owner, repoName, results.Uncertain, githubBlock)
+		return nil
+	}
+
+	for i, result := range results.Uncertain {
+		printReviewPR(os.Stdout, i+1, result)
 	}
 
 	fmt.Println("To block a user: prguard block <username> --reason \"...\" --evidence <url>")
@@ -85,3 +112,63 @@ func runReview(configPath, repo string) error {
 
 	return nil
 }
+
+// printReviewPR prints the details of a PR needing review
+func printReviewPR(w io.Writer, index int, result *scanner.ScanResult) {
+	fmt.Fprintf(w, "%d. PR #%d: %s\n", index, result.PR.Number, result.PR.Title)
+	fmt.Fprintf(w, "   Author: %s\n", result.PR.Author)
+	fmt.Fprintf(w, "   URL: %s\n", result.PR.HTMLURL)
+	fmt.Fprintf(w, "   Files changed: %d\n", result.PR.FilesCount)
+	fmt.Fprintf(w, "   Lines: +%d -%d\n", result.PR.Additions, result.PR.Deletions)
+	fmt.Fprintf(w, "   Suspicious indicators:\n")
+	for _, reason := range result.Reasons {
+		fmt.Fprintf(w, "     - %s\n", reason)
+	}
+	fmt.Fprintf(w, "   Recommendation: %s\n", result.RecommendAction)
+	fmt.Fprintln(w)
+}
+
+// reviewInteractively prompts for an action on each PR and executes it immediately
+func reviewInteractively(ctx *ActionContext, reader *bufio.Reader, owner, repoName string, results []*scanner.ScanResult, githubBlock bool) {
+	for i, result := range results {
+		printReviewPR(ctx.out, i+1, result)
+
+		for {
+			fmt.Fprint(ctx.out, "Action? (b)lock, (c)lose, (s)kip, (q)uit: ")
+			response, err := reader.ReadString('\n')
+			response = strings.TrimSpace(strings.ToLower(response))
+			if response == "" && err != nil {
+				// Input closed; treat as quit
+				fmt.Fprintln(ctx.out)
+				return
+			}
+
+			switch response {
+			case "b", "block":
+				reason := fmt.Sprintf("Manual review: %s", strings.Join(result.Reasons, ", "))
+				blockUser(ctx, result.PR.Author, reason, result.PR.HTMLURL, reviewSeverity(result), models.SourceManual, result.Signals, githubBlock)
+			case "c", "close":
+				closeSpamPR(ctx, owner, repoName, result)
+			case "s", "skip":
+			case "q", "quit":
+				fmt.Fprintln(ctx.out, "Review stopped.")
+				return
+			default:
+				fmt.Fprintf(ctx.out, "Unknown action %q\n", response)
+				continue
+			}
+			break
+		}
+		fmt.Fprintln(ctx.out)
+	}
+
+	fmt.Fprintln(ctx.out, "✓ Review complete")
+}
+
+// reviewSeverity returns the severity to record when blocking from review
+func reviewSeverity(result *scanner.ScanResult) string {
+	if result.Severity != "" {
+		return result.Severity
+	}
+	return models.SeverityMedium
+}
@@ -15,13 +15,19 @@
 package commands
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/internal/config"
 	"github.com/prguard/prguard/internal/github"
 	"github.com/prguard/prguard/internal/mocks"
 	"github.com/prguard/prguard/internal/scanner"
+	"github.com/prguard/prguard/pkg/models"
 )
 
 func TestReviewCommand_Flags(t *testing.T) {
@@ -162,6 +168,111 @@ func TestReview_ScanError(t *testing.T) {
 	}
 }
 
+func newReviewTestContext(gh *mocks.MockGitHubClient, bl *mocks.MockBlocklistManager) (*ActionContext, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return &ActionContext{
+		cfg:       &config.Config{GitHub: config.GitHubConfig{User: "testowner"}},
+		ghClient:  gh,
+		blManager: bl,
+		out:       out,
+	}, out
+}
+
+func reviewTestResults() []*scanner.ScanResult {
+	var results []*scanner.ScanResult
+	for i, author := range []string{"alice", "bob", "carol"} {
+		results = append(results, &scanner.ScanResult{
+			PR: &github.PullRequest{
+				Number:  i + 1,
+				Title:   "Suspicious PR",
+				Author:  author,
+				HTMLURL: fmt.Sprintf("https://github.com/test/repo/pull/%d", i+1),
+			},
+			IsUncertain: true,
+			Reasons:     []string{"Minimal changes"},
+			Severity:    "low",
+		})
+	}
+	return results
+}
+
+func TestReviewInteractively_Actions(t *testing.T) {
+	var closed []int
+	var blocked []string
+
+	mockGH := &mocks.MockGitHubClient{
+		ClosePullRequestFn: func(owner, repo string, number int, comment string) error {
+			closed = append(closed, number)
+			return nil
+		},
+	}
+	mockBL := &mocks.MockBlocklistManager{
+		BlockFn: func(req blocklist.BlockRequest) (*blocklist.BlockResult, error) {
+			blocked = append(blocked, req.Username)
+			if req.Source != models.SourceManual {
+				t.Errorf("expected manual source, got %s", req.Source)
+			}
+			return &blocklist.BlockResult{Entry: models.NewBlocklistEntry(req.Username, req.Reason, req.EvidenceURL, req.BlockedBy, req.Severity, req.Source), Offense: 1}, nil
+		},
+	}
+
+	ctx, out := newReviewTestContext(mockGH, mockBL)
+	reader := bufio.NewReader(strings.NewReader("b\nc\ns\n"))
+	reviewInteractively(ctx, reader, "test", "repo", reviewTestResults(), false)
+
+	if len(blocked) != 1 || blocked[0] != "alice" {
+		t.Errorf("expected alice to be blocked, got %v", blocked)
+	}
+	if len(closed) != 1 || closed[0] != 2 {
+		t.Errorf("expected PR #2 to be closed, got %v", closed)
+	}
+	if !strings.Contains(out.String(), "Review complete") {
+		t.Error("expected review to complete")
+	}
+}
+
+func TestReviewInteractively_Quit(t *testing.T) {
+	closeCalls := 0
+	mockGH := &mocks.MockGitHubClient{
+		ClosePullRequestFn: func(owner, repo string, number int, comment string) error {
+			closeCalls++
+			return nil
+		},
+	}
+
+	ctx, out := newReviewTestContext(mockGH, &mocks.MockBlocklistManager{})
+	reader := bufio.NewReader(strings.NewReader("x\nq\nc\n"))
+	reviewInteractively(ctx, reader, "test", "repo", reviewTestResults(), false)
+
+	if closeCalls != 0 {
+		t.Errorf("expected no PRs closed after quit, got %d", closeCalls)
+	}
+	if !strings.Contains(out.String(), `Unknown action "x"`) {
+		t.Error("expected unknown action to be reported")
+	}
+	if !strings.Contains(out.String(), "Review stopped") {
+		t.Error("expected review to stop")
+	}
+}
+
+func TestReviewInteractively_EOF(t *testing.T) {
+	blockCalls := 0
+	mockBL := &mocks.MockBlocklistManager{
+		BlockFn: func(req blocklist.BlockRequest) (*blocklist.BlockResult, error) {
+			blockCalls++
+			return &blocklist.BlockResult{Entry: models.NewBlocklistEntry(req.Username, req.Reason, req.EvidenceURL, req.BlockedBy, req.Severity, req.Source), Offense: 1}, nil
+		},
+	}
+
+	ctx, _ := newReviewTestContext(&mocks.MockGitHubClient{}, mockBL)
+	reader := bufio.NewReader(strings.NewReader("b"))
+	reviewInteractively(ctx, reader, "test", "repo", reviewTestResults(), false)
+
+	if blockCalls != 1 {
+		t.Errorf("expected a final unterminated answer to be honoured once, got %d blocks", blockCalls)
+	}
+}
+
 // executeReview is a testable helper function that performs the review logic
 func executeReview(ghClient github.GitHubClient, scan scanner.PRScanner, owner, repo string) (*scanner.ScanResults, error) {
 	// Scan repository
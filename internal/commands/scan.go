@@ -16,17 +16,158 @@ package commands
 
 import (
 	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/database"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/notify"
+	"github.com/prguard/prguard/internal/report"
 	"github.com/prguard/prguard/internal/scanner"
+	"github.com/prguard/prguard/pkg/models"
 	"github.com/spf13/cobra"
 )
 
+// scanOptions holds the command-line options for a scan
+type scanOptions struct {
+	autoClose          bool
+	autoBlock          bool
+	githubBlock        bool
+	jsonOutput         bool
+	format             string // text, json, or sarif
+	outputPath         string // File the json or sarif document is written to instead of stdout
+	failOn             string // spam, uncertain, or none: findings that make scan exit non-zero
+	yes                bool
+	dryRun             bool
+	since              string
+	author             string
+	rateLimitThreshold int      // scan-all pauses when fewer API requests remain; 0 disables
+	concurrency        int      // scan-all repositories scanned in parallel
+	batchID            string   // Action log batch shared by every repository in a run; generated when empty
+	repoList           []string // scan-all repositories to scan instead of every configured one
+	repoFile           string   // scan-all file listing repositories to scan, one per line
+	allowUnlisted      bool     // scan-all may scan repositories missing from the config
+	htmlReport         string   // scan-all file an HTML report across every repository is written to
+	verbose            bool     // Also list clean PRs and the checks each reviewed or clean PR passed
+
+	// blocker throttles GitHub blocks across every repository in a scan-all or
+	// watch run; nil gives each scan its own
+	blocker *githubBlocker
+}
+
+// Scan output formats
+const (
+	scanFormatText  = "text"
+	scanFormatJSON  = "json"
+	scanFormatSARIF = "sarif"
+)
+
+// Values accepted by scan --fail-on
+const (
+	failOnNone      = "none"
+	failOnSpam      = "spam"
+	failOnUncertain = "uncertain"
+)
+
+// Exit codes returned by scan --fail-on
+const (
+	exitSpamFound      = 2
+	exitUncertainFound = 3
+)
+
+// validateFailOn checks a --fail-on value
+func validateFailOn(failOn string) error {
+	switch failOn {
+	case "", failOnNone, failOnSpam, failOnUncertain:
+		return nil
+	}
+	return fmt.Errorf("invalid --fail-on %q, must be %s, %s, or %s", failOn, failOnSpam, failOnUncertain, failOnNone)
+}
+
+// checkFailOn returns an *ExitError when results meet the --fail-on condition:
+// exit code 2 when spam was found, or 3 when only uncertain PRs were found
+// with --fail-on uncertain
+func checkFailOn(failOn string, results *scanner.ScanResults) error {
+	if results == nil || failOn == "" || failOn == failOnNone {
+		return nil
+	}
+	if n := len(results.Spam); n > 0 {
+		return &ExitError{Code: exitSpamFound, Err: fmt.Errorf("%d spam %s detected (--fail-on %s)", n, pluralize("PR", "PRs", n), failOn)}
+	}
+	if n := len(results.Uncertain); n > 0 && failOn == failOnUncertain {
+		return &ExitError{Code: exitUncertainFound, Err: fmt.Errorf("%d %s need manual review (--fail-on %s)", n, pluralize("PR", "PRs", n), failOn)}
+	}
+	return nil
+}
+
+// structured reports whether the scan prints a json or sarif document
+func (o scanOptions) structured() bool {
+	return o.format == scanFormatJSON || o.format == scanFormatSARIF
+}
+
+// resolveFormat folds --json into --format and validates --format and --output
+func (o *scanOptions) resolveFormat() error {
+	if o.format == "" {
+		o.format = scanFormatText
+	}
+	if o.jsonOutput {
+		if o.format != scanFormatText && o.format != scanFormatJSON {
+			return fmt.Errorf("--json cannot be combined with --format %s", o.format)
+		}
+		o.format = scanFormatJSON
+	}
+	switch o.format {
+	case scanFormatText, scanFormatJSON, scanFormatSARIF:
+	default:
+		return fmt.Errorf("invalid --format %q, must be %s, %s, or %s", o.format, scanFormatText, scanFormatJSON, scanFormatSARIF)
+	}
+	if o.outputPath != "" && !o.structured() {
+		return fmt.Errorf("--output requires --format %s or %s", scanFormatJSON, scanFormatSARIF)
+	}
+	return nil
+}
+
+// addScanFormatFlags registers the --json, --format and --output flags shared by
+// the scan commands; resolveFormat validates them
+func addScanFormatFlags(cmd *cobra.Command, opts *scanOptions) {
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Output scan results as JSON (same as --format json)")
+	cmd.Flags().StringVar(&opts.format, "format", scanFormatText, "Output format (text/json/sarif)")
+	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "Write json or sarif output to a file instead of stdout")
+}
+
+// parseSince converts a --since style value into a cutoff time. It accepts a duration
+// such as "7d" or "48h" (relative to now), a date (YYYY-MM-DD), or an RFC 3339
+// timestamp; an empty value returns the zero time.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	d, err := parseDays(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid time %q: use a duration (e.g. 7d, 48h) or a date (YYYY-MM-DD)", value)
+	}
+	return now.Add(-d), nil
+}
+
 // NewScanCommand creates the scan command
-func NewScanCommand(configPath *string) *cobra.Command {
-	var autoClose, autoBlock, githubBlock bool
+func NewScanCommand(configPath *string, assumeYes *bool) *cobra.Command {
+	var opts scanOptions
 
 	cmd := &cobra.Command{
 		Use:   "scan <owner>/<repo>",
@@ -36,25 +177,69 @@ func NewScanCommand(configPath *string) *cobra.Command {
 By default, scan only reports findings. Use flags to take action:
   --auto-close: Automatically close spam PRs
   --auto-block: Automatically add spam users to local blocklist
-  --github-block: Also block users via GitHub API (requires --auto-block)`,
-		Args: cobra.ExactArgs(1),
-		RunE: func(_ *cobra.Command, args []string) error {
-			return runScan(*configPath, args[0], autoClose, autoBlock, githubBlock)
+  --github-block: Also block users via GitHub API (requires --auto-block)
+
+Use --json (or --format json) to print machine-readable results to stdout, or
+--format sarif for a SARIF log that code scanning tools can ingest. Add --output
+to write the document to a file instead. In JSON and SARIF modes automated
+actions are skipped unless --yes is also passed.
+
+Use --dry-run to print the actions that would be taken without changing anything.
+
+Use --since to only scan PRs opened after a duration ago (e.g. 7d, 48h) or a
+date (e.g. 2025-01-31).
+
+Use --author to only scan PRs opened by one user.
+
+Use --verbose to also list clean PRs and, for clean and uncertain PRs, the
+heuristics that were checked and passed. This helps when tuning thresholds.
+
+Use --fail-on for CI: with spam, scan exits with code 2 when spam is found;
+with uncertain, it also exits with code 3 when PRs need manual review. Other
+errors exit with code 1.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeRepositories(configPath),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.yes = *assumeYes
+			err := runScan(*configPath, args[0], opts)
+			var exitErr *ExitError
+			if errors.As(err, &exitErr) {
+				// The scan itself succeeded, so usage help would be noise
+				cmd.SilenceUsage = true
+			}
+			return err
 		},
 	}
 
-	cmd.Flags().BoolVar(&autoClose, "auto-close", false, "Automatically close spam PRs")
-	cmd.Flags().BoolVar(&autoBlock, "auto-block", false, "Automatically add spam users to blocklist")
-	cmd.Flags().BoolVar(&githubBlock, "github-block", false, "Also block users via GitHub API (requires --auto-block)")
+	cmd.Flags().BoolVar(&opts.autoClose, "auto-close", false, "Automatically close spam PRs")
+	cmd.Flags().BoolVar(&opts.autoBlock, "auto-block", false, "Automatically add spam users to blocklist")
+	cmd.Flags().BoolVar(&opts.githubBlock, "github-block", false, "Also block users via GitHub API (requires --auto-block)")
+	addScanFormatFlags(cmd, &opts)
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print automated actions without executing them")
+	cmd.Flags().StringVar(&opts.since, "since", "", "Only scan PRs opened after a duration ago (e.g. 7d) or a date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&opts.author, "author", "", "Only scan PRs opened by this user")
+	cmd.Flags().StringVar(&opts.failOn, "fail-on", failOnNone, "Exit non-zero when findings are detected (spam/uncertain/none)")
+	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", false, "Also list clean PRs and the checks each PR passed")
 
 	return cmd
 }
 
-func runScan(configPath, repo string, autoClose, autoBlock, githubBlock bool) error {
+func runScan(configPath, repo string, opts scanOptions) error {
 	// Validate flags
-	if githubBlock && !autoBlock {
+	if opts.githubBlock && !opts.autoBlock {
 		return fmt.Errorf("--github-block requires --auto-block")
 	}
+	if err := opts.resolveFormat(); err != nil {
+		return err
+	}
+	if err := validateFailOn(opts.failOn); err != nil {
+		return err
+	}
+
+	since, err := parseSince(opts.since, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
 
 	// Initialize clients and database
 	cfg, ghClient, blManager, db, err := initClients(configPath)
@@ -63,65 +248,186 @@ func runScan(configPath, repo string, autoClose, autoBlock, githubBlock bool) er
 	}
 	defer db.Close() //nolint:errcheck
 
+	ctx, stop := scanContext(cfg)
+	defer stop()
+
+	results, err := scanRepository(os.Stdout, cfg, ghClient.WithContext(ctx), blManager, db, repo, since, opts)
+	if err != nil {
+		return err
+	}
+	return checkFailOn(opts.failOn, results)
+}
+
+// scanRepository scans one repository with already-initialized clients, then
+// reports the results to w and runs any requested automated actions
+func scanRepository(w io.Writer, cfg *config.Config, ghClient github.GitHubClient, blManager blocklist.BlocklistManager, db *database.DB, repo string, since time.Time, opts scanOptions) (*scanner.ScanResults, error) {
 	// Apply config defaults to flags
-	autoClose, autoBlock = applyConfigDefaults(cfg, autoClose, autoBlock)
+	opts.autoClose, opts.autoBlock = applyConfigDefaults(cfg, opts.autoClose, opts.autoBlock)
 
 	// Parse owner/repo
 	owner, repoName, err := parseRepo(repo)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Printf("Scanning repository %s/%s...\n\n", owner, repoName)
+	if !opts.structured() {
+		fmt.Fprintf(w, "Scanning repository %s/%s...\n\n", owner, repoName)
+	}
 
 	// Scan repository for spam PRs
-	scan := scanner.NewScanner(cfg)
+	scan, err := scanner.NewScannerE(cfg)
+	if err != nil {
+		return nil, err
+	}
+	scan.SetSince(since)
+	scan.SetAuthor(opts.author)
 	results, err := scan.ScanRepository(ghClient, owner, repoName)
 	if err != nil {
-		return fmt.Errorf("scan failed: %w", err)
+		return nil, fmt.Errorf("scan failed: %w", err)
 	}
 
-	// Display scan results
-	displayScanSummary(results)
-	spamUsers := collectSpamUsers(results)
-	displayUncertainResults(results)
+	// A dry run leaves history and notification channels untouched
+	if !opts.dryRun {
+		// Record scan history for trend tracking
+		history := models.NewScanHistoryEntry(owner+"/"+repoName, results.Total, len(results.Spam), len(results.Uncertain), len(results.Clean))
+		if err := db.RecordScan(history); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record scan history: %v\n", err)
+		}
+
+		// Notify external services about detected spam
+		if notifiers := notify.FromConfig(cfg.Notifications); len(notifiers) > 0 {
+			notifySpam(notifiers, owner, repoName, results, os.Stderr)
+		}
+	}
 
-	// Execute automated actions if requested
 	ctx := &ActionContext{
 		cfg:       cfg,
 		ghClient:  ghClient,
 		blManager: blManager,
+		out:       w,
+		dryRun:    opts.dryRun,
+		actions:   db,
+		batchID:   opts.batchID,
+		blocker:   opts.blocker,
+	}
+	if ctx.batchID == "" {
+		ctx.batchID = uuid.New().String()
+	}
+
+	if opts.structured() {
+		if err := writeScanOutput(w, opts, scanner.NewReport(owner, repoName, results)); err != nil {
+			return nil, err
+		}
+		// Automated actions require explicit consent in JSON and SARIF modes
+		if !opts.yes {
+			return results, nil
+		}
+		// Keep stdout clean for the document unless it went to a file
+		if opts.outputPath == "" {
+			ctx.out = os.Stderr
+		}
+	} else {
+		// Display scan results
+		displayScanSummary(w, results)
+		displaySpamResults(w, results)
+		displayUncertainResults(w, results, opts.verbose)
+		if opts.verbose {
+			displayCleanResults(w, results)
+		}
 	}
+
+	// Execute automated actions if requested
+	spamUsers := collectSpamUsers(results)
 	flags := &ActionFlags{
-		autoClose:   autoClose,
-		autoBlock:   autoBlock,
-		githubBlock: githubBlock,
+		autoClose:   opts.autoClose,
+		autoBlock:   opts.autoBlock,
+		githubBlock: opts.githubBlock,
+		skipConfirm: opts.yes,
 	}
 	if err := executeAutomatedActions(ctx, owner, repoName, results, spamUsers, flags); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Show suggestions if no actions taken
-	displayActionSuggestions(repo, len(results.Spam) > 0, autoClose, autoBlock, githubBlock)
+	if !opts.structured() {
+		displayActionSuggestions(w, repo, len(results.Spam) > 0, opts.autoClose, opts.autoBlock, opts.githubBlock)
+	}
+
+	return results, nil
+}
+
+// writeScanOutput writes a scan report in the json or sarif format to w, or to
+// the --output file when one was given
+func writeScanOutput(w io.Writer, opts scanOptions, r *scanner.Report) error {
+	if opts.outputPath != "" {
+		file, err := os.Create(opts.outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close() //nolint:errcheck
+		if err := writeScanDocument(file, opts.format, r); err != nil {
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		fmt.Fprintf(w, "✓ Wrote %s scan results to %s\n", opts.format, opts.outputPath)
+		return nil
+	}
+	return writeScanDocument(w, opts.format, r)
+}
+
+// writeScanDocument encodes a scan report as json or sarif
+func writeScanDocument(w io.Writer, format string, r *scanner.Report) error {
+	if format == scanFormatSARIF {
+		return report.WriteSARIF(w, r)
+	}
+	return writeScanJSON(w, r)
+}
 
+// writeScanJSON writes a scan report as indented JSON
+func writeScanJSON(w io.Writer, report *scanner.Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode scan results: %w", err)
+	}
 	return nil
 }
 
-func confirmAction(numPRs, numUsers int, autoClose, autoBlock, githubBlock bool) bool {
-	fmt.Println()
-	fmt.Printf("About to take the following actions:\n")
-	if autoBlock {
-		fmt.Printf("  - Add %d users to local blocklist\n", numUsers)
-		if githubBlock {
-			fmt.Printf("  - Block %d users via GitHub API (ALL repos)\n", numUsers)
+// notifySpam sends a spam notification when spam was detected; delivery failures only warn
+func notifySpam(notifier notify.Notifier, owner, repoName string, results *scanner.ScanResults, w io.Writer) {
+	if len(results.Spam) == 0 {
+		return
+	}
+	if err := notifier.NotifySpam(notify.NewSpamEvent(owner, repoName, results)); err != nil {
+		fmt.Fprintf(w, "Warning: failed to send spam notification: %v\n", err)
+	}
+}
+
+// confirmAction summarizes the pending automated actions and asks for confirmation.
+// When flags.skipConfirm is set (--yes) the prompt is answered without reading input.
+func confirmAction(w io.Writer, reader *bufio.Reader, plan *actionPlan, flags *ActionFlags) bool {
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "About to take the following actions:\n")
+	if len(plan.block) > 0 {
+		fmt.Fprintf(w, "  - Add %d users to local blocklist\n", len(plan.block))
+		if flags.githubBlock {
+			fmt.Fprintf(w, "  - Block %d users via GitHub API (ALL repos)\n", len(plan.block))
 		}
 	}
-	if autoClose {
-		fmt.Printf("  - Close %d spam PRs\n", numPRs)
+	if len(plan.label) > 0 {
+		fmt.Fprintf(w, "  - Label %d spam PRs\n", len(plan.label))
+	}
+	if len(plan.close) > 0 {
+		fmt.Fprintf(w, "  - Close %d spam PRs\n", len(plan.close))
 	}
 
-	fmt.Print("\nContinue? (y/N): ")
-	reader := bufio.NewReader(os.Stdin)
+	fmt.Fprint(w, "\nContinue? (y/N): ")
+	if flags.skipConfirm {
+		fmt.Fprintln(w, "y (--yes)")
+		return true
+	}
 	response, _ := reader.ReadString('\n')
 	response = strings.TrimSpace(strings.ToLower(response))
 
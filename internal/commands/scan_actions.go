@@ -15,7 +15,11 @@
 package commands
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"os"
+	"slices"
 	"strings"
 
 	"github.com/prguard/prguard/internal/blocklist"
@@ -31,6 +35,7 @@ type spamUserInfo struct {
 	evidenceURL string
 	severity    string
 	reasons     []string
+	signals     []string
 }
 
 // ActionContext holds service dependencies for executing actions
@@ -38,6 +43,84 @@ type ActionContext struct {
 	cfg       *config.Config
 	ghClient  github.GitHubClient
 	blManager blocklist.BlocklistManager
+	out       io.Writer       // Destination for action progress output
+	dryRun    bool            // Report mutating calls instead of making them
+	actions   actionRecorder  // Records actions so `undo` can reverse them; nil disables recording
+	batchID   string          // Groups the actions recorded by one run
+	blocker   *githubBlocker  // Throttles GitHub blocks; shared by a multi-repository run or created on first use
+	labeled   map[string]bool // Repositories whose spam labels are known to exist
+}
+
+// gitHubBlocker returns the run's GitHub blocker, which waits github.block_delay
+// between API calls and skips users an earlier run already blocked
+func (ctx *ActionContext) gitHubBlocker() *githubBlocker {
+	if ctx.blocker == nil {
+		ctx.blocker = newGitHubBlocker(ctx.cfg, ctx.ghClient, ctx.blManager, ctx.out, ctx.cfg.GitHub.BlockDelay)
+	}
+	return ctx.blocker
+}
+
+// ensureLabels creates the configured spam labels missing from a repository,
+// checking each repository once per run
+func (ctx *ActionContext) ensureLabels(owner, repoName string) error {
+	repo := owner + "/" + repoName
+	if ctx.labeled[repo] {
+		return nil
+	}
+	if err := ensureSpamLabels(ctx.ghClient, ctx.cfg.Actions, owner, repoName); err != nil {
+		return err
+	}
+	if ctx.labeled == nil {
+		ctx.labeled = make(map[string]bool)
+	}
+	ctx.labeled[repo] = true
+	return nil
+}
+
+// ensureSpamLabels creates the configured spam labels missing from a
+// repository with actions.label_color
+func ensureSpamLabels(ghClient github.GitHubClient, actions config.ActionsConfig, owner, repoName string) error {
+	color := actions.LabelColor
+	if color == "" {
+		color = config.DefaultLabelColor
+	}
+	for _, label := range actions.LabelNames() {
+		if err := ghClient.EnsureLabel(owner, repoName, label, color); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addSpamLabels adds the configured spam labels to a PR
+func addSpamLabels(ghClient github.GitHubClient, actions config.ActionsConfig, owner, repoName string, number int) error {
+	for _, label := range actions.LabelNames() {
+		if err := ghClient.AddLabel(owner, repoName, number, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// describeLabels quotes label names for progress output, e.g. 'spam' label
+// or 'spam', 'invalid' labels
+func describeLabels(names []string) string {
+	return "'" + strings.Join(names, "', '") + "' " + pluralize("label", "labels", len(names))
+}
+
+// actionRecorder persists action log entries; implemented by *database.DB
+type actionRecorder interface {
+	RecordAction(entry *models.ActionLogEntry) error
+}
+
+// recordAction logs an action for undo. Failures only warn since the action itself succeeded.
+func (ctx *ActionContext) recordAction(entry *models.ActionLogEntry) {
+	if ctx.actions == nil {
+		return
+	}
+	if err := ctx.actions.RecordAction(entry); err != nil {
+		fmt.Fprintf(ctx.out, "  ⚠ Failed to record action for undo: %v\n", err)
+	}
 }
 
 // ActionFlags holds configuration for which actions to execute
@@ -45,6 +128,7 @@ type ActionFlags struct {
 	autoClose   bool
 	autoBlock   bool
 	githubBlock bool
+	skipConfirm bool
 }
 
 // applyConfigDefaults applies config defaults to action flags
@@ -62,33 +146,48 @@ func applyConfigDefaults(cfg *config.Config, autoClose, autoBlock bool) (bool, b
 }
 
 // displayScanSummary prints the scan results summary
-func displayScanSummary(results *scanner.ScanResults) {
-	fmt.Printf("Total PRs: %d\n", results.Total)
-	fmt.Printf("Spam detected: %d\n", len(results.Spam))
-	fmt.Printf("Uncertain: %d\n", len(results.Uncertain))
-	fmt.Printf("Clean: %d\n\n", len(results.Clean))
+func displayScanSummary(w io.Writer, results *scanner.ScanResults) {
+	fmt.Fprintf(w, "Total PRs: %d\n", results.Total)
+	fmt.Fprintln(w, colorize(colorRed, fmt.Sprintf("Spam detected: %d", len(results.Spam))))
+	fmt.Fprintln(w, colorize(colorYellow, fmt.Sprintf("Uncertain: %d", len(results.Uncertain))))
+	fmt.Fprintln(w, colorize(colorGreen, fmt.Sprintf("Clean: %d", len(results.Clean))))
+	if len(results.Errors) > 0 {
+		fmt.Fprintf(w, "Failed to scan: %d\n", len(results.Errors))
+		for _, scanErr := range results.Errors {
+			fmt.Fprintf(w, "  ⚠ %v\n", scanErr)
+		}
+	}
+	fmt.Fprintln(w)
 }
 
-// collectSpamUsers collects unique spam users and displays spam PRs
-func collectSpamUsers(results *scanner.ScanResults) map[string]spamUserInfo {
-	spamUsers := make(map[string]spamUserInfo)
-
+// displaySpamResults prints the PRs detected as spam
+func displaySpamResults(w io.Writer, results *scanner.ScanResults) {
 	if len(results.Spam) == 0 {
-		return spamUsers
+		return
 	}
 
-	fmt.Println("=== SPAM DETECTED ===")
+	fmt.Fprintln(w, colorize(colorRed, "=== SPAM DETECTED ==="))
 	for _, result := range results.Spam {
-		fmt.Printf("\nPR #%d: %s\n", result.PR.Number, result.PR.Title)
-		fmt.Printf("  Author: %s\n", result.PR.Author)
-		fmt.Printf("  URL: %s\n", result.PR.HTMLURL)
-		fmt.Printf("  Severity: %s\n", result.Severity)
-		fmt.Printf("  Reasons:\n")
+		fmt.Fprintf(w, "\nPR #%d: %s\n", result.PR.Number, result.PR.Title)
+		fmt.Fprintf(w, "  Author: %s\n", result.PR.Author)
+		fmt.Fprintf(w, "  URL: %s\n", result.PR.HTMLURL)
+		if result.PR.BaseRef != "" {
+			fmt.Fprintf(w, "  Base: %s\n", result.PR.BaseRef)
+		}
+		fmt.Fprintf(w, "  Severity: %s\n", result.Severity)
+		fmt.Fprintf(w, "  Reasons:\n")
 		for _, reason := range result.Reasons {
-			fmt.Printf("    - %s\n", reason)
+			fmt.Fprintf(w, "    - %s\n", reason)
 		}
-		fmt.Printf("  Recommended action: %s\n", result.RecommendAction)
+		fmt.Fprintf(w, "  Recommended action: %s\n", result.RecommendAction)
+	}
+}
+
+// collectSpamUsers collects unique spam users, keeping the first PR seen for each
+func collectSpamUsers(results *scanner.ScanResults) map[string]spamUserInfo {
+	spamUsers := make(map[string]spamUserInfo)
 
+	for _, result := range results.Spam {
 		// Track user for potential blocking
 		if _, exists := spamUsers[result.PR.Author]; !exists {
 			spamUsers[result.PR.Author] = spamUserInfo{
@@ -96,6 +195,7 @@ func collectSpamUsers(results *scanner.ScanResults) map[string]spamUserInfo {
 				evidenceURL: result.PR.HTMLURL,
 				severity:    result.Severity,
 				reasons:     result.Reasons,
+				signals:     result.Signals,
 			}
 		}
 	}
@@ -103,94 +203,309 @@ func collectSpamUsers(results *scanner.ScanResults) map[string]spamUserInfo {
 	return spamUsers
 }
 
-// displayUncertainResults shows PRs that need manual review
-func displayUncertainResults(results *scanner.ScanResults) {
+// displayUncertainResults shows PRs that need manual review. With showChecks
+// set (--verbose), it also lists the checks each PR passed.
+func displayUncertainResults(w io.Writer, results *scanner.ScanResults, showChecks bool) {
 	if len(results.Uncertain) == 0 {
 		return
 	}
 
-	fmt.Println("\n=== MANUAL REVIEW NEEDED ===")
+	fmt.Fprintln(w, "\n"+colorize(colorYellow, "=== MANUAL REVIEW NEEDED ==="))
 	for _, result := range results.Uncertain {
-		fmt.Printf("\nPR #%d: %s\n", result.PR.Number, result.PR.Title)
-		fmt.Printf("  Author: %s\n", result.PR.Author)
-		fmt.Printf("  URL: %s\n", result.PR.HTMLURL)
-		fmt.Printf("  Reasons:\n")
+		fmt.Fprintf(w, "\nPR #%d: %s\n", result.PR.Number, result.PR.Title)
+		fmt.Fprintf(w, "  Author: %s\n", result.PR.Author)
+		fmt.Fprintf(w, "  URL: %s\n", result.PR.HTMLURL)
+		if result.PR.BaseRef != "" {
+			fmt.Fprintf(w, "  Base: %s\n", result.PR.BaseRef)
+		}
+		fmt.Fprintf(w, "  Reasons:\n")
 		for _, reason := range result.Reasons {
-			fmt.Printf("    - %s\n", reason)
+			fmt.Fprintf(w, "    - %s\n", reason)
+		}
+		if showChecks {
+			displayPassedChecks(w, result)
+		}
+	}
+}
+
+// displayCleanResults lists the PRs no heuristic flagged and the checks they passed (--verbose)
+func displayCleanResults(w io.Writer, results *scanner.ScanResults) {
+	if len(results.Clean) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\n"+colorize(colorGreen, "=== CLEAN ==="))
+	for _, result := range results.Clean {
+		fmt.Fprintf(w, "\nPR #%d: %s\n", result.PR.Number, result.PR.Title)
+		fmt.Fprintf(w, "  Author: %s\n", result.PR.Author)
+		fmt.Fprintf(w, "  URL: %s\n", result.PR.HTMLURL)
+		fmt.Fprintf(w, "  Recommended action: %s\n", result.RecommendAction)
+		displayPassedChecks(w, result)
+	}
+}
+
+// displayPassedChecks prints the checks that ran for the PR without flagging it
+func displayPassedChecks(w io.Writer, result *scanner.ScanResult) {
+	checks := result.Checks()
+	if checks == nil {
+		fmt.Fprintln(w, "  Checks: skipped (author is whitelisted or a trusted organization member)")
+		return
+	}
+	var passed []string
+	for _, signal := range checks {
+		if !slices.Contains(result.Signals, signal) {
+			passed = append(passed, signal)
 		}
 	}
+	if len(passed) == 0 {
+		fmt.Fprintln(w, "  Checks passed: none")
+		return
+	}
+	fmt.Fprintf(w, "  Checks passed: %s\n", strings.Join(passed, ", "))
 }
 
 // executeBlockActions blocks spam users in local blocklist and optionally on GitHub
-func executeBlockActions(ctx *ActionContext, spamUsers map[string]spamUserInfo, githubBlock bool) {
-	fmt.Printf("\nBlocking %d spam users...\n", len(spamUsers))
+func executeBlockActions(ctx *ActionContext, owner, repoName string, spamUsers map[string]spamUserInfo, githubBlock bool) {
+	fmt.Fprintf(ctx.out, "\nBlocking %d spam users...\n", len(spamUsers))
+
+	for username, info := range spamUsers {
+		reason := blockReason(ctx, owner+"/"+repoName, username, info)
+		blockUser(ctx, username, reason, info.evidenceURL, info.severity, models.SourceAutoDetected, info.signals, githubBlock)
+	}
+}
 
+// blockReason renders the configured block reason template for a spam user,
+// falling back to the default reason when unset or invalid
+func blockReason(ctx *ActionContext, repo, username string, info spamUserInfo) string {
+	reasons := strings.Join(info.reasons, ", ")
+	reason := fmt.Sprintf("Auto-detected spam: %s", reasons)
+
+	if tmpl := ctx.cfg.Actions.BlockReasonTemplate; tmpl != "" {
+		rendered, err := renderTemplate("block_reason_template", tmpl, templateData{
+			Author:   username,
+			PRNumber: info.firstPR,
+			Reasons:  reasons,
+			Repo:     repo,
+		})
+		if err != nil {
+			fmt.Fprintf(ctx.out, "  ⚠ %v; using default reason\n", err)
+			return reason
+		}
+		reason = rendered
+	}
+	return reason
+}
+
+// blockUser adds a user to the local blocklist, recording the scanner signals
+// that flagged them, and optionally blocks them on GitHub
+func blockUser(ctx *ActionContext, username, reason, evidenceURL, severity, source string, signals []string, githubBlock bool) bool {
 	blockedBy := ctx.cfg.GitHub.User
 	if blockedBy == "" {
 		blockedBy = ctx.cfg.GitHub.Org
 	}
 
-	for username, info := range spamUsers {
-		// Add to local blocklist
-		reason := fmt.Sprintf("Auto-detected spam: %s", strings.Join(info.reasons, ", "))
-		_, err := ctx.blManager.Block(username, reason, info.evidenceURL, blockedBy, info.severity, models.SourceAutoDetected)
-		if err != nil {
-			fmt.Printf("  ✗ Failed to block %s: %v\n", username, err)
-			continue
+	if ctx.dryRun {
+		fmt.Fprintf(ctx.out, "  [dry-run] would block %s in local blocklist\n", username)
+		if githubBlock {
+			blockOnGitHub(ctx, username)
 		}
-		fmt.Printf("  ✓ Blocked %s in local blocklist\n", username)
+		return true
+	}
 
-		// Block on GitHub if requested
-		if githubBlock {
-			blockOnGitHub(ctx.cfg, ctx.ghClient, username)
+	result, err := ctx.blManager.Block(blocklist.BlockRequest{
+		Username:    username,
+		Reason:      reason,
+		EvidenceURL: evidenceURL,
+		BlockedBy:   blockedBy,
+		Severity:    severity,
+		Source:      source,
+		Signals:     signals,
+		Escalate:    true,
+		Snapshot:    ctx.ghClient,
+		// Re-scanning a still-open PR must not count as another offense
+		UpdateExisting: true,
+	})
+	if err != nil {
+		fmt.Fprintf(ctx.out, "  ✗ Failed to block %s: %v\n", username, err)
+		return false
+	}
+	switch {
+	case result.Updated:
+		// The entry predates this run, so undo must not remove it
+		fmt.Fprintf(ctx.out, "  - %s already blocked for this evidence; entry updated\n", username)
+	default:
+		fmt.Fprintf(ctx.out, "  ✓ Blocked %s in local blocklist\n", username)
+		if result.Offense > 1 {
+			fmt.Fprintf(ctx.out, "    Repeat offense #%d, severity %s\n", result.Offense, result.Entry.Severity)
 		}
+		ctx.recordAction(models.NewBlockAction(ctx.batchID, result.Entry))
+	}
+
+	// Block on GitHub if requested
+	if githubBlock {
+		blockOnGitHub(ctx, username)
 	}
+	return true
 }
 
 // blockOnGitHub blocks a user via GitHub API (org or personal)
-func blockOnGitHub(cfg *config.Config, ghClient github.GitHubClient, username string) {
-	if cfg.GitHub.Org != "" {
-		if err := ghClient.BlockUserOrg(cfg.GitHub.Org, username); err != nil {
-			fmt.Printf("    ⚠ Failed to block on GitHub (org): %v\n", err)
-		} else {
-			fmt.Printf("    ✓ Blocked on GitHub (org level)\n")
-		}
-	} else if cfg.GitHub.User != "" {
-		if err := ghClient.BlockUserPersonal(username); err != nil {
-			fmt.Printf("    ⚠ Failed to block on GitHub (personal): %v\n", err)
-		} else {
-			fmt.Printf("    ✓ Blocked on GitHub (personal level)\n")
+func blockOnGitHub(ctx *ActionContext, username string) {
+	cfg := ctx.cfg
+	if ctx.dryRun {
+		if cfg.GitHub.Org != "" {
+			fmt.Fprintf(ctx.out, "    [dry-run] would block %s on GitHub (org %s)\n", username, cfg.GitHub.Org)
+		} else if cfg.GitHub.User != "" {
+			fmt.Fprintf(ctx.out, "    [dry-run] would block %s on GitHub (personal)\n", username)
 		}
+		return
+	}
+
+	level := "personal"
+	if cfg.GitHub.Org != "" {
+		level = "org"
+	} else if cfg.GitHub.User == "" {
+		return
+	}
+
+	skipped, err := ctx.gitHubBlocker().block(username)
+	switch {
+	case err != nil:
+		fmt.Fprintf(ctx.out, "    ⚠ Failed to block on GitHub (%s): %v\n", level, err)
+	case skipped:
+		fmt.Fprintf(ctx.out, "    - Already blocked on GitHub (%s level)\n", level)
+	default:
+		fmt.Fprintf(ctx.out, "    ✓ Blocked on GitHub (%s level)\n", level)
 	}
 }
 
 // executeCloseActions closes spam PRs and optionally adds labels
-func executeCloseActions(ctx *ActionContext, owner, repoName string, results *scanner.ScanResults) {
-	fmt.Printf("\nClosing %d spam PRs...\n", len(results.Spam))
+func executeCloseActions(ctx *ActionContext, owner, repoName string, prs []*scanner.ScanResult) {
+	fmt.Fprintf(ctx.out, "\nClosing %d spam PRs...\n", len(prs))
+
+	for _, result := range prs {
+		closeSpamPR(ctx, owner, repoName, result)
+	}
+}
+
+// executeLabelActions adds the spam labels to PRs that are not being closed with them
+func executeLabelActions(ctx *ActionContext, owner, repoName string, prs []*scanner.ScanResult) {
+	fmt.Fprintf(ctx.out, "\nLabeling %d spam PRs...\n", len(prs))
+
+	for _, result := range prs {
+		labelSpamPR(ctx, owner, repoName, result.PR.Number)
+	}
+}
 
+// labelSpamPR adds the configured spam labels to a PR, creating any the
+// repository is missing
+func labelSpamPR(ctx *ActionContext, owner, repoName string, number int) bool {
+	labels := describeLabels(ctx.cfg.Actions.LabelNames())
+	if ctx.dryRun {
+		fmt.Fprintf(ctx.out, "  [dry-run] would add %s to PR #%d\n", labels, number)
+		return true
+	}
+	if err := ctx.ensureLabels(owner, repoName); err != nil {
+		fmt.Fprintf(ctx.out, "  ⚠ PR #%d: failed to create label: %v\n", number, err)
+		return false
+	}
+	if err := addSpamLabels(ctx.ghClient, ctx.cfg.Actions, owner, repoName, number); err != nil {
+		fmt.Fprintf(ctx.out, "  ⚠ PR #%d: failed to add label: %v\n", number, err)
+		return false
+	}
+	fmt.Fprintf(ctx.out, "  ✓ PR #%d: added %s\n", number, labels)
+	return true
+}
+
+// closeSpamPR labels a PR as spam if configured and closes it with the
+// configured comment, rendered for the PR
+func closeSpamPR(ctx *ActionContext, owner, repoName string, result *scanner.ScanResult) bool {
+	number := result.PR.Number
 	comment := ctx.cfg.Actions.CommentTemplate
 	if comment == "" {
-		comment = "This PR has been automatically closed due to spam indicators."
+		comment = defaultPRComment
+	}
+	comment, err := renderTemplate("comment_template", comment, templateData{
+		Author:   result.PR.Author,
+		PRNumber: number,
+		Reasons:  strings.Join(result.Reasons, ", "),
+		Repo:     owner + "/" + repoName,
+	})
+	if err != nil {
+		fmt.Fprintf(ctx.out, "  ⚠ PR #%d: %v; using default comment\n", number, err)
+		comment = defaultPRComment
 	}
 
-	for _, result := range results.Spam {
-		// Add label if configured
+	if ctx.dryRun {
 		if ctx.cfg.Actions.AddSpamLabel {
-			if err := ctx.ghClient.AddLabel(owner, repoName, result.PR.Number, "spam"); err != nil {
-				fmt.Printf("  ⚠ PR #%d: failed to add label: %v\n", result.PR.Number, err)
-			}
+			fmt.Fprintf(ctx.out, "  [dry-run] would add %s to PR #%d\n", describeLabels(ctx.cfg.Actions.LabelNames()), number)
 		}
+		fmt.Fprintf(ctx.out, "  [dry-run] would close PR #%d\n", number)
+		return true
+	}
 
-		// Close the PR
-		if err := ctx.ghClient.ClosePullRequest(owner, repoName, result.PR.Number, comment); err != nil {
-			fmt.Printf("  ✗ PR #%d: failed to close: %v\n", result.PR.Number, err)
-		} else {
-			fmt.Printf("  ✓ PR #%d closed\n", result.PR.Number)
+	// Add label if configured
+	if ctx.cfg.Actions.AddSpamLabel {
+		if err := ctx.ensureLabels(owner, repoName); err != nil {
+			fmt.Fprintf(ctx.out, "  ⚠ PR #%d: failed to create label: %v\n", number, err)
+		} else if err := addSpamLabels(ctx.ghClient, ctx.cfg.Actions, owner, repoName, number); err != nil {
+			fmt.Fprintf(ctx.out, "  ⚠ PR #%d: failed to add label: %v\n", number, err)
 		}
 	}
+
+	// Close the PR
+	if err := ctx.ghClient.ClosePullRequest(owner, repoName, number, comment); err != nil {
+		fmt.Fprintf(ctx.out, "  ✗ PR #%d: failed to close: %v\n", number, err)
+		return false
+	}
+	fmt.Fprintf(ctx.out, "  ✓ PR #%d closed\n", number)
+	ctx.recordAction(models.NewClosePRAction(ctx.batchID, owner+"/"+repoName, number))
+	return true
 }
 
-// executeAutomatedActions orchestrates blocking and closing actions
+// actionPlan lists the automated actions to take for a scan's spam PRs
+type actionPlan struct {
+	close []*scanner.ScanResult   // PRs to close
+	label []*scanner.ScanResult   // PRs to label without closing them
+	block map[string]spamUserInfo // Users to block, keyed by username
+}
+
+// empty reports whether the plan has nothing to do
+func (p *actionPlan) empty() bool {
+	return len(p.close) == 0 && len(p.label) == 0 && len(p.block) == 0
+}
+
+// planActions decides the actions for each spam PR. Severities listed in
+// actions.severity_actions get the mapped actions, limited to those the flags
+// enable; other severities get every enabled action.
+func planActions(cfg *config.Config, results *scanner.ScanResults, spamUsers map[string]spamUserInfo, flags *ActionFlags) *actionPlan {
+	plan := &actionPlan{block: make(map[string]spamUserInfo)}
+
+	for _, result := range results.Spam {
+		closePR, blockAuthor, labelPR := flags.autoClose, flags.autoBlock, false
+		if action, ok := cfg.Actions.SeverityActionFor(result.Severity); ok {
+			closePR = action.Close && flags.autoClose
+			blockAuthor = action.Block && flags.autoBlock
+			labelPR = action.Label
+		}
+
+		if closePR {
+			plan.close = append(plan.close, result)
+		}
+		// Closing already labels the PR when add_spam_label is set
+		if labelPR && !(closePR && cfg.Actions.AddSpamLabel) {
+			plan.label = append(plan.label, result)
+		}
+		if blockAuthor {
+			if info, ok := spamUsers[result.PR.Author]; ok {
+				plan.block[result.PR.Author] = info
+			}
+		}
+	}
+
+	return plan
+}
+
+// executeAutomatedActions orchestrates blocking, labeling, and closing actions
 func executeAutomatedActions(
 	ctx *ActionContext,
 	owner, repoName string,
@@ -202,37 +517,56 @@ func executeAutomatedActions(
 		return nil
 	}
 
-	fmt.Println("\n=== AUTOMATED ACTIONS ===")
+	if ctx.dryRun {
+		fmt.Fprintln(ctx.out, "\n=== AUTOMATED ACTIONS (dry run) ===")
+	} else {
+		fmt.Fprintln(ctx.out, "\n=== AUTOMATED ACTIONS ===")
+	}
+
+	plan := planActions(ctx.cfg, results, spamUsers, flags)
+	if plan.empty() {
+		fmt.Fprintln(ctx.out, "No actions apply to the detected severities (see actions.severity_actions).")
+		return nil
+	}
 
-	// Confirm with user
-	if !confirmAction(len(results.Spam), len(spamUsers), flags.autoClose, flags.autoBlock, flags.githubBlock) {
-		fmt.Println("Actions cancelled by user.")
+	// Confirm with user unless confirmation was skipped; a dry run changes nothing
+	if !ctx.dryRun && !confirmAction(ctx.out, bufio.NewReader(os.Stdin), plan, flags) {
+		fmt.Fprintln(ctx.out, "Actions cancelled by user.")
 		return nil
 	}
 
 	// Block users first
-	if flags.autoBlock {
-		executeBlockActions(ctx, spamUsers, flags.githubBlock)
+	if len(plan.block) > 0 {
+		executeBlockActions(ctx, owner, repoName, plan.block, flags.githubBlock)
+	}
+
+	// Label PRs that stay open
+	if len(plan.label) > 0 {
+		executeLabelActions(ctx, owner, repoName, plan.label)
 	}
 
 	// Close PRs
-	if flags.autoClose {
-		executeCloseActions(ctx, owner, repoName, results)
+	if len(plan.close) > 0 {
+		executeCloseActions(ctx, owner, repoName, plan.close)
 	}
 
-	fmt.Println("\n✓ Automated actions completed")
+	if ctx.dryRun {
+		fmt.Fprintln(ctx.out, "\n✓ Dry run completed; no changes were made")
+	} else {
+		fmt.Fprintln(ctx.out, "\n✓ Automated actions completed")
+	}
 	return nil
 }
 
 // displayActionSuggestions shows suggestions if no automated actions were taken
-func displayActionSuggestions(repo string, hasSpam, autoClose, autoBlock, githubBlock bool) {
+func displayActionSuggestions(w io.Writer, repo string, hasSpam, autoClose, autoBlock, githubBlock bool) {
 	if !hasSpam || autoClose || autoBlock {
 		return
 	}
 
-	fmt.Println("\nTo take action automatically, use:")
-	fmt.Printf("  prguard scan %s --auto-close --auto-block\n", repo)
+	fmt.Fprintln(w, "\nTo take action automatically, use:")
+	fmt.Fprintf(w, "  prguard scan %s --auto-close --auto-block\n", repo)
 	if githubBlock {
-		fmt.Printf("  Add --github-block to also block on GitHub\n")
+		fmt.Fprintf(w, "  Add --github-block to also block on GitHub\n")
 	}
 }
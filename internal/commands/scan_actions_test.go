@@ -0,0 +1,471 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/mocks"
+	"github.com/prguard/prguard/internal/scanner"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+func spamTestResults() *scanner.ScanResults {
+	return &scanner.ScanResults{
+		Total: 1,
+		Spam: []*scanner.ScanResult{
+			{
+				PR: &github.PullRequest{
+					Number:  1,
+					Author:  "spammer",
+					HTMLURL: "https://github.com/test/repo/pull/1",
+				},
+				IsSpam:   true,
+				Reasons:  []string{"Contains spam phrases"},
+				Signals:  []string{scanner.SignalSpamPhrase},
+				Severity: models.SeverityHigh,
+			},
+		},
+	}
+}
+
+// countingClients returns mocks that count every mutating call
+func countingClients(calls map[string]int) (*mocks.MockGitHubClient, *mocks.MockBlocklistManager) {
+	gh := &mocks.MockGitHubClient{
+		ClosePullRequestFn: func(_, _ string, _ int, _ string) error {
+			calls["ClosePullRequest"]++
+			return nil
+		},
+		AddLabelFn: func(_, _ string, _ int, _ string) error {
+			calls["AddLabel"]++
+			return nil
+		},
+		BlockUserOrgFn: func(_, _ string) error {
+			calls["BlockUserOrg"]++
+			return nil
+		},
+		BlockUserPersonalFn: func(_ string) error {
+			calls["BlockUserPersonal"]++
+			return nil
+		},
+	}
+	bl := &mocks.MockBlocklistManager{
+		BlockFn: func(req blocklist.BlockRequest) (*blocklist.BlockResult, error) {
+			calls["Block"]++
+			return &blocklist.BlockResult{Entry: models.NewBlocklistEntry(req.Username, req.Reason, req.EvidenceURL, req.BlockedBy, req.Severity, req.Source), Offense: 1}, nil
+		},
+	}
+	return gh, bl
+}
+
+func TestExecuteAutomatedActions_DryRun(t *testing.T) {
+	for _, ghCfg := range []config.GitHubConfig{{Org: "test-org"}, {User: "testowner"}} {
+		calls := map[string]int{}
+		gh, bl := countingClients(calls)
+
+		out := &bytes.Buffer{}
+		ctx := &ActionContext{
+			cfg:       &config.Config{GitHub: ghCfg, Actions: config.ActionsConfig{AddSpamLabel: true}},
+			ghClient:  gh,
+			blManager: bl,
+			out:       out,
+			dryRun:    true,
+		}
+		results := spamTestResults()
+		flags := &ActionFlags{autoClose: true, autoBlock: true, githubBlock: true}
+
+		if err := executeAutomatedActions(ctx, "test", "repo", results, collectSpamUsers(results), flags); err != nil {
+			t.Fatalf("executeAutomatedActions failed: %v", err)
+		}
+
+		if len(calls) != 0 {
+			t.Errorf("expected no mutating calls under dry-run, got %v", calls)
+		}
+		for _, want := range []string{
+			"[dry-run] would block spammer in local blocklist",
+			"[dry-run] would block spammer on GitHub",
+			"[dry-run] would add 'spam' label to PR #1",
+			"[dry-run] would close PR #1",
+		} {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, out.String())
+			}
+		}
+	}
+}
+
+func TestExecuteAutomatedActions_Executes(t *testing.T) {
+	calls := map[string]int{}
+	gh, bl := countingClients(calls)
+
+	ctx := &ActionContext{
+		cfg:       &config.Config{GitHub: config.GitHubConfig{Org: "test-org"}, Actions: config.ActionsConfig{AddSpamLabel: true}},
+		ghClient:  gh,
+		blManager: bl,
+		out:       &bytes.Buffer{},
+	}
+	results := spamTestResults()
+	flags := &ActionFlags{autoClose: true, autoBlock: true, githubBlock: true, skipConfirm: true}
+
+	if err := executeAutomatedActions(ctx, "test", "repo", results, collectSpamUsers(results), flags); err != nil {
+		t.Fatalf("executeAutomatedActions failed: %v", err)
+	}
+
+	for _, method := range []string{"Block", "BlockUserOrg", "AddLabel", "ClosePullRequest"} {
+		if calls[method] != 1 {
+			t.Errorf("expected 1 %s call, got %d", method, calls[method])
+		}
+	}
+}
+
+func TestExecuteAutomatedActions_SeverityActions(t *testing.T) {
+	var labeled, closed []int
+	var blocked []string
+	gh := &mocks.MockGitHubClient{
+		AddLabelFn: func(_, _ string, number int, _ string) error {
+			labeled = append(labeled, number)
+			return nil
+		},
+		ClosePullRequestFn: func(_, _ string, number int, _ string) error {
+			closed = append(closed, number)
+			return nil
+		},
+	}
+	bl := &mocks.MockBlocklistManager{
+		BlockFn: func(req blocklist.BlockRequest) (*blocklist.BlockResult, error) {
+			blocked = append(blocked, req.Username)
+			return &blocklist.BlockResult{Entry: models.NewBlocklistEntry(req.Username, req.Reason, req.EvidenceURL, req.BlockedBy, req.Severity, req.Source), Offense: 1}, nil
+		},
+	}
+	ctx := &ActionContext{
+		cfg: &config.Config{
+			GitHub: config.GitHubConfig{Org: "test-org"},
+			Actions: config.ActionsConfig{SeverityActions: map[string]string{
+				models.SeverityHigh: "close+block",
+				models.SeverityLow:  "label",
+			}},
+		},
+		ghClient:  gh,
+		blManager: bl,
+		out:       &bytes.Buffer{},
+	}
+	results := &scanner.ScanResults{
+		Total: 2,
+		Spam: []*scanner.ScanResult{
+			{
+				PR:       &github.PullRequest{Number: 1, Author: "spammer", HTMLURL: "https://github.com/test/repo/pull/1"},
+				IsSpam:   true,
+				Reasons:  []string{"Contains spam phrases"},
+				Severity: models.SeverityHigh,
+			},
+			{
+				PR:       &github.PullRequest{Number: 2, Author: "newcomer", HTMLURL: "https://github.com/test/repo/pull/2"},
+				IsSpam:   true,
+				Reasons:  []string{"Only modifies README"},
+				Severity: models.SeverityLow,
+			},
+		},
+	}
+	flags := &ActionFlags{autoClose: true, autoBlock: true, skipConfirm: true}
+
+	if err := executeAutomatedActions(ctx, "test", "repo", results, collectSpamUsers(results), flags); err != nil {
+		t.Fatalf("executeAutomatedActions failed: %v", err)
+	}
+
+	if len(closed) != 1 || closed[0] != 1 {
+		t.Errorf("expected only the high severity PR #1 to be closed, got %v", closed)
+	}
+	if len(blocked) != 1 || blocked[0] != "spammer" {
+		t.Errorf("expected only the high severity author to be blocked, got %v", blocked)
+	}
+	if len(labeled) != 1 || labeled[0] != 2 {
+		t.Errorf("expected only the low severity PR #2 to be labeled, got %v", labeled)
+	}
+}
+
+func TestLabelSpamPR_ConfiguredLabels(t *testing.T) {
+	existing := map[string]bool{"needs-triage": true}
+	var ensured, added []string
+	gh := &mocks.MockGitHubClient{
+		EnsureLabelFn: func(owner, repo, name, color string) error {
+			if owner != "test" || repo != "repo" {
+				t.Errorf("unexpected repository %s/%s", owner, repo)
+			}
+			if color != "00ff00" {
+				t.Errorf("expected configured color 00ff00, got %s", color)
+			}
+			if !existing[name] {
+				existing[name] = true
+				ensured = append(ensured, name)
+			}
+			return nil
+		},
+		AddLabelFn: func(_, _ string, number int, label string) error {
+			added = append(added, fmt.Sprintf("%d:%s", number, label))
+			return nil
+		},
+	}
+	out := &bytes.Buffer{}
+	ctx := &ActionContext{
+		cfg: &config.Config{Actions: config.ActionsConfig{
+			SpamLabel:  "invalid",
+			Labels:     []string{"needs-triage", "invalid"},
+			LabelColor: "00ff00",
+		}},
+		ghClient: gh,
+		out:      out,
+	}
+
+	for _, number := range []int{1, 2} {
+		if !labelSpamPR(ctx, "test", "repo", number) {
+			t.Fatalf("labelSpamPR(#%d) failed: %s", number, out.String())
+		}
+	}
+
+	if want := []string{"invalid"}; !slices.Equal(ensured, want) {
+		t.Errorf("expected missing labels %v to be created once, got %v", want, ensured)
+	}
+	if want := []string{"1:invalid", "1:needs-triage", "2:invalid", "2:needs-triage"}; !slices.Equal(added, want) {
+		t.Errorf("expected labels %v, got %v", want, added)
+	}
+	if !strings.Contains(out.String(), "added 'invalid', 'needs-triage' labels") {
+		t.Errorf("expected the configured labels in the output, got:\n%s", out.String())
+	}
+}
+
+func TestLabelSpamPR_EnsureFails(t *testing.T) {
+	labeled := false
+	gh := &mocks.MockGitHubClient{
+		EnsureLabelFn: func(_, _, _, _ string) error {
+			return errors.New("forbidden")
+		},
+		AddLabelFn: func(_, _ string, _ int, _ string) error {
+			labeled = true
+			return nil
+		},
+	}
+	ctx := &ActionContext{cfg: &config.Config{}, ghClient: gh, out: &bytes.Buffer{}}
+
+	if labelSpamPR(ctx, "test", "repo", 1) {
+		t.Error("expected labeling to fail when the label cannot be created")
+	}
+	if labeled {
+		t.Error("expected no label to be added when creating it failed")
+	}
+}
+
+func TestExecuteAutomatedActions_SeverityReportOnly(t *testing.T) {
+	calls := map[string]int{}
+	gh, bl := countingClients(calls)
+
+	out := &bytes.Buffer{}
+	ctx := &ActionContext{
+		cfg: &config.Config{
+			GitHub:  config.GitHubConfig{Org: "test-org"},
+			Actions: config.ActionsConfig{SeverityActions: map[string]string{models.SeverityHigh: "report-only"}},
+		},
+		ghClient:  gh,
+		blManager: bl,
+		out:       out,
+	}
+	results := spamTestResults()
+	flags := &ActionFlags{autoClose: true, autoBlock: true, skipConfirm: true}
+
+	if err := executeAutomatedActions(ctx, "test", "repo", results, collectSpamUsers(results), flags); err != nil {
+		t.Fatalf("executeAutomatedActions failed: %v", err)
+	}
+
+	if len(calls) != 0 {
+		t.Errorf("expected no mutating calls for report-only severity, got %v", calls)
+	}
+	if !strings.Contains(out.String(), "No actions apply") {
+		t.Errorf("expected no-actions message, got:\n%s", out.String())
+	}
+}
+
+func TestExecuteBlockActions_RecordsSignals(t *testing.T) {
+	var gotSignals []string
+	bl := &mocks.MockBlocklistManager{
+		BlockFn: func(req blocklist.BlockRequest) (*blocklist.BlockResult, error) {
+			gotSignals = req.Signals
+			return &blocklist.BlockResult{Entry: models.NewBlocklistEntry(req.Username, req.Reason, req.EvidenceURL, req.BlockedBy, req.Severity, req.Source), Offense: 1}, nil
+		},
+	}
+	ctx := &ActionContext{
+		cfg:       &config.Config{GitHub: config.GitHubConfig{Org: "test-org"}},
+		ghClient:  &mocks.MockGitHubClient{},
+		blManager: bl,
+		out:       &bytes.Buffer{},
+	}
+
+	executeBlockActions(ctx, "test", "repo", collectSpamUsers(spamTestResults()), false)
+
+	if len(gotSignals) != 1 || gotSignals[0] != scanner.SignalSpamPhrase {
+		t.Errorf("expected signals [%s] to be recorded, got %v", scanner.SignalSpamPhrase, gotSignals)
+	}
+}
+
+func TestExecuteBlockActions_RescanDoesNotEscalate(t *testing.T) {
+	_, db := setupTestConfig(t)
+	manager := blocklist.NewManager(db)
+	ctx := &ActionContext{
+		cfg:       &config.Config{GitHub: config.GitHubConfig{Org: "test-org"}},
+		ghClient:  &mocks.MockGitHubClient{},
+		blManager: manager,
+		out:       &bytes.Buffer{},
+	}
+
+	// The same still-open spam PR is found by three scans in a row
+	for i := 0; i < 3; i++ {
+		results := spamTestResults()
+		results.Spam[0].Severity = models.SeverityLow
+		executeBlockActions(ctx, "test", "repo", collectSpamUsers(results), false)
+	}
+
+	entries, err := manager.GetByUsername("spammer")
+	if err != nil {
+		t.Fatalf("GetByUsername failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry after re-scans, got %d", len(entries))
+	}
+	if entries[0].Severity != models.SeverityLow {
+		t.Errorf("expected severity to stay low, got %s", entries[0].Severity)
+	}
+}
+
+func TestExecuteBlockActions_SkipsUsersAlreadyBlockedOnGitHub(t *testing.T) {
+	calls := map[string]int{}
+	gh, bl := countingClients(calls)
+	marked := map[string]bool{"spammer": true}
+	bl.IsGitHubBlockedFn = func(username string) (bool, error) { return marked[username], nil }
+
+	out := &bytes.Buffer{}
+	ctx := &ActionContext{
+		cfg:       &config.Config{GitHub: config.GitHubConfig{Org: "test-org"}},
+		ghClient:  gh,
+		blManager: bl,
+		out:       out,
+	}
+
+	executeBlockActions(ctx, "test", "repo", collectSpamUsers(spamTestResults()), true)
+
+	if calls["Block"] != 1 || calls["BlockUserOrg"] != 0 {
+		t.Errorf("expected a local block and no GitHub call, got %v", calls)
+	}
+	if !strings.Contains(out.String(), "Already blocked on GitHub (org level)") {
+		t.Errorf("expected skip message, got:\n%s", out.String())
+	}
+}
+
+func TestCloseSpamPR_RendersCommentTemplate(t *testing.T) {
+	var gotComment string
+	ctx := &ActionContext{
+		cfg: &config.Config{Actions: config.ActionsConfig{
+			CommentTemplate: "@{{.Author}}, #{{.PRNumber}} in {{.Repo}} was closed: {{.Reasons}}",
+		}},
+		ghClient: &mocks.MockGitHubClient{
+			ClosePullRequestFn: func(_, _ string, _ int, comment string) error {
+				gotComment = comment
+				return nil
+			},
+		},
+		out: &bytes.Buffer{},
+	}
+
+	if !closeSpamPR(ctx, "test", "repo", spamTestResults().Spam[0]) {
+		t.Fatal("closeSpamPR() failed")
+	}
+
+	want := "@spammer, #1 in test/repo was closed: Contains spam phrases"
+	if gotComment != want {
+		t.Errorf("comment = %q, want %q", gotComment, want)
+	}
+}
+
+func TestCloseSpamPR_MalformedTemplateFallsBack(t *testing.T) {
+	var gotComment string
+	out := &bytes.Buffer{}
+	ctx := &ActionContext{
+		cfg: &config.Config{Actions: config.ActionsConfig{CommentTemplate: "Closed {{.PRNumber"}},
+		ghClient: &mocks.MockGitHubClient{
+			ClosePullRequestFn: func(_, _ string, _ int, comment string) error {
+				gotComment = comment
+				return nil
+			},
+		},
+		out: out,
+	}
+
+	if !closeSpamPR(ctx, "test", "repo", spamTestResults().Spam[0]) {
+		t.Fatal("closeSpamPR() failed")
+	}
+
+	if gotComment != defaultPRComment {
+		t.Errorf("expected the default comment, got %q", gotComment)
+	}
+	if !strings.Contains(out.String(), "using default comment") {
+		t.Errorf("expected a template warning, got:\n%s", out.String())
+	}
+}
+
+func TestExecuteBlockActions_ReasonTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"default", "", "Auto-detected spam: Contains spam phrases"},
+		{"rendered", "Spam PR #{{.PRNumber}} in {{.Repo}} ({{.Reasons}})", "Spam PR #1 in test/repo (Contains spam phrases)"},
+		{"literal", "Blocked by the spam bot", "Blocked by the spam bot"},
+		{"malformed falls back", "Spam in {{.Repo", "Auto-detected spam: Contains spam phrases"},
+		{"unknown field falls back", "Spam by {{.User}}", "Auto-detected spam: Contains spam phrases"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotReason string
+			bl := &mocks.MockBlocklistManager{
+				BlockFn: func(req blocklist.BlockRequest) (*blocklist.BlockResult, error) {
+					gotReason = req.Reason
+					return &blocklist.BlockResult{Entry: models.NewBlocklistEntry(req.Username, req.Reason, req.EvidenceURL, req.BlockedBy, req.Severity, req.Source), Offense: 1}, nil
+				},
+			}
+			ctx := &ActionContext{
+				cfg: &config.Config{
+					GitHub:  config.GitHubConfig{Org: "test-org"},
+					Actions: config.ActionsConfig{BlockReasonTemplate: tt.template},
+				},
+				ghClient:  &mocks.MockGitHubClient{},
+				blManager: bl,
+				out:       &bytes.Buffer{},
+			}
+
+			executeBlockActions(ctx, "test", "repo", collectSpamUsers(spamTestResults()), false)
+
+			if gotReason != tt.want {
+				t.Errorf("reason = %q, want %q", gotReason, tt.want)
+			}
+		})
+	}
+}
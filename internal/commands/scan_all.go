@@ -15,14 +15,28 @@
 package commands
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/report"
+	"github.com/prguard/prguard/internal/scanner"
 
 	"github.com/spf13/cobra"
 )
 
 // NewScanAllCommand creates the scan-all command
-func NewScanAllCommand(configPath *string) *cobra.Command {
-	var autoClose, autoBlock, githubBlock bool
+func NewScanAllCommand(configPath *string, assumeYes *bool) *cobra.Command {
+	var opts scanOptions
 
 	cmd := &cobra.Command{
 		Use:   "scan-all",
@@ -32,48 +46,391 @@ func NewScanAllCommand(configPath *string) *cobra.Command {
 By default, scan-all only reports findings. Use flags to take action:
   --auto-close: Automatically close spam PRs
   --auto-block: Automatically add spam users to local blocklist
-  --github-block: Also block users via GitHub API (requires --auto-block)`,
+  --github-block: Also block users via GitHub API (requires --auto-block)
+
+Use --dry-run to print the actions that would be taken without changing anything.
+
+Use --since to only scan PRs opened after a duration ago (e.g. 7d, 48h) or a
+date (e.g. 2025-01-31).
+
+Use --concurrency to scan several repositories in parallel; each repository's
+output is still printed as one block, in configured order. Taking automated
+actions in parallel requires --yes or --dry-run.
+
+Use --rate-limit-threshold to pause before a repository until the GitHub API
+budget resets whenever fewer requests than the threshold remain.
+
+Use --repo-list owner/a,owner/b or --repo-file (one repository per line) to
+scan only some of the configured repositories. Repositories missing from the
+config are rejected unless --allow-unlisted is given.
+
+Use --html-report to also write an HTML report with a section per repository,
+totals across them, and the spam authors seen most often.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			return runScanAll(*configPath, autoClose, autoBlock, githubBlock)
+			opts.yes = *assumeYes
+			return runScanAll(*configPath, opts)
 		},
 	}
 
-	cmd.Flags().BoolVar(&autoClose, "auto-close", false, "Automatically close spam PRs")
-	cmd.Flags().BoolVar(&autoBlock, "auto-block", false, "Automatically add spam users to blocklist")
-	cmd.Flags().BoolVar(&githubBlock, "github-block", false, "Also block users via GitHub API (requires --auto-block)")
+	cmd.Flags().BoolVar(&opts.autoClose, "auto-close", false, "Automatically close spam PRs")
+	cmd.Flags().BoolVar(&opts.autoBlock, "auto-block", false, "Automatically add spam users to blocklist")
+	cmd.Flags().BoolVar(&opts.githubBlock, "github-block", false, "Also block users via GitHub API (requires --auto-block)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print automated actions without executing them")
+	cmd.Flags().StringVar(&opts.since, "since", "", "Only scan PRs opened after a duration ago (e.g. 7d) or a date (YYYY-MM-DD)")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 1, "Number of repositories to scan in parallel")
+	cmd.Flags().IntVar(&opts.rateLimitThreshold, "rate-limit-threshold", 0, "Pause until the API budget resets when fewer requests remain (0 disables)")
+	cmd.Flags().StringSliceVar(&opts.repoList, "repo-list", nil, "Only scan these configured repositories (comma-separated owner/repo)")
+	cmd.Flags().StringVar(&opts.repoFile, "repo-file", "", "Only scan the configured repositories listed in this file (one owner/repo per line)")
+	cmd.Flags().BoolVar(&opts.allowUnlisted, "allow-unlisted", false, "Allow --repo-list and --repo-file to name repositories missing from the config")
+	cmd.Flags().StringVar(&opts.htmlReport, "html-report", "", "Also write an HTML report across every scanned repository to this file")
+	_ = cmd.RegisterFlagCompletionFunc("repo-list", completeRepositories(configPath))
 
 	return cmd
 }
 
-func runScanAll(configPath string, autoClose, autoBlock, githubBlock bool) error {
+func runScanAll(configPath string, opts scanOptions) error {
 	// Validate flags
-	if githubBlock && !autoBlock {
+	if opts.githubBlock && !opts.autoBlock {
 		return fmt.Errorf("--github-block requires --auto-block")
 	}
+	if opts.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	since, err := parseSince(opts.since, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
 
-	cfg, _, _, db, err := initClients(configPath)
+	requested := opts.repoList
+	if opts.repoFile != "" {
+		fromFile, err := readRepoFile(opts.repoFile)
+		if err != nil {
+			return err
+		}
+		requested = append(requested, fromFile...)
+	}
+
+	cfg, ghClient, blManager, db, err := initClients(configPath)
 	if err != nil {
 		return err
 	}
 	defer db.Close() //nolint:errcheck
 
-	if len(cfg.Repositories) == 0 {
+	if len(cfg.Repositories) == 0 && !opts.allowUnlisted {
 		return fmt.Errorf("no repositories configured. Add repositories to your config.yaml file")
 	}
 
-	fmt.Printf("Scanning %d configured repositories...\n\n", len(cfg.Repositories))
+	repos, err := selectRepositories(cfg.Repositories, requested, opts.allowUnlisted)
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		return fmt.Errorf("no repositories to scan")
+	}
+
+	// Confirmation prompts from parallel scans would be buffered out of sight
+	autoClose, autoBlock := applyConfigDefaults(cfg, opts.autoClose, opts.autoBlock)
+	if opts.concurrency > 1 && (autoClose || autoBlock) && !opts.dryRun && !opts.yes {
+		return fmt.Errorf("--concurrency above 1 requires --yes or --dry-run when taking automated actions")
+	}
 
-	for _, repo := range cfg.Repositories {
-		fmt.Printf("=== %s ===\n", repo.FullName())
+	if len(requested) > 0 {
+		fmt.Printf("Scanning %d selected repositories...\n\n", len(repos))
+	} else {
+		fmt.Printf("Scanning %d configured repositories...\n\n", len(repos))
+	}
 
-		// Run individual scan for each repository
-		if err := runScan(configPath, repo.FullName(), autoClose, autoBlock, githubBlock); err != nil {
-			fmt.Printf("Error scanning %s: %v\n\n", repo.FullName(), err)
-			continue
+	// Actions across every repository are undone together
+	opts.batchID = uuid.New().String()
+
+	ctx, stop := scanContext(cfg)
+	defer stop()
+	// Authors often open PRs across several repositories, so share user lookups for the whole run
+	cachedClient := github.NewCachedClient(ghClient.WithContext(ctx))
+	// Parallel scans share one blocker so github.block_delay spaces every block in the run
+	opts.blocker = newGitHubBlocker(cfg, cachedClient, blManager, os.Stderr, cfg.GitHub.BlockDelay)
+
+	reports := newRepositoryReports()
+	summary, err := scanRepositories(ctx, os.Stdout, repos, opts.concurrency, func(w io.Writer, repo string) (*scanner.ScanResults, error) {
+		if err := waitForRateLimit(ctx, w, cachedClient, opts.rateLimitThreshold); err != nil {
+			return nil, err
 		}
+		results, err := scanRepository(w, cfg, cachedClient, blManager, db, repo, since, opts)
+		if err == nil {
+			reports.add(repo, results)
+		}
+		return results, err
+	})
+	displayScanAllSummary(os.Stdout, summary)
 
-		fmt.Println()
+	// A partial report is still useful when the run was aborted
+	if opts.htmlReport != "" {
+		if reportErr := writeAggregateReport(opts.htmlReport, reports.inOrder(repos)); reportErr != nil {
+			return reportErr
+		}
+		fmt.Printf("✓ HTML report written to %s\n", opts.htmlReport)
 	}
 
+	return err
+}
+
+// repositoryReports collects the reports of repositories scanned in parallel
+type repositoryReports struct {
+	mu      sync.Mutex
+	reports map[string]*scanner.Report
+}
+
+func newRepositoryReports() *repositoryReports {
+	return &repositoryReports{reports: make(map[string]*scanner.Report)}
+}
+
+// add records the report for a repository given as owner/repo
+func (r *repositoryReports) add(repo string, results *scanner.ScanResults) {
+	owner, repoName, _ := parseRepo(repo) //nolint:errcheck // repositories are validated before scanning
+	scanReport := scanner.NewReport(owner, repoName, results)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports[repo] = scanReport
+}
+
+// inOrder returns the collected reports in repository order, skipping
+// repositories that failed or were never scanned
+func (r *repositoryReports) inOrder(repos []string) []*scanner.Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var reports []*scanner.Report
+	for _, repo := range repos {
+		if scanReport, ok := r.reports[repo]; ok {
+			reports = append(reports, scanReport)
+		}
+	}
+	return reports
+}
+
+// writeAggregateReport writes an HTML report across several repositories to path
+func writeAggregateReport(path string, reports []*scanner.Report) error {
+	f, err := os.Create(path) //nolint:gosec // path is provided by the user
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	if err := report.RenderAggregateHTML(f, report.NewAggregate(reports)); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
 	return nil
 }
+
+// selectRepositories returns the repositories scan-all should scan: every
+// configured repository when requested is empty, otherwise the requested ones
+// in the order given. Requested repositories match configured ones ignoring
+// case; ones missing from the config are an error unless allowUnlisted is set.
+func selectRepositories(configured []config.Repository, requested []string, allowUnlisted bool) ([]string, error) {
+	if len(requested) == 0 {
+		repos := make([]string, len(configured))
+		for i, repo := range configured {
+			repos[i] = repo.FullName()
+		}
+		return repos, nil
+	}
+
+	known := make(map[string]string, len(configured))
+	for _, repo := range configured {
+		known[strings.ToLower(repo.FullName())] = repo.FullName()
+	}
+
+	var repos, unlisted []string
+	seen := make(map[string]bool, len(requested))
+	for _, name := range requested {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if owner, repo, err := parseRepo(name); err != nil || owner == "" || repo == "" {
+			return nil, fmt.Errorf("invalid repository %q, expected owner/repo", name)
+		}
+		key := strings.ToLower(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if fullName, ok := known[key]; ok {
+			repos = append(repos, fullName)
+			continue
+		}
+		if !allowUnlisted {
+			unlisted = append(unlisted, name)
+			continue
+		}
+		repos = append(repos, name)
+	}
+
+	if len(unlisted) > 0 {
+		return nil, fmt.Errorf("%s not configured: %s (use --allow-unlisted to scan anyway)",
+			pluralize("repository is", "repositories are", len(unlisted)), strings.Join(unlisted, ", "))
+	}
+	return repos, nil
+}
+
+// readRepoFile reads one owner/repo per line, ignoring blank lines and # comments
+func readRepoFile(path string) ([]string, error) {
+	f, err := os.Open(path) //nolint:gosec // path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	var repos []string
+	lines := bufio.NewScanner(f)
+	for lines.Scan() {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		repos = append(repos, line)
+	}
+	if err := lines.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read repository file: %w", err)
+	}
+	return repos, nil
+}
+
+// scanAllSummary aggregates scan results across repositories
+type scanAllSummary struct {
+	Repositories int
+	Failed       int
+	Total        int
+	Spam         int
+	Uncertain    int
+}
+
+// scanRepositories runs scan for each repository with at most concurrency scans
+// in flight. Each repository's output is buffered and written to w in repository
+// order, so parallel scans never interleave. It stops starting new scans once ctx
+// is cancelled.
+func scanRepositories(ctx context.Context, w io.Writer, repos []string, concurrency int, scan func(w io.Writer, repo string) (*scanner.ScanResults, error)) (*scanAllSummary, error) {
+	out := newOrderedOutput(w, len(repos))
+	summary := &scanAllSummary{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	started := 0
+	for i, repo := range repos {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		started++
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer out.finish(i)
+
+			rw := out.writer(i)
+			fmt.Fprintf(rw, "[%d/%d] scanning %s\n", i+1, len(repos), repo)
+			results, err := scan(rw, repo)
+
+			mu.Lock()
+			summary.Repositories++
+			if err != nil {
+				summary.Failed++
+			} else {
+				summary.Total += results.Total
+				summary.Spam += len(results.Spam)
+				summary.Uncertain += len(results.Uncertain)
+			}
+			mu.Unlock()
+
+			if err != nil && ctx.Err() == nil {
+				fmt.Fprintf(rw, "Error scanning %s: %v\n", repo, err)
+			}
+			fmt.Fprintln(rw)
+		}()
+	}
+	wg.Wait()
+
+	// Release output ordering for repositories that were never started
+	for i := started; i < len(repos); i++ {
+		out.finish(i)
+	}
+
+	if ctx.Err() != nil {
+		return summary, fmt.Errorf("scan-all aborted: %w", ctx.Err())
+	}
+	return summary, nil
+}
+
+// displayScanAllSummary prints the totals across every scanned repository
+func displayScanAllSummary(w io.Writer, summary *scanAllSummary) {
+	fmt.Fprintln(w, "=== SUMMARY ===")
+	fmt.Fprintf(w, "Repositories scanned: %d", summary.Repositories)
+	if summary.Failed > 0 {
+		fmt.Fprintf(w, " (%d failed)", summary.Failed)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Total PRs: %d\n", summary.Total)
+	fmt.Fprintln(w, colorize(colorRed, fmt.Sprintf("Spam detected: %d", summary.Spam)))
+	fmt.Fprintln(w, colorize(colorYellow, fmt.Sprintf("Uncertain: %d", summary.Uncertain)))
+}
+
+// orderedOutput serializes per-repository output in repository order. The
+// earliest unfinished repository writes straight through; later ones are
+// buffered and flushed once everything before them has finished.
+type orderedOutput struct {
+	mu   sync.Mutex
+	w    io.Writer
+	next int
+	bufs []bytes.Buffer
+	done []bool
+}
+
+func newOrderedOutput(w io.Writer, n int) *orderedOutput {
+	return &orderedOutput{w: w, bufs: make([]bytes.Buffer, n), done: make([]bool, n)}
+}
+
+// writer returns the writer for the repository at index i
+func (o *orderedOutput) writer(i int) io.Writer {
+	return orderedWriter{o: o, i: i}
+}
+
+// finish marks repository i complete and flushes any repositories it was holding back
+func (o *orderedOutput) finish(i int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.done[i] = true
+	for o.next < len(o.done) && o.done[o.next] {
+		o.next++
+		if o.next < len(o.bufs) {
+			o.w.Write(o.bufs[o.next].Bytes()) //nolint:errcheck
+			o.bufs[o.next].Reset()
+		}
+	}
+}
+
+type orderedWriter struct {
+	o *orderedOutput
+	i int
+}
+
+func (w orderedWriter) Write(p []byte) (int, error) {
+	w.o.mu.Lock()
+	defer w.o.mu.Unlock()
+
+	if w.i == w.o.next {
+		return w.o.w.Write(p)
+	}
+	return w.o.bufs[w.i].Write(p)
+}
@@ -15,13 +15,26 @@
 package commands
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/scanner"
 )
 
 func TestScanAllCommand_FlagExistence(t *testing.T) {
 	configPath := "config.yaml"
-	cmd := NewScanAllCommand(&configPath)
+	cmd := NewScanAllCommand(&configPath, new(bool))
 
 	// Check flags exist
 	autoCloseFlag := cmd.Flags().Lookup("auto-close")
@@ -38,6 +51,20 @@ func TestScanAllCommand_FlagExistence(t *testing.T) {
 	if githubBlockFlag == nil {
 		t.Error("github-block flag not found")
 	}
+
+	if cmd.Flags().Lookup("concurrency") == nil {
+		t.Error("concurrency flag not found")
+	}
+
+	if cmd.Flags().Lookup("rate-limit-threshold") == nil {
+		t.Error("rate-limit-threshold flag not found")
+	}
+
+	for _, name := range []string{"repo-list", "repo-file", "allow-unlisted", "html-report"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("%s flag not found", name)
+		}
+	}
 }
 
 func TestScanAll_FlagValidation(t *testing.T) {
@@ -113,3 +140,222 @@ func validateScanAllFlags(autoClose, autoBlock, githubBlock bool) error {
 	}
 	return nil
 }
+
+// fakeRepoScan returns a scan function reporting spam and uncertain PR counts
+// per repository; earlier repositories take longer so parallel scans finish out of order
+func fakeRepoScan(repos []string, inFlight, maxInFlight *atomic.Int32) func(w io.Writer, repo string) (*scanner.ScanResults, error) {
+	return func(w io.Writer, repo string) (*scanner.ScanResults, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			peak := maxInFlight.Load()
+			if n <= peak || maxInFlight.CompareAndSwap(peak, n) {
+				break
+			}
+		}
+
+		index := 0
+		for i, name := range repos {
+			if name == repo {
+				index = i
+			}
+		}
+		time.Sleep(time.Duration(len(repos)-index) * 5 * time.Millisecond)
+
+		if repo == "org/broken" {
+			return nil, fmt.Errorf("boom")
+		}
+		fmt.Fprintf(w, "output for %s\n", repo)
+		return &scanner.ScanResults{
+			Total:     3,
+			Spam:      []*scanner.ScanResult{{}, {}},
+			Uncertain: []*scanner.ScanResult{{}},
+		}, nil
+	}
+}
+
+func TestScanRepositories_Concurrent(t *testing.T) {
+	repos := []string{"org/a", "org/b", "org/broken", "org/c", "org/d"}
+	var inFlight, maxInFlight atomic.Int32
+	var out bytes.Buffer
+
+	summary, err := scanRepositories(context.Background(), &out, repos, 3, fakeRepoScan(repos, &inFlight, &maxInFlight))
+	if err != nil {
+		t.Fatalf("scanRepositories() error = %v", err)
+	}
+
+	if summary.Repositories != 5 || summary.Failed != 1 {
+		t.Errorf("expected 5 repositories with 1 failure, got %+v", summary)
+	}
+	if summary.Total != 12 || summary.Spam != 8 || summary.Uncertain != 4 {
+		t.Errorf("expected 12 PRs, 8 spam, 4 uncertain, got %+v", summary)
+	}
+	if peak := maxInFlight.Load(); peak > 3 {
+		t.Errorf("expected at most 3 concurrent scans, got %d", peak)
+	}
+
+	// Each repository's block appears whole and in configured order
+	want := "[1/5] scanning org/a\noutput for org/a\n\n" +
+		"[2/5] scanning org/b\noutput for org/b\n\n" +
+		"[3/5] scanning org/broken\nError scanning org/broken: boom\n\n" +
+		"[4/5] scanning org/c\noutput for org/c\n\n" +
+		"[5/5] scanning org/d\noutput for org/d\n\n"
+	if out.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestScanRepositories_Serial(t *testing.T) {
+	repos := []string{"org/a", "org/b"}
+	var inFlight, maxInFlight atomic.Int32
+
+	summary, err := scanRepositories(context.Background(), io.Discard, repos, 1, fakeRepoScan(repos, &inFlight, &maxInFlight))
+	if err != nil {
+		t.Fatalf("scanRepositories() error = %v", err)
+	}
+	if summary.Repositories != 2 {
+		t.Errorf("expected 2 repositories scanned, got %d", summary.Repositories)
+	}
+	if peak := maxInFlight.Load(); peak != 1 {
+		t.Errorf("expected serial scans, got %d in flight", peak)
+	}
+}
+
+func TestScanRepositories_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var scanned atomic.Int32
+	_, err := scanRepositories(ctx, io.Discard, []string{"org/a", "org/b"}, 2, func(_ io.Writer, _ string) (*scanner.ScanResults, error) {
+		scanned.Add(1)
+		return &scanner.ScanResults{}, nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "scan-all aborted") {
+		t.Errorf("expected an aborted error, got %v", err)
+	}
+	if scanned.Load() != 0 {
+		t.Errorf("expected no scans after cancellation, got %d", scanned.Load())
+	}
+}
+
+func TestDisplayScanAllSummary(t *testing.T) {
+	var out bytes.Buffer
+	displayScanAllSummary(&out, &scanAllSummary{Repositories: 4, Failed: 1, Total: 20, Spam: 5, Uncertain: 2})
+
+	for _, want := range []string{"Repositories scanned: 4 (1 failed)", "Total PRs: 20", "Spam detected: 5", "Uncertain: 2"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestSelectRepositories(t *testing.T) {
+	configured := []config.Repository{
+		{Owner: "org", Name: "api"},
+		{Owner: "org", Name: "web"},
+		{Owner: "org", Name: "docs"},
+	}
+
+	tests := []struct {
+		name          string
+		requested     []string
+		allowUnlisted bool
+		want          []string
+		wantErr       string
+	}{
+		{name: "all configured", want: []string{"org/api", "org/web", "org/docs"}},
+		{name: "subset", requested: []string{"org/docs", "ORG/API", "org/docs"}, want: []string{"org/docs", "org/api"}},
+		{name: "unknown repository", requested: []string{"org/api", "other/repo"}, wantErr: "repository is not configured: other/repo"},
+		{name: "allow unlisted", requested: []string{"org/api", "other/repo"}, allowUnlisted: true, want: []string{"org/api", "other/repo"}},
+		{name: "invalid name", requested: []string{"api"}, wantErr: "invalid repository"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectRepositories(configured, tt.requested, tt.allowUnlisted)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("selectRepositories() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanRepositories_OnlySelectedSubset(t *testing.T) {
+	configured := []config.Repository{
+		{Owner: "org", Name: "api"},
+		{Owner: "org", Name: "web"},
+		{Owner: "org", Name: "docs"},
+	}
+	repos, err := selectRepositories(configured, []string{"org/web", "org/docs"}, false)
+	if err != nil {
+		t.Fatalf("selectRepositories failed: %v", err)
+	}
+
+	var scanned []string
+	_, err = scanRepositories(context.Background(), io.Discard, repos, 1, func(_ io.Writer, repo string) (*scanner.ScanResults, error) {
+		scanned = append(scanned, repo)
+		return &scanner.ScanResults{}, nil
+	})
+	if err != nil {
+		t.Fatalf("scanRepositories failed: %v", err)
+	}
+	if !slices.Equal(scanned, []string{"org/web", "org/docs"}) {
+		t.Errorf("expected only the selected repositories to be scanned, got %v", scanned)
+	}
+}
+
+func TestReadRepoFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repos.txt")
+	if err := os.WriteFile(path, []byte("# weekly sweep\norg/api\n\n  org/web  \n"), 0o600); err != nil {
+		t.Fatalf("failed to write repo file: %v", err)
+	}
+
+	repos, err := readRepoFile(path)
+	if err != nil {
+		t.Fatalf("readRepoFile failed: %v", err)
+	}
+	if !slices.Equal(repos, []string{"org/api", "org/web"}) {
+		t.Errorf("readRepoFile() = %v", repos)
+	}
+
+	if _, err := readRepoFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestWriteAggregateReport(t *testing.T) {
+	reports := newRepositoryReports()
+	for _, repo := range []string{"org/b", "org/a"} {
+		reports.add(repo, &scanner.ScanResults{
+			Total: 1,
+			Spam:  []*scanner.ScanResult{{PR: &github.PullRequest{Number: 1, Author: "spammer"}, IsSpam: true, Severity: "high"}},
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "report.html")
+	if err := writeAggregateReport(path, reports.inOrder([]string{"org/a", "org/broken", "org/b"})); err != nil {
+		t.Fatalf("writeAggregateReport failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	out := string(data)
+	a, b := strings.Index(out, "<h2>org/a</h2>"), strings.Index(out, "<h2>org/b</h2>")
+	if a < 0 || b < 0 || a > b {
+		t.Errorf("expected sections for org/a then org/b:\n%s", out)
+	}
+	if strings.Contains(out, "org/broken") {
+		t.Error("repositories without results should not be listed")
+	}
+}
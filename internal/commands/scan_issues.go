@@ -0,0 +1,154 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/scanner"
+	"github.com/spf13/cobra"
+)
+
+// defaultIssueComment is posted when closing a spam issue without --comment
+const defaultIssueComment = "This issue has been automatically closed due to spam indicators."
+
+// NewScanIssuesCommand creates the scan-issues command
+func NewScanIssuesCommand(configPath *string, assumeYes *bool) *cobra.Command {
+	var closeIssues bool
+	var comment string
+
+	cmd := &cobra.Command{
+		Use:   "scan-issues <owner>/<repo>",
+		Short: "Scan a repository for spam issues",
+		Long: `Analyzes all open issues in a repository for spam indicators.
+
+Issues are checked against the configured spam phrases and patterns, account
+age, and reputation thresholds; file and line count checks only apply to PRs.
+
+By default, scan-issues only reports findings. Use --close to close spam issues.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeRepositories(configPath),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runScanIssues(*configPath, args[0], closeIssues, comment, *assumeYes)
+		},
+	}
+
+	cmd.Flags().BoolVar(&closeIssues, "close", false, "Close spam issues")
+	cmd.Flags().StringVarP(&comment, "comment", "c", "", "Comment to add when closing")
+
+	return cmd
+}
+
+func runScanIssues(configPath, repo string, closeIssues bool, comment string, assumeYes bool) error {
+	owner, repoName, err := parseRepo(repo)
+	if err != nil {
+		return err
+	}
+
+	cfg, ghClient, _, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	ctx, stop := scanContext(cfg)
+	defer stop()
+
+	return executeScanIssues(os.Stdout, bufio.NewReader(os.Stdin), cfg, ghClient.WithContext(ctx), owner, repoName, closeIssues, comment, assumeYes)
+}
+
+// executeScanIssues scans a repository's issues, reports the results and
+// closes spam issues after confirmation when closeIssues is set
+func executeScanIssues(w io.Writer, reader *bufio.Reader, cfg *config.Config, ghClient github.GitHubClient, owner, repoName string, closeIssues bool, comment string, assumeYes bool) error {
+	fmt.Fprintf(w, "Scanning issues in %s/%s...\n\n", owner, repoName)
+
+	scan, err := scanner.NewScannerE(cfg)
+	if err != nil {
+		return err
+	}
+	results, err := scan.ScanIssues(ghClient, owner, repoName)
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	displayIssueResults(w, results)
+
+	if len(results.Spam) == 0 {
+		return nil
+	}
+	if !closeIssues {
+		fmt.Fprintf(w, "\nTo close spam issues, run:\n  prguard scan-issues %s/%s --close\n", owner, repoName)
+		return nil
+	}
+
+	fmt.Fprintf(w, "\nAbout to close %d spam %s.\n", len(results.Spam), pluralize("issue", "issues", len(results.Spam)))
+	if !confirmPrompt(reader, assumeYes) {
+		fmt.Fprintln(w, "Cancelled.")
+		return nil
+	}
+
+	if comment == "" {
+		comment = defaultIssueComment
+	}
+	for _, result := range results.Spam {
+		if err := ghClient.CloseIssue(owner, repoName, result.Issue.Number, comment); err != nil {
+			fmt.Fprintf(w, "  ⚠ Failed to close issue #%d: %v\n", result.Issue.Number, err)
+			continue
+		}
+		fmt.Fprintf(w, "  ✓ Closed issue #%d\n", result.Issue.Number)
+	}
+
+	return nil
+}
+
+// displayIssueResults prints an issue scan summary and the spam and uncertain issues
+func displayIssueResults(w io.Writer, results *scanner.IssueScanResults) {
+	fmt.Fprintf(w, "Total issues: %d\n", results.Total)
+	fmt.Fprintln(w, colorize(colorRed, fmt.Sprintf("Spam detected: %d", len(results.Spam))))
+	fmt.Fprintln(w, colorize(colorYellow, fmt.Sprintf("Uncertain: %d", len(results.Uncertain))))
+	fmt.Fprintln(w, colorize(colorGreen, fmt.Sprintf("Clean: %d", len(results.Clean))))
+
+	if len(results.Spam) > 0 {
+		fmt.Fprintln(w, "\n"+colorize(colorRed, "=== SPAM DETECTED ==="))
+		for _, result := range results.Spam {
+			displayIssueResult(w, result)
+			fmt.Fprintf(w, "  Recommended action: %s\n", result.RecommendAction)
+		}
+	}
+
+	if len(results.Uncertain) > 0 {
+		fmt.Fprintln(w, "\n"+colorize(colorYellow, "=== MANUAL REVIEW NEEDED ==="))
+		for _, result := range results.Uncertain {
+			displayIssueResult(w, result)
+		}
+	}
+}
+
+// displayIssueResult prints one scanned issue and the reasons it was flagged
+func displayIssueResult(w io.Writer, result *scanner.IssueScanResult) {
+	fmt.Fprintf(w, "\nIssue #%d: %s\n", result.Issue.Number, result.Issue.Title)
+	fmt.Fprintf(w, "  Author: %s\n", result.Issue.Author)
+	fmt.Fprintf(w, "  URL: %s\n", result.Issue.HTMLURL)
+	fmt.Fprintf(w, "  Severity: %s\n", result.Severity)
+	fmt.Fprintf(w, "  Reasons:\n")
+	for _, reason := range result.Reasons {
+		fmt.Fprintf(w, "    - %s\n", reason)
+	}
+}
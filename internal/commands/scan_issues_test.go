@@ -0,0 +1,111 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/mocks"
+)
+
+// spamIssueClient returns a mock serving one spam issue from a new account and one clean issue
+func spamIssueClient(closed *[]int) *mocks.MockGitHubClient {
+	return &mocks.MockGitHubClient{
+		GetIssuesFn: func(_, _ string) ([]*github.Issue, error) {
+			return []*github.Issue{
+				{Number: 10, Title: "Free crypto airdrop", Body: "Visit my site", Author: "spammer", HTMLURL: "https://github.com/org/repo/issues/10"},
+				{Number: 11, Title: "Docs are out of date", Author: "veteran"},
+			}, nil
+		},
+		GetUserFn: func(username string) (*github.User, error) {
+			created := time.Now().Add(-365 * 24 * time.Hour)
+			if username == "spammer" {
+				created = time.Now().Add(-time.Hour)
+			}
+			return &github.User{Login: username, CreatedAt: created}, nil
+		},
+		CloseIssueFn: func(_, _ string, number int, comment string) error {
+			if comment != defaultIssueComment {
+				return fmt.Errorf("unexpected comment %q", comment)
+			}
+			*closed = append(*closed, number)
+			return nil
+		},
+	}
+}
+
+func issueTestConfig() *config.Config {
+	cfg := &config.Config{Filters: config.FiltersConfig{AccountAgeDays: 7, SpamPhrases: []string{"crypto airdrop"}}}
+	cfg.SetDefaults()
+	return cfg
+}
+
+func TestExecuteScanIssues_ReportOnly(t *testing.T) {
+	var closed []int
+	var out bytes.Buffer
+
+	err := executeScanIssues(&out, noStdin(t), issueTestConfig(), spamIssueClient(&closed), "org", "repo", false, "", false)
+	if err != nil {
+		t.Fatalf("executeScanIssues() error = %v", err)
+	}
+
+	output := out.String()
+	for _, want := range []string{"Total issues: 2", "Spam detected: 1", "Issue #10: Free crypto airdrop", "Account created recently", "--close"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if len(closed) != 0 {
+		t.Errorf("issues should not be closed without --close, got %v", closed)
+	}
+}
+
+func TestExecuteScanIssues_Close(t *testing.T) {
+	var closed []int
+	var out bytes.Buffer
+
+	err := executeScanIssues(&out, noStdin(t), issueTestConfig(), spamIssueClient(&closed), "org", "repo", true, "", true)
+	if err != nil {
+		t.Fatalf("executeScanIssues() error = %v", err)
+	}
+
+	if len(closed) != 1 || closed[0] != 10 {
+		t.Errorf("expected only issue #10 to be closed, got %v", closed)
+	}
+	if !strings.Contains(out.String(), "✓ Closed issue #10") {
+		t.Errorf("output missing close confirmation:\n%s", out.String())
+	}
+}
+
+func TestExecuteScanIssues_CloseDeclined(t *testing.T) {
+	var closed []int
+	var out bytes.Buffer
+	reader := bufio.NewReader(strings.NewReader("n\n"))
+
+	err := executeScanIssues(&out, reader, issueTestConfig(), spamIssueClient(&closed), "org", "repo", true, "", false)
+	if err != nil {
+		t.Fatalf("executeScanIssues() error = %v", err)
+	}
+	if len(closed) != 0 {
+		t.Errorf("declining the prompt should close nothing, got %v", closed)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/prguard/prguard/internal/scanner"
+	"github.com/spf13/cobra"
+)
+
+// NewScanPRCommand creates the scan-pr command
+func NewScanPRCommand(configPath *string, assumeYes *bool) *cobra.Command {
+	var opts scanOptions
+
+	cmd := &cobra.Command{
+		Use:   "scan-pr <owner>/<repo> <pr-number>",
+		Short: "Scan a single pull request for spam",
+		Long: `Fetches and scans one pull request and prints its classification and reasons,
+without scanning the rest of the repository.
+
+The same flags as scan take action on a spam PR:
+  --auto-close: Close the PR
+  --auto-block: Add the author to the local blocklist
+  --github-block: Also block the author via GitHub API (requires --auto-block)
+
+Use --json (or --format json) or --format sarif for machine-readable output, and
+--output to write it to a file instead of stdout. In JSON and SARIF modes
+automated actions are skipped unless --yes is also passed.
+
+The duplicate title check is skipped since it compares PRs against each other.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeRepositories(configPath),
+		RunE: func(_ *cobra.Command, args []string) error {
+			opts.yes = *assumeYes
+			return runScanPR(*configPath, args[0], args[1], opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.autoClose, "auto-close", false, "Automatically close the PR if it is spam")
+	cmd.Flags().BoolVar(&opts.autoBlock, "auto-block", false, "Automatically add the author to the blocklist if the PR is spam")
+	cmd.Flags().BoolVar(&opts.githubBlock, "github-block", false, "Also block the author via GitHub API (requires --auto-block)")
+	addScanFormatFlags(cmd, &opts)
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print automated actions without executing them")
+
+	return cmd
+}
+
+func runScanPR(configPath, repo, prNumber string, opts scanOptions) error {
+	if opts.githubBlock && !opts.autoBlock {
+		return fmt.Errorf("--github-block requires --auto-block")
+	}
+	if err := opts.resolveFormat(); err != nil {
+		return err
+	}
+	owner, repoName, err := parseRepo(repo)
+	if err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(prNumber)
+	if err != nil || number < 1 {
+		return fmt.Errorf("invalid PR number: %s", prNumber)
+	}
+
+	cfg, ghClient, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	ctx, stop := scanContext(cfg)
+	defer stop()
+
+	actx := &ActionContext{
+		cfg:       cfg,
+		ghClient:  ghClient.WithContext(ctx),
+		blManager: blManager,
+		out:       os.Stdout,
+		dryRun:    opts.dryRun,
+		actions:   db,
+		batchID:   uuid.New().String(),
+	}
+	_, err = scanSinglePR(actx, owner, repoName, number, opts)
+	return err
+}
+
+// scanSinglePR scans one PR, reports its classification to ctx.out and runs
+// any requested automated actions
+func scanSinglePR(ctx *ActionContext, owner, repoName string, number int, opts scanOptions) (*scanner.ScanResults, error) {
+	opts.autoClose, opts.autoBlock = applyConfigDefaults(ctx.cfg, opts.autoClose, opts.autoBlock)
+
+	if !opts.structured() {
+		fmt.Fprintf(ctx.out, "Scanning PR #%d in %s/%s...\n\n", number, owner, repoName)
+	}
+
+	scan, err := scanner.NewScannerE(ctx.cfg)
+	if err != nil {
+		return nil, err
+	}
+	result, err := scan.ScanPullRequest(ctx.ghClient, owner, repoName, number)
+	if err != nil {
+		return nil, fmt.Errorf("scan failed: %w", err)
+	}
+	results := singleScanResults(result)
+
+	if opts.structured() {
+		if err := writeScanOutput(ctx.out, opts, scanner.NewReport(owner, repoName, results)); err != nil {
+			return nil, err
+		}
+		// Automated actions require explicit consent in JSON and SARIF modes
+		if !opts.yes {
+			return results, nil
+		}
+		// Keep stdout clean for the document unless it went to a file
+		if opts.outputPath == "" {
+			ctx.out = os.Stderr
+		}
+	} else {
+		displayPRResult(ctx.out, result)
+	}
+
+	flags := &ActionFlags{
+		autoClose:   opts.autoClose,
+		autoBlock:   opts.autoBlock,
+		githubBlock: opts.githubBlock,
+		skipConfirm: opts.yes,
+	}
+	if err := executeAutomatedActions(ctx, owner, repoName, results, collectSpamUsers(results), flags); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// singleScanResults files one scan result under its classification
+func singleScanResults(result *scanner.ScanResult) *scanner.ScanResults {
+	results := &scanner.ScanResults{Total: 1}
+	switch classifyResult(result) {
+	case scanner.ClassificationSpam:
+		results.Spam = append(results.Spam, result)
+	case scanner.ClassificationUncertain:
+		results.Uncertain = append(results.Uncertain, result)
+	default:
+		results.Clean = append(results.Clean, result)
+	}
+	return results
+}
+
+// classifyResult returns the report classification of a scan result
+func classifyResult(result *scanner.ScanResult) string {
+	switch {
+	case result.IsSpam:
+		return scanner.ClassificationSpam
+	case result.IsUncertain:
+		return scanner.ClassificationUncertain
+	default:
+		return scanner.ClassificationClean
+	}
+}
+
+// displayPRResult prints the classification and reasons of a single PR
+func displayPRResult(w io.Writer, result *scanner.ScanResult) {
+	classification := classifyResult(result)
+	switch classification {
+	case scanner.ClassificationSpam:
+		classification = colorize(colorRed, classification)
+	case scanner.ClassificationUncertain:
+		classification = colorize(colorYellow, classification)
+	default:
+		classification = colorize(colorGreen, classification)
+	}
+
+	fmt.Fprintf(w, "PR #%d: %s\n", result.PR.Number, result.PR.Title)
+	fmt.Fprintf(w, "  Author: %s\n", result.PR.Author)
+	fmt.Fprintf(w, "  URL: %s\n", result.PR.HTMLURL)
+	if result.PR.BaseRef != "" {
+		fmt.Fprintf(w, "  Base: %s\n", result.PR.BaseRef)
+	}
+	fmt.Fprintf(w, "  Classification: %s\n", classification)
+	fmt.Fprintf(w, "  Severity: %s\n", result.Severity)
+	if len(result.Reasons) > 0 {
+		fmt.Fprintf(w, "  Reasons:\n")
+		for _, reason := range result.Reasons {
+			fmt.Fprintf(w, "    - %s\n", reason)
+		}
+	}
+	fmt.Fprintf(w, "  Recommended action: %s\n", result.RecommendAction)
+}
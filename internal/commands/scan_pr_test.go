@@ -0,0 +1,214 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/mocks"
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+// singlePRClient returns a mock serving PR #42, a README-only edit by a new
+// account, and failing if any other PR is fetched or the repository is listed
+func singlePRClient(t *testing.T) *mocks.MockGitHubClient {
+	return &mocks.MockGitHubClient{
+		ListPullRequestNumbersFn: func(_, _ string) ([]int, error) {
+			t.Error("scan-pr should not list the repository's PRs")
+			return nil, nil
+		},
+		GetPullRequestFn: func(owner, repo string, number int) (*github.PullRequest, error) {
+			if owner != "test" || repo != "repo" || number != 42 {
+				t.Errorf("unexpected PR %s/%s#%d", owner, repo, number)
+			}
+			return &github.PullRequest{
+				Number:     number,
+				Title:      "Update README",
+				Author:     "spammer",
+				FilesCount: 1,
+				Files:      []string{"README.md"},
+				Additions:  2,
+				HTMLURL:    "https://github.com/test/repo/pull/42",
+			}, nil
+		},
+		GetUserFn: func(username string) (*github.User, error) {
+			return &github.User{Login: username, CreatedAt: time.Now().Add(-24 * time.Hour)}, nil
+		},
+	}
+}
+
+func scanPRTestConfig() *config.Config {
+	cfg := &config.Config{GitHub: config.GitHubConfig{Org: "test-org"}}
+	cfg.SetDefaults()
+	cfg.Filters.ReadmeOnlyBlock = true
+	cfg.Actions.ClosePRs = false
+	return cfg
+}
+
+func TestScanSinglePR_ReportsClassification(t *testing.T) {
+	out := &bytes.Buffer{}
+	ctx := &ActionContext{cfg: scanPRTestConfig(), ghClient: singlePRClient(t), blManager: &mocks.MockBlocklistManager{}, out: out}
+
+	results, err := scanSinglePR(ctx, "test", "repo", 42, scanOptions{})
+	if err != nil {
+		t.Fatalf("scanSinglePR failed: %v", err)
+	}
+
+	if results.Total != 1 || len(results.Spam) != 1 {
+		t.Fatalf("expected PR #42 to be spam, got %d spam of %d", len(results.Spam), results.Total)
+	}
+	output := out.String()
+	for _, want := range []string{
+		"Scanning PR #42 in test/repo",
+		"PR #42: Update README",
+		"Classification: spam",
+		"- Single-file README-only edit",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "AUTOMATED ACTIONS") {
+		t.Errorf("expected no actions without --auto-close or --auto-block, got:\n%s", output)
+	}
+}
+
+func TestScanSinglePR_CleanPR(t *testing.T) {
+	client := singlePRClient(t)
+	client.GetPullRequestFn = func(_, _ string, number int) (*github.PullRequest, error) {
+		return &github.PullRequest{Number: number, Title: "Add feature", Author: "contributor", FilesCount: 4, Files: []string{"a.go", "b.go", "c.go", "d.go"}, Additions: 120}, nil
+	}
+	client.GetUserFn = func(username string) (*github.User, error) {
+		return &github.User{Login: username, CreatedAt: time.Now().Add(-365 * 24 * time.Hour), Followers: 50, PublicRepos: 20}, nil
+	}
+	out := &bytes.Buffer{}
+	ctx := &ActionContext{cfg: scanPRTestConfig(), ghClient: client, out: out}
+
+	results, err := scanSinglePR(ctx, "test", "repo", 7, scanOptions{})
+	if err != nil {
+		t.Fatalf("scanSinglePR failed: %v", err)
+	}
+	if len(results.Clean) != 1 {
+		t.Errorf("expected PR #7 to be clean, got %d spam and %d uncertain", len(results.Spam), len(results.Uncertain))
+	}
+	if !strings.Contains(out.String(), "Classification: clean") {
+		t.Errorf("expected clean classification, got:\n%s", out.String())
+	}
+}
+
+func TestScanSinglePR_JSON(t *testing.T) {
+	out := &bytes.Buffer{}
+	ctx := &ActionContext{cfg: scanPRTestConfig(), ghClient: singlePRClient(t), out: out}
+
+	if _, err := scanSinglePR(ctx, "test", "repo", 42, scanOptions{format: scanFormatJSON}); err != nil {
+		t.Fatalf("scanSinglePR failed: %v", err)
+	}
+
+	var report scanner.Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("expected a JSON report, got %v:\n%s", err, out.String())
+	}
+	if len(report.PullRequests) != 1 || report.PullRequests[0].Classification != scanner.ClassificationSpam {
+		t.Errorf("expected one spam PR in the report, got %+v", report.PullRequests)
+	}
+}
+
+func TestScanSinglePR_SARIFFile(t *testing.T) {
+	out := &bytes.Buffer{}
+	ctx := &ActionContext{cfg: scanPRTestConfig(), ghClient: singlePRClient(t), out: out}
+	path := filepath.Join(t.TempDir(), "pr.sarif")
+
+	if _, err := scanSinglePR(ctx, "test", "repo", 42, scanOptions{format: scanFormatSARIF, outputPath: path}); err != nil {
+		t.Fatalf("scanSinglePR failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Wrote sarif scan results to "+path) {
+		t.Errorf("expected confirmation of the written file, got:\n%s", out.String())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	var doc struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("output file is not valid JSON: %v", err)
+	}
+	if doc.Version != "2.1.0" || len(doc.Runs) != 1 || len(doc.Runs[0].Results) != 1 {
+		t.Errorf("unexpected SARIF document: %s", data)
+	}
+}
+
+func TestScanPRCommand_FormatFlags(t *testing.T) {
+	configPath := "config.yaml"
+	cmd := NewScanPRCommand(&configPath, new(bool))
+
+	for _, name := range []string{"json", "format", "output"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("%s flag not found", name)
+		}
+	}
+}
+
+func TestRunScanPR_InvalidFormat(t *testing.T) {
+	err := runScanPR("unused", "test/repo", "42", scanOptions{format: "xml"})
+	if err == nil || !strings.Contains(err.Error(), "--format") {
+		t.Errorf("expected --format error, got %v", err)
+	}
+}
+
+func TestScanSinglePR_AutoClose(t *testing.T) {
+	calls := map[string]int{}
+	gh, bl := countingClients(calls)
+	pr := singlePRClient(t)
+	gh.GetPullRequestFn, gh.GetUserFn = pr.GetPullRequestFn, pr.GetUserFn
+	ctx := &ActionContext{cfg: scanPRTestConfig(), ghClient: gh, blManager: bl, out: &bytes.Buffer{}}
+
+	if _, err := scanSinglePR(ctx, "test", "repo", 42, scanOptions{autoClose: true, yes: true}); err != nil {
+		t.Fatalf("scanSinglePR failed: %v", err)
+	}
+	if calls["ClosePullRequest"] != 1 {
+		t.Errorf("expected the spam PR to be closed once, got %d", calls["ClosePullRequest"])
+	}
+	if calls["Block"] != 0 {
+		t.Errorf("expected no blocks without --auto-block, got %d", calls["Block"])
+	}
+}
+
+func TestRunScanPR_InvalidArgs(t *testing.T) {
+	if err := runScanPR("config.yaml", "test/repo", "abc", scanOptions{}); err == nil {
+		t.Error("expected error for a non-numeric PR number")
+	}
+	if err := runScanPR("config.yaml", "repo", "1", scanOptions{}); err == nil {
+		t.Error("expected error for a repository without an owner")
+	}
+	if err := runScanPR("config.yaml", "test/repo", "1", scanOptions{githubBlock: true}); err == nil {
+		t.Error("expected error for --github-block without --auto-block")
+	}
+}
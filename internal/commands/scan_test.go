@@ -15,7 +15,24 @@
 package commands
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/mocks"
+	"github.com/prguard/prguard/internal/notify"
+	"github.com/prguard/prguard/internal/scanner"
 )
 
 func TestParseRepo(t *testing.T) {
@@ -116,7 +133,7 @@ func TestParseRepo(t *testing.T) {
 
 func TestScanCommand_Flags(t *testing.T) {
 	configPath := "config.yaml"
-	cmd := NewScanCommand(&configPath)
+	cmd := NewScanCommand(&configPath, new(bool))
 
 	if cmd.Use != "scan <owner>/<repo>" {
 		t.Errorf("unexpected Use: %s", cmd.Use)
@@ -137,7 +154,7 @@ func TestScanCommand_Flags(t *testing.T) {
 
 func TestScanAllCommand_Flags(t *testing.T) {
 	configPath := "config.yaml"
-	cmd := NewScanAllCommand(&configPath)
+	cmd := NewScanAllCommand(&configPath, new(bool))
 
 	if cmd.Use != "scan-all" {
 		t.Errorf("unexpected Use: %s", cmd.Use)
@@ -150,6 +167,241 @@ func TestScanAllCommand_Flags(t *testing.T) {
 	}
 }
 
+func TestScanCommand_JSONFlags(t *testing.T) {
+	configPath := "config.yaml"
+	cmd := NewScanCommand(&configPath, new(bool))
+
+	if cmd.Flags().Lookup("json") == nil {
+		t.Error("json flag not found")
+	}
+}
+
+func TestConfirmAction_AssumeYes(t *testing.T) {
+	var out bytes.Buffer
+	flags := &ActionFlags{autoClose: true, autoBlock: true, skipConfirm: true}
+	plan := &actionPlan{
+		close: make([]*scanner.ScanResult, 2),
+		block: map[string]spamUserInfo{"spammer": {}},
+	}
+
+	if !confirmAction(&out, noStdin(t), plan, flags) {
+		t.Fatal("expected confirmation to be accepted with --yes")
+	}
+	if !strings.Contains(out.String(), "Close 2 spam PRs") {
+		t.Errorf("expected action summary, got %q", out.String())
+	}
+
+	flags.skipConfirm = false
+	if confirmAction(&out, bufio.NewReader(strings.NewReader("n\n")), plan, flags) {
+		t.Error("expected confirmation to be declined")
+	}
+}
+
+func TestScanCommands_DryRunFlag(t *testing.T) {
+	configPath := "config.yaml"
+	if NewScanCommand(&configPath, new(bool)).Flags().Lookup("dry-run") == nil {
+		t.Error("scan dry-run flag not found")
+	}
+	if NewScanAllCommand(&configPath, new(bool)).Flags().Lookup("dry-run") == nil {
+		t.Error("scan-all dry-run flag not found")
+	}
+}
+
+func TestScanRepository_DryRunRecordsAndNotifiesNothing(t *testing.T) {
+	_, db := setupTestConfig(t)
+
+	var hooks int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&hooks, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{Notifications: config.NotificationsConfig{WebhookURL: server.URL, SlackWebhookURL: server.URL}}
+	cfg.Filters.SpamPhrases = []string{"airdrop"}
+	cfg.SetDefaults()
+	ghClient := &mocks.MockGitHubClient{
+		ListPullRequestNumbersFn: func(_, _ string) ([]int, error) { return []int{1}, nil },
+		GetPullRequestFn: func(_, _ string, number int) (*github.PullRequest, error) {
+			return &github.PullRequest{Number: number, Title: "Claim your airdrop", Author: "spammer", FilesCount: 1, Files: []string{"README.md"}, Additions: 1}, nil
+		},
+	}
+
+	var out bytes.Buffer
+	results, err := scanRepository(&out, cfg, ghClient, &mocks.MockBlocklistManager{}, db, "org/repo", time.Time{}, scanOptions{format: scanFormatText, dryRun: true})
+	if err != nil {
+		t.Fatalf("scanRepository failed: %v", err)
+	}
+	if len(results.Spam) != 1 {
+		t.Fatalf("expected the PR to be detected as spam, got %+v", results)
+	}
+	if hooks != 0 {
+		t.Errorf("expected no notifications in a dry run, got %d", hooks)
+	}
+	history, err := db.ListScanHistory("org/repo", 10)
+	if err != nil {
+		t.Fatalf("ListScanHistory failed: %v", err)
+	}
+	if len(history) != 0 {
+		t.Errorf("expected no scan history in a dry run, got %d rows", len(history))
+	}
+}
+
+func TestScanCommands_SinceFlag(t *testing.T) {
+	configPath := "config.yaml"
+	if NewScanCommand(&configPath, new(bool)).Flags().Lookup("since") == nil {
+		t.Error("scan since flag not found")
+	}
+	if NewScanAllCommand(&configPath, new(bool)).Flags().Lookup("since") == nil {
+		t.Error("scan-all since flag not found")
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "", want: time.Time{}},
+		{value: "7d", want: now.Add(-7 * 24 * time.Hour)},
+		{value: "48h", want: now.Add(-48 * time.Hour)},
+		{value: "2025-01-31", want: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{value: "2025-02-01T15:04:05Z", want: time.Date(2025, 2, 1, 15, 4, 5, 0, time.UTC)},
+		{value: "-3d", wantErr: true},
+		{value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseSince(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSince(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteScanJSON_RoundTrip(t *testing.T) {
+	results := &scanner.ScanResults{
+		Total: 4,
+		Spam: []*scanner.ScanResult{
+			{
+				PR:              &github.PullRequest{Number: 1, Title: "Update README", Author: "spammer", HTMLURL: "https://github.com/o/r/pull/1"},
+				IsSpam:          true,
+				Reasons:         []string{"Single-file README-only edit"},
+				Severity:        "high",
+				RecommendAction: "Block user and close PR",
+			},
+		},
+		Uncertain: []*scanner.ScanResult{
+			{
+				PR:              &github.PullRequest{Number: 2, Title: "Typo", Author: "newbie"},
+				IsUncertain:     true,
+				Reasons:         []string{"Minimal changes (below threshold)"},
+				Severity:        "low",
+				RecommendAction: "Manual review recommended",
+			},
+		},
+		Clean: []*scanner.ScanResult{
+			{
+				PR:              &github.PullRequest{Number: 3, Title: "Add feature", Author: "contributor"},
+				Reasons:         []string{},
+				Severity:        "low",
+				RecommendAction: "No action needed",
+			},
+		},
+		Errors: []*scanner.ScanError{
+			{Number: 4, Err: errors.New("not found")},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeScanJSON(&buf, scanner.NewReport("owner", "repo", results)); err != nil {
+		t.Fatalf("writeScanJSON failed: %v", err)
+	}
+
+	var report scanner.Report
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v\n%s", err, buf.String())
+	}
+
+	if report.Repository != "owner/repo" {
+		t.Errorf("Expected repository 'owner/repo', got '%s'", report.Repository)
+	}
+	if report.Total != 4 {
+		t.Errorf("Expected total 4, got %d", report.Total)
+	}
+	if len(report.PullRequests) != 3 {
+		t.Fatalf("Expected 3 PRs, got %d", len(report.PullRequests))
+	}
+	if report.SpamCount != 1 || report.UncertainCount != 1 || report.CleanCount != 1 {
+		t.Errorf("Unexpected counts: spam=%d uncertain=%d clean=%d", report.SpamCount, report.UncertainCount, report.CleanCount)
+	}
+
+	spam := report.PullRequests[0]
+	if spam.Number != 1 || spam.Author != "spammer" || spam.Classification != scanner.ClassificationSpam {
+		t.Errorf("Unexpected spam PR: %+v", spam)
+	}
+	if spam.Severity != "high" || len(spam.Reasons) != 1 {
+		t.Errorf("Unexpected spam PR details: %+v", spam)
+	}
+	if report.PullRequests[1].Classification != scanner.ClassificationUncertain {
+		t.Errorf("Expected second PR to be uncertain, got %s", report.PullRequests[1].Classification)
+	}
+	if len(report.Errors) != 1 || report.Errors[0].Number != 4 {
+		t.Errorf("Unexpected errors: %+v", report.Errors)
+	}
+}
+
+func TestNotifySpam(t *testing.T) {
+	results := &scanner.ScanResults{
+		Spam: []*scanner.ScanResult{
+			{PR: &github.PullRequest{Number: 3, Author: "spammer"}, IsSpam: true},
+		},
+	}
+
+	var delivered *notify.SpamEvent
+	notifier := &mocks.MockNotifier{
+		NotifySpamFn: func(event *notify.SpamEvent) error {
+			delivered = event
+			return nil
+		},
+	}
+
+	var warnings bytes.Buffer
+	notifySpam(notifier, "org", "repo", results, &warnings)
+	if delivered == nil || delivered.Repository != "org/repo" || len(delivered.PullRequests) != 1 {
+		t.Fatalf("Unexpected notification: %+v", delivered)
+	}
+	if warnings.Len() != 0 {
+		t.Errorf("Unexpected warning: %s", warnings.String())
+	}
+
+	// Delivery failures warn but do not fail
+	notifier.NotifySpamFn = func(*notify.SpamEvent) error { return errors.New("connection refused") }
+	notifySpam(notifier, "org", "repo", results, &warnings)
+	if !bytes.Contains(warnings.Bytes(), []byte("connection refused")) {
+		t.Errorf("Expected delivery warning, got %q", warnings.String())
+	}
+
+	// Nothing is sent without spam
+	delivered = nil
+	notifier.NotifySpamFn = func(event *notify.SpamEvent) error {
+		delivered = event
+		return nil
+	}
+	notifySpam(notifier, "org", "repo", &scanner.ScanResults{}, &warnings)
+	if delivered != nil {
+		t.Error("Expected no notification without spam")
+	}
+}
+
 // NOTE: Full integration tests for scan commands would require:
 // - Mocking the GitHub client to return fake PR/user data
 // - Mocking the scanner to return fake scan results
@@ -163,3 +415,198 @@ func TestScanAllCommand_Flags(t *testing.T) {
 // 1. Creating interfaces: GitHubClient, Scanner
 // 2. Passing implementations via dependency injection
 // 3. Using test doubles in tests
+
+func TestScanOptions_ResolveFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    scanOptions
+		want    string
+		wantErr bool
+	}{
+		{name: "default", opts: scanOptions{}, want: scanFormatText},
+		{name: "json flag", opts: scanOptions{jsonOutput: true, format: scanFormatText}, want: scanFormatJSON},
+		{name: "sarif", opts: scanOptions{format: scanFormatSARIF}, want: scanFormatSARIF},
+		{name: "sarif to file", opts: scanOptions{format: scanFormatSARIF, outputPath: "scan.sarif"}, want: scanFormatSARIF},
+		{name: "json flag with sarif", opts: scanOptions{jsonOutput: true, format: scanFormatSARIF}, wantErr: true},
+		{name: "unknown format", opts: scanOptions{format: "xml"}, wantErr: true},
+		{name: "output with text", opts: scanOptions{format: scanFormatText, outputPath: "scan.txt"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.resolveFormat()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.opts.format != tt.want {
+				t.Errorf("Expected format %q, got %q", tt.want, tt.opts.format)
+			}
+		})
+	}
+}
+
+func TestWriteScanOutput_SARIFFile(t *testing.T) {
+	results := &scanner.ScanResults{
+		Total: 1,
+		Spam: []*scanner.ScanResult{
+			{
+				PR:       &github.PullRequest{Number: 1, Title: "Update README", Author: "spammer", HTMLURL: "https://github.com/owner/repo/pull/1"},
+				IsSpam:   true,
+				Reasons:  []string{"Contains spam phrases"},
+				Severity: "high",
+			},
+		},
+	}
+	path := filepath.Join(t.TempDir(), "scan.sarif")
+	opts := scanOptions{format: scanFormatSARIF, outputPath: path}
+
+	var out bytes.Buffer
+	if err := writeScanOutput(&out, opts, scanner.NewReport("owner", "repo", results)); err != nil {
+		t.Fatalf("writeScanOutput failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Wrote sarif scan results to "+path) {
+		t.Errorf("Expected confirmation on stdout, got %q", out.String())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	var doc struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Output file is not valid JSON: %v", err)
+	}
+	if doc.Version != "2.1.0" || len(doc.Runs) != 1 || len(doc.Runs[0].Results) != 1 {
+		t.Errorf("Unexpected SARIF document: %s", data)
+	}
+}
+
+func TestScanCommand_FormatFlags(t *testing.T) {
+	configPath := "config.yaml"
+	cmd := NewScanCommand(&configPath, new(bool))
+
+	for _, name := range []string{"format", "output"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("%s flag not found", name)
+		}
+	}
+}
+
+func TestCheckFailOn(t *testing.T) {
+	spam := &scanner.ScanResults{Spam: []*scanner.ScanResult{{}}, Uncertain: []*scanner.ScanResult{{}}}
+	uncertain := &scanner.ScanResults{Uncertain: []*scanner.ScanResult{{}, {}}}
+	clean := &scanner.ScanResults{Clean: []*scanner.ScanResult{{}}}
+
+	tests := []struct {
+		name     string
+		failOn   string
+		results  *scanner.ScanResults
+		wantCode int // 0 means no error
+	}{
+		{name: "none with spam", failOn: failOnNone, results: spam},
+		{name: "unset with spam", failOn: "", results: spam},
+		{name: "spam with spam", failOn: failOnSpam, results: spam, wantCode: exitSpamFound},
+		{name: "spam with uncertain only", failOn: failOnSpam, results: uncertain},
+		{name: "uncertain with spam", failOn: failOnUncertain, results: spam, wantCode: exitSpamFound},
+		{name: "uncertain with uncertain", failOn: failOnUncertain, results: uncertain, wantCode: exitUncertainFound},
+		{name: "uncertain with clean", failOn: failOnUncertain, results: clean},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkFailOn(tt.failOn, tt.results)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) {
+				t.Fatalf("expected *ExitError, got %v", err)
+			}
+			if exitErr.Code != tt.wantCode {
+				t.Errorf("expected exit code %d, got %d", tt.wantCode, exitErr.Code)
+			}
+		})
+	}
+
+	err := checkFailOn(failOnUncertain, uncertain)
+	if err == nil || err.Error() != "2 PRs need manual review (--fail-on uncertain)" {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestValidateFailOn(t *testing.T) {
+	for _, value := range []string{"", failOnNone, failOnSpam, failOnUncertain} {
+		if err := validateFailOn(value); err != nil {
+			t.Errorf("validateFailOn(%q) failed: %v", value, err)
+		}
+	}
+	if err := validateFailOn("clean"); err == nil {
+		t.Error("expected an error for an unknown --fail-on value")
+	}
+
+	configPath := "config.yaml"
+	if NewScanCommand(&configPath, new(bool)).Flags().Lookup("fail-on") == nil {
+		t.Error("fail-on flag not found")
+	}
+}
+
+func TestScanRepository_Verbose(t *testing.T) {
+	_, db := setupTestConfig(t)
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+
+	prs := map[int]*github.PullRequest{
+		1: {Number: 1, Title: "Fix parser crash", Author: "regular", FilesCount: 3, Files: []string{"parser.go", "parser_test.go", "CHANGELOG.md"}, Additions: 40, Deletions: 5},
+		2: {Number: 2, Title: "Typo", Author: "regular", FilesCount: 1, Files: []string{"docs/guide.md"}, Additions: 1, Deletions: 2},
+	}
+	ghClient := &mocks.MockGitHubClient{
+		ListPullRequestNumbersFn: func(_, _ string) ([]int, error) { return []int{1, 2}, nil },
+		GetPullRequestFn: func(_, _ string, number int) (*github.PullRequest, error) {
+			return prs[number], nil
+		},
+		GetUserFn: func(username string) (*github.User, error) {
+			return &github.User{Login: username, CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}, nil
+		},
+	}
+
+	scan := func(verbose bool) string {
+		var out bytes.Buffer
+		if _, err := scanRepository(&out, cfg, ghClient, &mocks.MockBlocklistManager{}, db, "org/repo", time.Time{}, scanOptions{format: scanFormatText, verbose: verbose}); err != nil {
+			t.Fatalf("scanRepository failed: %v", err)
+		}
+		return out.String()
+	}
+
+	quiet := scan(false)
+	if strings.Contains(quiet, "Fix parser crash") || strings.Contains(quiet, "Checks passed") {
+		t.Errorf("expected clean PRs and checks to be omitted without --verbose:\n%s", quiet)
+	}
+
+	verbose := scan(true)
+	for _, want := range []string{
+		"=== CLEAN ===",
+		"PR #1: Fix parser crash",
+		"Recommended action: No action needed",
+		"Checks passed: sensitive_files, new_account, minimal_changes, no_net_change",
+	} {
+		if !strings.Contains(verbose, want) {
+			t.Errorf("expected %q in verbose output:\n%s", want, verbose)
+		}
+	}
+	// The uncertain PR lists what it passed, without the check that flagged it
+	_, uncertain, _ := strings.Cut(verbose, "PR #2: Typo")
+	uncertain, _, _ = strings.Cut(uncertain, "\n\n")
+	if !strings.Contains(uncertain, "Checks passed: sensitive_files, new_account, no_net_change") {
+		t.Errorf("expected the uncertain PR's passed checks to skip minimal_changes:\n%s", uncertain)
+	}
+}
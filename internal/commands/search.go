@@ -0,0 +1,76 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/prguard/prguard/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// NewSearchCommand creates the search command
+func NewSearchCommand(configPath *string) *cobra.Command {
+	var field, severity string
+
+	cmd := &cobra.Command{
+		Use:   "search <term>",
+		Short: "Search the blocklist",
+		Long: `Finds blocklist entries whose username, reason, or evidence URL contain the term.
+Matching is case-insensitive.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runSearch(*configPath, args[0], field, severity)
+		},
+	}
+
+	cmd.Flags().StringVarP(&field, "field", "f", "", "Restrict the match to one field (username/reason/evidence)")
+	cmd.Flags().StringVarP(&severity, "severity", "s", "", "Only show entries with this severity (low/medium/high)")
+	_ = cmd.RegisterFlagCompletionFunc("severity", completeSeverity)
+
+	return cmd
+}
+
+func runSearch(configPath, term, field, severity string) error {
+	switch field {
+	case "", "username", "reason", "evidence":
+	default:
+		return fmt.Errorf("invalid field, must be username/reason/evidence")
+	}
+	if severity != "" && severity != models.SeverityLow && severity != models.SeverityMedium && severity != models.SeverityHigh {
+		return fmt.Errorf("invalid severity, must be low/medium/high")
+	}
+
+	cfg, _, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	entries, err := blManager.Search(term, field, severity)
+	if err != nil {
+		return fmt.Errorf("failed to search blocklist: %w", err)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No blocklist entries match %q\n", term)
+		return nil
+	}
+
+	fmt.Printf("Found %d matching entries:\n\n", len(entries))
+	printEntries(entries, 1, newTimeFormatter(cfg.Display))
+
+	return nil
+}
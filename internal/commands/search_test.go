@@ -0,0 +1,67 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+func TestSearchCommand_Flags(t *testing.T) {
+	configPath := "config.yaml"
+	cmd := NewSearchCommand(&configPath)
+
+	if cmd.Flags().Lookup("field") == nil {
+		t.Error("field flag not found")
+	}
+	if cmd.Flags().Lookup("severity") == nil {
+		t.Error("severity flag not found")
+	}
+
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error with no args")
+	}
+}
+
+func TestSearchCommand_WithEntries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+	manager := blocklist.NewManager(db)
+	if _, err := manager.Block(blocklist.BlockRequest{Username: "spammer1", Reason: "README spam", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "testowner", Severity: models.SeverityHigh, Source: models.SourceManual}); err != nil {
+		t.Fatalf("failed to add test user: %v", err)
+	}
+
+	if err := runSearch(configPath, "SPAM", "", ""); err != nil {
+		t.Errorf("runSearch failed: %v", err)
+	}
+	if err := runSearch(configPath, "nomatch", "username", models.SeverityLow); err != nil {
+		t.Errorf("runSearch with no matches failed: %v", err)
+	}
+}
+
+func TestSearchCommand_InvalidFlags(t *testing.T) {
+	if err := runSearch("config.yaml", "spam", "bogus", ""); err == nil {
+		t.Error("expected error with invalid field")
+	}
+	if err := runSearch("config.yaml", "spam", "", "critical"); err == nil {
+		t.Error("expected error with invalid severity")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/metrics"
+	"github.com/prguard/prguard/internal/scanner"
+
+	"github.com/spf13/cobra"
+)
+
+// shutdownTimeout bounds how long the metrics server waits for in-flight scrapes
+const shutdownTimeout = 5 * time.Second
+
+// NewServeCommand creates the serve command
+func NewServeCommand(configPath *string) *cobra.Command {
+	var metricsAddr string
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Periodically scan configured repositories and expose Prometheus metrics",
+		Long: `Runs as a long-lived process that scans every configured repository on an
+interval and serves Prometheus counters at /metrics:
+
+  prguard_prs_scanned_total
+  prguard_spam_detected_total
+  prguard_github_api_errors_total
+
+Scans only report findings; no automated actions are taken. Stop with Ctrl-C or SIGTERM.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runServe(*configPath, metricsAddr, interval)
+		},
+	}
+
+	cmd.Flags().StringVar(&metricsAddr, "metrics-addr", ":9090", "Address to serve /metrics on")
+	cmd.Flags().DurationVar(&interval, "interval", 15*time.Minute, "Time between scans")
+
+	return cmd
+}
+
+func runServe(configPath, metricsAddr string, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be greater than 0")
+	}
+
+	cfg, ghClient, _, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	if len(cfg.Repositories) == 0 {
+		return fmt.Errorf("no repositories configured. Add repositories to your config.yaml file")
+	}
+
+	scan, err := scanner.NewScannerE(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	m := metrics.New()
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	server := &http.Server{
+		Addr:              metricsAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Scanning stops when the server fails as well as on a signal
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	scansDone := make(chan struct{})
+	go func() {
+		defer close(scansDone)
+		scanLoop(ctx, os.Stdout, cfg, scan, ghClient, interval, m)
+	}()
+
+	fmt.Printf("Serving metrics on %s/metrics, scanning every %s\n", metricsAddr, interval)
+	err = serveUntilDone(ctx, server, shutdownTimeout)
+	cancel()
+	<-scansDone
+	return err
+}
+
+// scanLoop runs a scan cycle immediately and then every interval until ctx is
+// done, bounding each cycle by github.timeout when set
+func scanLoop(ctx context.Context, w io.Writer, cfg *config.Config, scan scanner.PRScanner, ghClient github.GitHubClient, interval time.Duration, m scanObserver) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		func() {
+			ctx := ctx
+			if cfg.GitHub.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, cfg.GitHub.Timeout)
+				defer cancel()
+			}
+			scanCycle(w, scan, ghClient.WithContext(ctx), cfg.Repositories, m)
+		}()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scanObserver records the outcome of each repository scan
+type scanObserver interface {
+	ObserveScan(repository string, results *scanner.ScanResults)
+	ObserveError(repository string)
+}
+
+// scanCycle scans each repository once and records the results in m
+func scanCycle(w io.Writer, scan scanner.PRScanner, ghClient github.GitHubClient, repos []config.Repository, m scanObserver) {
+	for _, repo := range repos {
+		results, err := scan.ScanRepository(ghClient, repo.Owner, repo.Name)
+		if err != nil {
+			m.ObserveError(repo.FullName())
+			fmt.Fprintf(w, "Error scanning %s: %v\n", repo.FullName(), err)
+			continue
+		}
+
+		m.ObserveScan(repo.FullName(), results)
+		fmt.Fprintf(w, "%s: %d scanned, %d spam, %d uncertain\n", repo.FullName(), results.Total, len(results.Spam), len(results.Uncertain))
+	}
+}
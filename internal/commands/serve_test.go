@@ -0,0 +1,120 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/mocks"
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+// recordingObserver counts the scans and errors reported for each repository
+type recordingObserver struct {
+	scanned map[string]int
+	spam    map[string]int
+	errors  map[string]int
+}
+
+func newRecordingObserver() *recordingObserver {
+	return &recordingObserver{scanned: map[string]int{}, spam: map[string]int{}, errors: map[string]int{}}
+}
+
+func (o *recordingObserver) ObserveScan(repository string, results *scanner.ScanResults) {
+	o.scanned[repository] += results.Total
+	o.spam[repository] += len(results.Spam)
+}
+
+func (o *recordingObserver) ObserveError(repository string) {
+	o.errors[repository]++
+}
+
+func TestScanCycle_UpdatesMetrics(t *testing.T) {
+	scan := &mocks.MockScanner{
+		ScanRepositoryFn: func(_ github.GitHubClient, _, repo string) (*scanner.ScanResults, error) {
+			if repo == "broken" {
+				return nil, errors.New("failed to list pull requests")
+			}
+			return &scanner.ScanResults{
+				Total: 4,
+				Spam:  []*scanner.ScanResult{{}, {}},
+			}, nil
+		},
+	}
+	repos := []config.Repository{
+		{Owner: "org", Name: "app"},
+		{Owner: "org", Name: "broken"},
+	}
+	m := newRecordingObserver()
+
+	var out bytes.Buffer
+	scanCycle(&out, scan, &mocks.MockGitHubClient{}, repos, m)
+	scanCycle(&out, scan, &mocks.MockGitHubClient{}, repos, m)
+
+	if m.scanned["org/app"] != 8 || m.spam["org/app"] != 4 {
+		t.Errorf("expected 8 scanned and 4 spam for org/app, got %d and %d", m.scanned["org/app"], m.spam["org/app"])
+	}
+	if m.errors["org/broken"] != 2 {
+		t.Errorf("expected 2 errors for org/broken, got %d", m.errors["org/broken"])
+	}
+
+	if !strings.Contains(out.String(), "Error scanning org/broken") {
+		t.Errorf("expected scan error in output, got:\n%s", out.String())
+	}
+}
+
+func TestRunServe_InvalidInterval(t *testing.T) {
+	if err := runServe("unused", ":0", 0); err == nil {
+		t.Error("expected error for zero interval")
+	}
+}
+
+func TestScanLoop_BoundsEachCycleByTimeout(t *testing.T) {
+	cfg := &config.Config{
+		GitHub:       config.GitHubConfig{Timeout: time.Minute},
+		Repositories: []config.Repository{{Owner: "org", Name: "app"}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var deadlines []bool
+	ghClient := &mocks.MockGitHubClient{}
+	ghClient.WithContextFn = func(cycleCtx context.Context) github.GitHubClient {
+		_, ok := cycleCtx.Deadline()
+		deadlines = append(deadlines, ok)
+		// Stop after the first cycle
+		cancel()
+		return ghClient
+	}
+	scan := &mocks.MockScanner{
+		ScanRepositoryFn: func(_ github.GitHubClient, _, _ string) (*scanner.ScanResults, error) {
+			return &scanner.ScanResults{}, nil
+		},
+	}
+
+	var out bytes.Buffer
+	scanLoop(ctx, &out, cfg, scan, ghClient, time.Hour, newRecordingObserver())
+
+	if len(deadlines) != 1 || !deadlines[0] {
+		t.Errorf("expected one scan cycle with a deadline, got %v", deadlines)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// NewSyncCommand creates the sync command
+func NewSyncCommand(configPath *string) *cobra.Command {
+	var confirm bool
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync blocklist sources configured for auto-sync",
+		Long: `Fetches every blocklist source in the config with auto_sync enabled.
+
+Entries from trusted sources are imported directly. Entries from untrusted
+sources are staged and listed; rerun with --confirm to merge them. If an
+untrusted source changed since it was listed, --confirm lists the new entries
+instead of merging them.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runSync(*configPath, confirm)
+		},
+	}
+
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Merge entries staged from untrusted sources")
+
+	return cmd
+}
+
+func runSync(configPath string, confirm bool) error {
+	cfg, _, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	return executeSync(os.Stdout, blManager, cfg.Blocklist.Sources, confirm)
+}
+
+// printStaged lists entries staged from an untrusted source
+func printStaged(w io.Writer, entries []*models.BlocklistEntry) {
+	for _, entry := range entries {
+		fmt.Fprintf(w, "      %s (%s): %s\n", entry.Username, entry.Severity, entry.Reason)
+	}
+}
+
+// executeSync syncs the auto-sync sources and reports per-source counts. It
+// returns an error if any source failed so scheduled runs exit non-zero. With
+// confirm, staged entries are merged only if the previous sync listed the same
+// set, so a source that changed after review is listed again instead.
+func executeSync(w io.Writer, blManager blocklist.BlocklistManager, sources []config.BlocklistSource, confirm bool) error {
+	results := blManager.SyncSources(sources)
+	if len(results) == 0 {
+		fmt.Fprintln(w, "No blocklist sources have auto_sync enabled")
+		return nil
+	}
+
+	fmt.Fprintf(w, "Syncing %d blocklist %s...\n", len(results), pluralize("source", "sources", len(results)))
+
+	failed := 0
+	pending := 0
+	for _, result := range results {
+		name := result.Source.Name
+		if name == "" {
+			name = result.Source.URL
+		}
+
+		switch {
+		case result.Err != nil:
+			failed++
+			fmt.Fprintf(w, "  ✗ %s: %v\n", name, result.Err)
+		case result.NotModified:
+			fmt.Fprintf(w, "  ✓ %s: no changes\n", name)
+		case result.Source.Trusted:
+			fmt.Fprintf(w, "  ✓ %s: imported %d %s\n", name, result.Imported, pluralize("entry", "entries", result.Imported))
+		case len(result.Staged) == 0:
+			fmt.Fprintf(w, "  ✓ %s (untrusted): no new entries\n", name)
+		case confirm && !result.Reviewed:
+			pending += len(result.Staged)
+			fmt.Fprintf(w, "  ⚠ %s (untrusted): changed since the last sync, %d %s staged and not merged\n", name, len(result.Staged), pluralize("entry", "entries", len(result.Staged)))
+			printStaged(w, result.Staged)
+		case confirm:
+			merged, err := blManager.MergeStaged(result.Staged)
+			if err != nil {
+				failed++
+				fmt.Fprintf(w, "  ✗ %s (untrusted): merge failed: %v\n", name, err)
+				continue
+			}
+			fmt.Fprintf(w, "  ✓ %s (untrusted): merged %d %s\n", name, merged, pluralize("entry", "entries", merged))
+			printStaged(w, result.Staged)
+		default:
+			pending += len(result.Staged)
+			fmt.Fprintf(w, "  ⚠ %s (untrusted): %d %s staged\n", name, len(result.Staged), pluralize("entry", "entries", len(result.Staged)))
+			printStaged(w, result.Staged)
+		}
+	}
+
+	if pending > 0 {
+		fmt.Fprintf(w, "\nReview the staged entries above, then run 'prguard sync --confirm' to merge them.\n")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d sources failed to sync", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/mocks"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+func syncTestManager(merged *int, reviewed bool) *mocks.MockBlocklistManager {
+	staged := []*models.BlocklistEntry{
+		models.NewBlocklistEntry("partner-spammer", "spam", "", "partner", models.SeverityMedium, models.SourceManual),
+	}
+	return &mocks.MockBlocklistManager{
+		SyncSourcesFn: func(sources []config.BlocklistSource) []*blocklist.SyncResult {
+			return []*blocklist.SyncResult{
+				{Source: sources[0], Imported: 4},
+				{Source: sources[1], Staged: staged, Reviewed: reviewed},
+			}
+		},
+		MergeStagedFn: func(entries []*models.BlocklistEntry) (int, error) {
+			*merged += len(entries)
+			return len(entries), nil
+		},
+	}
+}
+
+var syncTestSources = []config.BlocklistSource{
+	{Name: "community", URL: "https://example.com/community.json", Trusted: true, AutoSync: true},
+	{Name: "partner", URL: "https://example.com/partner.json", AutoSync: true},
+}
+
+func TestExecuteSync_StagesUntrusted(t *testing.T) {
+	merged := 0
+	var out bytes.Buffer
+
+	if err := executeSync(&out, syncTestManager(&merged, false), syncTestSources, false); err != nil {
+		t.Fatalf("executeSync() error = %v", err)
+	}
+
+	output := out.String()
+	for _, want := range []string{"community: imported 4 entries", "partner (untrusted): 1 entry staged", "partner-spammer", "--confirm"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if merged != 0 {
+		t.Errorf("untrusted entries should not be merged without --confirm, merged %d", merged)
+	}
+}
+
+func TestExecuteSync_Confirm(t *testing.T) {
+	merged := 0
+	var out bytes.Buffer
+
+	if err := executeSync(&out, syncTestManager(&merged, true), syncTestSources, true); err != nil {
+		t.Fatalf("executeSync() error = %v", err)
+	}
+	if merged != 1 {
+		t.Errorf("expected 1 merged entry, got %d", merged)
+	}
+	for _, want := range []string{"partner (untrusted): merged 1 entry", "partner-spammer"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestExecuteSync_ConfirmRefusesChangedSource(t *testing.T) {
+	merged := 0
+	var out bytes.Buffer
+
+	if err := executeSync(&out, syncTestManager(&merged, false), syncTestSources, true); err != nil {
+		t.Fatalf("executeSync() error = %v", err)
+	}
+	if merged != 0 {
+		t.Errorf("entries changed since the last sync should not be merged, merged %d", merged)
+	}
+	for _, want := range []string{"partner (untrusted): changed since the last sync", "partner-spammer", "--confirm"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestExecuteSync_ReportsFailures(t *testing.T) {
+	blManager := &mocks.MockBlocklistManager{
+		SyncSourcesFn: func(sources []config.BlocklistSource) []*blocklist.SyncResult {
+			return []*blocklist.SyncResult{
+				{Source: sources[0], NotModified: true},
+				{Source: sources[1], Err: errors.New("HTTP error: 404 Not Found")},
+			}
+		},
+	}
+	var out bytes.Buffer
+
+	err := executeSync(&out, blManager, syncTestSources, false)
+	if err == nil || err.Error() != "1 of 2 sources failed to sync" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "community: no changes") {
+		t.Errorf("output missing not-modified result:\n%s", out.String())
+	}
+}
+
+func TestExecuteSync_NoSources(t *testing.T) {
+	var out bytes.Buffer
+	if err := executeSync(&out, &mocks.MockBlocklistManager{}, nil, false); err != nil {
+		t.Fatalf("executeSync() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "No blocklist sources") {
+		t.Errorf("unexpected output: %s", out.String())
+	}
+}
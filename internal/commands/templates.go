@@ -0,0 +1,54 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// defaultPRComment is posted when closing a spam PR without a usable comment template
+const defaultPRComment = "This PR has been automatically closed due to spam indicators."
+
+// templateData holds the variables available to action templates
+type templateData struct {
+	Author   string
+	PRNumber int
+	Reasons  string
+	Repo     string
+}
+
+// renderTemplate renders text with Go template syntax. Text without template
+// actions is returned as is; if the template cannot be parsed or executed an
+// error is returned and callers fall back to their default text, so a broken
+// template is never posted verbatim.
+func renderTemplate(name, text string, data templateData) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplate_Variables(t *testing.T) {
+	data := templateData{
+		Author:   "spammer",
+		PRNumber: 42,
+		Reasons:  "Only modifies README, Account is 3 days old",
+		Repo:     "org/repo",
+	}
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Hi @{{.Author}}", "Hi @spammer"},
+		{"Closing #{{.PRNumber}}", "Closing #42"},
+		{"Flagged: {{.Reasons}}", "Flagged: Only modifies README, Account is 3 days old"},
+		{"See {{.Repo}} guidelines", "See org/repo guidelines"},
+	}
+
+	for _, tt := range tests {
+		got, err := renderTemplate("comment_template", tt.text, data)
+		if err != nil {
+			t.Errorf("renderTemplate(%q) error = %v", tt.text, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("renderTemplate(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRenderTemplate_LiteralText(t *testing.T) {
+	text := "Closed as spam. 100% automated; {not a template}"
+	got, err := renderTemplate("comment_template", text, templateData{PRNumber: 1})
+	if err != nil {
+		t.Fatalf("renderTemplate() error = %v", err)
+	}
+	if got != text {
+		t.Errorf("renderTemplate() = %q, want literal %q", got, text)
+	}
+}
+
+func TestRenderTemplate_Malformed(t *testing.T) {
+	for _, text := range []string{
+		"Closing #{{.PRNumber", // parse error
+		"Hi {{.Username}}",     // unknown field
+		"{{if .Author}}no end", // unterminated action
+	} {
+		got, err := renderTemplate("comment_template", text, templateData{Author: "spammer"})
+		if err == nil {
+			t.Errorf("renderTemplate(%q) expected error", text)
+		} else if !strings.Contains(err.Error(), "comment_template") {
+			t.Errorf("renderTemplate(%q) error should name the template, got %v", text, err)
+		}
+		if got != "" {
+			t.Errorf("renderTemplate(%q) = %q, want no text on error", text, got)
+		}
+	}
+}
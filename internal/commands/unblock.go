@@ -15,48 +15,152 @@
 package commands
 
 import (
+	"bufio"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
 	"github.com/spf13/cobra"
 )
 
 // NewUnblockCommand creates the unblock command
-func NewUnblockCommand(configPath *string) *cobra.Command {
+func NewUnblockCommand(configPath *string, assumeYes *bool) *cobra.Command {
+	var githubUnblock bool
+	var id string
+
 	cmd := &cobra.Command{
 		Use:   "unblock <username>",
 		Short: "Remove a user from the blocklist",
-		Long:  `Removes all blocklist entries for a GitHub user`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Removes all blocklist entries for a GitHub user, or with --id a single
+entry such as a mistaken import, keeping the user's other entries.
+
+Optionally unblocks them via GitHub API using --github-unblock flag.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if id != "" {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		RunE: func(_ *cobra.Command, args []string) error {
-			return runUnblock(*configPath, args[0])
+			if id != "" {
+				return runUnblockByID(*configPath, id)
+			}
+			return runUnblock(*configPath, args[0], githubUnblock, *assumeYes)
 		},
 	}
+
+	cmd.Flags().BoolVar(&githubUnblock, "github-unblock", false, "Also unblock user via GitHub API (affects ALL repos in org/account)")
+	cmd.Flags().StringVar(&id, "id", "", "Remove only the entry with this ID instead of all of a user's entries")
+	cmd.MarkFlagsMutuallyExclusive("id", "github-unblock")
+
 	return cmd
 }
 
-func runUnblock(configPath, username string) error {
+func runUnblock(configPath, username string, githubUnblock, assumeYes bool) error {
+	cfg, ghClient, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	return executeUnblock(cfg, ghClient, blManager, bufio.NewReader(os.Stdin), username, githubUnblock, assumeYes)
+}
+
+func runUnblockByID(configPath, id string) error {
 	_, _, blManager, db, err := initClients(configPath)
 	if err != nil {
 		return err
 	}
 	defer db.Close() //nolint:errcheck
 
-	// Check if user is blocked
-	blocked, err := blManager.IsBlocked(username)
+	return executeUnblockByID(blManager, id)
+}
+
+// executeUnblockByID removes a single blocklist entry and reports whether the user is still blocked
+func executeUnblockByID(blManager blocklist.BlocklistManager, id string) error {
+	entry, err := blManager.RemoveByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to remove entry: %w", err)
+	}
+	fmt.Printf("✓ Removed entry %s for %s\n", entry.ID, entry.Username)
+
+	remaining, err := blManager.GetByUsername(entry.Username)
+	if err != nil {
+		return fmt.Errorf("failed to get remaining entries: %w", err)
+	}
+	if len(remaining) > 0 {
+		fmt.Printf("  %s still has %d blocklist %s\n", entry.Username, len(remaining), pluralize("entry", "entries", len(remaining)))
+	}
+	return nil
+}
+
+// executeUnblock removes a user from the local blocklist and optionally unblocks them on GitHub
+func executeUnblock(cfg *config.Config, ghClient github.GitHubClient, blManager blocklist.BlocklistManager, reader *bufio.Reader, username string, githubUnblock, assumeYes bool) error {
+	// Look up entries rather than the block status so expired entries, which
+	// still count as prior offenses, are removed too
+	entries, err := blManager.GetByUsername(username)
 	if err != nil {
-		return fmt.Errorf("failed to check block status: %w", err)
+		return fmt.Errorf("failed to get blocklist entries: %w", err)
 	}
 
-	if !blocked {
+	if len(entries) > 0 {
+		// Unblock the user
+		if err := blManager.Unblock(username); err != nil {
+			return fmt.Errorf("failed to unblock user: %w", err)
+		}
+		fmt.Printf("✓ User %s has been removed from the blocklist\n", username)
+	} else {
 		fmt.Printf("User %s is not in the blocklist\n", username)
+	}
+
+	if !githubUnblock {
 		return nil
 	}
 
-	// Unblock the user
-	if err := blManager.Unblock(username); err != nil {
-		return fmt.Errorf("failed to unblock user: %w", err)
+	// GitHub API unblocking
+	fmt.Println()
+	switch {
+	case cfg.GitHub.Org != "":
+		fmt.Printf("⚠️  This will unblock %s for ALL repositories in the '%s' organization.\n", username, cfg.GitHub.Org)
+		if !confirmPrompt(reader, assumeYes) {
+			fmt.Println("GitHub unblocking cancelled.")
+			return nil
+		}
+
+		if err := ghClient.UnblockUserOrg(cfg.GitHub.Org, username); err != nil {
+			return fmt.Errorf("failed to unblock user via GitHub API: %w", err)
+		}
+		fmt.Printf("✓ User %s unblocked at organization level via GitHub API\n", username)
+	case cfg.GitHub.User != "":
+		fmt.Printf("⚠️  This will unblock %s for ALL repositories owned by your personal account (%s).\n", username, cfg.GitHub.User)
+		if !confirmPrompt(reader, assumeYes) {
+			fmt.Println("GitHub unblocking cancelled.")
+			return nil
+		}
+
+		if err := ghClient.UnblockUserPersonal(username); err != nil {
+			return fmt.Errorf("failed to unblock user via GitHub API: %w", err)
+		}
+		fmt.Printf("✓ User %s unblocked at personal account level via GitHub API\n", username)
+	default:
+		return fmt.Errorf("cannot use --github-unblock: neither github.org nor github.user is configured")
 	}
 
-	fmt.Printf("✓ User %s has been removed from the blocklist\n", username)
 	return nil
 }
+
+// confirmPrompt asks "Continue? (y/N)" and reports whether the answer was yes.
+// With assumeYes the prompt is answered automatically without reading input.
+func confirmPrompt(reader *bufio.Reader, assumeYes bool) bool {
+	fmt.Print("Continue? (y/N): ")
+	if assumeYes {
+		fmt.Println("y (--yes)")
+		return true
+	}
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
+}
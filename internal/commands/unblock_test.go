@@ -15,12 +15,18 @@
 package commands
 
 import (
+	"bufio"
+	"errors"
+	"io"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/prguard/prguard/internal/blocklist"
 	"github.com/prguard/prguard/internal/config"
 	"github.com/prguard/prguard/internal/database"
+	"github.com/prguard/prguard/internal/mocks"
 	"github.com/prguard/prguard/pkg/models"
 )
 
@@ -59,7 +65,7 @@ func TestUnblockCommand_Success(t *testing.T) {
 
 	// Add a blocked user
 	manager := blocklist.NewManager(db)
-	_, err = manager.Block("testspammer", "spam", "https://github.com/test/repo/pull/1", "test-org", models.SeverityMedium, models.SourceManual)
+	_, err = manager.Block(blocklist.BlockRequest{Username: "testspammer", Reason: "spam", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "test-org", Severity: models.SeverityMedium, Source: models.SourceManual})
 	if err != nil {
 		t.Fatalf("failed to block user: %v", err)
 	}
@@ -74,7 +80,7 @@ func TestUnblockCommand_Success(t *testing.T) {
 	}
 
 	// Unblock user
-	err = runUnblock(configPath, "testspammer")
+	err = runUnblock(configPath, "testspammer", false, false)
 	if err != nil {
 		t.Errorf("runUnblock failed: %v", err)
 	}
@@ -123,7 +129,7 @@ func TestUnblockCommand_UserNotBlocked(t *testing.T) {
 	defer db.Close() //nolint:errcheck
 
 	// Try to unblock a user that isn't blocked - should succeed without error
-	err = runUnblock(configPath, "notblocked")
+	err = runUnblock(configPath, "notblocked", false, false)
 	if err != nil {
 		t.Errorf("runUnblock should succeed for non-blocked user: %v", err)
 	}
@@ -164,12 +170,12 @@ func TestUnblockCommand_MultipleEntries(t *testing.T) {
 
 	// Add multiple entries for the same user
 	manager := blocklist.NewManager(db)
-	_, err = manager.Block("multientry", "spam 1", "https://github.com/test/repo/pull/1", "test-org", models.SeverityLow, models.SourceManual)
+	_, err = manager.Block(blocklist.BlockRequest{Username: "multientry", Reason: "spam 1", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "test-org", Severity: models.SeverityLow, Source: models.SourceManual})
 	if err != nil {
 		t.Fatalf("failed to block user first time: %v", err)
 	}
 
-	_, err = manager.Block("multientry", "spam 2", "https://github.com/test/repo/pull/2", "test-org", models.SeverityHigh, models.SourceManual)
+	_, err = manager.Block(blocklist.BlockRequest{Username: "multientry", Reason: "spam 2", EvidenceURL: "https://github.com/test/repo/pull/2", BlockedBy: "test-org", Severity: models.SeverityHigh, Source: models.SourceManual})
 	if err != nil {
 		t.Fatalf("failed to block user second time: %v", err)
 	}
@@ -184,7 +190,7 @@ func TestUnblockCommand_MultipleEntries(t *testing.T) {
 	}
 
 	// Unblock should remove ALL entries for the user
-	err = runUnblock(configPath, "multientry")
+	err = runUnblock(configPath, "multientry", false, false)
 	if err != nil {
 		t.Errorf("runUnblock failed: %v", err)
 	}
@@ -207,10 +213,183 @@ func TestUnblockCommand_MultipleEntries(t *testing.T) {
 	}
 }
 
+func TestUnblockCommand_OnlyExpiredEntries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+
+	past := time.Now().Add(-time.Hour)
+	manager := blocklist.NewManager(db)
+	_, err := manager.Block(blocklist.BlockRequest{Username: "expired", Reason: "spam", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "test-org", Severity: models.SeverityLow, Source: models.SourceManual, ExpiresAt: &past})
+	if err != nil {
+		t.Fatalf("failed to block user: %v", err)
+	}
+
+	// Expired entries are not active blocks but still count as offenses, so
+	// unblock must remove them
+	if err := runUnblock(configPath, "expired", false, false); err != nil {
+		t.Fatalf("runUnblock failed: %v", err)
+	}
+
+	entries, err := manager.GetByUsername("expired")
+	if err != nil {
+		t.Fatalf("failed to get entries after unblock: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected 0 entries after unblock, got %d", len(entries))
+	}
+}
+
+func TestUnblockCommand_ByID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	configPath, db := setupTestConfig(t)
+	manager := blocklist.NewManager(db)
+
+	mistake, err := manager.Block(blocklist.BlockRequest{Username: "multientry", Reason: "imported by mistake", EvidenceURL: "https://github.com/test/repo/pull/1", BlockedBy: "test-org", Severity: models.SeverityLow, Source: models.SourceImported})
+	if err != nil {
+		t.Fatalf("failed to block user: %v", err)
+	}
+	legitimate, err := manager.Block(blocklist.BlockRequest{Username: "multientry", Reason: "spam", EvidenceURL: "https://github.com/test/repo/pull/2", BlockedBy: "test-org", Severity: models.SeverityHigh, Source: models.SourceManual})
+	if err != nil {
+		t.Fatalf("failed to block user: %v", err)
+	}
+
+	if err := runUnblockByID(configPath, mistake.Entry.ID); err != nil {
+		t.Fatalf("runUnblockByID failed: %v", err)
+	}
+
+	entries, err := manager.GetByUsername("multientry")
+	if err != nil {
+		t.Fatalf("failed to get entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != legitimate.Entry.ID {
+		t.Errorf("expected only the legitimate entry to remain, got %d entries", len(entries))
+	}
+
+	if err := runUnblockByID(configPath, "no-such-id"); !errors.Is(err, blocklist.ErrEntryNotFound) {
+		t.Errorf("expected ErrEntryNotFound for an unknown ID, got %v", err)
+	}
+}
+
 func TestUnblockCommand_MissingConfig(t *testing.T) {
 	configPath := "/nonexistent/config.yaml"
-	err := runUnblock(configPath, "testuser")
+	err := runUnblock(configPath, "testuser", false, false)
 	if err == nil {
 		t.Error("expected error with missing config")
 	}
 }
+
+func TestExecuteUnblock_GitHubUnblock(t *testing.T) {
+	tests := []struct {
+		name         string
+		github       config.GitHubConfig
+		input        string
+		githubFlag   bool
+		wantOrg      int
+		wantPersonal int
+	}{
+		{"org confirmed", config.GitHubConfig{Org: "test-org"}, "y\n", true, 1, 0},
+		{"personal confirmed", config.GitHubConfig{User: "testowner"}, "yes\n", true, 0, 1},
+		{"org declined", config.GitHubConfig{Org: "test-org"}, "n\n", true, 0, 0},
+		{"flag not set", config.GitHubConfig{Org: "test-org"}, "y\n", false, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var orgCalls, personalCalls, localCalls int
+			mockGH := &mocks.MockGitHubClient{
+				UnblockUserOrgFn: func(org, username string) error {
+					if org != "test-org" || username != "spammer" {
+						t.Errorf("unexpected org unblock %s/%s", org, username)
+					}
+					orgCalls++
+					return nil
+				},
+				UnblockUserPersonalFn: func(username string) error {
+					personalCalls++
+					return nil
+				},
+			}
+			mockBL := &mocks.MockBlocklistManager{
+				GetByUsernameFn: func(username string) ([]*models.BlocklistEntry, error) {
+					return []*models.BlocklistEntry{{Username: username}}, nil
+				},
+				UnblockFn: func(string) error {
+					localCalls++
+					return nil
+				},
+			}
+
+			cfg := &config.Config{GitHub: tt.github}
+			reader := bufio.NewReader(strings.NewReader(tt.input))
+			if err := executeUnblock(cfg, mockGH, mockBL, reader, "spammer", tt.githubFlag, false); err != nil {
+				t.Fatalf("executeUnblock failed: %v", err)
+			}
+
+			if localCalls != 1 {
+				t.Errorf("expected local unblock, got %d calls", localCalls)
+			}
+			if orgCalls != tt.wantOrg {
+				t.Errorf("expected %d org unblock calls, got %d", tt.wantOrg, orgCalls)
+			}
+			if personalCalls != tt.wantPersonal {
+				t.Errorf("expected %d personal unblock calls, got %d", tt.wantPersonal, personalCalls)
+			}
+		})
+	}
+}
+
+func TestExecuteUnblock_GitHubUnblockWithoutAccount(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("y\n"))
+	err := executeUnblock(&config.Config{}, &mocks.MockGitHubClient{}, &mocks.MockBlocklistManager{}, reader, "spammer", true, false)
+	if err == nil {
+		t.Error("expected error without github.org or github.user")
+	}
+}
+
+// failingReader fails the test if anything tries to read from it
+type failingReader struct {
+	t *testing.T
+}
+
+func (r failingReader) Read([]byte) (int, error) {
+	r.t.Error("stdin should not be read when --yes is set")
+	return 0, io.EOF
+}
+
+// noStdin returns a reader that must not be read from
+func noStdin(t *testing.T) *bufio.Reader {
+	return bufio.NewReader(failingReader{t: t})
+}
+
+func TestConfirmPrompt_AssumeYes(t *testing.T) {
+	if !confirmPrompt(noStdin(t), true) {
+		t.Error("expected prompt to be accepted with --yes")
+	}
+}
+
+func TestExecuteUnblock_AssumeYes(t *testing.T) {
+	var orgCalls int
+	mockGH := &mocks.MockGitHubClient{
+		UnblockUserOrgFn: func(string, string) error {
+			orgCalls++
+			return nil
+		},
+	}
+	mockBL := &mocks.MockBlocklistManager{GetByUsernameFn: func(username string) ([]*models.BlocklistEntry, error) {
+		return []*models.BlocklistEntry{{Username: username}}, nil
+	}}
+
+	cfg := &config.Config{GitHub: config.GitHubConfig{Org: "test-org"}}
+	if err := executeUnblock(cfg, mockGH, mockBL, noStdin(t), "spammer", true, true); err != nil {
+		t.Fatalf("executeUnblock failed: %v", err)
+	}
+	if orgCalls != 1 {
+		t.Errorf("expected 1 org unblock call, got %d", orgCalls)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/prguard/prguard/internal/database"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// NewUndoCommand creates the undo command
+func NewUndoCommand(configPath *string, assumeYes *bool) *cobra.Command {
+	var reopen bool
+
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Reverse the most recent batch of automated actions",
+		Long: `Removes the blocklist entries added by the most recent scan, scan-all, or review
+run that took automated actions.
+
+Closed PRs stay closed unless --reopen is given. Users blocked via the GitHub API
+are not unblocked; use 'prguard unblock --github-unblock' for that.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runUndo(*configPath, reopen, *assumeYes)
+		},
+	}
+
+	cmd.Flags().BoolVar(&reopen, "reopen", false, "Also reopen the PRs closed by the batch")
+
+	return cmd
+}
+
+func runUndo(configPath string, reopen, assumeYes bool) error {
+	_, ghClient, _, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	return executeUndo(db, ghClient, bufio.NewReader(os.Stdin), reopen, assumeYes)
+}
+
+// executeUndo reverses the most recent action batch after confirmation
+func executeUndo(db *database.DB, ghClient github.GitHubClient, reader *bufio.Reader, reopen, assumeYes bool) error {
+	batch, err := db.LatestActionBatch()
+	if err != nil {
+		return fmt.Errorf("failed to read action log: %w", err)
+	}
+	if len(batch) == 0 {
+		fmt.Println("Nothing to undo")
+		return nil
+	}
+
+	var blocks, closes []*models.ActionLogEntry
+	for _, action := range batch {
+		switch action.Action {
+		case models.ActionBlock:
+			blocks = append(blocks, action)
+		case models.ActionClosePR:
+			closes = append(closes, action)
+		}
+	}
+
+	fmt.Printf("Most recent action batch (%s):\n", batch[0].CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	for _, action := range blocks {
+		fmt.Printf("  - Remove %s from the blocklist\n", action.Username)
+	}
+	for _, action := range closes {
+		if reopen {
+			fmt.Printf("  - Reopen %s#%d\n", action.Repository, action.PRNumber)
+		} else {
+			fmt.Printf("  - Leave %s#%d closed (use --reopen to reopen)\n", action.Repository, action.PRNumber)
+		}
+	}
+	fmt.Println()
+
+	if !confirmPrompt(reader, assumeYes) {
+		fmt.Println("Undo cancelled.")
+		return nil
+	}
+
+	if reopen {
+		for _, action := range closes {
+			owner, repoName, err := parseRepo(action.Repository)
+			if err != nil {
+				fmt.Printf("  ⚠ %s#%d: %v\n", action.Repository, action.PRNumber, err)
+				continue
+			}
+			if err := ghClient.ReopenPullRequest(owner, repoName, action.PRNumber, ""); err != nil {
+				fmt.Printf("  ⚠ %s#%d: %v\n", action.Repository, action.PRNumber, err)
+				continue
+			}
+			fmt.Printf("  ✓ Reopened %s#%d\n", action.Repository, action.PRNumber)
+		}
+	}
+
+	removed, err := db.UndoActionBatch(batch[0].BatchID)
+	if err != nil {
+		return fmt.Errorf("failed to undo action batch: %w", err)
+	}
+	fmt.Printf("✓ Removed %d blocklist %s\n", removed, pluralize("entry", "entries", int(removed)))
+
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/database"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/mocks"
+	"github.com/prguard/prguard/internal/scanner"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+// runTestBatch blocks users and closes PRs through the action helpers as a
+// single recorded batch
+func runTestBatch(t *testing.T, db *database.DB, gh *mocks.MockGitHubClient, batchID string, usernames []string, prs []int) {
+	t.Helper()
+
+	ctx := &ActionContext{
+		cfg:       &config.Config{GitHub: config.GitHubConfig{User: "maintainer"}},
+		ghClient:  gh,
+		blManager: blocklist.NewManager(db),
+		out:       io.Discard,
+		actions:   db,
+		batchID:   batchID,
+	}
+	for _, username := range usernames {
+		if !blockUser(ctx, username, "spam", "", models.SeverityHigh, models.SourceAutoDetected, nil, false) {
+			t.Fatalf("failed to block %s", username)
+		}
+	}
+	for _, number := range prs {
+		if !closeSpamPR(ctx, "org", "repo", &scanner.ScanResult{PR: &github.PullRequest{Number: number}}) {
+			t.Fatalf("failed to close PR #%d", number)
+		}
+	}
+}
+
+func TestExecuteUndo_RemovesLatestBatch(t *testing.T) {
+	_, db := setupTestConfig(t)
+	gh := &mocks.MockGitHubClient{}
+
+	runTestBatch(t, db, gh, "earlier", []string{"old-spammer"}, nil)
+	runTestBatch(t, db, gh, "latest", []string{"spammer1", "spammer2", "spammer3"}, []int{4})
+
+	reopened := 0
+	gh.ReopenPullRequestFn = func(_, _ string, _ int, _ string) error {
+		reopened++
+		return nil
+	}
+
+	if err := executeUndo(db, gh, noStdin(t), false, true); err != nil {
+		t.Fatalf("executeUndo() error = %v", err)
+	}
+
+	for _, username := range []string{"spammer1", "spammer2", "spammer3"} {
+		if blocked, _ := db.IsBlocked(username); blocked {
+			t.Errorf("%s should have been unblocked", username)
+		}
+	}
+	if blocked, _ := db.IsBlocked("old-spammer"); !blocked {
+		t.Error("entries from earlier batches should be kept")
+	}
+	if reopened != 0 {
+		t.Errorf("PRs should not be reopened without --reopen, got %d calls", reopened)
+	}
+
+	// A second undo reverses the earlier batch
+	if err := executeUndo(db, gh, noStdin(t), false, true); err != nil {
+		t.Fatalf("executeUndo() error = %v", err)
+	}
+	if blocked, _ := db.IsBlocked("old-spammer"); blocked {
+		t.Error("second undo should reverse the earlier batch")
+	}
+}
+
+func TestExecuteUndo_Reopen(t *testing.T) {
+	_, db := setupTestConfig(t)
+	gh := &mocks.MockGitHubClient{}
+
+	runTestBatch(t, db, gh, "batch", []string{"spammer"}, []int{4, 5})
+
+	var reopened []int
+	gh.ReopenPullRequestFn = func(owner, repo string, number int, _ string) error {
+		if owner != "org" || repo != "repo" {
+			t.Errorf("unexpected repository %s/%s", owner, repo)
+		}
+		reopened = append(reopened, number)
+		return nil
+	}
+
+	if err := executeUndo(db, gh, noStdin(t), true, true); err != nil {
+		t.Fatalf("executeUndo() error = %v", err)
+	}
+	if len(reopened) != 2 || reopened[0] != 4 || reopened[1] != 5 {
+		t.Errorf("reopened PRs = %v, want [4 5]", reopened)
+	}
+}
+
+func TestExecuteUndo_Declined(t *testing.T) {
+	_, db := setupTestConfig(t)
+	gh := &mocks.MockGitHubClient{}
+
+	runTestBatch(t, db, gh, "batch", []string{"spammer"}, nil)
+
+	if err := executeUndo(db, gh, bufio.NewReader(strings.NewReader("n\n")), false, false); err != nil {
+		t.Fatalf("executeUndo() error = %v", err)
+	}
+	if blocked, _ := db.IsBlocked("spammer"); !blocked {
+		t.Error("declining the prompt should leave entries in place")
+	}
+}
+
+func TestExecuteUndo_Empty(t *testing.T) {
+	_, db := setupTestConfig(t)
+
+	if err := executeUndo(db, &mocks.MockGitHubClient{}, noStdin(t), false, true); err != nil {
+		t.Fatalf("executeUndo() on empty log error = %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+// NewWatchCommand creates the watch command
+func NewWatchCommand(configPath *string, assumeYes *bool) *cobra.Command {
+	var opts scanOptions
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Scan configured repositories on an interval",
+		Long: `Runs as a long-lived process that scans every configured repository, applies
+the requested automated actions, and sleeps until the next interval.
+
+Because no one is present to answer confirmation prompts, watch requires --yes.
+A repository whose previous scan is still running is skipped for that interval.
+Stop with Ctrl-C or SIGTERM; in-flight scans are cancelled before exiting.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			opts.yes = *assumeYes
+			return runWatch(*configPath, interval, opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 15*time.Minute, "Time between scans")
+	cmd.Flags().BoolVar(&opts.autoClose, "auto-close", false, "Automatically close spam PRs")
+	cmd.Flags().BoolVar(&opts.autoBlock, "auto-block", false, "Automatically add spam users to blocklist")
+	cmd.Flags().BoolVar(&opts.githubBlock, "github-block", false, "Also block users via GitHub API (requires --auto-block)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print automated actions without executing them")
+
+	return cmd
+}
+
+func runWatch(configPath string, interval time.Duration, opts scanOptions) error {
+	if !opts.yes {
+		return fmt.Errorf("watch runs unattended and cannot prompt for confirmation; pass --yes")
+	}
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be greater than 0")
+	}
+	if opts.githubBlock && !opts.autoBlock {
+		return fmt.Errorf("--github-block requires --auto-block")
+	}
+
+	cfg, ghClient, blManager, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	if len(cfg.Repositories) == 0 {
+		return fmt.Errorf("no repositories configured. Add repositories to your config.yaml file")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Scans of every repository and tick share one blocker so github.block_delay
+	// spaces every block the watch makes
+	opts.blocker = newGitHubBlocker(cfg, ghClient.WithContext(ctx), blManager, os.Stderr, cfg.GitHub.BlockDelay)
+
+	w := newWatcher(os.Stdout, cfg.Repositories, func(ctx context.Context, out io.Writer, repo config.Repository) error {
+		if cfg.GitHub.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, cfg.GitHub.Timeout)
+			defer cancel()
+		}
+		_, err := scanRepository(out, cfg, ghClient.WithContext(ctx), blManager, db, repo.FullName(), time.Time{}, opts)
+		return err
+	})
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	fmt.Printf("Watching %d repositories, scanning every %s\n\n", len(cfg.Repositories), interval)
+	w.run(ctx, ticker.C)
+	fmt.Println("Stopped watching")
+
+	return nil
+}
+
+// watcher scans each repository once per tick, skipping repositories whose
+// previous scan has not finished yet. Each scan writes to its own buffer, which
+// is copied to out in one piece when the scan returns so concurrent scans do
+// not interleave their output.
+type watcher struct {
+	out   io.Writer
+	repos []config.Repository
+	scan  func(ctx context.Context, out io.Writer, repo config.Repository) error
+
+	mu      sync.Mutex
+	running map[string]bool
+	wg      sync.WaitGroup
+	outMu   sync.Mutex
+}
+
+func newWatcher(out io.Writer, repos []config.Repository, scan func(ctx context.Context, out io.Writer, repo config.Repository) error) *watcher {
+	return &watcher{
+		out:     out,
+		repos:   repos,
+		scan:    scan,
+		running: make(map[string]bool),
+	}
+}
+
+// run scans immediately and then on every tick until ctx is cancelled, then waits
+// for in-flight scans to return
+func (w *watcher) run(ctx context.Context, ticks <-chan time.Time) {
+	w.tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			w.wg.Wait()
+			return
+		case <-ticks:
+			w.tick(ctx)
+		}
+	}
+}
+
+// tick starts a scan for every repository that is not already being scanned
+func (w *watcher) tick(ctx context.Context) {
+	for _, repo := range w.repos {
+		name := repo.FullName()
+
+		w.mu.Lock()
+		if w.running[name] {
+			w.mu.Unlock()
+			w.logf("Skipping %s: previous scan still running\n", name)
+			continue
+		}
+		w.running[name] = true
+		w.mu.Unlock()
+
+		w.wg.Add(1)
+		go func(repo config.Repository) {
+			defer w.wg.Done()
+			defer w.finish(repo.FullName())
+
+			var buf bytes.Buffer
+			err := w.scan(ctx, &buf, repo)
+			if err != nil {
+				fmt.Fprintf(&buf, "Error scanning %s: %v\n", repo.FullName(), err)
+			}
+			w.flush(&buf)
+		}(repo)
+	}
+}
+
+func (w *watcher) finish(name string) {
+	w.mu.Lock()
+	delete(w.running, name)
+	w.mu.Unlock()
+}
+
+// flush copies one scan's buffered output to out
+func (w *watcher) flush(buf *bytes.Buffer) {
+	w.outMu.Lock()
+	defer w.outMu.Unlock()
+	_, _ = buf.WriteTo(w.out)
+}
+
+// logf writes to out; concurrent scans share the writer
+func (w *watcher) logf(format string, args ...any) {
+	w.outMu.Lock()
+	defer w.outMu.Unlock()
+	fmt.Fprintf(w.out, format, args...)
+}
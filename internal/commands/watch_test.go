@@ -0,0 +1,252 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent writers
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// scanCounter records how many times each repository was scanned
+type scanCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (c *scanCounter) record(repo config.Repository) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[repo.FullName()]++
+}
+
+func (c *scanCounter) get(name string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[name]
+}
+
+// waitUntil polls cond until it holds or the test times out
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("timed out waiting for scans")
+}
+
+// isRunning reports whether a scan of the named repository is in flight
+func (w *watcher) isRunning(name string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.running[name]
+}
+
+// waitIdle blocks until the watcher has no scans in flight
+func waitIdle(t *testing.T, w *watcher) {
+	t.Helper()
+
+	waitUntil(t, func() bool {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		return len(w.running) == 0
+	})
+}
+
+var watchRepos = []config.Repository{
+	{Owner: "org", Name: "app"},
+	{Owner: "org", Name: "lib"},
+}
+
+func TestWatcher_ScansEachRepoOncePerTick(t *testing.T) {
+	counter := &scanCounter{counts: map[string]int{}}
+	w := newWatcher(&syncBuffer{}, watchRepos, func(_ context.Context, _ io.Writer, repo config.Repository) error {
+		counter.record(repo)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := make(chan time.Time)
+	done := make(chan struct{})
+	go func() {
+		w.run(ctx, ticks)
+		close(done)
+	}()
+
+	// run scans immediately, then once for each of the two ticks
+	for want := 1; want <= 3; want++ {
+		if want > 1 {
+			ticks <- time.Now()
+		}
+		waitUntil(t, func() bool {
+			return counter.get("org/app") >= want && counter.get("org/lib") >= want && !w.isRunning("org/app") && !w.isRunning("org/lib")
+		})
+	}
+	cancel()
+	<-done
+
+	for _, repo := range watchRepos {
+		if got := counter.get(repo.FullName()); got != 3 {
+			t.Errorf("expected %s to be scanned 3 times, got %d", repo.FullName(), got)
+		}
+	}
+}
+
+func TestWatcher_SkipsRepoStillRunning(t *testing.T) {
+	counter := &scanCounter{counts: map[string]int{}}
+	release := make(chan struct{})
+	out := &syncBuffer{}
+	w := newWatcher(out, watchRepos, func(ctx context.Context, _ io.Writer, repo config.Repository) error {
+		counter.record(repo)
+		if repo.Name == "app" {
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := make(chan time.Time)
+	done := make(chan struct{})
+	go func() {
+		w.run(ctx, ticks)
+		close(done)
+	}()
+
+	// org/lib finishes each tick while org/app stays busy from the first scan
+	for want := 1; want <= 3; want++ {
+		if want > 1 {
+			ticks <- time.Now()
+		}
+		waitUntil(t, func() bool { return counter.get("org/lib") == want && !w.isRunning("org/lib") })
+	}
+	if got := counter.get("org/app"); got != 1 {
+		t.Errorf("expected org/app to be scanned once while busy, got %d", got)
+	}
+
+	close(release)
+	waitIdle(t, w)
+	cancel()
+	<-done
+
+	if !strings.Contains(out.String(), "Skipping org/app") {
+		t.Errorf("expected skip message, got:\n%s", out.String())
+	}
+}
+
+func TestWatcher_StopsOnCancel(t *testing.T) {
+	out := &syncBuffer{}
+	w := newWatcher(out, watchRepos[:1], func(ctx context.Context, _ io.Writer, _ config.Repository) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		w.run(ctx, make(chan time.Time))
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watcher did not stop after cancellation")
+	}
+
+	if !strings.Contains(out.String(), context.Canceled.Error()) {
+		t.Errorf("expected in-flight scan to report cancellation, got:\n%s", out.String())
+	}
+}
+
+func TestWatcher_KeepsEachScanOutputTogether(t *testing.T) {
+	out := &syncBuffer{}
+	started := make(chan struct{}, len(watchRepos))
+	release := make(chan struct{})
+	w := newWatcher(out, watchRepos, func(_ context.Context, scanOut io.Writer, repo config.Repository) error {
+		fmt.Fprintf(scanOut, "start %s\n", repo.FullName())
+		started <- struct{}{}
+		<-release
+		fmt.Fprintf(scanOut, "end %s\n", repo.FullName())
+		return nil
+	})
+
+	// Both scans write their first line before either writes its second
+	w.tick(context.Background())
+	for range watchRepos {
+		<-started
+	}
+	close(release)
+	waitIdle(t, w)
+	w.wg.Wait()
+
+	got := out.String()
+	for _, repo := range watchRepos {
+		block := fmt.Sprintf("start %[1]s\nend %[1]s\n", repo.FullName())
+		if !strings.Contains(got, block) {
+			t.Errorf("expected output of %s to be contiguous, got:\n%s", repo.FullName(), got)
+		}
+	}
+}
+
+func TestRunWatch_RequiresYes(t *testing.T) {
+	err := runWatch("unused", time.Minute, scanOptions{})
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Errorf("expected --yes error, got %v", err)
+	}
+
+	err = runWatch("unused", 0, scanOptions{yes: true})
+	if err == nil || !strings.Contains(err.Error(), "--interval") {
+		t.Errorf("expected interval error, got %v", err)
+	}
+
+	err = runWatch("unused", time.Minute, scanOptions{yes: true, githubBlock: true})
+	if err == nil || !strings.Contains(err.Error(), "--auto-block") {
+		t.Errorf("expected --auto-block error, got %v", err)
+	}
+}
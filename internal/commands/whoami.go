@@ -0,0 +1,96 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/spf13/cobra"
+)
+
+// NewWhoamiCommand creates the whoami command
+func NewWhoamiCommand(configPath *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Check the GitHub token and show the authenticated user",
+		Long: `Confirms the configured token works by printing the user it belongs to and its
+OAuth scopes. Warns when scopes needed for the configured mode are missing: repo
+to close PRs, plus admin:org to block org members or user to block from a personal
+account.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runWhoami(*configPath)
+		},
+	}
+
+	return cmd
+}
+
+func runWhoami(configPath string) error {
+	cfg, ghClient, _, db, err := initClients(configPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck
+
+	return printWhoami(os.Stdout, cfg, ghClient)
+}
+
+// printWhoami prints the authenticated user and warns about missing scopes
+func printWhoami(w io.Writer, cfg *config.Config, ghClient github.GitHubClient) error {
+	user, scopes, err := ghClient.GetAuthenticatedUser()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Logged in as: %s\n", user.Login)
+	if scopes == nil {
+		fmt.Fprintln(w, "Scopes: not reported (fine-grained tokens list permissions on GitHub instead)")
+		return nil
+	}
+	if len(scopes) == 0 {
+		fmt.Fprintln(w, "Scopes: (none)")
+	} else {
+		fmt.Fprintf(w, "Scopes: %s\n", strings.Join(scopes, ", "))
+	}
+
+	for _, scope := range requiredScopes(cfg) {
+		if !slices.Contains(scopes, scope) {
+			fmt.Fprintf(w, "⚠ Missing %q scope: %s\n", scope, scopePurpose[scope])
+		}
+	}
+	return nil
+}
+
+// scopePurpose explains why each required scope is needed
+var scopePurpose = map[string]string{
+	"repo":      "needed to close and label pull requests",
+	"admin:org": "needed to block users from the organization",
+	"user":      "needed to block users from your personal account",
+}
+
+// requiredScopes lists the OAuth scopes the configured mode relies on
+func requiredScopes(cfg *config.Config) []string {
+	if cfg.GitHub.Org != "" {
+		return []string{"repo", "admin:org"}
+	}
+	return []string{"repo", "user"}
+}
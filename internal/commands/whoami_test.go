@@ -0,0 +1,103 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/mocks"
+)
+
+func whoamiClient(scopes []string) *mocks.MockGitHubClient {
+	return &mocks.MockGitHubClient{
+		GetAuthenticatedUserFn: func() (*github.User, []string, error) {
+			return &github.User{Login: "maintainer"}, scopes, nil
+		},
+	}
+}
+
+func TestPrintWhoami(t *testing.T) {
+	tests := []struct {
+		name    string
+		github  config.GitHubConfig
+		scopes  []string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "org mode with all scopes",
+			github:  config.GitHubConfig{Org: "acme"},
+			scopes:  []string{"repo", "admin:org"},
+			want:    []string{"Logged in as: maintainer", "Scopes: repo, admin:org"},
+			notWant: []string{"Missing"},
+		},
+		{
+			name:    "org mode missing admin:org",
+			github:  config.GitHubConfig{Org: "acme"},
+			scopes:  []string{"repo"},
+			want:    []string{`Missing "admin:org" scope`},
+			notWant: []string{`Missing "repo"`},
+		},
+		{
+			name:   "personal mode without scopes",
+			github: config.GitHubConfig{User: "maintainer"},
+			scopes: []string{},
+			want:   []string{"Scopes: (none)", `Missing "repo" scope`, `Missing "user" scope`},
+		},
+		{
+			name:    "fine-grained token",
+			github:  config.GitHubConfig{Org: "acme"},
+			scopes:  nil,
+			want:    []string{"Scopes: not reported"},
+			notWant: []string{"Missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := printWhoami(&out, &config.Config{GitHub: tt.github}, whoamiClient(tt.scopes)); err != nil {
+				t.Fatalf("printWhoami() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out.String(), notWant) {
+					t.Errorf("output should not contain %q:\n%s", notWant, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestPrintWhoami_Error(t *testing.T) {
+	client := &mocks.MockGitHubClient{
+		GetAuthenticatedUserFn: func() (*github.User, []string, error) {
+			return nil, nil, fmt.Errorf("401 Bad credentials")
+		},
+	}
+
+	if err := printWhoami(&bytes.Buffer{}, &config.Config{}, client); err == nil || !strings.Contains(err.Error(), "Bad credentials") {
+		t.Errorf("expected the API error, got %v", err)
+	}
+}
@@ -18,25 +18,35 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"text/template"
+	"time"
 
+	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the application configuration
 type Config struct {
-	GitHub       GitHubConfig    `yaml:"github"`
-	Database     DatabaseConfig  `yaml:"database"`
-	Repositories []Repository    `yaml:"repositories"`
-	Filters      FiltersConfig   `yaml:"filters"`
-	Blocklist    BlocklistConfig `yaml:"blocklist"`
-	Actions      ActionsConfig   `yaml:"actions"`
+	GitHub        GitHubConfig        `yaml:"github" toml:"github"`
+	Database      DatabaseConfig      `yaml:"database" toml:"database"`
+	Repositories  []Repository        `yaml:"repositories" toml:"repositories"`
+	Filters       FiltersConfig       `yaml:"filters" toml:"filters"`
+	Blocklist     BlocklistConfig     `yaml:"blocklist" toml:"blocklist"`
+	Actions       ActionsConfig       `yaml:"actions" toml:"actions"`
+	Notifications NotificationsConfig `yaml:"notifications" toml:"notifications"`
+	API           APIConfig           `yaml:"api" toml:"api"`
+	Display       DisplayConfig       `yaml:"display" toml:"display"`
 }
 
 // Repository represents a GitHub repository to monitor
 type Repository struct {
-	Owner string `yaml:"owner"`
-	Name  string `yaml:"name"`
+	Owner   string             `yaml:"owner" toml:"owner"`
+	Name    string             `yaml:"name" toml:"name"`
+	Filters *RepositoryFilters `yaml:"filters,omitempty" toml:"filters,omitempty"` // Optional per-repo overrides
 }
 
 // FullName returns the repository in owner/name format
@@ -46,50 +56,310 @@ func (r Repository) FullName() string {
 
 // GitHubConfig holds GitHub API configuration
 type GitHubConfig struct {
-	Token string `yaml:"token"`
-	Org   string `yaml:"org"`
-	User  string `yaml:"user"`
+	Token      string `yaml:"token" toml:"token"`
+	TokenFile  string `yaml:"token_file,omitempty" toml:"token_file,omitempty"` // File holding the token, e.g. a mounted secret; used instead of token
+	Org        string `yaml:"org" toml:"org"`
+	User       string `yaml:"user" toml:"user"`
+	MaxRetries *int   `yaml:"max_retries,omitempty" toml:"max_retries,omitempty"` // Retries for rate-limited or 5xx API calls; 0 disables, unset uses 3
+
+	// Timeout bounds a whole scan's GitHub calls (e.g. "30m"); zero means no limit
+	Timeout time.Duration `yaml:"timeout,omitempty" toml:"timeout,omitempty"`
+
+	// BlockDelay is the wait between GitHub block API calls when blocking
+	// several users (e.g. "2s"); defaults to one second
+	BlockDelay time.Duration `yaml:"block_delay,omitempty" toml:"block_delay,omitempty"`
+
+	// GitHub App authentication; used instead of Token when all three are set
+	AppID          int64  `yaml:"app_id,omitempty" toml:"app_id,omitempty"`
+	InstallationID int64  `yaml:"installation_id,omitempty" toml:"installation_id,omitempty"`
+	PrivateKeyPath string `yaml:"private_key_path,omitempty" toml:"private_key_path,omitempty"`
+}
+
+// DefaultMaxRetries is the number of retries used when max_retries is unset
+const DefaultMaxRetries = 3
+
+// Retries returns max_retries, or DefaultMaxRetries when it is unset. An
+// explicit 0 disables retries.
+func (g GitHubConfig) Retries() int {
+	if g.MaxRetries == nil {
+		return DefaultMaxRetries
+	}
+	return max(*g.MaxRetries, 0)
+}
+
+// UsesApp reports whether GitHub App authentication is configured
+func (g GitHubConfig) UsesApp() bool {
+	return g.AppID != 0 || g.InstallationID != 0 || g.PrivateKeyPath != ""
 }
 
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
-	Type      string `yaml:"type"`       // sqlite or turso
-	Path      string `yaml:"path"`       // for sqlite
-	URL       string `yaml:"url"`        // for turso
-	AuthToken string `yaml:"auth_token"` // for turso
+	Type      string `yaml:"type" toml:"type"`             // sqlite or turso
+	Path      string `yaml:"path" toml:"path"`             // for sqlite
+	URL       string `yaml:"url" toml:"url"`               // for turso
+	AuthToken string `yaml:"auth_token" toml:"auth_token"` // for turso
 }
 
 // FiltersConfig holds PR quality filter configuration
 type FiltersConfig struct {
-	MinFiles        int      `yaml:"min_files"`
-	MinLines        int      `yaml:"min_lines"`
-	AccountAgeDays  int      `yaml:"account_age_days"`
-	ReadmeOnlyBlock bool     `yaml:"readme_only_block"`
-	Whitelist       []string `yaml:"whitelist"`
-	SpamPhrases     []string `yaml:"spam_phrases"`
+	MinFiles              int      `yaml:"min_files" toml:"min_files"`
+	MinLines              int      `yaml:"min_lines" toml:"min_lines"`
+	AccountAgeDays        int      `yaml:"account_age_days" toml:"account_age_days"`
+	MinFollowers          int      `yaml:"min_followers" toml:"min_followers"`       // Reputation threshold on followers (0 disables)
+	MinPublicRepos        int      `yaml:"min_public_repos" toml:"min_public_repos"` // Reputation threshold on public repos (0 disables)
+	ReadmeOnlyBlock       bool     `yaml:"readme_only_block" toml:"readme_only_block"`
+	ReadmeMinLines        int      `yaml:"readme_min_lines" toml:"readme_min_lines"` // README-only PRs adding more lines than this are marked for review instead of spam (0 disables)
+	Whitelist             []string `yaml:"whitelist" toml:"whitelist"`
+	TrustedOrgs           []string `yaml:"trusted_orgs" toml:"trusted_orgs"` // Members of these organizations bypass spam detection like the whitelist (one API call per author and org)
+	SpamPhrases           []string `yaml:"spam_phrases" toml:"spam_phrases"`
+	SpamRegexes           []string `yaml:"spam_regexes" toml:"spam_regexes"`                       // Regular expressions matched against title+body
+	GeneratedFilePatterns []string `yaml:"generated_file_patterns" toml:"generated_file_patterns"` // Globs for generated/lock files
+	SensitiveFiles        []string `yaml:"sensitive_files" toml:"sensitive_files"`                 // Globs for files such as LICENSE whose edits are flagged
+	Concurrency           int      `yaml:"concurrency" toml:"concurrency"`                         // Number of PRs scanned in parallel
+	MinDuplicateTitles    int      `yaml:"min_duplicate_titles" toml:"min_duplicate_titles"`       // Cluster size at which near-identical titles are flagged (0 disables)
+	MinBodyLength         int      `yaml:"min_body_length" toml:"min_body_length"`                 // PRs whose trimmed body has fewer characters than this are marked for review (0 disables)
+	MinOriginalRepoRatio  float64  `yaml:"min_original_repo_ratio" toml:"min_original_repo_ratio"` // Authors whose share of original (non-fork, non-empty) repos is below this are marked for review (0 disables; one API call per author)
+	FirstTimeContributors bool     `yaml:"first_time_contributors" toml:"first_time_contributors"` // Flag authors with no prior commits to the repo (one API call per author)
+	FlagNonDefaultBase    bool     `yaml:"flag_non_default_base" toml:"flag_non_default_base"`     // Flag PRs targeting a branch other than the repository default
+	SuspiciousEncoding    bool     `yaml:"suspicious_encoding" toml:"suspicious_encoding"`         // Flag small PRs whose body is mostly non-Latin text with links
+	ScanPatchLinks        bool     `yaml:"scan_patch_links" toml:"scan_patch_links"`               // Check links added by flagged PRs against spam_regexes (one extra API call per flagged PR)
+	FlagReopened          bool     `yaml:"flag_reopened" toml:"flag_reopened"`                     // Flag PRs their author reopened after they were closed (one extra API call per PR)
+}
+
+// DefaultGeneratedFilePatterns lists common generated and lock files
+var DefaultGeneratedFilePatterns = []string{
+	"package-lock.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"go.sum",
+	"Cargo.lock",
+	"Gemfile.lock",
+	"poetry.lock",
+	"composer.lock",
+	"*.min.js",
+	"*.min.css",
+}
+
+// DefaultSensitiveFiles lists files whose modification warrants a closer look.
+// Patterns are matched case-insensitively against the path and basename.
+var DefaultSensitiveFiles = []string{
+	"LICENSE*",
+	"COPYING*",
+	"SECURITY.md",
+	"CODEOWNERS",
+}
+
+// RepositoryFilters holds per-repository filter overrides.
+// Unset (nil) fields fall back to the global filters.
+type RepositoryFilters struct {
+	MinFiles           *int     `yaml:"min_files,omitempty" toml:"min_files,omitempty"`
+	MinLines           *int     `yaml:"min_lines,omitempty" toml:"min_lines,omitempty"`
+	AccountAgeDays     *int     `yaml:"account_age_days,omitempty" toml:"account_age_days,omitempty"`
+	ReadmeOnlyBlock    *bool    `yaml:"readme_only_block,omitempty" toml:"readme_only_block,omitempty"`
+	SuspiciousEncoding *bool    `yaml:"suspicious_encoding,omitempty" toml:"suspicious_encoding,omitempty"`
+	Whitelist          []string `yaml:"whitelist,omitempty" toml:"whitelist,omitempty"`
+	SpamPhrases        []string `yaml:"spam_phrases,omitempty" toml:"spam_phrases,omitempty"`
+}
+
+// apply returns a copy of base with the overrides applied
+func (o *RepositoryFilters) apply(base FiltersConfig) FiltersConfig {
+	if o == nil {
+		return base
+	}
+	if o.MinFiles != nil {
+		base.MinFiles = *o.MinFiles
+	}
+	if o.MinLines != nil {
+		base.MinLines = *o.MinLines
+	}
+	if o.AccountAgeDays != nil {
+		base.AccountAgeDays = *o.AccountAgeDays
+	}
+	if o.ReadmeOnlyBlock != nil {
+		base.ReadmeOnlyBlock = *o.ReadmeOnlyBlock
+	}
+	if o.SuspiciousEncoding != nil {
+		base.SuspiciousEncoding = *o.SuspiciousEncoding
+	}
+	if o.Whitelist != nil {
+		base.Whitelist = o.Whitelist
+	}
+	if o.SpamPhrases != nil {
+		base.SpamPhrases = o.SpamPhrases
+	}
+	return base
+}
+
+// FiltersFor returns the effective filters for a repository, applying any
+// per-repo overrides on top of the global filters. Owner and name are matched
+// case-insensitively as GitHub does, and unset overrides inherit the global
+// filters at lookup, so later changes to them are picked up.
+func (c *Config) FiltersFor(owner, name string) FiltersConfig {
+	for _, repo := range c.Repositories {
+		if strings.EqualFold(repo.Owner, owner) && strings.EqualFold(repo.Name, name) {
+			return repo.Filters.apply(c.Filters)
+		}
+	}
+	return c.Filters
 }
 
 // BlocklistConfig holds blocklist management configuration
 type BlocklistConfig struct {
-	AutoExport bool              `yaml:"auto_export"`
-	ExportPath string            `yaml:"export_path"`
-	Sources    []BlocklistSource `yaml:"sources"`
+	AutoExport bool              `yaml:"auto_export" toml:"auto_export"`
+	ExportPath string            `yaml:"export_path" toml:"export_path"`
+	Sources    []BlocklistSource `yaml:"sources" toml:"sources"`
+
+	// FetchTimeout bounds each request for a remote blocklist (e.g. "30s");
+	// defaults to 30 seconds
+	FetchTimeout time.Duration `yaml:"fetch_timeout,omitempty" toml:"fetch_timeout,omitempty"`
 }
 
 // BlocklistSource represents a remote blocklist source
 type BlocklistSource struct {
-	Name     string `yaml:"name"`
-	URL      string `yaml:"url"`
-	Trusted  bool   `yaml:"trusted"`
-	AutoSync bool   `yaml:"auto_sync"`
+	Name     string `yaml:"name" toml:"name"`
+	URL      string `yaml:"url" toml:"url"`
+	Trusted  bool   `yaml:"trusted" toml:"trusted"`
+	AutoSync bool   `yaml:"auto_sync" toml:"auto_sync"`
 }
 
 // ActionsConfig holds default action configuration
 type ActionsConfig struct {
-	ClosePRs        bool   `yaml:"close_prs"`
-	BlockUsers      bool   `yaml:"block_users"`
-	AddSpamLabel    bool   `yaml:"add_spam_label"`
-	CommentTemplate string `yaml:"comment_template"`
+	ClosePRs            bool     `yaml:"close_prs" toml:"close_prs"`
+	BlockUsers          bool     `yaml:"block_users" toml:"block_users"`
+	AddSpamLabel        bool     `yaml:"add_spam_label" toml:"add_spam_label"`
+	SpamLabel           string   `yaml:"spam_label" toml:"spam_label"`   // Label added to spam PRs (default "spam")
+	Labels              []string `yaml:"labels" toml:"labels"`           // Additional labels added alongside spam_label
+	LabelColor          string   `yaml:"label_color" toml:"label_color"` // Hex color for labels created when missing (default "d73a4a")
+	CommentTemplate     string   `yaml:"comment_template" toml:"comment_template"`
+	BlockReasonTemplate string   `yaml:"block_reason_template" toml:"block_reason_template"` // Reason recorded for auto-blocked users
+
+	// SeverityActions maps a spam severity (low, medium, high) to the actions
+	// taken for it, e.g. "close+block", "label", or "report-only". Severities
+	// without an entry get every enabled action.
+	SeverityActions map[string]string `yaml:"severity_actions" toml:"severity_actions"`
+}
+
+// labelColorPattern matches the 6-digit hex colors GitHub accepts for labels
+var labelColorPattern = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+// SeverityAction is the set of actions a severity_actions entry allows
+type SeverityAction struct {
+	Close bool
+	Block bool
+	Label bool
+}
+
+// ParseSeverityAction parses a severity_actions value: "close", "block", and
+// "label" joined with "+", or "report-only" to take no action
+func ParseSeverityAction(spec string) (SeverityAction, error) {
+	var action SeverityAction
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	if spec == "report-only" || spec == "none" {
+		return action, nil
+	}
+
+	for _, part := range strings.Split(spec, "+") {
+		switch strings.TrimSpace(part) {
+		case "close":
+			action.Close = true
+		case "block":
+			action.Block = true
+		case "label":
+			action.Label = true
+		default:
+			return action, fmt.Errorf("unknown action %q (expected close, block, label, or report-only)", part)
+		}
+	}
+	return action, nil
+}
+
+// Defaults for the labels added to spam PRs
+const (
+	DefaultSpamLabel  = "spam"
+	DefaultLabelColor = "d73a4a"
+)
+
+// LabelNames returns the labels added to spam PRs: spam_label (or "spam" when
+// unset) followed by labels, without blanks or duplicates
+func (a ActionsConfig) LabelNames() []string {
+	spamLabel := a.SpamLabel
+	if strings.TrimSpace(spamLabel) == "" {
+		spamLabel = DefaultSpamLabel
+	}
+	var names []string
+	for _, name := range append([]string{spamLabel}, a.Labels...) {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// SeverityActionFor returns the configured actions for a severity and whether
+// the severity has an entry in severity_actions. Invalid entries are treated
+// as report-only.
+func (a ActionsConfig) SeverityActionFor(severity string) (SeverityAction, bool) {
+	spec, ok := a.SeverityActions[strings.ToLower(severity)]
+	if !ok {
+		return SeverityAction{}, false
+	}
+	action, err := ParseSeverityAction(spec)
+	if err != nil {
+		return SeverityAction{}, true
+	}
+	return action, true
+}
+
+// NotificationsConfig holds configuration for scan notifications
+type NotificationsConfig struct {
+	WebhookURL string `yaml:"webhook_url" toml:"webhook_url"` // POSTed a JSON payload when a scan detects spam
+	Secret     string `yaml:"secret" toml:"secret"`           // Optional HMAC-SHA256 signing secret
+
+	SlackWebhookURL string `yaml:"slack_webhook_url" toml:"slack_webhook_url"` // Slack incoming webhook for Block Kit summaries
+	SlackMaxPRs     int    `yaml:"slack_max_prs" toml:"slack_max_prs"`         // Maximum spam PRs listed in a Slack message
+}
+
+// APIConfig holds configuration for the HTTP API server
+type APIConfig struct {
+	Token string `yaml:"token" toml:"token"` // Bearer token required by mutating routes such as POST /scan
+}
+
+// DisplayConfig holds how commands show timestamps
+type DisplayConfig struct {
+	TimeFormat string `yaml:"time_format" toml:"time_format"` // Go layout or a name such as RFC3339 or DateTime (default RFC3339)
+	TimeZone   string `yaml:"time_zone" toml:"time_zone"`     // IANA zone such as Europe/Berlin, or UTC or Local (default UTC)
+}
+
+// timeLayouts maps the layout names accepted by time_format to Go layouts
+var timeLayouts = map[string]string{
+	"RFC3339":  time.RFC3339,
+	"RFC1123":  time.RFC1123,
+	"RFC1123Z": time.RFC1123Z,
+	"RFC822":   time.RFC822,
+	"RFC822Z":  time.RFC822Z,
+	"DateTime": time.DateTime,
+	"DateOnly": time.DateOnly,
+}
+
+// Layout returns the Go time layout for time_format, which may name a standard
+// layout; an empty value means RFC3339
+func (d DisplayConfig) Layout() string {
+	if d.TimeFormat == "" {
+		return time.RFC3339
+	}
+	if layout, ok := timeLayouts[d.TimeFormat]; ok {
+		return layout
+	}
+	return d.TimeFormat
+}
+
+// Location loads time_zone; an empty value means UTC
+func (d DisplayConfig) Location() (*time.Location, error) {
+	if d.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(d.TimeZone)
 }
 
 // FindConfigPath searches for a config file in standard locations
@@ -102,16 +372,17 @@ func FindConfigPath(userSpecified string) (string, error) {
 		return "", fmt.Errorf("config file not found at specified path: %s", userSpecified)
 	}
 
-	// Check standard locations in order
+	// Check standard locations in order, preferring YAML within each directory
 	locations := []string{}
 
-	// 1. ~/.config/prguard/config.yaml
+	// 1. ~/.config/prguard/config.{yaml,toml}
 	if home, err := os.UserHomeDir(); err == nil {
-		locations = append(locations, filepath.Join(home, ".config", "prguard", "config.yaml"))
+		dir := filepath.Join(home, ".config", "prguard")
+		locations = append(locations, filepath.Join(dir, "config.yaml"), filepath.Join(dir, "config.toml"))
 	}
 
 	// 2. Current directory
-	locations = append(locations, "config.yaml")
+	locations = append(locations, "config.yaml", "config.toml")
 
 	// Return the first existing config file
 	for _, path := range locations {
@@ -123,36 +394,82 @@ func FindConfigPath(userSpecified string) (string, error) {
 	return "", fmt.Errorf("config file not found in any standard location: %v", locations)
 }
 
-// Load reads and parses the configuration file
+// Load reads, parses and validates the configuration file
 func Load(path string) (*Config, error) {
+	config, _, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate configuration
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// Read locates and parses the configuration file and applies environment
+// overrides without validating it. It also returns the path that was read.
+func Read(path string) (*Config, string, error) {
 	// Find the actual config path
 	configPath, err := FindConfigPath(path)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	data, err := os.ReadFile(configPath) //nolint:gosec // user-specified config path
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, configPath, fmt.Errorf("failed to read config file: %w", err)
 	}
 
 	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	if isTOML(configPath) {
+		err = toml.Unmarshal(data, &config)
+	} else {
+		err = yaml.Unmarshal(data, &config)
+	}
+	if err != nil {
+		return nil, configPath, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
 	// Apply environment variable overrides
 	applyEnvOverrides(&config)
 
-	// Validate configuration
-	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid configuration: %w", err)
+	if err := resolveTokenFile(&config); err != nil {
+		return nil, configPath, err
+	}
+
+	return &config, configPath, nil
+}
+
+// resolveTokenFile reads the GitHub token from github.token_file when one is
+// set. A PRGUARD_GITHUB_TOKEN environment variable still takes precedence, and
+// the file takes precedence over an inline token.
+func resolveTokenFile(config *Config) error {
+	if config.GitHub.TokenFile == "" || os.Getenv("PRGUARD_GITHUB_TOKEN") != "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(config.GitHub.TokenFile) //nolint:gosec // user-specified token path
+	if err != nil {
+		return fmt.Errorf("failed to read github.token_file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return fmt.Errorf("github.token_file %s is empty", config.GitHub.TokenFile)
 	}
+	config.GitHub.Token = token
+	return nil
+}
 
-	return &config, nil
+// isTOML reports whether path names a TOML file; anything else is read as YAML
+func isTOML(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".toml")
 }
 
-// Save writes the configuration to a file
+// Save writes the configuration to a file, as TOML if path ends in .toml and
+// as YAML otherwise
 func Save(config *Config, path string) error {
 	// Ensure directory exists
 	dir := filepath.Dir(path)
@@ -160,7 +477,13 @@ func Save(config *Config, path string) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	data, err := yaml.Marshal(config)
+	var data []byte
+	var err error
+	if isTOML(path) {
+		data, err = toml.Marshal(config)
+	} else {
+		data, err = yaml.Marshal(config)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -177,6 +500,9 @@ func applyEnvOverrides(config *Config) {
 	if token := os.Getenv("PRGUARD_GITHUB_TOKEN"); token != "" {
 		config.GitHub.Token = token
 	}
+	if tokenFile := os.Getenv("PRGUARD_GITHUB_TOKEN_FILE"); tokenFile != "" {
+		config.GitHub.TokenFile = tokenFile
+	}
 	if org := os.Getenv("PRGUARD_GITHUB_ORG"); org != "" {
 		config.GitHub.Org = org
 	}
@@ -195,37 +521,148 @@ func applyEnvOverrides(config *Config) {
 	if authToken := os.Getenv("PRGUARD_DATABASE_AUTH_TOKEN"); authToken != "" {
 		config.Database.AuthToken = authToken
 	}
+	if secret := os.Getenv("PRGUARD_NOTIFICATIONS_SECRET"); secret != "" {
+		config.Notifications.Secret = secret
+	}
+	if apiToken := os.Getenv("PRGUARD_API_TOKEN"); apiToken != "" {
+		config.API.Token = apiToken
+	}
 }
 
-// Validate checks if the configuration is valid
+// Validate checks if the configuration is valid, returning the first problem found
 func (c *Config) Validate() error {
+	if problems := c.Problems(); len(problems) > 0 {
+		return problems[0]
+	}
+	return nil
+}
+
+// Problems returns every reason the configuration is invalid
+func (c *Config) Problems() []error {
+	var problems []error
+
 	// Validate GitHub config
-	if c.GitHub.Token == "" {
-		return fmt.Errorf("github.token is required")
+	if c.GitHub.UsesApp() {
+		if c.GitHub.AppID == 0 || c.GitHub.InstallationID == 0 || c.GitHub.PrivateKeyPath == "" {
+			problems = append(problems, fmt.Errorf("github.app_id, github.installation_id, and github.private_key_path must be set together"))
+		}
+	} else if c.GitHub.Token == "" {
+		problems = append(problems, fmt.Errorf("github.token is required"))
 	}
 	if c.GitHub.Org == "" && c.GitHub.User == "" {
-		return fmt.Errorf("either github.org or github.user must be specified")
+		problems = append(problems, fmt.Errorf("either github.org or github.user must be specified"))
 	}
 
 	// Validate database config
-	if c.Database.Type == "" {
-		return fmt.Errorf("database.type is required")
+	switch c.Database.Type {
+	case "":
+		problems = append(problems, fmt.Errorf("database.type is required"))
+	case "sqlite":
+		if c.Database.Path == "" {
+			problems = append(problems, fmt.Errorf("database.path is required for sqlite"))
+		}
+	case "turso":
+		if c.Database.URL == "" {
+			problems = append(problems, fmt.Errorf("database.url is required for turso"))
+		}
+	default:
+		problems = append(problems, fmt.Errorf("database.type must be 'sqlite' or 'turso'"))
 	}
-	if c.Database.Type != "sqlite" && c.Database.Type != "turso" {
-		return fmt.Errorf("database.type must be 'sqlite' or 'turso'")
+
+	// Per-repository filters inherit the global spam regexes
+	for _, pattern := range c.Filters.SpamRegexes {
+		if _, err := regexp.Compile(pattern); err != nil {
+			problems = append(problems, fmt.Errorf("filters.spam_regexes: invalid regex %q: %w", pattern, err))
+		}
 	}
-	if c.Database.Type == "sqlite" && c.Database.Path == "" {
-		return fmt.Errorf("database.path is required for sqlite")
+
+	if _, err := c.Display.Location(); err != nil {
+		problems = append(problems, fmt.Errorf("display.time_zone: %w", err))
 	}
-	if c.Database.Type == "turso" && c.Database.URL == "" {
-		return fmt.Errorf("database.url is required for turso")
+
+	if c.Actions.LabelColor != "" && !labelColorPattern.MatchString(c.Actions.LabelColor) {
+		problems = append(problems, fmt.Errorf("actions.label_color must be a 6-digit hex color such as d73a4a, got %q", c.Actions.LabelColor))
+	}
+	if _, err := template.New("comment_template").Parse(c.Actions.CommentTemplate); err != nil {
+		problems = append(problems, fmt.Errorf("actions.comment_template: %w", err))
+	}
+	if _, err := template.New("block_reason_template").Parse(c.Actions.BlockReasonTemplate); err != nil {
+		problems = append(problems, fmt.Errorf("actions.block_reason_template: %w", err))
 	}
 
-	return nil
+	// Validate severity actions
+	severities := make([]string, 0, len(c.Actions.SeverityActions))
+	for severity := range c.Actions.SeverityActions {
+		severities = append(severities, severity)
+	}
+	sort.Strings(severities)
+	for _, severity := range severities {
+		spec := c.Actions.SeverityActions[severity]
+		switch severity {
+		case "low", "medium", "high":
+		default:
+			problems = append(problems, fmt.Errorf("actions.severity_actions: unknown severity %q (expected low, medium, or high)", severity))
+			continue
+		}
+		if _, err := ParseSeverityAction(spec); err != nil {
+			problems = append(problems, fmt.Errorf("actions.severity_actions.%s: %w", severity, err))
+		}
+	}
+
+	return problems
+}
+
+// Warnings returns suspicious but valid settings. Call it after SetDefaults so
+// that only values the user explicitly set are reported.
+func (c *Config) Warnings() []string {
+	var warnings []string
+
+	if c.Filters.MinFiles <= 0 {
+		warnings = append(warnings, fmt.Sprintf("filters.min_files is %d; every PR passes the file count check", c.Filters.MinFiles))
+	}
+	if c.Filters.MinLines <= 0 {
+		warnings = append(warnings, fmt.Sprintf("filters.min_lines is %d; every PR passes the line count check", c.Filters.MinLines))
+	}
+	if c.Filters.AccountAgeDays <= 0 {
+		warnings = append(warnings, fmt.Sprintf("filters.account_age_days is %d; new accounts are never flagged", c.Filters.AccountAgeDays))
+	}
+	if c.Filters.Concurrency < 1 {
+		warnings = append(warnings, fmt.Sprintf("filters.concurrency is %d; scans fall back to a single worker", c.Filters.Concurrency))
+	}
+	if len(c.Filters.Whitelist) == 0 {
+		warnings = append(warnings, "filters.whitelist is empty; bots such as dependabot will be scanned like any other author")
+	}
+	if len(c.Repositories) == 0 {
+		warnings = append(warnings, "no repositories configured; scan-all has nothing to scan")
+	}
+	if c.GitHub.MaxRetries != nil && *c.GitHub.MaxRetries < 0 {
+		warnings = append(warnings, "github.max_retries is negative; API calls are not retried")
+	}
+	if c.GitHub.Timeout < 0 {
+		warnings = append(warnings, "github.timeout is negative; scans run without a timeout")
+	}
+	if c.GitHub.BlockDelay < 0 {
+		warnings = append(warnings, "github.block_delay is negative; GitHub blocks are sent without waiting")
+	}
+	if c.Blocklist.FetchTimeout < 0 {
+		warnings = append(warnings, "blocklist.fetch_timeout is negative; the default of 30s is used")
+	}
+	if c.Filters.MinOriginalRepoRatio > 1 {
+		warnings = append(warnings, fmt.Sprintf("filters.min_original_repo_ratio is %g; it is a fraction between 0 and 1, so every author with repositories is flagged", c.Filters.MinOriginalRepoRatio))
+	}
+
+	return warnings
 }
 
 // SetDefaults sets default values for optional configuration fields
 func (c *Config) SetDefaults() {
+	if c.GitHub.MaxRetries == nil {
+		retries := DefaultMaxRetries
+		c.GitHub.MaxRetries = &retries
+	}
+	if c.GitHub.BlockDelay == 0 {
+		c.GitHub.BlockDelay = time.Second
+	}
 	if c.Filters.MinFiles == 0 {
 		c.Filters.MinFiles = 2
 	}
@@ -235,9 +672,36 @@ func (c *Config) SetDefaults() {
 	if c.Filters.AccountAgeDays == 0 {
 		c.Filters.AccountAgeDays = 7
 	}
+	if c.Filters.Concurrency == 0 {
+		c.Filters.Concurrency = 4
+	}
+	if c.Filters.GeneratedFilePatterns == nil {
+		c.Filters.GeneratedFilePatterns = DefaultGeneratedFilePatterns
+	}
+	if c.Filters.SensitiveFiles == nil {
+		c.Filters.SensitiveFiles = DefaultSensitiveFiles
+	}
+	if c.Notifications.SlackMaxPRs == 0 {
+		c.Notifications.SlackMaxPRs = 10
+	}
 	if c.Blocklist.ExportPath == "" {
 		c.Blocklist.ExportPath = "./exports"
 	}
+	if c.Blocklist.FetchTimeout == 0 {
+		c.Blocklist.FetchTimeout = 30 * time.Second
+	}
+	if c.Display.TimeFormat == "" {
+		c.Display.TimeFormat = "RFC3339"
+	}
+	if c.Display.TimeZone == "" {
+		c.Display.TimeZone = "UTC"
+	}
+	if c.Actions.SpamLabel == "" {
+		c.Actions.SpamLabel = DefaultSpamLabel
+	}
+	if c.Actions.LabelColor == "" {
+		c.Actions.LabelColor = DefaultLabelColor
+	}
 	if c.Actions.CommentTemplate == "" {
 		c.Actions.CommentTemplate = "This PR has been automatically closed due to low quality indicators.\nIf you believe this is an error, please contact the maintainers."
 	}
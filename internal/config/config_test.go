@@ -17,14 +17,15 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
-func TestLoad(t *testing.T) {
-	tmpDir := t.TempDir()
-	configPath := filepath.Join(tmpDir, "test-config.yaml")
-
-	configContent := `
+// loadTestYAML and loadTestTOML describe the same configuration
+const loadTestYAML = `
 github:
   token: "test-token-123"
   org: "test-org"
@@ -55,6 +56,39 @@ actions:
   comment_template: "Test comment"
 `
 
+const loadTestTOML = `
+[github]
+token = "test-token-123"
+org = "test-org"
+
+[database]
+type = "sqlite"
+path = "/tmp/test.db"
+
+[filters]
+min_files = 3
+min_lines = 15
+account_age_days = 10
+readme_only_block = true
+whitelist = ["bot1", "bot2"]
+spam_phrases = ["spam1", "spam2"]
+
+[blocklist]
+auto_export = true
+export_path = "/tmp/exports"
+
+[actions]
+close_prs = true
+add_spam_label = true
+comment_template = "Test comment"
+`
+
+func TestLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "test-config.yaml")
+
+	configContent := loadTestYAML
+
 	err := os.WriteFile(configPath, []byte(configContent), 0644) //nolint:gosec // test file
 	if err != nil {
 		t.Fatalf("Failed to write test config: %v", err)
@@ -121,6 +155,66 @@ actions:
 	}
 }
 
+func TestLoad_TOML(t *testing.T) {
+	tmpDir := t.TempDir()
+	yamlPath := filepath.Join(tmpDir, "test-config.yaml")
+	tomlPath := filepath.Join(tmpDir, "test-config.toml")
+
+	if err := os.WriteFile(yamlPath, []byte(loadTestYAML), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	if err := os.WriteFile(tomlPath, []byte(loadTestTOML), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	fromYAML, err := Load(yamlPath)
+	if err != nil {
+		t.Fatalf("Load YAML failed: %v", err)
+	}
+	fromTOML, err := Load(tomlPath)
+	if err != nil {
+		t.Fatalf("Load TOML failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(fromYAML, fromTOML) {
+		t.Errorf("TOML config differs from YAML config:\nyaml: %+v\ntoml: %+v", fromYAML, fromTOML)
+	}
+}
+
+func TestSave_TOMLRoundTrip(t *testing.T) {
+	minLines := 50
+	cfg := &Config{
+		GitHub:   GitHubConfig{Token: "test-token", User: "test-user", Timeout: 30 * time.Minute},
+		Database: DatabaseConfig{Type: "sqlite", Path: "/tmp/test.db"},
+		Repositories: []Repository{
+			{Owner: "org1", Name: "repo1", Filters: &RepositoryFilters{MinLines: &minLines}},
+			{Owner: "org2", Name: "repo2"},
+		},
+		Filters: FiltersConfig{Whitelist: []string{"dependabot[bot]"}},
+	}
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := Save(cfg, path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("Failed to read saved config: %v", err)
+	}
+	if !strings.Contains(string(data), "[github]") {
+		t.Errorf("Expected TOML output, got:\n%s", data)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, loaded) {
+		t.Errorf("Round-tripped config differs:\nsaved:  %+v\nloaded: %+v", cfg, loaded)
+	}
+}
+
 func TestLoadWithRepositories(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "test-config.yaml")
@@ -181,6 +275,32 @@ func TestValidate_MissingToken(t *testing.T) {
 	}
 }
 
+func TestValidate_GitHubApp(t *testing.T) {
+	tests := []struct {
+		name    string
+		github  GitHubConfig
+		wantErr bool
+	}{
+		{"complete app config without token", GitHubConfig{Org: "test-org", AppID: 1, InstallationID: 2, PrivateKeyPath: "/tmp/key.pem"}, false},
+		{"missing installation id", GitHubConfig{Org: "test-org", AppID: 1, PrivateKeyPath: "/tmp/key.pem"}, true},
+		{"missing private key", GitHubConfig{Org: "test-org", AppID: 1, InstallationID: 2}, true},
+		{"missing app id", GitHubConfig{Token: "test-token", Org: "test-org", InstallationID: 2, PrivateKeyPath: "/tmp/key.pem"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				GitHub:   tt.github,
+				Database: DatabaseConfig{Type: "sqlite", Path: "/tmp/test.db"},
+			}
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidate_MissingOrgAndUser(t *testing.T) {
 	cfg := &Config{
 		GitHub: GitHubConfig{
@@ -236,6 +356,33 @@ func TestValidate_MissingDatabasePath(t *testing.T) {
 	}
 }
 
+func TestLoad_MaxRetriesZeroDisablesRetries(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+github:
+  token: "test-token"
+  org: "test-org"
+  max_retries: 0
+
+database:
+  type: "sqlite"
+  path: "/tmp/test.db"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	cfg.SetDefaults()
+
+	if got := cfg.GitHub.Retries(); got != 0 {
+		t.Errorf("Expected max_retries 0 to disable retries, got %d", got)
+	}
+}
+
 func TestSetDefaults(t *testing.T) {
 	cfg := &Config{
 		GitHub: GitHubConfig{
@@ -253,6 +400,13 @@ func TestSetDefaults(t *testing.T) {
 
 	cfg.SetDefaults()
 
+	if cfg.GitHub.BlockDelay != time.Second {
+		t.Errorf("Expected default block_delay 1s, got %v", cfg.GitHub.BlockDelay)
+	}
+	if cfg.GitHub.Retries() != DefaultMaxRetries {
+		t.Errorf("Expected default max_retries %d, got %d", DefaultMaxRetries, cfg.GitHub.Retries())
+	}
+
 	// Check filter defaults
 	if cfg.Filters.MinFiles != 2 {
 		t.Errorf("Expected default min_files 2, got %d", cfg.Filters.MinFiles)
@@ -367,3 +521,441 @@ func TestFindConfigPath_NotFound(t *testing.T) {
 		t.Error("Expected error for non-existent config file")
 	}
 }
+
+func TestFindConfigPath_TOML(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := filepath.Join(home, ".config", "prguard")
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	tomlPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(tomlPath, []byte(loadTestTOML), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	path, err := FindConfigPath("config.yaml")
+	if err != nil {
+		t.Fatalf("FindConfigPath failed: %v", err)
+	}
+	if path != tomlPath {
+		t.Errorf("Expected %s, got %s", tomlPath, path)
+	}
+
+	// YAML wins when both exist in the same directory
+	yamlPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(yamlPath, []byte(loadTestYAML), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	if path, _ := FindConfigPath("config.yaml"); path != yamlPath {
+		t.Errorf("Expected %s, got %s", yamlPath, path)
+	}
+}
+
+func TestFiltersFor_RepositoryOverride(t *testing.T) {
+	readmeOnly := false
+	minLines := 50
+	cfg := &Config{
+		Filters: FiltersConfig{
+			MinFiles:        2,
+			MinLines:        10,
+			ReadmeOnlyBlock: true,
+			Whitelist:       []string{"dependabot[bot]"},
+		},
+		Repositories: []Repository{
+			{Owner: "org", Name: "docs", Filters: &RepositoryFilters{ReadmeOnlyBlock: &readmeOnly, MinLines: &minLines}},
+			{Owner: "org", Name: "code"},
+		},
+	}
+
+	docs := cfg.FiltersFor("org", "docs")
+	if docs.ReadmeOnlyBlock {
+		t.Error("Expected readme_only_block override to be false for org/docs")
+	}
+	if docs.MinLines != 50 {
+		t.Errorf("Expected min_lines override 50, got %d", docs.MinLines)
+	}
+	if docs.MinFiles != 2 {
+		t.Errorf("Expected min_files to fall back to global 2, got %d", docs.MinFiles)
+	}
+	if len(docs.Whitelist) != 1 {
+		t.Errorf("Expected whitelist to fall back to global, got %v", docs.Whitelist)
+	}
+
+	code := cfg.FiltersFor("org", "code")
+	if !code.ReadmeOnlyBlock {
+		t.Error("Expected org/code to use global readme_only_block")
+	}
+
+	unknown := cfg.FiltersFor("other", "repo")
+	if unknown.MinLines != 10 {
+		t.Errorf("Expected unconfigured repo to use global min_lines 10, got %d", unknown.MinLines)
+	}
+}
+
+func TestFiltersFor_MatchesCaseInsensitively(t *testing.T) {
+	readmeOnly := false
+	cfg := &Config{
+		Filters:      FiltersConfig{ReadmeOnlyBlock: true},
+		Repositories: []Repository{{Owner: "MyOrg", Name: "Docs", Filters: &RepositoryFilters{ReadmeOnlyBlock: &readmeOnly}}},
+	}
+
+	if cfg.FiltersFor("myorg", "docs").ReadmeOnlyBlock {
+		t.Error("Expected the MyOrg/Docs override to apply to myorg/docs")
+	}
+}
+
+func TestFiltersFor_InheritsLaterGlobalChanges(t *testing.T) {
+	minLines := 50
+	cfg := &Config{
+		Repositories: []Repository{{Owner: "org", Name: "docs", Filters: &RepositoryFilters{MinLines: &minLines}}},
+	}
+	cfg.SetDefaults()
+	cfg.Filters.AccountAgeDays = 30
+
+	if got := cfg.FiltersFor("org", "docs").AccountAgeDays; got != 30 {
+		t.Errorf("Expected account_age_days to follow the global filters, got %d", got)
+	}
+}
+
+func TestFiltersFor_SuspiciousEncodingOverride(t *testing.T) {
+	disabled := false
+	cfg := &Config{
+		Filters: FiltersConfig{SuspiciousEncoding: true},
+		Repositories: []Repository{
+			{Owner: "org", Name: "translations", Filters: &RepositoryFilters{SuspiciousEncoding: &disabled}},
+		},
+	}
+
+	if cfg.FiltersFor("org", "translations").SuspiciousEncoding {
+		t.Error("Expected suspicious_encoding to be disabled for org/translations")
+	}
+	if !cfg.FiltersFor("org", "app").SuspiciousEncoding {
+		t.Error("Expected other repositories to use global suspicious_encoding")
+	}
+}
+
+func TestLoadWithRepositoryFilters(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "test-config.yaml")
+
+	configContent := `
+github:
+  token: "test-token"
+  org: "test-org"
+
+database:
+  type: "sqlite"
+  path: "/tmp/test.db"
+
+filters:
+  readme_only_block: true
+
+repositories:
+  - owner: "org1"
+    name: "docs"
+    filters:
+      readme_only_block: false
+  - owner: "org1"
+    name: "code"
+`
+
+	_ = os.WriteFile(configPath, []byte(configContent), 0644) //nolint:errcheck,gosec // test file
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	cfg.SetDefaults()
+
+	override := cfg.Repositories[0].Filters
+	if override == nil {
+		t.Fatal("Expected filters override for org1/docs")
+	}
+	if override.ReadmeOnlyBlock == nil || *override.ReadmeOnlyBlock {
+		t.Error("Expected readme_only_block override to be false")
+	}
+
+	// Unset override fields inherit the global filters at lookup
+	if override.MinFiles != nil {
+		t.Errorf("Expected min_files override to stay unset, got %v", *override.MinFiles)
+	}
+	docs := cfg.FiltersFor("org1", "docs")
+	if docs.MinFiles != 2 || docs.AccountAgeDays != 7 {
+		t.Errorf("Expected defaults 2 and 7 to be inherited, got min_files %d and account_age_days %d", docs.MinFiles, docs.AccountAgeDays)
+	}
+
+	if cfg.Repositories[1].Filters != nil {
+		t.Error("Expected no filters override for org1/code")
+	}
+}
+
+func TestProblems_ReportsEveryError(t *testing.T) {
+	cfg := &Config{Database: DatabaseConfig{Type: "postgres"}}
+
+	problems := cfg.Problems()
+	if len(problems) != 3 {
+		t.Fatalf("Expected 3 problems, got %d: %v", len(problems), problems)
+	}
+	if err := cfg.Validate(); err == nil || err.Error() != problems[0].Error() {
+		t.Errorf("Expected Validate to return the first problem, got %v", err)
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	cfg := &Config{
+		GitHub:   GitHubConfig{Token: "test-token", Org: "test-org"},
+		Database: DatabaseConfig{Type: "sqlite", Path: "/tmp/test.db"},
+	}
+	cfg.SetDefaults()
+
+	warnings := cfg.Warnings()
+	if len(warnings) != 2 {
+		t.Errorf("Expected whitelist and repositories warnings, got %v", warnings)
+	}
+
+	cfg.Filters.Whitelist = []string{"dependabot[bot]"}
+	cfg.Repositories = []Repository{{Owner: "o", Name: "r"}}
+	cfg.Filters.MinLines = -5
+	warnings = cfg.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "filters.min_lines") {
+		t.Errorf("Expected only a min_lines warning, got %v", warnings)
+	}
+}
+
+func TestParseSeverityAction(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    SeverityAction
+		wantErr bool
+	}{
+		{"close+block", SeverityAction{Close: true, Block: true}, false},
+		{"label", SeverityAction{Label: true}, false},
+		{" Close + Label ", SeverityAction{Close: true, Label: true}, false},
+		{"report-only", SeverityAction{}, false},
+		{"none", SeverityAction{}, false},
+		{"close+ban", SeverityAction{}, true},
+		{"", SeverityAction{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSeverityAction(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSeverityAction(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseSeverityAction(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestProblems_SeverityActions(t *testing.T) {
+	cfg := &Config{
+		GitHub:   GitHubConfig{Token: "token", User: "testuser"},
+		Database: DatabaseConfig{Type: "sqlite", Path: "test.db"},
+		Actions: ActionsConfig{SeverityActions: map[string]string{
+			"high":     "close+block",
+			"medium":   "label+delete",
+			"critical": "close",
+		}},
+	}
+
+	problems := cfg.Problems()
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %d: %v", len(problems), problems)
+	}
+	if !strings.Contains(problems[0].Error(), `unknown severity "critical"`) {
+		t.Errorf("Expected unknown severity problem, got %v", problems[0])
+	}
+	if !strings.Contains(problems[1].Error(), "severity_actions.medium") {
+		t.Errorf("Expected invalid medium action problem, got %v", problems[1])
+	}
+}
+
+func TestLabelNames(t *testing.T) {
+	tests := []struct {
+		actions ActionsConfig
+		want    []string
+	}{
+		{ActionsConfig{}, []string{"spam"}},
+		{ActionsConfig{SpamLabel: "invalid"}, []string{"invalid"}},
+		{ActionsConfig{SpamLabel: "spam", Labels: []string{"needs-triage", " ", "spam"}}, []string{"spam", "needs-triage"}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.actions.LabelNames(); !slices.Equal(got, tt.want) {
+			t.Errorf("LabelNames(%+v) = %v, want %v", tt.actions, got, tt.want)
+		}
+	}
+}
+
+func TestProblems_LabelColor(t *testing.T) {
+	cfg := &Config{
+		GitHub:   GitHubConfig{Token: "token", User: "testuser"},
+		Database: DatabaseConfig{Type: "sqlite", Path: "test.db"},
+		Actions:  ActionsConfig{LabelColor: "#red"},
+	}
+
+	problems := cfg.Problems()
+	if len(problems) != 1 || !strings.Contains(problems[0].Error(), "actions.label_color") {
+		t.Errorf("Expected a label_color problem, got %v", problems)
+	}
+
+	cfg.Actions.LabelColor = "D73A4A"
+	if problems := cfg.Problems(); len(problems) != 0 {
+		t.Errorf("Expected no problems for a hex color, got %v", problems)
+	}
+}
+
+func TestProblems_SpamRegexes(t *testing.T) {
+	cfg := &Config{
+		GitHub:   GitHubConfig{Token: "token", User: "testuser"},
+		Database: DatabaseConfig{Type: "sqlite", Path: "test.db"},
+		Filters:  FiltersConfig{SpamRegexes: []string{`(?i)free\s+money`, `crypto(`}},
+	}
+
+	problems := cfg.Problems()
+	if len(problems) != 1 || !strings.Contains(problems[0].Error(), `filters.spam_regexes: invalid regex "crypto("`) {
+		t.Errorf("Expected an invalid spam regex problem, got %v", problems)
+	}
+
+	cfg.Filters.SpamRegexes = []string{`(?i)free\s+money`}
+	if problems := cfg.Problems(); len(problems) != 0 {
+		t.Errorf("Expected no problems for valid regexes, got %v", problems)
+	}
+}
+
+func TestProblems_Templates(t *testing.T) {
+	cfg := &Config{
+		GitHub:   GitHubConfig{Token: "token", User: "testuser"},
+		Database: DatabaseConfig{Type: "sqlite", Path: "test.db"},
+		Actions: ActionsConfig{
+			CommentTemplate:     "Closing #{{.PRNumber",
+			BlockReasonTemplate: "{{if .Author}}no end",
+		},
+	}
+
+	problems := cfg.Problems()
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %d: %v", len(problems), problems)
+	}
+	if !strings.Contains(problems[0].Error(), "actions.comment_template") {
+		t.Errorf("Expected a comment_template problem, got %v", problems[0])
+	}
+	if !strings.Contains(problems[1].Error(), "actions.block_reason_template") {
+		t.Errorf("Expected a block_reason_template problem, got %v", problems[1])
+	}
+
+	cfg.Actions.CommentTemplate = "Hi @{{.Author}}"
+	cfg.Actions.BlockReasonTemplate = "Spam in {{.Repo}}"
+	if problems := cfg.Problems(); len(problems) != 0 {
+		t.Errorf("Expected no problems for valid templates, got %v", problems)
+	}
+}
+
+func TestProblems_DisplayTimeZone(t *testing.T) {
+	cfg := &Config{
+		GitHub:   GitHubConfig{Token: "token", User: "testuser"},
+		Database: DatabaseConfig{Type: "sqlite", Path: "test.db"},
+		Display:  DisplayConfig{TimeZone: "Mars/Olympus"},
+	}
+
+	problems := cfg.Problems()
+	if len(problems) != 1 || !strings.Contains(problems[0].Error(), "display.time_zone") {
+		t.Errorf("Expected a time_zone problem, got %v", problems)
+	}
+
+	cfg.Display.TimeZone = "Local"
+	if problems := cfg.Problems(); len(problems) != 0 {
+		t.Errorf("Expected no problems for the local zone, got %v", problems)
+	}
+}
+
+func TestDisplayLayout(t *testing.T) {
+	tests := map[string]string{
+		"":                 time.RFC3339,
+		"RFC3339":          time.RFC3339,
+		"DateTime":         time.DateTime,
+		"2006-01-02 15:04": "2006-01-02 15:04",
+	}
+	for format, want := range tests {
+		if got := (DisplayConfig{TimeFormat: format}).Layout(); got != want {
+			t.Errorf("Layout(%q) = %q, want %q", format, got, want)
+		}
+	}
+}
+
+func TestTokenFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	tokenPath := filepath.Join(tmpDir, "token")
+	if err := os.WriteFile(tokenPath, []byte("  file-token\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+	envTokenPath := filepath.Join(tmpDir, "env-token")
+	if err := os.WriteFile(envTokenPath, []byte("env-file-token\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+
+	writeConfig := func(t *testing.T, github string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		content := "github:\n" + github + "  user: \"tester\"\ndatabase:\n  type: \"sqlite\"\n  path: \"/tmp/test.db\"\n"
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name      string
+		github    string
+		envToken  string
+		envFile   string
+		wantToken string
+	}{
+		{name: "token_file", github: "  token_file: \"" + tokenPath + "\"\n", wantToken: "file-token"},
+		{name: "token_file over inline token", github: "  token: \"inline-token\"\n  token_file: \"" + tokenPath + "\"\n", wantToken: "file-token"},
+		{name: "env token over token_file", github: "  token_file: \"" + tokenPath + "\"\n", envToken: "env-token", wantToken: "env-token"},
+		{name: "env token file", github: "  token: \"inline-token\"\n", envFile: envTokenPath, wantToken: "env-file-token"},
+		{name: "env token file over config token_file", github: "  token_file: \"" + tokenPath + "\"\n", envFile: envTokenPath, wantToken: "env-file-token"},
+		{name: "inline token", github: "  token: \"inline-token\"\n", wantToken: "inline-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PRGUARD_GITHUB_TOKEN", tt.envToken)
+			t.Setenv("PRGUARD_GITHUB_TOKEN_FILE", tt.envFile)
+
+			cfg, err := Load(writeConfig(t, tt.github))
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if cfg.GitHub.Token != tt.wantToken {
+				t.Errorf("Expected token %q, got %q", tt.wantToken, cfg.GitHub.Token)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Setenv("PRGUARD_GITHUB_TOKEN", "")
+		t.Setenv("PRGUARD_GITHUB_TOKEN_FILE", "")
+		_, err := Load(writeConfig(t, "  token_file: \""+filepath.Join(tmpDir, "missing")+"\"\n"))
+		if err == nil || !strings.Contains(err.Error(), "github.token_file") {
+			t.Errorf("Expected token_file read error, got %v", err)
+		}
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		t.Setenv("PRGUARD_GITHUB_TOKEN", "")
+		t.Setenv("PRGUARD_GITHUB_TOKEN_FILE", "")
+		emptyPath := filepath.Join(tmpDir, "empty")
+		if err := os.WriteFile(emptyPath, []byte("\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write token file: %v", err)
+		}
+		if _, err := Load(writeConfig(t, "  token_file: \""+emptyPath+"\"\n")); err == nil || !strings.Contains(err.Error(), "is empty") {
+			t.Errorf("Expected empty token_file error, got %v", err)
+		}
+	})
+}
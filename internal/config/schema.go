@@ -0,0 +1,119 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/prguard/prguard/pkg/models"
+)
+
+// schemaURI identifies the JSON Schema draft the generated schema follows
+const schemaURI = "https://json-schema.org/draft/2020-12/schema"
+
+// schemaEnums restricts fields, by dotted YAML path, to a fixed set of values
+var schemaEnums = map[string][]string{
+	"database.type": {"sqlite", "turso"},
+}
+
+// severities lists the severities accepted as severity_actions keys
+var severities = []string{models.SeverityLow, models.SeverityMedium, models.SeverityHigh}
+
+// severityActionPattern matches the severity_actions values ParseSeverityAction accepts
+const severityActionPattern = `^(report-only|none|(close|block|label)(\+(close|block|label))*)$`
+
+// Schema returns a JSON Schema describing the configuration file, generated
+// from the Config struct and its YAML field names
+func Schema() map[string]any {
+	schema := schemaFor(reflect.TypeOf(Config{}), "")
+	schema["$schema"] = schemaURI
+	schema["title"] = "PRGuard configuration"
+	return schema
+}
+
+// SchemaJSON returns Schema encoded as indented JSON
+func SchemaJSON() ([]byte, error) {
+	return json.MarshalIndent(Schema(), "", "  ")
+}
+
+// schemaFor describes a Go type; path is the dotted YAML path of the field
+func schemaFor(t reflect.Type, path string) map[string]any {
+	if values, ok := schemaEnums[path]; ok {
+		return map[string]any{"type": "string", "enum": values}
+	}
+
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return map[string]any{"type": "string", "description": "Go duration such as 30s, 5m or 1h"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem(), path)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), path+"[]")}
+	case reflect.Map:
+		schema := map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), path+".*")}
+		if path == "actions.severity_actions" {
+			schema["propertyNames"] = map[string]any{"enum": severities}
+			schema["additionalProperties"] = map[string]any{"type": "string", "pattern": severityActionPattern}
+		}
+		return schema
+	case reflect.Struct:
+		properties := make(map[string]any)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := yamlName(field)
+			if name == "" {
+				continue
+			}
+			fieldPath := name
+			if path != "" {
+				fieldPath = path + "." + name
+			}
+			properties[name] = schemaFor(field.Type, fieldPath)
+		}
+		return map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+	default:
+		return map[string]any{}
+	}
+}
+
+// yamlName returns the YAML key of an exported struct field, or "" when the
+// field is unexported or skipped
+func yamlName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestSchemaJSON(t *testing.T) {
+	data, err := SchemaJSON()
+	if err != nil {
+		t.Fatalf("SchemaJSON failed: %v", err)
+	}
+
+	var schema struct {
+		Properties map[string]struct {
+			Type       string `json:"type"`
+			Properties map[string]struct {
+				Type                 string                   `json:"type"`
+				Enum                 []string                 `json:"enum"`
+				PropertyNames        struct{ Enum []string }  `json:"propertyNames"`
+				AdditionalProperties struct{ Pattern string } `json:"additionalProperties"`
+			} `json:"properties"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	dbType := schema.Properties["database"].Properties["type"]
+	if !slices.Equal(dbType.Enum, []string{"sqlite", "turso"}) {
+		t.Errorf("Expected database.type enum [sqlite turso], got %v", dbType.Enum)
+	}
+
+	severityActions := schema.Properties["actions"].Properties["severity_actions"]
+	if !slices.Equal(severityActions.PropertyNames.Enum, []string{"low", "medium", "high"}) {
+		t.Errorf("Expected severity keys, got %v", severityActions.PropertyNames.Enum)
+	}
+	if severityActions.AdditionalProperties.Pattern == "" {
+		t.Error("Expected severity_actions values to be restricted by pattern")
+	}
+
+	filters := schema.Properties["filters"].Properties
+	if filters["min_files"].Type != "integer" || filters["whitelist"].Type != "array" {
+		t.Errorf("Expected filter field types, got min_files=%s whitelist=%s", filters["min_files"].Type, filters["whitelist"].Type)
+	}
+	if schema.Properties["github"].Properties["timeout"].Type != "string" {
+		t.Error("Expected durations to be strings")
+	}
+}
+
+func TestSchema_SeverityActionPattern(t *testing.T) {
+	for _, spec := range []string{"close+block", "label", "report-only", "none", "close+block+label"} {
+		if _, err := ParseSeverityAction(spec); err != nil {
+			t.Fatalf("ParseSeverityAction(%q) failed: %v", spec, err)
+		}
+		if !regexp.MustCompile(severityActionPattern).MatchString(spec) {
+			t.Errorf("Expected schema pattern to accept %q", spec)
+		}
+	}
+}
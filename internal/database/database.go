@@ -16,27 +16,24 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	_ "embed"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3" // SQLite driver
 	"github.com/prguard/prguard/pkg/models"
 	_ "github.com/tursodatabase/libsql-client-go/libsql" // Turso/libSQL driver
 )
 
-//go:embed migrations/001_initial_schema.up.sql
-var initialSchema string
-
 // DB wraps a database connection
 type DB struct {
 	conn *sql.DB
-	// Store connection info for migrations
-	dbType    string
-	dbURL     string
-	authToken string
 }
 
 // NewSQLiteDB creates a new SQLite database connection
@@ -54,40 +51,21 @@ func NewSQLiteDB(path string) (*DB, error) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	if err := conn.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+	// Each connection to :memory: is a separate database, so pin the pool
+	// to one connection to keep the migrated schema visible
+	if path == ":memory:" {
+		conn.SetMaxOpenConns(1)
 	}
 
-	db := &DB{
-		conn:      conn,
-		dbType:    "sqlite",
-		dbURL:     path,
-		authToken: "",
+	if err := conn.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// For in-memory databases (used in tests), run SQL migrations directly
-	// For file-based databases, use geni
-	if path == ":memory:" {
-		if err := runSQLMigrations(conn); err != nil {
-			return nil, fmt.Errorf("failed to run SQL migrations: %w", err)
-		}
-	} else {
-		if err := RunMigrations(conn, db.dbType, db.dbURL, db.authToken); err != nil {
-			return nil, fmt.Errorf("failed to run migrations: %w", err)
-		}
+	if err := RunMigrations(conn); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	return db, nil
-}
-
-// runSQLMigrations runs migrations directly from embedded SQL (for in-memory databases)
-func runSQLMigrations(conn *sql.DB) error {
-	// Execute the initial schema
-	_, err := conn.Exec(initialSchema)
-	if err != nil {
-		return fmt.Errorf("failed to execute initial schema: %w", err)
-	}
-	return nil
+	return &DB{conn: conn}, nil
 }
 
 // NewTursoDB creates a new Turso (libSQL) database connection
@@ -104,19 +82,11 @@ func NewTursoDB(url, authToken string) (*DB, error) {
 		return nil, fmt.Errorf("failed to ping turso database: %w", err)
 	}
 
-	db := &DB{
-		conn:      conn,
-		dbType:    "turso",
-		dbURL:     url,
-		authToken: authToken,
-	}
-
-	// Run migrations
-	if err := RunMigrations(conn, db.dbType, db.dbURL, db.authToken); err != nil {
+	if err := RunMigrations(conn); err != nil {
 		return nil, fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	return db, nil
+	return &DB{conn: conn}, nil
 }
 
 // Close closes the database connection
@@ -124,13 +94,187 @@ func (db *DB) Close() error {
 	return db.conn.Close()
 }
 
+// Ping verifies the database connection is still usable
+func (db *DB) Ping(ctx context.Context) error {
+	return db.conn.PingContext(ctx)
+}
+
+// entryColumns lists the blocklist columns in scan order
+const entryColumns = `id, username, reason, evidence_url, timestamp, blocked_by, severity, source, metadata, expires_at, tags`
+
+// querier is implemented by *sql.DB and *sql.Tx so single-row operations can
+// run inside or outside a transaction
+type querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// Tx is a transaction exposing the blocklist operations that can be grouped
+// with WithTransaction
+type Tx struct {
+	tx *sql.Tx
+}
+
+// WithTransaction runs fn inside a transaction, committing only if fn returns
+// nil; otherwise every write made through tx is rolled back
+func (db *DB) WithTransaction(fn func(tx *Tx) error) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := fn(&Tx{tx: tx}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// AddEntry adds a new blocklist entry within the transaction
+func (t *Tx) AddEntry(entry *models.BlocklistEntry) error {
+	return addEntry(t.tx, entry)
+}
+
+// GetEntry retrieves a blocklist entry by ID within the transaction
+func (t *Tx) GetEntry(id string) (*models.BlocklistEntry, error) {
+	return getEntry(t.tx, id)
+}
+
+// UpdateEntry updates an existing blocklist entry within the transaction
+func (t *Tx) UpdateEntry(entry *models.BlocklistEntry) error {
+	return updateEntry(t.tx, entry)
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanEntry scans a blocklist row selected with entryColumns
+func scanEntry(row rowScanner) (*models.BlocklistEntry, error) {
+	var entry models.BlocklistEntry
+	var expiresAt sql.NullTime
+	var tags string
+	err := row.Scan(
+		&entry.ID,
+		&entry.Username,
+		&entry.Reason,
+		&entry.EvidenceURL,
+		&entry.Timestamp,
+		&entry.BlockedBy,
+		&entry.Severity,
+		&entry.Source,
+		&entry.Metadata,
+		&expiresAt,
+		&tags,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		entry.ExpiresAt = &expiresAt.Time
+	}
+	if err := json.Unmarshal([]byte(tags), &entry.Tags); err != nil {
+		return nil, fmt.Errorf("failed to decode tags for entry %s: %w", entry.ID, err)
+	}
+	return &entry, nil
+}
+
+// scanEntries scans all rows selected with entryColumns
+func scanEntries(rows *sql.Rows) ([]*models.BlocklistEntry, error) {
+	defer rows.Close() //nolint:errcheck
+
+	var entries []*models.BlocklistEntry
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// expiresAtValue converts an optional expiry to a UTC database value
+func expiresAtValue(expiresAt *time.Time) any {
+	if expiresAt == nil {
+		return nil
+	}
+	return expiresAt.UTC()
+}
+
+// tagsValue encodes tags as the JSON array stored in the tags column
+func tagsValue(tags []string) string {
+	if len(tags) == 0 {
+		return "[]"
+	}
+	data, _ := json.Marshal(tags) //nolint:errcheck // marshaling a []string cannot fail
+	return string(data)
+}
+
 // AddEntry adds a new blocklist entry
 func (db *DB) AddEntry(entry *models.BlocklistEntry) error {
-	query := `
-		INSERT INTO blocklist (id, username, reason, evidence_url, timestamp, blocked_by, severity, source, metadata)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
-	_, err := db.conn.Exec(query,
+	return addEntry(db.conn, entry)
+}
+
+func addEntry(q querier, entry *models.BlocklistEntry) error {
+	_, err := q.Exec(insertEntryQuery, entryArgs(entry)...)
+	return err
+}
+
+// AddEntries inserts entries in a single transaction, skipping any whose username
+// is already actively blocked or appears earlier in the batch. It returns the
+// number of entries added; on error nothing is written.
+func (db *DB) AddEntries(entries []*models.BlocklistEntry) (int, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	now := time.Now().UTC()
+	seen := make(map[string]bool, len(entries))
+	added := 0
+	for _, entry := range entries {
+		key := strings.ToLower(entry.Username)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		var count int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM blocklist WHERE username = ? COLLATE NOCASE AND (expires_at IS NULL OR expires_at > ?)`, entry.Username, now).Scan(&count); err != nil {
+			return 0, fmt.Errorf("failed to check existing entry for %s: %w", entry.Username, err)
+		}
+		if count > 0 {
+			continue
+		}
+
+		if _, err := tx.Exec(insertEntryQuery, entryArgs(entry)...); err != nil {
+			return 0, fmt.Errorf("failed to add entry for %s: %w", entry.Username, err)
+		}
+		added++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return added, nil
+}
+
+// insertEntryQuery inserts a single blocklist entry; see entryArgs for the parameters
+const insertEntryQuery = `
+	INSERT INTO blocklist (id, username, reason, evidence_url, timestamp, blocked_by, severity, source, metadata, expires_at, tags)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+// entryArgs returns the insertEntryQuery parameters for an entry
+func entryArgs(entry *models.BlocklistEntry) []any {
+	return []any{
 		entry.ID,
 		entry.Username,
 		entry.Reason,
@@ -140,69 +284,620 @@ func (db *DB) AddEntry(entry *models.BlocklistEntry) error {
 		entry.Severity,
 		entry.Source,
 		entry.Metadata,
-	)
-	return err
+		expiresAtValue(entry.ExpiresAt),
+		tagsValue(entry.Tags),
+	}
 }
 
 // GetEntry retrieves a blocklist entry by ID
 func (db *DB) GetEntry(id string) (*models.BlocklistEntry, error) {
-	query := `SELECT id, username, reason, evidence_url, timestamp, blocked_by, severity, source, metadata FROM blocklist WHERE id = ?`
+	return getEntry(db.conn, id)
+}
 
-	var entry models.BlocklistEntry
-	err := db.conn.QueryRow(query, id).Scan(
-		&entry.ID,
-		&entry.Username,
-		&entry.Reason,
-		&entry.EvidenceURL,
-		&entry.Timestamp,
-		&entry.BlockedBy,
-		&entry.Severity,
-		&entry.Source,
-		&entry.Metadata,
-	)
+func getEntry(q querier, id string) (*models.BlocklistEntry, error) {
+	query := `SELECT ` + entryColumns + ` FROM blocklist WHERE id = ?`
+
+	entry, err := scanEntry(q.QueryRow(query, id))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
-	return &entry, nil
+	return entry, nil
 }
 
-// IsBlocked checks if a username is in the blocklist
+// IsBlocked checks if a username is in the blocklist, ignoring expired entries.
+// Usernames match case-insensitively as they do on GitHub.
 func (db *DB) IsBlocked(username string) (bool, error) {
-	query := `SELECT COUNT(*) FROM blocklist WHERE username = ?`
+	query := `SELECT COUNT(*) FROM blocklist WHERE username = ? COLLATE NOCASE AND (expires_at IS NULL OR expires_at > ?)`
 	var count int
-	err := db.conn.QueryRow(query, username).Scan(&count)
+	err := db.conn.QueryRow(query, username, time.Now().UTC()).Scan(&count)
 	if err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
 
-// GetEntriesByUsername retrieves all blocklist entries for a username
+// CountOffenses returns how many times a username has been blocked, ignoring
+// case and including expired entries. Entries citing the same evidence URL are
+// one offense; entries without evidence each count.
+func (db *DB) CountOffenses(username string) (int, error) {
+	var count int
+	err := db.conn.QueryRow(`SELECT COUNT(DISTINCT CASE WHEN evidence_url = '' THEN id ELSE evidence_url END)
+		FROM blocklist WHERE username = ? COLLATE NOCASE`, username).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetEntryByEvidence retrieves the newest entry for a username (ignoring case)
+// recorded with exactly evidenceURL, or nil if there is none
+func (db *DB) GetEntryByEvidence(username, evidenceURL string) (*models.BlocklistEntry, error) {
+	query := `SELECT ` + entryColumns + ` FROM blocklist
+		WHERE username = ? COLLATE NOCASE AND evidence_url = ?
+		ORDER BY timestamp DESC LIMIT 1`
+
+	entry, err := scanEntry(db.conn.QueryRow(query, username, evidenceURL))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// GetEntriesByUsername retrieves all blocklist entries for a username, ignoring case
 func (db *DB) GetEntriesByUsername(username string) ([]*models.BlocklistEntry, error) {
-	query := `SELECT id, username, reason, evidence_url, timestamp, blocked_by, severity, source, metadata FROM blocklist WHERE username = ?`
+	query := `SELECT ` + entryColumns + ` FROM blocklist WHERE username = ? COLLATE NOCASE`
 
 	rows, err := db.conn.Query(query, username)
 	if err != nil {
 		return nil, err
 	}
+	return scanEntries(rows)
+}
+
+// GetEntriesByTag retrieves blocklist entries carrying tag, newest first
+func (db *DB) GetEntriesByTag(tag string) ([]*models.BlocklistEntry, error) {
+	query := `SELECT ` + entryColumns + ` FROM blocklist
+		WHERE EXISTS (SELECT 1 FROM json_each(blocklist.tags) WHERE json_each.value = ?)
+		ORDER BY timestamp DESC`
+
+	rows, err := db.conn.Query(query, tag)
+	if err != nil {
+		return nil, err
+	}
+	return scanEntries(rows)
+}
+
+// ListEntries retrieves all blocklist entries
+func (db *DB) ListEntries() ([]*models.BlocklistEntry, error) {
+	return db.ListEntriesFiltered(models.EntryFilter{})
+}
+
+// ListEntriesFiltered retrieves blocklist entries matching the filter, newest first
+func (db *DB) ListEntriesFiltered(filter models.EntryFilter) ([]*models.BlocklistEntry, error) {
+	query, args, err := filteredEntriesQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanEntries(rows)
+}
+
+// EachEntryFiltered calls fn for every entry matching filter, newest first, reading
+// rows one at a time so the full result set is never held in memory. Iteration
+// stops at the first error returned by fn.
+func (db *DB) EachEntryFiltered(filter models.EntryFilter, fn func(*models.BlocklistEntry) error) error {
+	query, args, err := filteredEntriesQuery(filter)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return err
+	}
 	defer rows.Close() //nolint:errcheck
 
-	var entries []*models.BlocklistEntry
 	for rows.Next() {
-		var entry models.BlocklistEntry
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// filteredEntriesQuery builds the SELECT for entries matching filter, newest
+// first, limited to the page given by its Limit and Offset
+func filteredEntriesQuery(filter models.EntryFilter) (string, []any, error) {
+	where, args, err := entryFilterWhere(filter)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query := `SELECT ` + entryColumns + ` FROM blocklist` + where + ` ORDER BY timestamp DESC, id`
+	if filter.Limit > 0 || filter.Offset > 0 {
+		// SQLite only accepts OFFSET after a LIMIT; -1 means no limit
+		limit := filter.Limit
+		if limit <= 0 {
+			limit = -1
+		}
+		query += ` LIMIT ? OFFSET ?`
+		args = append(args, limit, filter.Offset)
+	}
+
+	return query, args, nil
+}
+
+// entryFilterWhere builds the WHERE clause for filter's conditions, ignoring
+// its Limit and Offset; it is empty when no condition is set
+func entryFilterWhere(filter models.EntryFilter) (string, []any, error) {
+	var conditions []string
+	var args []any
+
+	if filter.MinSeverity != "" {
+		minRank := models.SeverityRank(filter.MinSeverity)
+		if minRank == 0 {
+			return "", nil, fmt.Errorf("invalid severity %q, must be low/medium/high", filter.MinSeverity)
+		}
+		var placeholders []string
+		for _, severity := range []string{models.SeverityLow, models.SeverityMedium, models.SeverityHigh} {
+			if models.SeverityRank(severity) >= minRank {
+				placeholders = append(placeholders, "?")
+				args = append(args, severity)
+			}
+		}
+		conditions = append(conditions, "severity IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.Tag != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM json_each(blocklist.tags) WHERE json_each.value = ?)")
+		args = append(args, filter.Tag)
+	}
+	// datetime() normalizes stored timestamps to UTC regardless of their offset
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "datetime(timestamp) >= datetime(?)")
+		args = append(args, filter.Since.UTC())
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "datetime(timestamp) < datetime(?)")
+		args = append(args, filter.Until.UTC())
+	}
+
+	if len(conditions) == 0 {
+		return "", args, nil
+	}
+	return ` WHERE ` + strings.Join(conditions, " AND "), args, nil
+}
+
+// CountEntries returns the number of blocklist entries, optionally within a severity
+func (db *DB) CountEntries(severity string) (int, error) {
+	return db.count(`COUNT(*)`, severity)
+}
+
+// CountUniqueUsernames returns the number of distinct blocked usernames, optionally within a severity
+func (db *DB) CountUniqueUsernames(severity string) (int, error) {
+	return db.count(`COUNT(DISTINCT username COLLATE NOCASE)`, severity)
+}
+
+// count runs an aggregate over the blocklist, filtering by severity when non-empty
+func (db *DB) count(aggregate, severity string) (int, error) {
+	query := `SELECT ` + aggregate + ` FROM blocklist`
+	var args []any
+	if severity != "" {
+		query += ` WHERE severity = ?`
+		args = append(args, severity)
+	}
+
+	var n int
+	if err := db.conn.QueryRow(query, args...).Scan(&n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// ListEntriesPaged retrieves the page of entries matching filter given by its
+// Limit and Offset, along with the total number of entries matching it
+func (db *DB) ListEntriesPaged(filter models.EntryFilter) ([]*models.BlocklistEntry, int, error) {
+	where, args, err := entryFilterWhere(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	var total int
+	if err := db.conn.QueryRow(`SELECT COUNT(*) FROM blocklist`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	entries, err := db.ListEntriesFiltered(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// severityRankExpr ranks the severity column from low (1) to high (3); unknown values rank 0
+const severityRankExpr = "CASE severity WHEN 'high' THEN 3 WHEN 'medium' THEN 2 WHEN 'low' THEN 1 ELSE 0 END"
+
+// sortOrder is the ORDER BY expression for a sort field and its natural direction
+type sortOrder struct {
+	expr string
+	desc bool
+}
+
+// sortOrders whitelists the fields entries may be sorted by. Usernames and
+// sources sort A–Z, severities high first and timestamps newest first.
+var sortOrders = map[string]sortOrder{
+	"username":  {expr: "username COLLATE NOCASE"},
+	"severity":  {expr: severityRankExpr, desc: true},
+	"timestamp": {expr: "timestamp", desc: true},
+	"source":    {expr: "source"},
+}
+
+// SortFields lists the fields accepted by ListEntriesSorted
+var SortFields = []string{"username", "severity", "timestamp", "source"}
+
+// ListEntriesSorted retrieves all blocklist entries ordered by sortField, which
+// must be one of SortFields. Reverse flips the field's natural direction; ties
+// are always broken newest first.
+func (db *DB) ListEntriesSorted(sortField string, reverse bool) ([]*models.BlocklistEntry, error) {
+	order, ok := sortOrders[sortField]
+	if !ok {
+		return nil, fmt.Errorf("invalid sort field %q, must be %s", sortField, strings.Join(SortFields, "/"))
+	}
+
+	direction := "ASC"
+	if order.desc != reverse {
+		direction = "DESC"
+	}
+	query := `SELECT ` + entryColumns + ` FROM blocklist ORDER BY ` + order.expr + ` ` + direction + `, timestamp DESC, id`
+
+	rows, err := db.conn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	return scanEntries(rows)
+}
+
+// searchColumns maps search field names to blocklist columns
+var searchColumns = map[string]string{
+	"username": "username",
+	"reason":   "reason",
+	"evidence": "evidence_url",
+}
+
+// SearchEntries finds entries whose username, reason, or evidence URL contain term
+// (case-insensitive). A non-empty field restricts the match to that column and a
+// non-empty severity restricts results to that severity.
+func (db *DB) SearchEntries(term, field, severity string) ([]*models.BlocklistEntry, error) {
+	columns := []string{"username", "reason", "evidence_url"}
+	if field != "" {
+		column, ok := searchColumns[field]
+		if !ok {
+			return nil, fmt.Errorf("invalid search field %q, must be username/reason/evidence", field)
+		}
+		columns = []string{column}
+	}
+
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(strings.ToLower(term))
+	pattern := "%" + escaped + "%"
+
+	var conditions []string
+	var args []any
+	for _, column := range columns {
+		conditions = append(conditions, "LOWER("+column+`) LIKE ? ESCAPE '\'`)
+		args = append(args, pattern)
+	}
+
+	query := `SELECT ` + entryColumns + ` FROM blocklist WHERE (` + strings.Join(conditions, " OR ") + `)`
+	if severity != "" {
+		query += ` AND severity = ?`
+		args = append(args, severity)
+	}
+	query += ` ORDER BY timestamp DESC`
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanEntries(rows)
+}
+
+// PurgeExpired deletes entries whose expiry has passed and returns the number removed
+func (db *DB) PurgeExpired() (int64, error) {
+	query := `DELETE FROM blocklist WHERE expires_at IS NOT NULL AND expires_at <= ?`
+	result, err := db.conn.Exec(query, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// FindDuplicateUsernames returns, sorted, the usernames (ignoring case) that
+// have more than one blocklist entry
+func (db *DB) FindDuplicateUsernames() ([]string, error) {
+	return findDuplicateUsernames(db.conn)
+}
+
+func findDuplicateUsernames(q interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}) ([]string, error) {
+	rows, err := q.Query(`SELECT MIN(username) FROM blocklist GROUP BY username COLLATE NOCASE HAVING COUNT(*) > 1 ORDER BY MIN(username) COLLATE NOCASE`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var usernames []string
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			return nil, err
+		}
+		usernames = append(usernames, username)
+	}
+	return usernames, rows.Err()
+}
+
+// PruneDuplicates collapses the entries of every username with more than one
+// into the highest-severity, most recent entry and returns the number of
+// entries removed. The kept entry takes the union of the tags, the longest
+// expiry, and any GitHub block mark, and records the removed entries' reasons
+// and evidence under merged_entries in its metadata. All changes are made in
+// one transaction.
+func (db *DB) PruneDuplicates() (int64, error) {
+	var removed int64
+	err := db.WithTransaction(func(tx *Tx) error {
+		usernames, err := findDuplicateUsernames(tx.tx)
+		if err != nil {
+			return fmt.Errorf("failed to find duplicate usernames: %w", err)
+		}
+
+		for _, username := range usernames {
+			rows, err := tx.tx.Query(`SELECT `+entryColumns+` FROM blocklist WHERE username = ? COLLATE NOCASE ORDER BY `+severityRankExpr+` DESC, timestamp DESC, id`, username)
+			if err != nil {
+				return fmt.Errorf("failed to query entries for %s: %w", username, err)
+			}
+			entries, err := scanEntries(rows)
+			if err != nil {
+				return fmt.Errorf("failed to read entries for %s: %w", username, err)
+			}
+			if len(entries) < 2 {
+				continue
+			}
+
+			keep, duplicates := entries[0], entries[1:]
+			if err := mergeDuplicates(keep, duplicates); err != nil {
+				return fmt.Errorf("failed to merge entries for %s: %w", username, err)
+			}
+			if err := updateEntry(tx.tx, keep); err != nil {
+				return fmt.Errorf("failed to update entry %s: %w", keep.ID, err)
+			}
+			for _, duplicate := range duplicates {
+				if _, err := tx.tx.Exec(`DELETE FROM blocklist WHERE id = ?`, duplicate.ID); err != nil {
+					return fmt.Errorf("failed to remove entry %s: %w", duplicate.ID, err)
+				}
+				removed++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// mergedEntry is how PruneDuplicates records a removed entry in the kept entry's metadata
+type mergedEntry struct {
+	Reason      string    `json:"reason"`
+	EvidenceURL string    `json:"evidence_url"`
+	Severity    string    `json:"severity"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// mergeDuplicates folds the tags, expiry, GitHub block mark, reasons and
+// evidence of duplicates into keep. Unknown metadata keys of keep are preserved.
+func mergeDuplicates(keep *models.BlocklistEntry, duplicates []*models.BlocklistEntry) error {
+	fields := make(map[string]json.RawMessage)
+	if keep.Metadata != "" {
+		if err := json.Unmarshal([]byte(keep.Metadata), &fields); err != nil {
+			return fmt.Errorf("failed to decode metadata: %w", err)
+		}
+	}
+
+	var merged []mergedEntry
+	if raw, ok := fields["merged_entries"]; ok {
+		if err := json.Unmarshal(raw, &merged); err != nil {
+			return fmt.Errorf("failed to decode merged entries: %w", err)
+		}
+	}
+
+	for _, duplicate := range duplicates {
+		merged = append(merged, mergedEntry{
+			Reason:      duplicate.Reason,
+			EvidenceURL: duplicate.EvidenceURL,
+			Severity:    duplicate.Severity,
+			Timestamp:   duplicate.Timestamp,
+		})
+
+		for _, tag := range duplicate.Tags {
+			if !slices.Contains(keep.Tags, tag) {
+				keep.Tags = append(keep.Tags, tag)
+			}
+		}
+
+		// A permanent block outlasts any expiring one
+		if keep.ExpiresAt != nil && (duplicate.ExpiresAt == nil || duplicate.ExpiresAt.After(*keep.ExpiresAt)) {
+			keep.ExpiresAt = duplicate.ExpiresAt
+		}
+
+		// Keep the GitHub block mark so resumed batch blocking still skips the user
+		if _, ok := fields["github_blocked_at"]; !ok {
+			if metadata, err := duplicate.ParseMetadata(); err == nil && metadata.GitHubBlockedAt != nil {
+				blockedAt, _ := json.Marshal(metadata.GitHubBlockedAt.UTC()) //nolint:errcheck // marshaling a time cannot fail
+				fields["github_blocked_at"] = blockedAt
+			}
+		}
+	}
+
+	encodedMerged, err := json.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("failed to encode merged entries: %w", err)
+	}
+	fields["merged_entries"] = encodedMerged
+
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	keep.Metadata = string(encoded)
+	return nil
+}
+
+// RemoveEntry removes a blocklist entry by ID
+func (db *DB) RemoveEntry(id string) error {
+	query := `DELETE FROM blocklist WHERE id = ?`
+	_, err := db.conn.Exec(query, id)
+	return err
+}
+
+// RemoveByUsername removes all blocklist entries for a username, ignoring case
+func (db *DB) RemoveByUsername(username string) error {
+	query := `DELETE FROM blocklist WHERE username = ? COLLATE NOCASE`
+	_, err := db.conn.Exec(query, username)
+	return err
+}
+
+// UpdateEntry updates an existing blocklist entry
+func (db *DB) UpdateEntry(entry *models.BlocklistEntry) error {
+	return updateEntry(db.conn, entry)
+}
+
+func updateEntry(q querier, entry *models.BlocklistEntry) error {
+	query := `
+		UPDATE blocklist
+		SET reason = ?, evidence_url = ?, severity = ?, metadata = ?, expires_at = ?, tags = ?
+		WHERE id = ?
+	`
+	_, err := q.Exec(query, entry.Reason, entry.EvidenceURL, entry.Severity, entry.Metadata, expiresAtValue(entry.ExpiresAt), tagsValue(entry.Tags), entry.ID)
+	return err
+}
+
+// MarkGitHubBlocked records in the metadata of every entry for username
+// (ignoring case) that the user was blocked via the GitHub API, so batch
+// blocking can skip them when it is resumed. Other metadata keys are kept.
+func (db *DB) MarkGitHubBlocked(username string) error {
+	return db.WithTransaction(func(tx *Tx) error {
+		rows, err := tx.tx.Query(`SELECT id, metadata FROM blocklist WHERE username = ? COLLATE NOCASE`, username)
+		if err != nil {
+			return fmt.Errorf("failed to query entries: %w", err)
+		}
+		metadata := make(map[string]string)
+		for rows.Next() {
+			var id, raw string
+			if err := rows.Scan(&id, &raw); err != nil {
+				rows.Close() //nolint:errcheck
+				return fmt.Errorf("failed to scan entry: %w", err)
+			}
+			metadata[id] = raw
+		}
+		if err := rows.Close(); err != nil {
+			return fmt.Errorf("failed to read entries: %w", err)
+		}
+
+		blockedAt, _ := json.Marshal(time.Now().UTC()) //nolint:errcheck // marshaling a time cannot fail
+		for id, raw := range metadata {
+			fields := make(map[string]json.RawMessage)
+			if raw != "" {
+				if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+					return fmt.Errorf("failed to decode metadata for entry %s: %w", id, err)
+				}
+			}
+			fields["github_blocked_at"] = blockedAt
+			encoded, err := json.Marshal(fields)
+			if err != nil {
+				return fmt.Errorf("failed to encode metadata for entry %s: %w", id, err)
+			}
+			if _, err := tx.tx.Exec(`UPDATE blocklist SET metadata = ? WHERE id = ?`, string(encoded), id); err != nil {
+				return fmt.Errorf("failed to update entry %s: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
+
+// IsGitHubBlocked reports whether any entry for username (ignoring case) was
+// marked with MarkGitHubBlocked
+func (db *DB) IsGitHubBlocked(username string) (bool, error) {
+	entries, err := db.GetEntriesByUsername(username)
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range entries {
+		metadata, err := entry.ParseMetadata()
+		if err != nil {
+			continue
+		}
+		if metadata.GitHubBlockedAt != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RecordScan inserts a scan history row
+func (db *DB) RecordScan(entry *models.ScanHistoryEntry) error {
+	query := `
+		INSERT INTO scan_history (id, repository, scanned_at, total_prs, spam_count, uncertain_count, clean_count)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := db.conn.Exec(query,
+		entry.ID,
+		entry.Repository,
+		entry.ScannedAt,
+		entry.TotalPRs,
+		entry.SpamCount,
+		entry.UncertainCount,
+		entry.CleanCount,
+	)
+	return err
+}
+
+// ListScanHistory retrieves the most recent scans for a repository, newest first
+func (db *DB) ListScanHistory(repository string, limit int) ([]*models.ScanHistoryEntry, error) {
+	query := `
+		SELECT id, repository, scanned_at, total_prs, spam_count, uncertain_count, clean_count
+		FROM scan_history WHERE repository = ? ORDER BY scanned_at DESC LIMIT ?
+	`
+
+	rows, err := db.conn.Query(query, repository, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var entries []*models.ScanHistoryEntry
+	for rows.Next() {
+		var entry models.ScanHistoryEntry
 		err := rows.Scan(
 			&entry.ID,
-			&entry.Username,
-			&entry.Reason,
-			&entry.EvidenceURL,
-			&entry.Timestamp,
-			&entry.BlockedBy,
-			&entry.Severity,
-			&entry.Source,
-			&entry.Metadata,
+			&entry.Repository,
+			&entry.ScannedAt,
+			&entry.TotalPRs,
+			&entry.SpamCount,
+			&entry.UncertainCount,
+			&entry.CleanCount,
 		)
 		if err != nil {
 			return nil, err
@@ -212,9 +907,34 @@ func (db *DB) GetEntriesByUsername(username string) ([]*models.BlocklistEntry, e
 	return entries, rows.Err()
 }
 
-// ListEntries retrieves all blocklist entries
-func (db *DB) ListEntries() ([]*models.BlocklistEntry, error) {
-	query := `SELECT id, username, reason, evidence_url, timestamp, blocked_by, severity, source, metadata FROM blocklist ORDER BY timestamp DESC`
+// RecordAction inserts an action log row
+func (db *DB) RecordAction(entry *models.ActionLogEntry) error {
+	query := `
+		INSERT INTO action_log (id, batch_id, action, entry_id, username, repository, pr_number, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := db.conn.Exec(query,
+		entry.ID,
+		entry.BatchID,
+		entry.Action,
+		entry.EntryID,
+		entry.Username,
+		entry.Repository,
+		entry.PRNumber,
+		entry.CreatedAt.UTC(), // UTC keeps created_at ordering consistent
+	)
+	return err
+}
+
+// LatestActionBatch retrieves the actions of the most recently recorded batch,
+// or nil if the log is empty
+func (db *DB) LatestActionBatch() ([]*models.ActionLogEntry, error) {
+	query := `
+		SELECT id, batch_id, action, entry_id, username, repository, pr_number, created_at
+		FROM action_log
+		WHERE batch_id = (SELECT batch_id FROM action_log ORDER BY created_at DESC LIMIT 1)
+		ORDER BY created_at
+	`
 
 	rows, err := db.conn.Query(query)
 	if err != nil {
@@ -222,19 +942,18 @@ func (db *DB) ListEntries() ([]*models.BlocklistEntry, error) {
 	}
 	defer rows.Close() //nolint:errcheck
 
-	var entries []*models.BlocklistEntry
+	var entries []*models.ActionLogEntry
 	for rows.Next() {
-		var entry models.BlocklistEntry
+		var entry models.ActionLogEntry
 		err := rows.Scan(
 			&entry.ID,
+			&entry.BatchID,
+			&entry.Action,
+			&entry.EntryID,
 			&entry.Username,
-			&entry.Reason,
-			&entry.EvidenceURL,
-			&entry.Timestamp,
-			&entry.BlockedBy,
-			&entry.Severity,
-			&entry.Source,
-			&entry.Metadata,
+			&entry.Repository,
+			&entry.PRNumber,
+			&entry.CreatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -244,27 +963,89 @@ func (db *DB) ListEntries() ([]*models.BlocklistEntry, error) {
 	return entries, rows.Err()
 }
 
-// RemoveEntry removes a blocklist entry by ID
-func (db *DB) RemoveEntry(id string) error {
-	query := `DELETE FROM blocklist WHERE id = ?`
-	_, err := db.conn.Exec(query, id)
-	return err
+// UndoActionBatch removes the blocklist entries added by a batch and deletes the
+// batch from the action log in one transaction. It returns the number of
+// blocklist entries removed; entries already unblocked are not counted.
+func (db *DB) UndoActionBatch(batchID string) (int64, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	result, err := tx.Exec(`
+		DELETE FROM blocklist WHERE id IN (
+			SELECT entry_id FROM action_log WHERE batch_id = ? AND action = ?
+		)
+	`, batchID, models.ActionBlock)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove blocklist entries: %w", err)
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM action_log WHERE batch_id = ?`, batchID); err != nil {
+		return 0, fmt.Errorf("failed to delete action batch: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return removed, nil
 }
 
-// RemoveByUsername removes all blocklist entries for a username
-func (db *DB) RemoveByUsername(username string) error {
-	query := `DELETE FROM blocklist WHERE username = ?`
-	_, err := db.conn.Exec(query, username)
+// GetSourceCache retrieves the cache validators for a blocklist URL, or nil if
+// the URL has not been imported
+func (db *DB) GetSourceCache(url string) (*models.SourceCache, error) {
+	query := `SELECT url, etag, last_modified, fetched_at FROM source_cache WHERE url = ?`
+
+	var cache models.SourceCache
+	err := db.conn.QueryRow(query, url).Scan(&cache.URL, &cache.ETag, &cache.LastModified, &cache.FetchedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cache, nil
+}
+
+// SaveSourceCache stores the cache validators for a blocklist URL, replacing any previous values
+func (db *DB) SaveSourceCache(cache *models.SourceCache) error {
+	query := `
+		INSERT INTO source_cache (url, etag, last_modified, fetched_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET
+			etag = excluded.etag,
+			last_modified = excluded.last_modified,
+			fetched_at = excluded.fetched_at
+	`
+	_, err := db.conn.Exec(query, cache.URL, cache.ETag, cache.LastModified, cache.FetchedAt.UTC())
 	return err
 }
 
-// UpdateEntry updates an existing blocklist entry
-func (db *DB) UpdateEntry(entry *models.BlocklistEntry) error {
+// GetStagedDigest retrieves the digest of the entries last staged from a
+// blocklist URL, or an empty string if nothing has been staged from it
+func (db *DB) GetStagedDigest(url string) (string, error) {
+	var digest string
+	err := db.conn.QueryRow(`SELECT digest FROM staged_sources WHERE url = ?`, url).Scan(&digest)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return digest, err
+}
+
+// SaveStagedDigest stores the digest of the entries staged from a blocklist URL, replacing any previous value
+func (db *DB) SaveStagedDigest(url, digest string) error {
 	query := `
-		UPDATE blocklist
-		SET reason = ?, evidence_url = ?, severity = ?, metadata = ?
-		WHERE id = ?
+		INSERT INTO staged_sources (url, digest, staged_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET
+			digest = excluded.digest,
+			staged_at = excluded.staged_at
 	`
-	_, err := db.conn.Exec(query, entry.Reason, entry.EvidenceURL, entry.Severity, entry.Metadata, entry.ID)
+	_, err := db.conn.Exec(query, url, digest, time.Now().UTC())
 	return err
 }
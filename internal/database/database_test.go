@@ -15,6 +15,10 @@
 package database
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,6 +34,21 @@ func setupTestDB(t *testing.T) *DB {
 	return db
 }
 
+func TestPing(t *testing.T) {
+	db := setupTestDB(t)
+
+	if err := db.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := db.Ping(context.Background()); err == nil {
+		t.Error("expected Ping() to fail on a closed database")
+	}
+}
+
 func TestAddEntry(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close() //nolint:errcheck
@@ -97,6 +116,87 @@ func TestIsBlocked(t *testing.T) {
 	}
 }
 
+func TestUsernames_CaseInsensitive(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	if err := db.AddEntry(models.NewBlocklistEntry("Spammer", "spam", "", "admin", models.SeverityHigh, models.SourceManual)); err != nil {
+		t.Fatalf("AddEntry failed: %v", err)
+	}
+
+	blocked, err := db.IsBlocked("spammer")
+	if err != nil {
+		t.Fatalf("IsBlocked failed: %v", err)
+	}
+	if !blocked {
+		t.Error("spammer should be blocked after blocking Spammer")
+	}
+
+	entries, err := db.GetEntriesByUsername("SPAMMER")
+	if err != nil {
+		t.Fatalf("GetEntriesByUsername failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Username != "Spammer" {
+		t.Errorf("Expected the Spammer entry with its original case, got %d entries", len(entries))
+	}
+
+	added, err := db.AddEntries([]*models.BlocklistEntry{
+		models.NewBlocklistEntry("spammer", "again", "", "admin", models.SeverityHigh, models.SourceImported),
+		models.NewBlocklistEntry("Other", "spam", "", "admin", models.SeverityLow, models.SourceImported),
+		models.NewBlocklistEntry("other", "spam", "", "admin", models.SeverityLow, models.SourceImported),
+	})
+	if err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+	if added != 1 {
+		t.Errorf("Expected only one of Other/other to be added, got %d", added)
+	}
+
+	users, err := db.CountUniqueUsernames("")
+	if err != nil {
+		t.Fatalf("CountUniqueUsernames failed: %v", err)
+	}
+	if users != 2 {
+		t.Errorf("Expected 2 unique users, got %d", users)
+	}
+
+	if err := db.RemoveByUsername("spammer"); err != nil {
+		t.Fatalf("RemoveByUsername failed: %v", err)
+	}
+	if blocked, _ := db.IsBlocked("Spammer"); blocked {
+		t.Error("Spammer should be unblocked after removing spammer")
+	}
+}
+
+func TestCountOffenses(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	expired := time.Now().Add(-time.Hour)
+	old := models.NewBlocklistEntry("Repeat", "spam", "", "admin", models.SeverityLow, models.SourceManual)
+	old.ExpiresAt = &expired
+	for _, entry := range []*models.BlocklistEntry{
+		old,
+		models.NewBlocklistEntry("repeat", "spam", "", "admin", models.SeverityLow, models.SourceManual),
+		models.NewBlocklistEntry("someone", "spam", "", "admin", models.SeverityLow, models.SourceManual),
+		models.NewBlocklistEntry("repeat", "spam", "https://github.com/org/repo/pull/1", "admin", models.SeverityLow, models.SourceManual),
+		// The same evidence again is not another offense
+		models.NewBlocklistEntry("repeat", "spam", "https://github.com/org/repo/pull/1", "admin", models.SeverityLow, models.SourceImported),
+	} {
+		if err := db.AddEntry(entry); err != nil {
+			t.Fatalf("AddEntry failed: %v", err)
+		}
+	}
+
+	count, err := db.CountOffenses("REPEAT")
+	if err != nil {
+		t.Fatalf("CountOffenses failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 offenses including the expired block, got %d", count)
+	}
+}
+
 func TestGetEntriesByUsername(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close() //nolint:errcheck
@@ -155,6 +255,350 @@ func TestListEntries(t *testing.T) {
 	}
 }
 
+func TestListEntriesPaged(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		entry := models.NewBlocklistEntry(
+			fmt.Sprintf("user%d", i),
+			"Test reason",
+			"https://example.com",
+			"admin",
+			models.SeverityLow,
+			models.SourceManual,
+		)
+		entry.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		if err := db.AddEntry(entry); err != nil {
+			t.Fatalf("Failed to add entry: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		limit     int
+		offset    int
+		wantUsers []string
+	}{
+		{"first page", 2, 0, []string{"user4", "user3"}},
+		{"middle page", 2, 2, []string{"user2", "user1"}},
+		{"partial last page", 2, 4, []string{"user0"}},
+		{"past the end", 2, 5, nil},
+		{"limit larger than total", 10, 0, []string{"user4", "user3", "user2", "user1", "user0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, total, err := db.ListEntriesPaged(models.EntryFilter{Limit: tt.limit, Offset: tt.offset})
+			if err != nil {
+				t.Fatalf("ListEntriesPaged failed: %v", err)
+			}
+			if total != 5 {
+				t.Errorf("Expected total 5, got %d", total)
+			}
+			if len(entries) != len(tt.wantUsers) {
+				t.Fatalf("Expected %d entries, got %d", len(tt.wantUsers), len(entries))
+			}
+			for i, want := range tt.wantUsers {
+				if entries[i].Username != want {
+					t.Errorf("Entry %d: expected %s, got %s", i, want, entries[i].Username)
+				}
+			}
+		})
+	}
+}
+
+func TestGetEntryByEvidence(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	entry := models.NewBlocklistEntry("SpamBot", "spam", "https://github.com/org/repo/pull/1", "admin", models.SeverityLow, models.SourceManual)
+	if err := db.AddEntry(entry); err != nil {
+		t.Fatalf("Failed to add entry: %v", err)
+	}
+
+	found, err := db.GetEntryByEvidence("spambot", "https://github.com/org/repo/pull/1")
+	if err != nil || found == nil || found.ID != entry.ID {
+		t.Fatalf("Expected entry %s, got %v (err %v)", entry.ID, found, err)
+	}
+	if found, err := db.GetEntryByEvidence("spambot", "https://github.com/org/repo/pull/2"); err != nil || found != nil {
+		t.Errorf("Expected no entry for other evidence, got %v (err %v)", found, err)
+	}
+}
+
+func TestMarkGitHubBlocked(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	entry := models.NewBlocklistEntry("SpamBot", "spam", "https://github.com/org/repo/pull/1", "admin", models.SeverityHigh, models.SourceAutoDetected)
+	entry.Metadata = `{"signals":["readme_only"],"custom":"kept"}`
+	if err := db.AddEntry(entry); err != nil {
+		t.Fatalf("Failed to add entry: %v", err)
+	}
+
+	if blocked, err := db.IsGitHubBlocked("spambot"); err != nil || blocked {
+		t.Fatalf("Expected user not to be GitHub-blocked yet, got %v (err %v)", blocked, err)
+	}
+	if err := db.MarkGitHubBlocked("spambot"); err != nil {
+		t.Fatalf("MarkGitHubBlocked failed: %v", err)
+	}
+	if blocked, err := db.IsGitHubBlocked("SPAMBOT"); err != nil || !blocked {
+		t.Errorf("Expected user to be GitHub-blocked, got %v (err %v)", blocked, err)
+	}
+
+	stored, err := db.GetEntry(entry.ID)
+	if err != nil {
+		t.Fatalf("Failed to get entry: %v", err)
+	}
+	for _, want := range []string{`"signals":["readme_only"]`, `"custom":"kept"`, `"github_blocked_at":`} {
+		if !strings.Contains(stored.Metadata, want) {
+			t.Errorf("Expected metadata to contain %s, got %s", want, stored.Metadata)
+		}
+	}
+
+	// Users without entries are a no-op
+	if err := db.MarkGitHubBlocked("unknown"); err != nil {
+		t.Errorf("MarkGitHubBlocked for unknown user failed: %v", err)
+	}
+	if blocked, _ := db.IsGitHubBlocked("unknown"); blocked {
+		t.Error("Expected unknown user not to be GitHub-blocked")
+	}
+}
+
+func TestListEntriesSorted(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	base := time.Now().Add(-time.Hour)
+	for i, e := range []struct{ username, severity, source string }{
+		{"bob", models.SeverityHigh, models.SourceManual},
+		{"alice", models.SeverityLow, models.SourceImported},
+		{"Carol", models.SeverityMedium, models.SourceAutoDetected},
+	} {
+		entry := models.NewBlocklistEntry(e.username, "Test reason", "https://example.com", "admin", e.severity, e.source)
+		entry.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		if err := db.AddEntry(entry); err != nil {
+			t.Fatalf("Failed to add entry: %v", err)
+		}
+	}
+
+	tests := []struct {
+		field     string
+		reverse   bool
+		wantUsers []string
+	}{
+		{"username", false, []string{"alice", "bob", "Carol"}},
+		{"username", true, []string{"Carol", "bob", "alice"}},
+		{"severity", false, []string{"bob", "Carol", "alice"}},
+		{"severity", true, []string{"alice", "Carol", "bob"}},
+		{"timestamp", false, []string{"Carol", "alice", "bob"}},
+		{"timestamp", true, []string{"bob", "alice", "Carol"}},
+		{"source", false, []string{"Carol", "alice", "bob"}},
+		{"source", true, []string{"bob", "alice", "Carol"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s reverse=%v", tt.field, tt.reverse), func(t *testing.T) {
+			entries, err := db.ListEntriesSorted(tt.field, tt.reverse)
+			if err != nil {
+				t.Fatalf("ListEntriesSorted failed: %v", err)
+			}
+			if len(entries) != len(tt.wantUsers) {
+				t.Fatalf("Expected %d entries, got %d", len(tt.wantUsers), len(entries))
+			}
+			for i, want := range tt.wantUsers {
+				if entries[i].Username != want {
+					t.Errorf("Entry %d: expected %s, got %s", i, want, entries[i].Username)
+				}
+			}
+		})
+	}
+}
+
+func TestListEntriesSorted_RejectsUnknownField(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	for _, field := range []string{"reason", "timestamp; DROP TABLE blocklist", ""} {
+		if _, err := db.ListEntriesSorted(field, false); err == nil {
+			t.Errorf("Expected an error for sort field %q", field)
+		}
+	}
+	if _, err := db.ListEntries(); err != nil {
+		t.Errorf("Expected the blocklist to be intact, got %v", err)
+	}
+}
+
+func TestListEntriesPaged_MinSeverityAndTag(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	base := time.Now().Add(-time.Hour)
+	for i, e := range []struct {
+		username, severity string
+		tags               []string
+	}{
+		{"low", models.SeverityLow, []string{"crypto-spam"}},
+		{"high-old", models.SeverityHigh, []string{"crypto-spam"}},
+		{"medium", models.SeverityMedium, nil},
+		{"high-new", models.SeverityHigh, []string{"link-spam"}},
+	} {
+		entry := models.NewBlocklistEntry(e.username, "Test reason", "https://example.com", "admin", e.severity, models.SourceManual)
+		entry.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		entry.Tags = e.tags
+		if err := db.AddEntry(entry); err != nil {
+			t.Fatalf("Failed to add entry: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		filter    models.EntryFilter
+		wantTotal int
+		wantUsers []string
+	}{
+		{"high", models.EntryFilter{MinSeverity: models.SeverityHigh}, 2, []string{"high-new", "high-old"}},
+		{"medium", models.EntryFilter{MinSeverity: models.SeverityMedium}, 3, []string{"high-new", "medium", "high-old"}},
+		{"low second page", models.EntryFilter{MinSeverity: models.SeverityLow, Limit: 2, Offset: 2}, 4, []string{"high-old", "low"}},
+		{"medium tagged", models.EntryFilter{MinSeverity: models.SeverityMedium, Tag: "crypto-spam"}, 1, []string{"high-old"}},
+		{"offset without limit", models.EntryFilter{Offset: 3}, 4, []string{"low"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, total, err := db.ListEntriesPaged(tt.filter)
+			if err != nil {
+				t.Fatalf("ListEntriesPaged failed: %v", err)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("Expected total %d, got %d", tt.wantTotal, total)
+			}
+			if len(entries) != len(tt.wantUsers) {
+				t.Fatalf("Expected %d entries, got %d", len(tt.wantUsers), len(entries))
+			}
+			for i, want := range tt.wantUsers {
+				if entries[i].Username != want {
+					t.Errorf("Entry %d: expected %s, got %s", i, want, entries[i].Username)
+				}
+			}
+		})
+	}
+
+	if _, _, err := db.ListEntriesPaged(models.EntryFilter{MinSeverity: "critical"}); err == nil {
+		t.Error("Expected an error for an unknown severity")
+	}
+}
+
+func TestSearchEntries(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	entries := []*models.BlocklistEntry{
+		models.NewBlocklistEntry("SpamBot42", "README spam", "https://github.com/org/repo/pull/1", "admin", models.SeverityHigh, models.SourceManual),
+		models.NewBlocklistEntry("helpful-user", "Spammy links in docs", "https://github.com/org/docs/pull/7", "admin", models.SeverityLow, models.SourceManual),
+		models.NewBlocklistEntry("another", "Fake contributions", "https://github.com/spam-org/repo/pull/3", "admin", models.SeverityMedium, models.SourceManual),
+		models.NewBlocklistEntry("percent", "100% legit", "https://example.com", "admin", models.SeverityLow, models.SourceManual),
+	}
+	for _, e := range entries {
+		if err := db.AddEntry(e); err != nil {
+			t.Fatalf("Failed to add entry: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		term      string
+		field     string
+		severity  string
+		wantUsers []string
+	}{
+		{"case-insensitive across fields", "SPAM", "", "", []string{"SpamBot42", "helpful-user", "another"}},
+		{"username only", "spam", "username", "", []string{"SpamBot42"}},
+		{"reason only", "spam", "reason", "", []string{"SpamBot42", "helpful-user"}},
+		{"evidence only", "spam", "evidence", "", []string{"another"}},
+		{"severity filter", "spam", "", models.SeverityLow, []string{"helpful-user"}},
+		{"wildcards are literal", "%", "", "", []string{"percent"}},
+		{"no match", "nothing", "", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := db.SearchEntries(tt.term, tt.field, tt.severity)
+			if err != nil {
+				t.Fatalf("SearchEntries failed: %v", err)
+			}
+			got := make(map[string]bool)
+			for _, e := range results {
+				got[e.Username] = true
+			}
+			if len(results) != len(tt.wantUsers) {
+				t.Fatalf("Expected %d matches, got %d", len(tt.wantUsers), len(results))
+			}
+			for _, want := range tt.wantUsers {
+				if !got[want] {
+					t.Errorf("Expected %s in results", want)
+				}
+			}
+		})
+	}
+
+	if _, err := db.SearchEntries("spam", "bogus", ""); err == nil {
+		t.Error("Expected error for invalid field")
+	}
+}
+
+func TestCountEntries(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	entries := []struct {
+		username string
+		severity string
+	}{
+		{"spammer", models.SeverityHigh},
+		{"spammer", models.SeverityLow},
+		{"spammer", models.SeverityLow},
+		{"other", models.SeverityLow},
+		{"third", models.SeverityMedium},
+	}
+	for _, e := range entries {
+		entry := models.NewBlocklistEntry(e.username, "reason", "https://example.com", "admin", e.severity, models.SourceManual)
+		if err := db.AddEntry(entry); err != nil {
+			t.Fatalf("Failed to add entry: %v", err)
+		}
+	}
+
+	tests := []struct {
+		severity    string
+		wantEntries int
+		wantUsers   int
+	}{
+		{"", 5, 3},
+		{models.SeverityLow, 3, 2},
+		{models.SeverityHigh, 1, 1},
+		{models.SeverityMedium, 1, 1},
+	}
+
+	for _, tt := range tests {
+		total, err := db.CountEntries(tt.severity)
+		if err != nil {
+			t.Fatalf("CountEntries failed: %v", err)
+		}
+		if total != tt.wantEntries {
+			t.Errorf("CountEntries(%q) = %d, expected %d", tt.severity, total, tt.wantEntries)
+		}
+
+		users, err := db.CountUniqueUsernames(tt.severity)
+		if err != nil {
+			t.Fatalf("CountUniqueUsernames failed: %v", err)
+		}
+		if users != tt.wantUsers {
+			t.Errorf("CountUniqueUsernames(%q) = %d, expected %d", tt.severity, users, tt.wantUsers)
+		}
+	}
+}
+
 func TestRemoveEntry(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close() //nolint:errcheck
@@ -238,6 +682,176 @@ func TestUpdateEntry(t *testing.T) {
 	}
 }
 
+func TestIsBlocked_IgnoresExpired(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	entry := models.NewBlocklistEntry(
+		"tempuser",
+		"Temporary block",
+		"https://example.com",
+		"admin",
+		models.SeverityLow,
+		models.SourceManual,
+	)
+	past := time.Now().Add(-time.Hour)
+	entry.ExpiresAt = &past
+	if err := db.AddEntry(entry); err != nil {
+		t.Fatalf("Failed to add entry: %v", err)
+	}
+
+	blocked, err := db.IsBlocked("tempuser")
+	if err != nil {
+		t.Fatalf("IsBlocked failed: %v", err)
+	}
+	if blocked {
+		t.Error("User with an expired entry should not be blocked")
+	}
+
+	// A future expiry still blocks
+	future := time.Now().Add(time.Hour)
+	entry = models.NewBlocklistEntry("tempuser", "Again", "https://example.com", "admin", models.SeverityLow, models.SourceManual)
+	entry.ExpiresAt = &future
+	if err := db.AddEntry(entry); err != nil {
+		t.Fatalf("Failed to add entry: %v", err)
+	}
+
+	blocked, err = db.IsBlocked("tempuser")
+	if err != nil {
+		t.Fatalf("IsBlocked failed: %v", err)
+	}
+	if !blocked {
+		t.Error("User with an unexpired entry should be blocked")
+	}
+
+	retrieved, err := db.GetEntry(entry.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve entry: %v", err)
+	}
+	if retrieved.ExpiresAt == nil || retrieved.ExpiresAt.Sub(future).Abs() > time.Second {
+		t.Errorf("Expected expiry %v, got %v", future, retrieved.ExpiresAt)
+	}
+}
+
+func TestPurgeExpired(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	expired := models.NewBlocklistEntry("expired", "r", "https://example.com", "admin", models.SeverityLow, models.SourceManual)
+	expired.ExpiresAt = &past
+	active := models.NewBlocklistEntry("active", "r", "https://example.com", "admin", models.SeverityLow, models.SourceManual)
+	active.ExpiresAt = &future
+	permanent := models.NewBlocklistEntry("permanent", "r", "https://example.com", "admin", models.SeverityLow, models.SourceManual)
+
+	for _, e := range []*models.BlocklistEntry{expired, active, permanent} {
+		if err := db.AddEntry(e); err != nil {
+			t.Fatalf("Failed to add entry: %v", err)
+		}
+	}
+
+	removed, err := db.PurgeExpired()
+	if err != nil {
+		t.Fatalf("PurgeExpired failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 entry purged, got %d", removed)
+	}
+
+	entries, err := db.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 remaining entries, got %d", len(entries))
+	}
+	for _, e := range entries {
+		if e.Username == "expired" {
+			t.Error("Expired entry should have been purged")
+		}
+	}
+}
+
+func TestFindDuplicateUsernames(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	for _, username := range []string{"spammer", "Spammer", "single", "other", "other"} {
+		entry := models.NewBlocklistEntry(username, "r", "https://example.com", "admin", models.SeverityLow, models.SourceManual)
+		if err := db.AddEntry(entry); err != nil {
+			t.Fatalf("Failed to add entry: %v", err)
+		}
+	}
+
+	usernames, err := db.FindDuplicateUsernames()
+	if err != nil {
+		t.Fatalf("FindDuplicateUsernames failed: %v", err)
+	}
+	if len(usernames) != 2 || usernames[0] != "other" || !strings.EqualFold(usernames[1], "spammer") {
+		t.Errorf("Expected [other spammer], got %v", usernames)
+	}
+}
+
+func TestPruneDuplicates(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	high := models.NewBlocklistEntry("spammer", "Crypto spam", "https://github.com/o/r/pull/1", "admin", models.SeverityHigh, models.SourceManual)
+	high.Timestamp = past
+	high.ExpiresAt = &future
+	high.Tags = []string{"crypto-spam"}
+	low := models.NewBlocklistEntry("spammer", "README edit", "https://github.com/o/r/pull/2", "admin", models.SeverityLow, models.SourceManual)
+	low.Tags = []string{"readme-only"}
+	other := models.NewBlocklistEntry("other", "r", "https://example.com", "admin", models.SeverityLow, models.SourceManual)
+
+	for _, e := range []*models.BlocklistEntry{high, low, other} {
+		if err := db.AddEntry(e); err != nil {
+			t.Fatalf("Failed to add entry: %v", err)
+		}
+	}
+	if err := db.MarkGitHubBlocked("spammer"); err != nil {
+		t.Fatalf("MarkGitHubBlocked failed: %v", err)
+	}
+
+	removed, err := db.PruneDuplicates()
+	if err != nil {
+		t.Fatalf("PruneDuplicates failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 entry removed, got %d", removed)
+	}
+
+	entries, err := db.GetEntriesByUsername("spammer")
+	if err != nil {
+		t.Fatalf("GetEntriesByUsername failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 remaining entry, got %d", len(entries))
+	}
+	kept := entries[0]
+	if kept.ID != high.ID || kept.Severity != models.SeverityHigh {
+		t.Errorf("Expected the high severity entry to survive, got %s (%s)", kept.ID, kept.Severity)
+	}
+	if len(kept.Tags) != 2 {
+		t.Errorf("Expected merged tags, got %v", kept.Tags)
+	}
+	if kept.ExpiresAt != nil {
+		t.Errorf("Expected the permanent duplicate to make the kept entry permanent, got %v", kept.ExpiresAt)
+	}
+	if !strings.Contains(kept.Metadata, "README edit") || !strings.Contains(kept.Metadata, "github_blocked_at") {
+		t.Errorf("Expected metadata to record the removed entry and block mark, got %s", kept.Metadata)
+	}
+
+	if usernames, err := db.FindDuplicateUsernames(); err != nil || len(usernames) != 0 {
+		t.Errorf("Expected no duplicates after pruning, got %v, %v", usernames, err)
+	}
+}
+
 func TestSeverityConstraint(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close() //nolint:errcheck
@@ -281,3 +895,299 @@ func TestSourceConstraint(t *testing.T) {
 		t.Error("Expected error for invalid source, got nil")
 	}
 }
+
+func TestRecordScanAndListHistory(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		entry := models.NewScanHistoryEntry("org/repo", 10+i, i, 1, 9-i)
+		entry.ScannedAt = base.Add(time.Duration(i) * time.Minute)
+		if err := db.RecordScan(entry); err != nil {
+			t.Fatalf("RecordScan failed: %v", err)
+		}
+	}
+	if err := db.RecordScan(models.NewScanHistoryEntry("org/other", 1, 0, 0, 1)); err != nil {
+		t.Fatalf("RecordScan failed: %v", err)
+	}
+
+	history, err := db.ListScanHistory("org/repo", 3)
+	if err != nil {
+		t.Fatalf("ListScanHistory failed: %v", err)
+	}
+
+	if len(history) != 3 {
+		t.Fatalf("Expected 3 history rows, got %d", len(history))
+	}
+
+	// Newest first
+	if history[0].TotalPRs != 14 || history[0].SpamCount != 4 {
+		t.Errorf("Expected newest scan first, got total=%d spam=%d", history[0].TotalPRs, history[0].SpamCount)
+	}
+	if history[2].TotalPRs != 12 {
+		t.Errorf("Expected third newest scan total 12, got %d", history[2].TotalPRs)
+	}
+	for _, entry := range history {
+		if entry.Repository != "org/repo" {
+			t.Errorf("Unexpected repository in history: %s", entry.Repository)
+		}
+	}
+}
+
+func TestListScanHistory_Empty(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	history, err := db.ListScanHistory("org/none", 10)
+	if err != nil {
+		t.Fatalf("ListScanHistory failed: %v", err)
+	}
+	if len(history) != 0 {
+		t.Errorf("Expected no history, got %d rows", len(history))
+	}
+}
+
+func TestAddEntries_PartialDuplicates(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	existing := models.NewBlocklistEntry("alice", "spam", "https://example.com/1", "maintainer", models.SeverityLow, models.SourceManual)
+	if err := db.AddEntry(existing); err != nil {
+		t.Fatalf("Failed to add entry: %v", err)
+	}
+
+	// An expired block does not count as present
+	past := time.Now().Add(-time.Hour)
+	expired := models.NewBlocklistEntry("carol", "old", "https://example.com/2", "maintainer", models.SeverityLow, models.SourceManual)
+	expired.ExpiresAt = &past
+	if err := db.AddEntry(expired); err != nil {
+		t.Fatalf("Failed to add expired entry: %v", err)
+	}
+
+	var batch []*models.BlocklistEntry
+	for _, username := range []string{"alice", "bob", "carol", "bob", "dave"} {
+		batch = append(batch, models.NewBlocklistEntry(username, "bulk", "https://example.com/review", "maintainer", models.SeverityHigh, models.SourceManual))
+	}
+
+	added, err := db.AddEntries(batch)
+	if err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+	if added != 3 {
+		t.Errorf("Expected 3 entries added, got %d", added)
+	}
+
+	wantCounts := map[string]int{"alice": 1, "bob": 1, "carol": 2, "dave": 1}
+	for username, want := range wantCounts {
+		entries, err := db.GetEntriesByUsername(username)
+		if err != nil {
+			t.Fatalf("Failed to get entries: %v", err)
+		}
+		if len(entries) != want {
+			t.Errorf("Expected %d entries for %s, got %d", want, username, len(entries))
+		}
+	}
+}
+
+func TestAddEntries_RollsBackOnError(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	first := models.NewBlocklistEntry("erin", "bulk", "https://example.com", "maintainer", models.SeverityLow, models.SourceManual)
+	clash := models.NewBlocklistEntry("frank", "bulk", "https://example.com", "maintainer", models.SeverityLow, models.SourceManual)
+	clash.ID = first.ID // primary key conflict aborts the batch
+
+	if _, err := db.AddEntries([]*models.BlocklistEntry{first, clash}); err == nil {
+		t.Fatal("Expected AddEntries to fail on duplicate ID")
+	}
+
+	blocked, err := db.IsBlocked("erin")
+	if err != nil {
+		t.Fatalf("IsBlocked failed: %v", err)
+	}
+	if blocked {
+		t.Error("Expected batch to be rolled back")
+	}
+}
+
+func TestWithTransaction_Commits(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	existing := models.NewBlocklistEntry("grace", "spam", "https://example.com", "maintainer", models.SeverityLow, models.SourceManual)
+	if err := db.AddEntry(existing); err != nil {
+		t.Fatalf("Failed to add entry: %v", err)
+	}
+	fresh := models.NewBlocklistEntry("heidi", "spam", "https://example.com", "maintainer", models.SeverityLow, models.SourceManual)
+
+	err := db.WithTransaction(func(tx *Tx) error {
+		if err := tx.AddEntry(fresh); err != nil {
+			return err
+		}
+		// Writes are visible to later reads in the same transaction
+		entry, err := tx.GetEntry(fresh.ID)
+		if err != nil || entry == nil {
+			return fmt.Errorf("expected to read back new entry: %v", err)
+		}
+
+		updated := *existing
+		updated.Severity = models.SeverityHigh
+		return tx.UpdateEntry(&updated)
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction failed: %v", err)
+	}
+
+	if entry, _ := db.GetEntry(fresh.ID); entry == nil {
+		t.Error("Expected new entry to be committed")
+	}
+	if entry, _ := db.GetEntry(existing.ID); entry.Severity != models.SeverityHigh {
+		t.Errorf("Expected update to be committed, got severity %s", entry.Severity)
+	}
+}
+
+func TestWithTransaction_FailureOnNthEntryRollsBack(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	var batch []*models.BlocklistEntry
+	for _, username := range []string{"ivan", "judy", "mallory", "niaj", "olivia"} {
+		batch = append(batch, models.NewBlocklistEntry(username, "spam", "https://example.com", "maintainer", models.SeverityLow, models.SourceImported))
+	}
+
+	const failAt = 3
+	injected := errors.New("injected failure")
+	err := db.WithTransaction(func(tx *Tx) error {
+		for i, entry := range batch {
+			if i == failAt {
+				return injected
+			}
+			if err := tx.AddEntry(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if !errors.Is(err, injected) {
+		t.Fatalf("Expected the injected error, got %v", err)
+	}
+
+	entries, err := db.ListEntries()
+	if err != nil {
+		t.Fatalf("Failed to list entries: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected no rows committed, found %d", len(entries))
+	}
+}
+
+func TestGetEntriesByTag(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	crypto := models.NewBlocklistEntry("crypto", "airdrop links", "", "admin", models.SeverityHigh, models.SourceManual)
+	crypto.Tags = []string{"crypto-spam", "link-spam"}
+	links := models.NewBlocklistEntry("links", "seo links", "", "admin", models.SeverityLow, models.SourceManual)
+	links.Tags = []string{"link-spam"}
+	untagged := models.NewBlocklistEntry("untagged", "spam", "", "admin", models.SeverityLow, models.SourceManual)
+	for _, entry := range []*models.BlocklistEntry{crypto, links, untagged} {
+		if err := db.AddEntry(entry); err != nil {
+			t.Fatalf("Failed to add entry: %v", err)
+		}
+	}
+
+	retrieved, err := db.GetEntry(crypto.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve entry: %v", err)
+	}
+	if len(retrieved.Tags) != 2 || retrieved.Tags[0] != "crypto-spam" || retrieved.Tags[1] != "link-spam" {
+		t.Errorf("Expected both tags to round-trip, got %v", retrieved.Tags)
+	}
+
+	entries, err := db.GetEntriesByTag("crypto-spam")
+	if err != nil {
+		t.Fatalf("GetEntriesByTag failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Username != "crypto" {
+		t.Errorf("Expected only crypto for crypto-spam, got %d entries", len(entries))
+	}
+
+	entries, err = db.GetEntriesByTag("link-spam")
+	if err != nil {
+		t.Fatalf("GetEntriesByTag failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 entries for link-spam, got %d", len(entries))
+	}
+
+	// Tags are matched exactly, not as substrings of the JSON text
+	entries, err = db.GetEntriesByTag("spam")
+	if err != nil {
+		t.Fatalf("GetEntriesByTag failed: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected no entries for partial tag, got %d", len(entries))
+	}
+}
+
+func TestUndoActionBatch(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	if batch, err := db.LatestActionBatch(); err != nil || batch != nil {
+		t.Fatalf("LatestActionBatch() on empty log = %v, %v; want nil, nil", batch, err)
+	}
+
+	record := func(batchID, username string) *models.BlocklistEntry {
+		t.Helper()
+		entry := models.NewBlocklistEntry(username, "spam", "", "maintainer", models.SeverityHigh, models.SourceManual)
+		if err := db.AddEntry(entry); err != nil {
+			t.Fatalf("Failed to add entry: %v", err)
+		}
+		if err := db.RecordAction(models.NewBlockAction(batchID, entry)); err != nil {
+			t.Fatalf("Failed to record action: %v", err)
+		}
+		return entry
+	}
+
+	kept := record("batch-1", "early-spammer")
+	record("batch-2", "spammer1")
+	record("batch-2", "spammer2")
+	if err := db.RecordAction(models.NewClosePRAction("batch-2", "org/repo", 7)); err != nil {
+		t.Fatalf("Failed to record action: %v", err)
+	}
+
+	batch, err := db.LatestActionBatch()
+	if err != nil {
+		t.Fatalf("LatestActionBatch() error = %v", err)
+	}
+	if len(batch) != 3 || batch[0].BatchID != "batch-2" {
+		t.Fatalf("LatestActionBatch() returned %d actions from %q, want 3 from batch-2", len(batch), batch[0].BatchID)
+	}
+
+	removed, err := db.UndoActionBatch("batch-2")
+	if err != nil {
+		t.Fatalf("UndoActionBatch() error = %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("UndoActionBatch() removed %d entries, want 2", removed)
+	}
+
+	for _, username := range []string{"spammer1", "spammer2"} {
+		if blocked, _ := db.IsBlocked(username); blocked {
+			t.Errorf("%s should no longer be blocked", username)
+		}
+	}
+	if blocked, _ := db.IsBlocked(kept.Username); !blocked {
+		t.Error("entry from an earlier batch should be kept")
+	}
+
+	batch, err = db.LatestActionBatch()
+	if err != nil {
+		t.Fatalf("LatestActionBatch() error = %v", err)
+	}
+	if len(batch) != 1 || batch[0].BatchID != "batch-1" {
+		t.Errorf("expected batch-1 to become the latest batch, got %v", batch)
+	}
+}
@@ -16,153 +16,202 @@ package database
 
 import (
 	"database/sql"
+	"embed"
 	"fmt"
-	"os/exec"
-	"path/filepath"
-	"runtime"
+	"io/fs"
+	"path"
+	"sort"
 	"strconv"
 	"strings"
 )
 
-// checkGeniInstalled checks if geni CLI is installed
-func checkGeniInstalled() error {
-	_, err := exec.LookPath("geni")
+//go:embed migrations/*.up.sql migrations/*.down.sql
+var migrationFiles embed.FS
+
+// migration is a single versioned schema change loaded from the embedded files
+type migration struct {
+	version int
+	id      string
+	name    string
+	up      string
+	down    string
+}
+
+// createMigrationsTable matches the layout geni used, so databases migrated
+// with geni keep working with the embedded runner
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (id VARCHAR(255) PRIMARY KEY)`
+
+// loadMigrations reads the embedded migration files sorted by version
+func loadMigrations() ([]migration, error) {
+	files, err := fs.Glob(migrationFiles, "migrations/*.up.sql")
 	if err != nil {
-		return fmt.Errorf(`geni CLI not found. Please install it:
-  - Homebrew: brew install geni
-  - Cargo:    cargo install geni
-  - See:      https://github.com/emilpriver/geni`)
+		return nil, fmt.Errorf("failed to list embedded migrations: %w", err)
 	}
-	return nil
-}
 
-// getMigrationsPath returns the absolute path to the migrations directory
-func getMigrationsPath() (string, error) {
-	_, filename, _, ok := runtime.Caller(0)
-	if !ok {
-		return "", fmt.Errorf("failed to get current file path")
+	migrations := make([]migration, 0, len(files))
+	for _, file := range files {
+		base := strings.TrimSuffix(path.Base(file), ".up.sql")
+		id, name, _ := strings.Cut(base, "_")
+		version, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name %s: %w", file, err)
+		}
+
+		up, err := migrationFiles.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", file, err)
+		}
+		downFile := strings.TrimSuffix(file, ".up.sql") + ".down.sql"
+		down, err := migrationFiles.ReadFile(downFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", downFile, err)
+		}
+
+		migrations = append(migrations, migration{
+			version: version,
+			id:      id,
+			name:    name,
+			up:      string(up),
+			down:    string(down),
+		})
 	}
-	dir := filepath.Dir(filename)
-	return filepath.Join(dir, "migrations"), nil
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
 }
 
-// RunMigrations executes all pending migrations using geni CLI
-// dbType: "sqlite" or "turso"
-// dbURL: file path for sqlite, or libsql:// URL for turso
-// authToken: empty for sqlite, auth token for turso
-func RunMigrations(_ *sql.DB, dbType, dbURL, authToken string) error {
-	if err := checkGeniInstalled(); err != nil {
-		return err
+// appliedVersions returns the set of migration versions recorded in schema_migrations
+func appliedVersions(db *sql.DB) (map[int]bool, error) {
+	rows, err := db.Query("SELECT id FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan migration id: %w", err)
+		}
+		version, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration id %q: %w", id, err)
+		}
+		applied[version] = true
 	}
+	return applied, rows.Err()
+}
 
-	migrationsPath, err := getMigrationsPath()
+// RunMigrations applies all pending embedded migrations in version order
+func RunMigrations(db *sql.DB) error {
+	migrations, err := loadMigrations()
 	if err != nil {
 		return err
 	}
 
-	// Build DATABASE_URL based on type
-	var databaseURL string
-	switch dbType {
-	case "sqlite":
-		databaseURL = "sqlite://" + dbURL
-	case "turso":
-		// Convert libsql:// to https:// for geni
-		databaseURL = strings.Replace(dbURL, "libsql://", "https://", 1)
-	default:
-		return fmt.Errorf("unsupported database type: %s", dbType)
+	if _, err := db.Exec(createMigrationsTable); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
 	}
 
-	// Run geni up command with DATABASE_URL environment variable
-	cmd := exec.Command("geni", "up")
-	cmd.Env = append(cmd.Env, "DATABASE_URL="+databaseURL)
-	cmd.Env = append(cmd.Env, "MIGRATIONS_DIR="+migrationsPath)
-	cmd.Dir = filepath.Dir(migrationsPath)
-
-	// Add auth token for Turso (geni uses DATABASE_TOKEN)
-	if dbType == "turso" && authToken != "" {
-		cmd.Env = append(cmd.Env, "DATABASE_TOKEN="+authToken)
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return err
 	}
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("migration failed: %w\nOutput: %s", err, string(output))
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+		if err := applyMigration(db, m.up, "INSERT INTO schema_migrations (id) VALUES (?)", m.id); err != nil {
+			return fmt.Errorf("failed to apply migration %s_%s: %w", m.id, m.name, err)
+		}
 	}
 
 	return nil
 }
 
-// Rollback rolls back the last migration using geni CLI
-func Rollback(_ *sql.DB, dbType, dbURL, authToken string) error {
-	if err := checkGeniInstalled(); err != nil {
+// Rollback reverts the most recently applied migration
+func Rollback(db *sql.DB) error {
+	migrations, err := loadMigrations()
+	if err != nil {
 		return err
 	}
 
-	migrationsPath, err := getMigrationsPath()
+	version, err := MigrationStatus(db)
 	if err != nil {
 		return err
 	}
+	if version == 0 {
+		return nil
+	}
 
-	// Build DATABASE_URL based on type
-	var databaseURL string
-	switch dbType {
-	case "sqlite":
-		databaseURL = "sqlite://" + dbURL
-	case "turso":
-		// Convert libsql:// to https:// for geni
-		databaseURL = strings.Replace(dbURL, "libsql://", "https://", 1)
-	default:
-		return fmt.Errorf("unsupported database type: %s", dbType)
+	for _, m := range migrations {
+		if m.version != version {
+			continue
+		}
+		if err := applyMigration(db, m.down, "DELETE FROM schema_migrations WHERE CAST(id AS INTEGER) = ?", m.version); err != nil {
+			return fmt.Errorf("failed to roll back migration %s_%s: %w", m.id, m.name, err)
+		}
+		return nil
 	}
 
-	// Run geni down command with DATABASE_URL environment variable
-	cmd := exec.Command("geni", "down")
-	cmd.Env = append(cmd.Env, "DATABASE_URL="+databaseURL)
-	cmd.Env = append(cmd.Env, "MIGRATIONS_DIR="+migrationsPath)
-	cmd.Dir = filepath.Dir(migrationsPath)
+	return fmt.Errorf("no migration file found for applied version %d", version)
+}
 
-	// Add auth token for Turso (geni uses DATABASE_TOKEN)
-	if dbType == "turso" && authToken != "" {
-		cmd.Env = append(cmd.Env, "DATABASE_TOKEN="+authToken)
+// applyMigration runs a migration script and records it in a single transaction
+func applyMigration(db *sql.DB, script, record string, arg any) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback() //nolint:errcheck
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("rollback failed: %w\nOutput: %s", err, string(output))
+	if _, err := tx.Exec(script); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(record, arg); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
 	}
 
-	return nil
+	return tx.Commit()
+}
+
+// LatestMigrationVersion returns the version of the newest embedded migration
+func LatestMigrationVersion() (int, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return 0, err
+	}
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+	return migrations[len(migrations)-1].version, nil
 }
 
 // MigrationStatus returns the current migration version
 func MigrationStatus(db *sql.DB) (int, error) {
-	// Query the geni migrations table directly
-	var version int
-	err := db.QueryRow("SELECT version FROM geni_migrations ORDER BY version DESC LIMIT 1").Scan(&version)
-	if err == sql.ErrNoRows {
+	var tableCount int
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='schema_migrations'").Scan(&tableCount)
+	if err != nil {
+		return 0, err
+	}
+	if tableCount == 0 {
 		return 0, nil
 	}
+
+	applied, err := appliedVersions(db)
 	if err != nil {
-		// Table might not exist yet
-		var tableCount int
-		err2 := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='geni_migrations'").Scan(&tableCount)
-		if err2 != nil {
-			return 0, err2
-		}
-		if tableCount == 0 {
-			return 0, nil
-		}
 		return 0, err
 	}
 
-	// Parse version from filename format (001_initial_schema)
-	versionStr := strconv.Itoa(version)
-	if strings.HasPrefix(versionStr, "00") {
-		versionStr = strings.TrimLeft(versionStr, "0")
-		if versionStr == "" {
-			versionStr = "0"
+	version := 0
+	for v := range applied {
+		if v > version {
+			version = v
 		}
-		version, _ = strconv.Atoi(versionStr)
 	}
-
 	return version, nil
 }
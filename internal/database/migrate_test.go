@@ -0,0 +1,232 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// openMigrationTestDBs returns raw in-memory and file-based SQLite connections
+func openMigrationTestDBs(t *testing.T) map[string]*sql.DB {
+	memory, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open in-memory database: %v", err)
+	}
+	memory.SetMaxOpenConns(1)
+
+	file, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "prguard.db"))
+	if err != nil {
+		t.Fatalf("Failed to open file database: %v", err)
+	}
+
+	t.Cleanup(func() {
+		memory.Close() //nolint:errcheck
+		file.Close()   //nolint:errcheck
+	})
+
+	return map[string]*sql.DB{"memory": memory, "file": file}
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name=?", name).Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to check table %s: %v", name, err)
+	}
+	return count > 0
+}
+
+func latestMigrationVersion(t *testing.T) int {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("No embedded migrations found")
+	}
+	return migrations[len(migrations)-1].version
+}
+
+func TestLatestMigrationVersion(t *testing.T) {
+	version, err := LatestMigrationVersion()
+	if err != nil {
+		t.Fatalf("LatestMigrationVersion failed: %v", err)
+	}
+	if want := latestMigrationVersion(t); version != want {
+		t.Errorf("Expected version %d, got %d", want, version)
+	}
+}
+
+func TestMigrationStatusFreshDatabase(t *testing.T) {
+	for name, db := range openMigrationTestDBs(t) {
+		t.Run(name, func(t *testing.T) {
+			version, err := MigrationStatus(db)
+			if err != nil {
+				t.Fatalf("MigrationStatus failed: %v", err)
+			}
+			if version != 0 {
+				t.Errorf("Expected version 0, got %d", version)
+			}
+		})
+	}
+}
+
+func TestRunMigrationsUpAndRollback(t *testing.T) {
+	latest := latestMigrationVersion(t)
+
+	for name, db := range openMigrationTestDBs(t) {
+		t.Run(name, func(t *testing.T) {
+			if err := RunMigrations(db); err != nil {
+				t.Fatalf("RunMigrations failed: %v", err)
+			}
+
+			version, err := MigrationStatus(db)
+			if err != nil {
+				t.Fatalf("MigrationStatus failed: %v", err)
+			}
+			if version != latest {
+				t.Errorf("Expected version %d, got %d", latest, version)
+			}
+			if !tableExists(t, db, "blocklist") || !tableExists(t, db, "scan_history") {
+				t.Fatal("Expected blocklist and scan_history tables to exist")
+			}
+
+			// Running again is a no-op
+			if err := RunMigrations(db); err != nil {
+				t.Fatalf("Second RunMigrations failed: %v", err)
+			}
+
+			if err := Rollback(db); err != nil {
+				t.Fatalf("Rollback failed: %v", err)
+			}
+			version, err = MigrationStatus(db)
+			if err != nil {
+				t.Fatalf("MigrationStatus failed: %v", err)
+			}
+			if version != latest-1 {
+				t.Errorf("Expected version %d after rollback, got %d", latest-1, version)
+			}
+
+			// Roll back everything, then the schema should be gone
+			for version > 0 {
+				if err := Rollback(db); err != nil {
+					t.Fatalf("Rollback failed: %v", err)
+				}
+				if version, err = MigrationStatus(db); err != nil {
+					t.Fatalf("MigrationStatus failed: %v", err)
+				}
+			}
+			if tableExists(t, db, "blocklist") {
+				t.Error("Expected blocklist table to be dropped")
+			}
+
+			// Rolling back with nothing applied is a no-op
+			if err := Rollback(db); err != nil {
+				t.Errorf("Rollback on empty schema failed: %v", err)
+			}
+
+			// Migrating up again restores the latest version
+			if err := RunMigrations(db); err != nil {
+				t.Fatalf("RunMigrations after rollback failed: %v", err)
+			}
+			if version, _ = MigrationStatus(db); version != latest {
+				t.Errorf("Expected version %d, got %d", latest, version)
+			}
+		})
+	}
+}
+
+func TestRunMigrationsResumesGeniDatabase(t *testing.T) {
+	db := openMigrationTestDBs(t)["file"]
+
+	// Simulate a database migrated by geni up to the first version
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+	if _, err := db.Exec(createMigrationsTable); err != nil {
+		t.Fatalf("Failed to create schema_migrations: %v", err)
+	}
+	if _, err := db.Exec(migrations[0].up); err != nil {
+		t.Fatalf("Failed to apply first migration: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO schema_migrations (id) VALUES (?)", migrations[0].id); err != nil {
+		t.Fatalf("Failed to record first migration: %v", err)
+	}
+
+	if err := RunMigrations(db); err != nil {
+		t.Fatalf("RunMigrations failed: %v", err)
+	}
+
+	version, err := MigrationStatus(db)
+	if err != nil {
+		t.Fatalf("MigrationStatus failed: %v", err)
+	}
+	if version != latestMigrationVersion(t) {
+		t.Errorf("Expected version %d, got %d", latestMigrationVersion(t), version)
+	}
+}
+
+func indexExists(t *testing.T, db *sql.DB, name string) bool {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='index' AND name=?", name).Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to check index %s: %v", name, err)
+	}
+	return count > 0
+}
+
+func TestRunMigrationsCreatesLookupIndexes(t *testing.T) {
+	for name, db := range openMigrationTestDBs(t) {
+		t.Run(name, func(t *testing.T) {
+			if err := RunMigrations(db); err != nil {
+				t.Fatalf("RunMigrations failed: %v", err)
+			}
+			for _, index := range []string{"idx_blocklist_username", "idx_blocklist_timestamp"} {
+				if !indexExists(t, db, index) {
+					t.Errorf("Expected index %s after migration", index)
+				}
+			}
+		})
+	}
+}
+
+func TestUsernameLookupsUseIndex(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close() //nolint:errcheck
+
+	// The index is COLLATE NOCASE so the case-insensitive lookups can use it
+	rows, err := db.conn.Query(`EXPLAIN QUERY PLAN SELECT COUNT(*) FROM blocklist WHERE username = ? COLLATE NOCASE`, "spammer")
+	if err != nil {
+		t.Fatalf("EXPLAIN QUERY PLAN failed: %v", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var plan []string
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			t.Fatalf("Failed to scan query plan: %v", err)
+		}
+		plan = append(plan, detail)
+	}
+	if !strings.Contains(strings.Join(plan, "\n"), "idx_blocklist_username") {
+		t.Errorf("Expected username lookup to use idx_blocklist_username, got plan %v", plan)
+	}
+}
@@ -0,0 +1,3 @@
+-- Rollback scan history
+DROP INDEX IF EXISTS idx_scan_history_repository;
+DROP TABLE IF EXISTS scan_history;
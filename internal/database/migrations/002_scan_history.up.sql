@@ -0,0 +1,12 @@
+-- Scan history for tracking spam trends over time
+CREATE TABLE IF NOT EXISTS scan_history (
+    id TEXT PRIMARY KEY,
+    repository TEXT NOT NULL,
+    scanned_at DATETIME NOT NULL,
+    total_prs INTEGER NOT NULL,
+    spam_count INTEGER NOT NULL,
+    uncertain_count INTEGER NOT NULL,
+    clean_count INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_scan_history_repository ON scan_history(repository, scanned_at);
@@ -0,0 +1,3 @@
+-- Rollback blocklist expiration
+DROP INDEX IF EXISTS idx_blocklist_expires_at;
+ALTER TABLE blocklist DROP COLUMN expires_at;
@@ -0,0 +1,4 @@
+-- Optional expiration for temporary blocks
+ALTER TABLE blocklist ADD COLUMN expires_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_blocklist_expires_at ON blocklist(expires_at);
@@ -0,0 +1,2 @@
+-- Rollback blocklist tags
+ALTER TABLE blocklist DROP COLUMN tags;
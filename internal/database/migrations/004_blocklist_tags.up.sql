@@ -0,0 +1,2 @@
+-- Reason-code tags stored as a JSON array of strings
+ALTER TABLE blocklist ADD COLUMN tags TEXT NOT NULL DEFAULT '[]';
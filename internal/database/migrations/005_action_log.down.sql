@@ -0,0 +1,4 @@
+-- Rollback action log
+DROP INDEX IF EXISTS idx_action_log_created_at;
+DROP INDEX IF EXISTS idx_action_log_batch;
+DROP TABLE IF EXISTS action_log;
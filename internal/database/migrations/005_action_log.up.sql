@@ -0,0 +1,14 @@
+-- Automated actions grouped by batch so a run can be undone
+CREATE TABLE IF NOT EXISTS action_log (
+    id TEXT PRIMARY KEY,
+    batch_id TEXT NOT NULL,
+    action TEXT NOT NULL CHECK(action IN ('block', 'close_pr')),
+    entry_id TEXT NOT NULL DEFAULT '',
+    username TEXT NOT NULL DEFAULT '',
+    repository TEXT NOT NULL DEFAULT '',
+    pr_number INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_action_log_batch ON action_log(batch_id);
+CREATE INDEX IF NOT EXISTS idx_action_log_created_at ON action_log(created_at);
@@ -0,0 +1,2 @@
+-- Rollback source cache
+DROP TABLE IF EXISTS source_cache;
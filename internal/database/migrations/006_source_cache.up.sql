@@ -0,0 +1,7 @@
+-- HTTP cache validators for remote blocklist sources
+CREATE TABLE IF NOT EXISTS source_cache (
+    url TEXT PRIMARY KEY,
+    etag TEXT NOT NULL DEFAULT '',
+    last_modified TEXT NOT NULL DEFAULT '',
+    fetched_at DATETIME NOT NULL
+);
@@ -0,0 +1,3 @@
+-- Rollback case-insensitive username index
+DROP INDEX IF EXISTS idx_blocklist_username;
+CREATE INDEX IF NOT EXISTS idx_blocklist_username ON blocklist(username);
@@ -0,0 +1,3 @@
+-- GitHub usernames are case-insensitive, so index them for COLLATE NOCASE lookups
+DROP INDEX IF EXISTS idx_blocklist_username;
+CREATE INDEX IF NOT EXISTS idx_blocklist_username ON blocklist(username COLLATE NOCASE);
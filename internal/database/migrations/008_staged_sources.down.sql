@@ -0,0 +1,2 @@
+-- Rollback staged sources
+DROP TABLE IF EXISTS staged_sources;
@@ -0,0 +1,6 @@
+-- Digest of the entries last staged from each untrusted blocklist source
+CREATE TABLE IF NOT EXISTS staged_sources (
+    url TEXT PRIMARY KEY,
+    digest TEXT NOT NULL,
+    staged_at DATETIME NOT NULL
+);
@@ -14,7 +14,41 @@ CREATE TABLE blocklist (
     severity TEXT NOT NULL CHECK(severity IN ('low', 'medium', 'high')),
     source TEXT NOT NULL CHECK(source IN ('manual', 'imported', 'auto-detected')),
     metadata TEXT NOT NULL DEFAULT '{}'
-);
-CREATE INDEX idx_blocklist_username ON blocklist(username);
+, expires_at DATETIME, tags TEXT NOT NULL DEFAULT '[]');
 CREATE INDEX idx_blocklist_severity ON blocklist(severity);
-CREATE INDEX idx_blocklist_timestamp ON blocklist(timestamp);
\ No newline at end of file
+CREATE INDEX idx_blocklist_timestamp ON blocklist(timestamp);
+CREATE INDEX idx_blocklist_expires_at ON blocklist(expires_at);
+CREATE TABLE scan_history (
+    id TEXT PRIMARY KEY,
+    repository TEXT NOT NULL,
+    scanned_at DATETIME NOT NULL,
+    total_prs INTEGER NOT NULL,
+    spam_count INTEGER NOT NULL,
+    uncertain_count INTEGER NOT NULL,
+    clean_count INTEGER NOT NULL
+);
+CREATE INDEX idx_scan_history_repository ON scan_history(repository, scanned_at);
+CREATE TABLE action_log (
+    id TEXT PRIMARY KEY,
+    batch_id TEXT NOT NULL,
+    action TEXT NOT NULL CHECK(action IN ('block', 'close_pr')),
+    entry_id TEXT NOT NULL DEFAULT '',
+    username TEXT NOT NULL DEFAULT '',
+    repository TEXT NOT NULL DEFAULT '',
+    pr_number INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL
+);
+CREATE INDEX idx_action_log_batch ON action_log(batch_id);
+CREATE INDEX idx_action_log_created_at ON action_log(created_at);
+CREATE TABLE source_cache (
+    url TEXT PRIMARY KEY,
+    etag TEXT NOT NULL DEFAULT '',
+    last_modified TEXT NOT NULL DEFAULT '',
+    fetched_at DATETIME NOT NULL
+);
+CREATE INDEX idx_blocklist_username ON blocklist(username COLLATE NOCASE);
+CREATE TABLE staged_sources (
+    url TEXT PRIMARY KEY,
+    digest TEXT NOT NULL,
+    staged_at DATETIME NOT NULL
+);
@@ -14,5 +14,5 @@
 
 package database
 
-// schema.go is deprecated - migrations are now managed via geni
+// schema.go is deprecated - migrations are embedded and applied by migrate.go
 // See internal/database/migrations/ for schema definitions
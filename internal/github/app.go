@@ -0,0 +1,192 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// Installation token lifetimes
+const (
+	appJWTLifetime     = 9 * time.Minute // GitHub rejects app JWTs valid for more than 10 minutes
+	appJWTClockSkew    = time.Minute     // Backdate iat to tolerate clock drift
+	tokenRefreshMargin = time.Minute     // Refresh installation tokens this long before expiry
+	defaultAPIBaseURL  = "https://api.github.com/"
+)
+
+// NewClientFromApp creates a GitHub API client authenticated as a GitHub App installation.
+// Installation tokens are minted from the app's private key and refreshed before they expire.
+func NewClientFromApp(appID, installationID int64, privateKeyPath string, maxRetries int) (*Client, error) {
+	pemData, err := os.ReadFile(privateKeyPath) //nolint:gosec // user-configured key path
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	key, err := parsePrivateKey(pemData)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := newInstallationTransport(http.DefaultTransport, appID, installationID, key)
+
+	return &Client{
+		client:     github.NewClient(&http.Client{Transport: transport}),
+		ctx:        context.Background(),
+		maxRetries: maxRetries,
+		baseDelay:  defaultRetryBaseDelay,
+	}, nil
+}
+
+// parsePrivateKey decodes a PEM-encoded PKCS#1 or PKCS#8 RSA private key
+func parsePrivateKey(pemData []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode private key: no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse private key: not an RSA key")
+	}
+	return key, nil
+}
+
+// installationTransport authenticates requests with a cached installation access token
+type installationTransport struct {
+	base           http.RoundTripper
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+	baseURL        string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// newInstallationTransport creates a transport that mints installation tokens on demand
+func newInstallationTransport(base http.RoundTripper, appID, installationID int64, key *rsa.PrivateKey) *installationTransport {
+	return &installationTransport{
+		base:           base,
+		appID:          appID,
+		installationID: installationID,
+		key:            key,
+		baseURL:        defaultAPIBaseURL,
+	}
+}
+
+// RoundTrip adds the installation token to the request
+func (t *installationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.installationToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	authed := req.Clone(req.Context())
+	authed.Header.Set("Authorization", "token "+token)
+	return t.base.RoundTrip(authed)
+}
+
+// installationToken returns the cached token, minting a new one when it is about to expire
+func (t *installationTransport) installationToken(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Until(t.expiresAt) > tokenRefreshMargin {
+		return t.token, nil
+	}
+
+	jwt, err := t.appJWT(time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%sapp/installations/%d/access_tokens", t.baseURL, t.installationID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request installation token: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to request installation token: status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode installation token: %w", err)
+	}
+
+	t.token = body.Token
+	t.expiresAt = body.ExpiresAt
+	return t.token, nil
+}
+
+// appJWT creates the RS256-signed JWT that authenticates as the app itself
+func (t *installationTransport) appJWT(now time.Time) (string, error) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(t.appID, 10),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT claims: %w", err)
+	}
+
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, t.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// writeTestKey generates an RSA key and writes it as a PKCS#1 PEM file
+func writeTestKey(t *testing.T) (string, *rsa.PrivateKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "app.pem")
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(path, pemData, 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	return path, key
+}
+
+func TestNewClientFromApp(t *testing.T) {
+	path, _ := writeTestKey(t)
+
+	c, err := NewClientFromApp(123, 456, path, 3)
+	if err != nil {
+		t.Fatalf("NewClientFromApp failed: %v", err)
+	}
+
+	transport, ok := c.client.Client().Transport.(*installationTransport)
+	if !ok {
+		t.Fatalf("Expected installation transport, got %T", c.client.Client().Transport)
+	}
+	if transport.appID != 123 || transport.installationID != 456 {
+		t.Errorf("Unexpected transport IDs: app %d, installation %d", transport.appID, transport.installationID)
+	}
+
+	if _, err := NewClientFromApp(123, 456, filepath.Join(t.TempDir(), "missing.pem"), 3); err == nil {
+		t.Error("Expected error for missing key file")
+	}
+
+	badPath := filepath.Join(t.TempDir(), "bad.pem")
+	if err := os.WriteFile(badPath, []byte("not a key"), 0600); err != nil {
+		t.Fatalf("Failed to write bad key: %v", err)
+	}
+	if _, err := NewClientFromApp(123, 456, badPath, 3); err == nil {
+		t.Error("Expected error for invalid key")
+	}
+}
+
+func TestInstallationTransport_AppJWT(t *testing.T) {
+	_, key := writeTestKey(t)
+	transport := newInstallationTransport(http.DefaultTransport, 123, 456, key)
+
+	now := time.Unix(1700000000, 0)
+	jwt, err := transport.appJWT(now)
+	if err != nil {
+		t.Fatalf("appJWT failed: %v", err)
+	}
+
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("Expected 3 JWT parts, got %d", len(parts))
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("Failed to decode signature: %v", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("JWT signature does not verify: %v", err)
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("Failed to decode claims: %v", err)
+	}
+	var claims struct {
+		Iat int64  `json:"iat"`
+		Exp int64  `json:"exp"`
+		Iss string `json:"iss"`
+	}
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		t.Fatalf("Failed to parse claims: %v", err)
+	}
+	if claims.Iss != "123" {
+		t.Errorf("Expected iss 123, got %s", claims.Iss)
+	}
+	if claims.Iat != now.Add(-time.Minute).Unix() || claims.Exp != now.Add(9*time.Minute).Unix() {
+		t.Errorf("Unexpected token lifetime: iat %d, exp %d", claims.Iat, claims.Exp)
+	}
+}
+
+func TestInstallationTransport_MintsAndCachesToken(t *testing.T) {
+	_, key := writeTestKey(t)
+
+	var tokenRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/app/installations/456/access_tokens" {
+			atomic.AddInt32(&tokenRequests, 1)
+			if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+				t.Errorf("Expected bearer JWT, got %q", r.Header.Get("Authorization"))
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token":"inst-token","expires_at":%q}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "token inst-token" {
+			t.Errorf("Expected installation token, got %q", got)
+		}
+	}))
+	defer server.Close()
+
+	transport := newInstallationTransport(http.DefaultTransport, 123, 456, key)
+	transport.baseURL = server.URL + "/"
+	client := &http.Client{Transport: transport}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL + "/repos/org/repo")
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		_ = resp.Body.Close() //nolint:errcheck
+	}
+
+	if tokenRequests != 1 {
+		t.Errorf("Expected the token to be minted once and cached, got %d requests", tokenRequests)
+	}
+}
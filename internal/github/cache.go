@@ -0,0 +1,72 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"sync"
+)
+
+// CachedGitHubClient decorates a GitHubClient with an in-memory cache of GetUser
+// results. All other calls pass straight through to the wrapped client.
+type CachedGitHubClient struct {
+	GitHubClient
+
+	cache *userCache
+}
+
+// userCache is shared between a CachedGitHubClient and its WithContext copies
+type userCache struct {
+	mu    sync.Mutex
+	users map[string]*User
+}
+
+// NewCachedClient wraps client so repeated GetUser calls for the same username
+// are served from memory for the lifetime of the returned client
+func NewCachedClient(client GitHubClient) *CachedGitHubClient {
+	return &CachedGitHubClient{
+		GitHubClient: client,
+		cache:        &userCache{users: make(map[string]*User)},
+	}
+}
+
+// WithContext returns a copy bound to ctx that shares this client's cache
+func (c *CachedGitHubClient) WithContext(ctx context.Context) GitHubClient {
+	return &CachedGitHubClient{
+		GitHubClient: c.GitHubClient.WithContext(ctx),
+		cache:        c.cache,
+	}
+}
+
+// GetUser returns the cached user when available, otherwise fetches and caches it.
+// Errors are not cached so a transient failure can be retried.
+func (c *CachedGitHubClient) GetUser(username string) (*User, error) {
+	c.cache.mu.Lock()
+	user, ok := c.cache.users[username]
+	c.cache.mu.Unlock()
+	if ok {
+		return user, nil
+	}
+
+	user, err := c.GitHubClient.GetUser(username)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.mu.Lock()
+	c.cache.users[username] = user
+	c.cache.mu.Unlock()
+	return user, nil
+}
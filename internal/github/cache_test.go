@@ -0,0 +1,119 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/mocks"
+)
+
+func TestCachedClient_GetUserCalledOnce(t *testing.T) {
+	calls := map[string]int{}
+	mock := &mocks.MockGitHubClient{
+		GetUserFn: func(username string) (*github.User, error) {
+			calls[username]++
+			return &github.User{Login: username, Followers: 3}, nil
+		},
+	}
+
+	client := github.NewCachedClient(mock)
+	for i := 0; i < 2; i++ {
+		user, err := client.GetUser("octocat")
+		if err != nil {
+			t.Fatalf("GetUser failed: %v", err)
+		}
+		if user.Login != "octocat" || user.Followers != 3 {
+			t.Errorf("unexpected user: %+v", user)
+		}
+	}
+	if _, err := client.GetUser("hubot"); err != nil {
+		t.Fatalf("GetUser failed: %v", err)
+	}
+
+	if calls["octocat"] != 1 {
+		t.Errorf("expected 1 GetUser call for octocat, got %d", calls["octocat"])
+	}
+	if calls["hubot"] != 1 {
+		t.Errorf("expected 1 GetUser call for hubot, got %d", calls["hubot"])
+	}
+}
+
+func TestCachedClient_ErrorsNotCached(t *testing.T) {
+	calls := 0
+	mock := &mocks.MockGitHubClient{
+		GetUserFn: func(username string) (*github.User, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("rate limited")
+			}
+			return &github.User{Login: username}, nil
+		},
+	}
+
+	client := github.NewCachedClient(mock)
+	if _, err := client.GetUser("octocat"); err == nil {
+		t.Fatal("expected first GetUser to fail")
+	}
+	if _, err := client.GetUser("octocat"); err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 GetUser calls, got %d", calls)
+	}
+}
+
+func TestCachedClient_PassesThrough(t *testing.T) {
+	closed := 0
+	mock := &mocks.MockGitHubClient{
+		ClosePullRequestFn: func(_, _ string, _ int, _ string) error {
+			closed++
+			return nil
+		},
+	}
+
+	var client github.GitHubClient = github.NewCachedClient(mock)
+	if err := client.ClosePullRequest("org", "repo", 1, ""); err != nil {
+		t.Fatalf("ClosePullRequest failed: %v", err)
+	}
+	if closed != 1 {
+		t.Errorf("expected call to reach wrapped client, got %d", closed)
+	}
+}
+
+func TestCachedClient_WithContextSharesCache(t *testing.T) {
+	calls := 0
+	mock := &mocks.MockGitHubClient{
+		GetUserFn: func(username string) (*github.User, error) {
+			calls++
+			return &github.User{Login: username}, nil
+		},
+	}
+
+	client := github.NewCachedClient(mock)
+	if _, err := client.GetUser("octocat"); err != nil {
+		t.Fatalf("GetUser failed: %v", err)
+	}
+	if _, err := client.WithContext(context.Background()).GetUser("octocat"); err != nil {
+		t.Fatalf("GetUser failed: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected 1 GetUser call, got %d", calls)
+	}
+}
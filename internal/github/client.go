@@ -16,21 +16,37 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v57/github"
 	"golang.org/x/oauth2"
 )
 
+// Retry defaults for rate-limited and server-error responses
+const (
+	defaultRetryBaseDelay = 1 * time.Second
+	maxRetryWait          = 5 * time.Minute
+)
+
+// ErrUserNotFound is returned by GetUser when the account does not exist,
+// which is also how GitHub reports deleted and suspended accounts
+var ErrUserNotFound = errors.New("user not found")
+
 // Client wraps the GitHub API client
 type Client struct {
-	client *github.Client
-	ctx    context.Context
+	client     *github.Client
+	ctx        context.Context
+	maxRetries int
+	baseDelay  time.Duration
 }
 
-// NewClient creates a new GitHub API client
-func NewClient(token string) *Client {
+// NewClient creates a new GitHub API client.
+// maxRetries is the number of times a rate-limited or 5xx request is retried.
+func NewClient(token string, maxRetries int) *Client {
 	ctx := context.Background()
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
@@ -38,75 +54,254 @@ func NewClient(token string) *Client {
 	tc := oauth2.NewClient(ctx, ts)
 
 	return &Client{
-		client: github.NewClient(tc),
-		ctx:    ctx,
+		client:     github.NewClient(tc),
+		ctx:        ctx,
+		maxRetries: maxRetries,
+		baseDelay:  defaultRetryBaseDelay,
+	}
+}
+
+// WithContext returns a copy of the client whose API calls are bound to ctx.
+// Cancelling ctx aborts in-flight requests and pending retry waits.
+func (c *Client) WithContext(ctx context.Context) GitHubClient {
+	clone := *c
+	clone.ctx = ctx
+	return &clone
+}
+
+// withRetry runs an API call, retrying on rate limits and server errors with
+// exponential backoff. Rate limit reset and Retry-After hints take precedence
+// over the backoff delay when they are longer.
+func (c *Client) withRetry(call func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= c.maxRetries {
+			return err
+		}
+
+		wait, retryable := retryDelay(err, c.baseDelay<<attempt)
+		if !retryable || wait > maxRetryWait {
+			return err
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-c.ctx.Done():
+			return err
+		}
+	}
+}
+
+// retryDelay reports whether err is retryable and how long to wait before retrying
+func retryDelay(err error, backoff time.Duration) (time.Duration, bool) {
+	var rateErr *github.RateLimitError
+	if errors.As(err, &rateErr) {
+		if untilReset := time.Until(rateErr.Rate.Reset.Time); untilReset > backoff {
+			return untilReset, true
+		}
+		return backoff, true
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		if abuseErr.RetryAfter != nil && *abuseErr.RetryAfter > backoff {
+			return *abuseErr.RetryAfter, true
+		}
+		return backoff, true
+	}
+
+	var respErr *github.ErrorResponse
+	if errors.As(err, &respErr) && respErr.Response != nil && respErr.Response.StatusCode >= http.StatusInternalServerError {
+		return backoff, true
 	}
+
+	return 0, false
 }
 
 // PullRequest represents a GitHub pull request with relevant metadata
 type PullRequest struct {
-	Number     int
-	Title      string
-	Body       string
-	Author     string
-	CreatedAt  time.Time
-	FilesCount int
-	Additions  int
-	Deletions  int
-	Files      []string
-	State      string
-	HTMLURL    string
+	Number        int
+	Title         string
+	Body          string
+	Author        string
+	CreatedAt     time.Time
+	FilesCount    int
+	Additions     int
+	Deletions     int
+	Files         []string
+	State         string
+	HTMLURL       string
+	BaseRef       string // Branch the PR targets
+	DefaultBranch string // Default branch of the target repository
+}
+
+// Issue represents a GitHub issue with relevant metadata
+type Issue struct {
+	Number    int
+	Title     string
+	Body      string
+	Author    string
+	CreatedAt time.Time
+	State     string
+	HTMLURL   string
+}
+
+// IssueEvent is a timeline event of an issue or pull request, such as "closed" or "reopened"
+type IssueEvent struct {
+	Event     string
+	Actor     string
+	CreatedAt time.Time
 }
 
 // User represents a GitHub user with account information
 type User struct {
-	Login     string
-	CreatedAt time.Time
-	Type      string
+	Login       string
+	CreatedAt   time.Time
+	Type        string
+	Followers   int
+	Following   int
+	PublicRepos int
+}
+
+// UserRepository is a public repository owned by a user
+type UserRepository struct {
+	Name string
+	Fork bool
+	Size int // Size in KB as reported by GitHub; 0 for an empty repository
+}
+
+// PullRequestFile is a file changed by a pull request with its unified diff
+type PullRequestFile struct {
+	Filename string
+	Patch    string // Empty for binary files and diffs too large for the API
+}
+
+// Repository represents the repository details the scanner needs
+type Repository struct {
+	DefaultBranch string
+	ReadmePath    string // Path of the README GitHub renders on the default branch, empty if there is none
 }
 
-// GetPullRequests fetches all open pull requests for a repository
+// GetPullRequests fetches all open pull requests for a repository. On error the
+// PRs fetched before the failure are returned along with it.
 func (c *Client) GetPullRequests(owner, repo string) ([]*PullRequest, error) {
+	var allPRs []*PullRequest
+	err := c.StreamPullRequests(owner, repo, func(pr *PullRequest) error {
+		allPRs = append(allPRs, pr)
+		return nil
+	})
+	return allPRs, err
+}
+
+// StreamPullRequests fetches open pull requests newest first and calls fn with
+// each one as its page is listed, so a failure on a later page leaves the PRs
+// already delivered usable. An error from fn stops the stream and is returned.
+func (c *Client) StreamPullRequests(owner, repo string, fn func(*PullRequest) error) error {
+	return c.forEachPullRequestPage(owner, repo, func(prs []*github.PullRequest) (bool, error) {
+		for _, pr := range prs {
+			details, err := c.GetPullRequest(owner, repo, pr.GetNumber())
+			if err != nil {
+				return true, err
+			}
+			if err := fn(details); err != nil {
+				return true, err
+			}
+		}
+		return false, nil
+	})
+}
+
+// ListPullRequestNumbers lists the numbers of all open pull requests for a repository.
+// On error the numbers listed before the failing page are returned along with it.
+func (c *Client) ListPullRequestNumbers(owner, repo string) ([]int, error) {
+	return c.ListPullRequestNumbersSince(owner, repo, time.Time{})
+}
+
+// ListPullRequestNumbersSince lists the numbers of open pull requests created after since.
+// PRs are listed newest first so pagination stops at the first older PR; a zero
+// since lists every open PR.
+func (c *Client) ListPullRequestNumbersSince(owner, repo string, since time.Time) ([]int, error) {
+	return c.listPullRequestNumbers(owner, repo, "", since)
+}
+
+// ListPullRequestNumbersByAuthor lists the numbers of open pull requests opened by
+// author (case-insensitive) and created after since. The API has no author filter,
+// so every page is listed but other authors' PRs are dropped before any detail fetch.
+func (c *Client) ListPullRequestNumbersByAuthor(owner, repo, author string, since time.Time) ([]int, error) {
+	return c.listPullRequestNumbers(owner, repo, author, since)
+}
+
+// listPullRequestNumbers lists open PR numbers newest first, stopping at since
+// and keeping only author's PRs when author is set. Numbers from pages listed
+// before a failure are returned with the error.
+func (c *Client) listPullRequestNumbers(owner, repo, author string, since time.Time) ([]int, error) {
+	var numbers []int
+	err := c.forEachPullRequestPage(owner, repo, func(prs []*github.PullRequest) (bool, error) {
+		for _, pr := range prs {
+			if !since.IsZero() && pr.GetCreatedAt().Time.Before(since) {
+				return true, nil
+			}
+			if author != "" && !strings.EqualFold(pr.GetUser().GetLogin(), author) {
+				continue
+			}
+			numbers = append(numbers, pr.GetNumber())
+		}
+		return false, nil
+	})
+	return numbers, err
+}
+
+// forEachPullRequestPage lists open PRs newest first, calling page with each page
+// of results until it reports done or returns an error, or the last page is reached
+func (c *Client) forEachPullRequestPage(owner, repo string, page func([]*github.PullRequest) (bool, error)) error {
 	opts := &github.PullRequestListOptions{
-		State: "open",
+		State:     "open",
+		Sort:      "created",
+		Direction: "desc",
 		ListOptions: github.ListOptions{
 			PerPage: 100,
 		},
 	}
 
-	var allPRs []*PullRequest
 	for {
-		prs, resp, err := c.client.PullRequests.List(c.ctx, owner, repo, opts)
+		var prs []*github.PullRequest
+		var resp *github.Response
+		err := c.withRetry(func() (err error) {
+			prs, resp, err = c.client.PullRequests.List(c.ctx, owner, repo, opts)
+			return err
+		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to list pull requests: %w", err)
+			return fmt.Errorf("failed to list pull requests: %w", err)
 		}
 
-		for _, pr := range prs {
-			prDetails, err := c.GetPullRequest(owner, repo, pr.GetNumber())
-			if err != nil {
-				return nil, err
-			}
-			allPRs = append(allPRs, prDetails)
+		done, err := page(prs)
+		if err != nil {
+			return err
 		}
-
-		if resp.NextPage == 0 {
-			break
+		if done || resp.NextPage == 0 {
+			return nil
 		}
 		opts.Page = resp.NextPage
 	}
-
-	return allPRs, nil
 }
 
 // GetPullRequest fetches detailed information about a specific PR
 func (c *Client) GetPullRequest(owner, repo string, number int) (*PullRequest, error) {
-	pr, _, err := c.client.PullRequests.Get(c.ctx, owner, repo, number)
+	var pr *github.PullRequest
+	err := c.withRetry(func() (err error) {
+		pr, _, err = c.client.PullRequests.Get(c.ctx, owner, repo, number)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pull request: %w", err)
 	}
 
 	// Fetch files changed in the PR
-	files, _, err := c.client.PullRequests.ListFiles(c.ctx, owner, repo, number, nil)
+	var files []*github.CommitFile
+	err = c.withRetry(func() (err error) {
+		files, _, err = c.client.PullRequests.ListFiles(c.ctx, owner, repo, number, nil)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list PR files: %w", err)
 	}
@@ -117,32 +312,230 @@ func (c *Client) GetPullRequest(owner, repo string, number int) (*PullRequest, e
 	}
 
 	return &PullRequest{
-		Number:     pr.GetNumber(),
-		Title:      pr.GetTitle(),
-		Body:       pr.GetBody(),
-		Author:     pr.GetUser().GetLogin(),
-		CreatedAt:  pr.GetCreatedAt().Time,
-		FilesCount: len(files),
-		Additions:  pr.GetAdditions(),
-		Deletions:  pr.GetDeletions(),
-		Files:      filenames,
-		State:      pr.GetState(),
-		HTMLURL:    pr.GetHTMLURL(),
+		Number:        pr.GetNumber(),
+		Title:         pr.GetTitle(),
+		Body:          pr.GetBody(),
+		Author:        pr.GetUser().GetLogin(),
+		CreatedAt:     pr.GetCreatedAt().Time,
+		FilesCount:    len(files),
+		Additions:     pr.GetAdditions(),
+		Deletions:     pr.GetDeletions(),
+		Files:         filenames,
+		State:         pr.GetState(),
+		HTMLURL:       pr.GetHTMLURL(),
+		BaseRef:       pr.GetBase().GetRef(),
+		DefaultBranch: pr.GetBase().GetRepo().GetDefaultBranch(),
 	}, nil
 }
 
+// ListPullRequestFiles fetches every file changed by a PR along with its patch
+func (c *Client) ListPullRequestFiles(owner, repo string, number int) ([]*PullRequestFile, error) {
+	opts := &github.ListOptions{PerPage: 100}
+
+	var result []*PullRequestFile
+	for {
+		var files []*github.CommitFile
+		var resp *github.Response
+		err := c.withRetry(func() (err error) {
+			files, resp, err = c.client.PullRequests.ListFiles(c.ctx, owner, repo, number, opts)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list PR files: %w", err)
+		}
+
+		for _, file := range files {
+			result = append(result, &PullRequestFile{Filename: file.GetFilename(), Patch: file.GetPatch()})
+		}
+		if resp.NextPage == 0 {
+			return result, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// GetIssueEvents lists the timeline events of an issue or pull request, oldest first
+func (c *Client) GetIssueEvents(owner, repo string, number int) ([]*IssueEvent, error) {
+	opts := &github.ListOptions{PerPage: 100}
+
+	var result []*IssueEvent
+	for {
+		var events []*github.IssueEvent
+		var resp *github.Response
+		err := c.withRetry(func() (err error) {
+			events, resp, err = c.client.Issues.ListIssueEvents(c.ctx, owner, repo, number, opts)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issue events: %w", err)
+		}
+
+		for _, event := range events {
+			result = append(result, &IssueEvent{
+				Event:     event.GetEvent(),
+				Actor:     event.GetActor().GetLogin(),
+				CreatedAt: event.GetCreatedAt().Time,
+			})
+		}
+		if resp.NextPage == 0 {
+			return result, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// GetAuthenticatedUser fetches the user the token belongs to along with the
+// OAuth scopes granted to it. Scopes are nil when GitHub does not report them,
+// as for fine-grained tokens.
+func (c *Client) GetAuthenticatedUser() (*User, []string, error) {
+	var user *github.User
+	var resp *github.Response
+	err := c.withRetry(func() (err error) {
+		user, resp, err = c.client.Users.Get(c.ctx, "")
+		return err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get authenticated user: %w", err)
+	}
+
+	var scopes []string
+	if header, ok := resp.Header[http.CanonicalHeaderKey("X-OAuth-Scopes")]; ok {
+		scopes = []string{}
+		for _, scope := range strings.Split(strings.Join(header, ","), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+
+	return newUser(user), scopes, nil
+}
+
 // GetUser fetches information about a GitHub user
 func (c *Client) GetUser(username string) (*User, error) {
-	user, _, err := c.client.Users.Get(c.ctx, username)
+	var user *github.User
+	err := c.withRetry(func() (err error) {
+		user, _, err = c.client.Users.Get(c.ctx, username)
+		return err
+	})
+	var respErr *github.ErrorResponse
+	if errors.As(err, &respErr) && respErr.Response != nil && respErr.Response.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("failed to get user %s: %w", username, ErrUserNotFound)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	return newUser(user), nil
+}
+
+// newUser converts a go-github user
+func newUser(user *github.User) *User {
 	return &User{
-		Login:     user.GetLogin(),
-		CreatedAt: user.GetCreatedAt().Time,
-		Type:      user.GetType(),
-	}, nil
+		Login:       user.GetLogin(),
+		CreatedAt:   user.GetCreatedAt().Time,
+		Type:        user.GetType(),
+		Followers:   user.GetFollowers(),
+		Following:   user.GetFollowing(),
+		PublicRepos: user.GetPublicRepos(),
+	}
+}
+
+// maxUserRepositories caps ListUserRepositories at one page of the API
+const maxUserRepositories = 100
+
+// ListUserRepositories fetches up to 100 of the public repositories a user
+// owns, most recently pushed first. Only one page is requested to keep the
+// lookup to a single API call per author.
+func (c *Client) ListUserRepositories(username string) ([]*UserRepository, error) {
+	opts := &github.RepositoryListByUserOptions{
+		Type:        "owner",
+		Sort:        "pushed",
+		ListOptions: github.ListOptions{PerPage: maxUserRepositories},
+	}
+	var repos []*github.Repository
+	err := c.withRetry(func() (err error) {
+		repos, _, err = c.client.Repositories.ListByUser(c.ctx, username, opts)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories for %s: %w", username, err)
+	}
+
+	result := make([]*UserRepository, 0, len(repos))
+	for _, repo := range repos {
+		result = append(result, &UserRepository{
+			Name: repo.GetName(),
+			Fork: repo.GetFork(),
+			Size: repo.GetSize(),
+		})
+	}
+	return result, nil
+}
+
+// GetRepository fetches a repository's default branch and the path of the
+// README GitHub shows for it. A repository without a README is not an error.
+func (c *Client) GetRepository(owner, repo string) (*Repository, error) {
+	var ghRepo *github.Repository
+	err := c.withRetry(func() (err error) {
+		ghRepo, _, err = c.client.Repositories.Get(c.ctx, owner, repo)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	result := &Repository{DefaultBranch: ghRepo.GetDefaultBranch()}
+
+	var readme *github.RepositoryContent
+	err = c.withRetry(func() (err error) {
+		readme, _, err = c.client.Repositories.GetReadme(c.ctx, owner, repo, &github.RepositoryContentGetOptions{Ref: result.DefaultBranch})
+		return err
+	})
+	var respErr *github.ErrorResponse
+	switch {
+	case err == nil:
+		result.ReadmePath = readme.GetPath()
+	case errors.As(err, &respErr) && respErr.Response != nil && respErr.Response.StatusCode == http.StatusNotFound:
+	default:
+		return nil, fmt.Errorf("failed to get readme: %w", err)
+	}
+
+	return result, nil
+}
+
+// HasPriorContribution reports whether a user has authored any commit on the
+// repository's default branch
+func (c *Client) HasPriorContribution(owner, repo, username string) (bool, error) {
+	opts := &github.CommitsListOptions{
+		Author:      username,
+		ListOptions: github.ListOptions{PerPage: 1},
+	}
+
+	var commits []*github.RepositoryCommit
+	err := c.withRetry(func() (err error) {
+		commits, _, err = c.client.Repositories.ListCommits(c.ctx, owner, repo, opts)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	return len(commits) > 0, nil
+}
+
+// IsOrgMember reports whether a user is a member of an organization. Private
+// memberships are only visible when the token belongs to an org member.
+func (c *Client) IsOrgMember(org, username string) (bool, error) {
+	var member bool
+	err := c.withRetry(func() (err error) {
+		member, _, err = c.client.Organizations.IsMember(c.ctx, org, username)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to check org membership: %w", err)
+	}
+	return member, nil
 }
 
 // ClosePullRequest closes a PR with an optional comment
@@ -170,6 +563,103 @@ func (c *Client) ClosePullRequest(owner, repo string, number int, comment string
 	return nil
 }
 
+// ReopenPullRequest reopens a closed pull request and optionally comments on it
+func (c *Client) ReopenPullRequest(owner, repo string, number int, comment string) error {
+	// Reopen the PR
+	pr := &github.PullRequest{
+		State: github.String("open"),
+	}
+	_, _, err := c.client.PullRequests.Edit(c.ctx, owner, repo, number, pr)
+	if err != nil {
+		return fmt.Errorf("failed to reopen pull request: %w", err)
+	}
+
+	// Add comment if provided
+	if comment != "" {
+		issueComment := &github.IssueComment{
+			Body: github.String(comment),
+		}
+		_, _, err := c.client.Issues.CreateComment(c.ctx, owner, repo, number, issueComment)
+		if err != nil {
+			return fmt.Errorf("failed to add comment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetIssues fetches all open issues for a repository. Pull requests, which the
+// issues API also returns, are skipped.
+func (c *Client) GetIssues(owner, repo string) ([]*Issue, error) {
+	opts := &github.IssueListByRepoOptions{
+		State: "open",
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	var allIssues []*Issue
+	for {
+		var issues []*github.Issue
+		var resp *github.Response
+		err := c.withRetry(func() (err error) {
+			issues, resp, err = c.client.Issues.ListByRepo(c.ctx, owner, repo, opts)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", err)
+		}
+
+		for _, issue := range issues {
+			if issue.IsPullRequest() {
+				continue
+			}
+			allIssues = append(allIssues, &Issue{
+				Number:    issue.GetNumber(),
+				Title:     issue.GetTitle(),
+				Body:      issue.GetBody(),
+				Author:    issue.GetUser().GetLogin(),
+				CreatedAt: issue.GetCreatedAt().Time,
+				State:     issue.GetState(),
+				HTMLURL:   issue.GetHTMLURL(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allIssues, nil
+}
+
+// CloseIssue closes an issue as not planned with an optional comment
+func (c *Client) CloseIssue(owner, repo string, number int, comment string) error {
+	// Add comment if provided
+	if comment != "" {
+		issueComment := &github.IssueComment{
+			Body: github.String(comment),
+		}
+		_, _, err := c.client.Issues.CreateComment(c.ctx, owner, repo, number, issueComment)
+		if err != nil {
+			return fmt.Errorf("failed to add comment: %w", err)
+		}
+	}
+
+	// Close the issue
+	issue := &github.IssueRequest{
+		State:       github.String("closed"),
+		StateReason: github.String("not_planned"),
+	}
+	_, _, err := c.client.Issues.Edit(c.ctx, owner, repo, number, issue)
+	if err != nil {
+		return fmt.Errorf("failed to close issue: %w", err)
+	}
+
+	return nil
+}
+
 // AddLabel adds a label to a pull request
 func (c *Client) AddLabel(owner, repo string, number int, label string) error {
 	_, _, err := c.client.Issues.AddLabelsToIssue(c.ctx, owner, repo, number, []string{label})
@@ -179,6 +669,29 @@ func (c *Client) AddLabel(owner, repo string, number int, label string) error {
 	return nil
 }
 
+// EnsureLabel creates a label with the given hex color unless the repository
+// already has one with that name; an existing label keeps its color
+func (c *Client) EnsureLabel(owner, repo, name, color string) error {
+	err := c.withRetry(func() (err error) {
+		_, _, err = c.client.Issues.GetLabel(c.ctx, owner, repo, name)
+		return err
+	})
+	var respErr *github.ErrorResponse
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &respErr) && respErr.Response != nil && respErr.Response.StatusCode == http.StatusNotFound:
+	default:
+		return fmt.Errorf("failed to get label: %w", err)
+	}
+
+	label := &github.Label{Name: github.String(name), Color: github.String(color)}
+	if _, _, err := c.client.Issues.CreateLabel(c.ctx, owner, repo, label); err != nil {
+		return fmt.Errorf("failed to create label: %w", err)
+	}
+	return nil
+}
+
 // BlockUserOrg blocks a user at the organization level
 // This blocks them from ALL repositories in the organization
 func (c *Client) BlockUserOrg(org, username string) error {
@@ -189,6 +702,15 @@ func (c *Client) BlockUserOrg(org, username string) error {
 	return nil
 }
 
+// UnblockUserOrg unblocks a user at the organization level
+func (c *Client) UnblockUserOrg(org, username string) error {
+	_, err := c.client.Organizations.UnblockUser(c.ctx, org, username)
+	if err != nil {
+		return fmt.Errorf("failed to unblock user at org level: %w", err)
+	}
+	return nil
+}
+
 // IsUserBlockedOrg checks if a user is blocked at the organization level
 func (c *Client) IsUserBlockedOrg(org, username string) (bool, error) {
 	blocked, _, err := c.client.Organizations.IsBlocked(c.ctx, org, username)
@@ -208,6 +730,15 @@ func (c *Client) BlockUserPersonal(username string) error {
 	return nil
 }
 
+// UnblockUserPersonal unblocks a user at the personal account level
+func (c *Client) UnblockUserPersonal(username string) error {
+	_, err := c.client.Users.UnblockUser(c.ctx, username)
+	if err != nil {
+		return fmt.Errorf("failed to unblock user at account level: %w", err)
+	}
+	return nil
+}
+
 // IsUserBlockedPersonal checks if a user is blocked at the personal account level
 func (c *Client) IsUserBlockedPersonal(username string) (bool, error) {
 	blocked, _, err := c.client.Users.IsBlocked(c.ctx, username)
@@ -216,3 +747,42 @@ func (c *Client) IsUserBlockedPersonal(username string) (bool, error) {
 	}
 	return blocked, nil
 }
+
+// RateLimit describes the request budget for one category of API calls
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimits holds the current core and search API budgets
+type RateLimits struct {
+	Core   RateLimit
+	Search RateLimit
+}
+
+// GetRateLimit fetches the remaining API budget. Checking the rate limit does
+// not itself count against it.
+func (c *Client) GetRateLimit() (*RateLimits, error) {
+	var limits *github.RateLimits
+	err := c.withRetry(func() (err error) {
+		limits, _, err = c.client.RateLimit.Get(c.ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate limit: %w", err)
+	}
+
+	return &RateLimits{
+		Core:   newRateLimit(limits.GetCore()),
+		Search: newRateLimit(limits.GetSearch()),
+	}, nil
+}
+
+// newRateLimit converts a go-github rate, which is nil when the category is absent
+func newRateLimit(rate *github.Rate) RateLimit {
+	if rate == nil {
+		return RateLimit{}
+	}
+	return RateLimit{Limit: rate.Limit, Remaining: rate.Remaining, Reset: rate.Reset.Time}
+}
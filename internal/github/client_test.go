@@ -0,0 +1,674 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient creates a client pointed at a test server with fast retries
+func newTestClient(t *testing.T, maxRetries int, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c := NewClient("test-token", maxRetries)
+	c.baseDelay = time.Millisecond
+
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("Failed to parse server URL: %v", err)
+	}
+	c.client.BaseURL = baseURL
+
+	return c
+}
+
+func writeUser(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = fmt.Fprint(w, `{"login":"octocat","type":"User","created_at":"2020-01-01T00:00:00Z"}`) //nolint:errcheck
+}
+
+func TestGetUser_RetriesSecondaryRateLimit(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, 3, func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprint(w, `{"message":"You have exceeded a secondary rate limit","documentation_url":"https://docs.github.com/rest/overview/resources-in-the-rest-api#secondary-rate-limits"}`) //nolint:errcheck
+			return
+		}
+		writeUser(w)
+	})
+
+	user, err := c.GetUser("octocat")
+	if err != nil {
+		t.Fatalf("GetUser failed: %v", err)
+	}
+	if user.Login != "octocat" {
+		t.Errorf("Expected login 'octocat', got '%s'", user.Login)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+}
+
+func TestGetUser_RetriesPrimaryRateLimit(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, 3, func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("X-RateLimit-Limit", "5000")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(-time.Second).Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprint(w, `{"message":"API rate limit exceeded"}`) //nolint:errcheck
+			return
+		}
+		writeUser(w)
+	})
+
+	if _, err := c.GetUser("octocat"); err != nil {
+		t.Fatalf("GetUser failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+}
+
+func TestGetUser_RetriesServerError(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, 3, func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		writeUser(w)
+	})
+
+	if _, err := c.GetUser("octocat"); err != nil {
+		t.Fatalf("GetUser failed: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestGetUser_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, 2, func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	if _, err := c.GetUser("octocat"); err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls (1 + 2 retries), got %d", calls)
+	}
+}
+
+func TestGetUser_DoesNotRetryNotFound(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, 3, func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	if _, err := c.GetUser("ghost"); err == nil {
+		t.Fatal("Expected error for 404")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestListPullRequestNumbersSince_StopsAtCutoff(t *testing.T) {
+	var pages int32
+	var c *Client
+	c = newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&pages, 1)
+		if r.URL.Query().Get("sort") != "created" || r.URL.Query().Get("direction") != "desc" {
+			t.Errorf("Expected newest-first listing, got query %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// Advertise a next page that must never be requested
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%srepos/o/r/pulls?page=2>; rel="next"`, c.client.BaseURL))
+		}
+		_, _ = fmt.Fprint(w, `[
+			{"number":3,"created_at":"2025-03-09T00:00:00Z"},
+			{"number":2,"created_at":"2025-03-05T00:00:00Z"},
+			{"number":1,"created_at":"2025-01-01T00:00:00Z"}
+		]`) //nolint:errcheck
+	})
+
+	numbers, err := c.ListPullRequestNumbersSince("o", "r", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ListPullRequestNumbersSince failed: %v", err)
+	}
+	if len(numbers) != 2 || numbers[0] != 3 || numbers[1] != 2 {
+		t.Errorf("Expected [3 2], got %v", numbers)
+	}
+	if pages != 1 {
+		t.Errorf("Expected pagination to stop after 1 page, got %d", pages)
+	}
+}
+
+func TestListPullRequestNumbersByAuthor_DropsOtherAuthors(t *testing.T) {
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `[
+			{"number":3,"created_at":"2025-03-09T00:00:00Z","user":{"login":"Target"}},
+			{"number":2,"created_at":"2025-03-05T00:00:00Z","user":{"login":"someone"}},
+			{"number":1,"created_at":"2025-03-02T00:00:00Z","user":{"login":"target"}}
+		]`) //nolint:errcheck
+	})
+
+	numbers, err := c.ListPullRequestNumbersByAuthor("o", "r", "target", time.Time{})
+	if err != nil {
+		t.Fatalf("ListPullRequestNumbersByAuthor failed: %v", err)
+	}
+	if len(numbers) != 2 || numbers[0] != 3 || numbers[1] != 1 {
+		t.Errorf("Expected [3 1], got %v", numbers)
+	}
+}
+
+func TestListPullRequestNumbers_CancelledMidList(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var pages int32
+	var c *Client
+	c = newTestClient(t, 3, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&pages, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Link", fmt.Sprintf(`<%srepos/o/r/pulls?page=2>; rel="next"`, c.client.BaseURL))
+		// Cancel after serving the first page so the next page request is aborted
+		cancel()
+		_, _ = fmt.Fprint(w, `[{"number":1,"created_at":"2025-03-09T00:00:00Z"}]`) //nolint:errcheck
+	})
+
+	_, err := c.WithContext(ctx).ListPullRequestNumbers("o", "r")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if pages != 1 {
+		t.Errorf("Expected listing to stop after 1 page, got %d", pages)
+	}
+}
+
+func TestReopenPullRequest_EditsStateAndComments(t *testing.T) {
+	var requests []string
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body) //nolint:errcheck
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{}`) //nolint:errcheck
+	})
+
+	if err := c.ReopenPullRequest("o", "r", 7, "Sorry about that"); err != nil {
+		t.Fatalf("ReopenPullRequest() error = %v", err)
+	}
+
+	want := []string{
+		`PATCH /repos/o/r/pulls/7 {"state":"open"}`,
+		`POST /repos/o/r/issues/7/comments {"body":"Sorry about that"}`,
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("Expected %d requests, got %v", len(want), requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("Request %d = %q, want %q", i, requests[i], want[i])
+		}
+	}
+}
+
+func TestReopenPullRequest_NoComment(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Method != http.MethodPatch {
+			t.Errorf("Unexpected %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{}`) //nolint:errcheck
+	})
+
+	if err := c.ReopenPullRequest("o", "r", 7, ""); err != nil {
+		t.Fatalf("ReopenPullRequest() error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected only the edit request, got %d requests", calls)
+	}
+}
+
+func TestGetIssues_SkipsPullRequests(t *testing.T) {
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/o/r/issues" || r.URL.Query().Get("state") != "open" {
+			t.Errorf("Unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `[
+			{"number":1,"title":"Spam","body":"buy now","user":{"login":"spammer"},"state":"open","html_url":"https://github.com/o/r/issues/1"},
+			{"number":2,"title":"A PR","user":{"login":"dev"},"pull_request":{"url":"https://api.github.com/repos/o/r/pulls/2"}}
+		]`) //nolint:errcheck
+	})
+
+	issues, err := c.GetIssues("o", "r")
+	if err != nil {
+		t.Fatalf("GetIssues() error = %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].Number != 1 || issues[0].Author != "spammer" || issues[0].Body != "buy now" {
+		t.Errorf("Unexpected issue: %+v", issues[0])
+	}
+}
+
+func TestCloseIssue_CommentsAndCloses(t *testing.T) {
+	var requests []string
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body) //nolint:errcheck
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{}`) //nolint:errcheck
+	})
+
+	if err := c.CloseIssue("o", "r", 3, "Spam"); err != nil {
+		t.Fatalf("CloseIssue() error = %v", err)
+	}
+
+	want := []string{
+		`POST /repos/o/r/issues/3/comments {"body":"Spam"}`,
+		`PATCH /repos/o/r/issues/3 {"state":"closed","state_reason":"not_planned"}`,
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("Expected %d requests, got %v", len(want), requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("Request %d = %q, want %q", i, requests[i], want[i])
+		}
+	}
+}
+
+func TestGetRateLimit(t *testing.T) {
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rate_limit" {
+			t.Errorf("Expected /rate_limit, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"resources":{
+			"core":{"limit":5000,"remaining":4321,"reset":1741824000},
+			"search":{"limit":30,"remaining":29,"reset":1741820460}
+		}}`) //nolint:errcheck
+	})
+
+	limits, err := c.GetRateLimit()
+	if err != nil {
+		t.Fatalf("GetRateLimit failed: %v", err)
+	}
+	if limits.Core.Limit != 5000 || limits.Core.Remaining != 4321 || limits.Core.Reset.Unix() != 1741824000 {
+		t.Errorf("Unexpected core limit %+v", limits.Core)
+	}
+	if limits.Search.Limit != 30 || limits.Search.Remaining != 29 || limits.Search.Reset.Unix() != 1741820460 {
+		t.Errorf("Unexpected search limit %+v", limits.Search)
+	}
+}
+
+func TestGetPullRequest_BaseRef(t *testing.T) {
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/o/r/pulls/5":
+			_, _ = fmt.Fprint(w, `{"number":5,"user":{"login":"dev"},"base":{"ref":"release-1.x","repo":{"default_branch":"main"}}}`) //nolint:errcheck
+		case "/repos/o/r/pulls/5/files":
+			_, _ = fmt.Fprint(w, `[{"filename":"main.go"}]`) //nolint:errcheck
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	})
+
+	pr, err := c.GetPullRequest("o", "r", 5)
+	if err != nil {
+		t.Fatalf("GetPullRequest() error = %v", err)
+	}
+	if pr.BaseRef != "release-1.x" || pr.DefaultBranch != "main" {
+		t.Errorf("Expected base release-1.x and default main, got %q and %q", pr.BaseRef, pr.DefaultBranch)
+	}
+}
+
+func TestGetAuthenticatedUser_Scopes(t *testing.T) {
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			t.Errorf("Expected /user, got %s", r.URL.Path)
+		}
+		w.Header().Set("X-OAuth-Scopes", "repo, admin:org")
+		writeUser(w)
+	})
+
+	user, scopes, err := c.GetAuthenticatedUser()
+	if err != nil {
+		t.Fatalf("GetAuthenticatedUser() error = %v", err)
+	}
+	if user.Login != "octocat" {
+		t.Errorf("Expected octocat, got %s", user.Login)
+	}
+	if len(scopes) != 2 || scopes[0] != "repo" || scopes[1] != "admin:org" {
+		t.Errorf("Expected [repo admin:org], got %v", scopes)
+	}
+}
+
+func TestGetAuthenticatedUser_NoScopeHeader(t *testing.T) {
+	c := newTestClient(t, 0, func(w http.ResponseWriter, _ *http.Request) {
+		writeUser(w)
+	})
+
+	_, scopes, err := c.GetAuthenticatedUser()
+	if err != nil {
+		t.Fatalf("GetAuthenticatedUser() error = %v", err)
+	}
+	if scopes != nil {
+		t.Errorf("Expected nil scopes without the header, got %v", scopes)
+	}
+}
+
+// pagedPRServer serves two pages of open PRs (#3 and #2, then #1) plus their
+// details; failPage2 makes the second list page fail with a server error
+func pagedPRServer(t *testing.T, failPage2 bool) *Client {
+	t.Helper()
+
+	var c *Client
+	c = newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/repos/o/r/pulls" && r.URL.Query().Get("page") == "":
+			w.Header().Set("Link", fmt.Sprintf(`<%srepos/o/r/pulls?page=2>; rel="next"`, c.client.BaseURL))
+			_, _ = fmt.Fprint(w, `[{"number":3},{"number":2}]`) //nolint:errcheck
+		case r.URL.Path == "/repos/o/r/pulls":
+			if failPage2 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = fmt.Fprint(w, `[{"number":1}]`) //nolint:errcheck
+		case strings.HasSuffix(r.URL.Path, "/files"):
+			_, _ = fmt.Fprint(w, `[]`) //nolint:errcheck
+		default:
+			number := strings.TrimPrefix(r.URL.Path, "/repos/o/r/pulls/")
+			_, _ = fmt.Fprintf(w, `{"number":%s,"user":{"login":"dev"}}`, number) //nolint:errcheck
+		}
+	})
+	return c
+}
+
+func TestStreamPullRequests_DeliversAllPages(t *testing.T) {
+	c := pagedPRServer(t, false)
+
+	var numbers []int
+	err := c.StreamPullRequests("o", "r", func(pr *PullRequest) error {
+		numbers = append(numbers, pr.Number)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamPullRequests() error = %v", err)
+	}
+	if len(numbers) != 3 || numbers[0] != 3 || numbers[1] != 2 || numbers[2] != 1 {
+		t.Errorf("Expected [3 2 1], got %v", numbers)
+	}
+}
+
+func TestStreamPullRequests_LateError(t *testing.T) {
+	c := pagedPRServer(t, true)
+
+	var numbers []int
+	err := c.StreamPullRequests("o", "r", func(pr *PullRequest) error {
+		numbers = append(numbers, pr.Number)
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Expected the second page's error")
+	}
+	if len(numbers) != 2 {
+		t.Errorf("Expected the first page's 2 PRs before the error, got %v", numbers)
+	}
+
+	prs, err := c.GetPullRequests("o", "r")
+	if err == nil || len(prs) != 2 {
+		t.Errorf("Expected 2 partial results with an error, got %d and %v", len(prs), err)
+	}
+}
+
+func TestListPullRequestNumbers_KeepsPagesBeforeError(t *testing.T) {
+	c := pagedPRServer(t, true)
+
+	numbers, err := c.ListPullRequestNumbers("o", "r")
+	if err == nil {
+		t.Fatal("Expected the second page's error")
+	}
+	if len(numbers) != 2 || numbers[0] != 3 || numbers[1] != 2 {
+		t.Errorf("Expected the first page's [3 2] with the error, got %v", numbers)
+	}
+}
+
+func TestStreamPullRequests_CallbackErrorStops(t *testing.T) {
+	c := pagedPRServer(t, false)
+
+	stop := fmt.Errorf("stop")
+	calls := 0
+	err := c.StreamPullRequests("o", "r", func(_ *PullRequest) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("Expected the callback error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected streaming to stop after 1 PR, got %d", calls)
+	}
+}
+
+func TestGetUser_NotFound(t *testing.T) {
+	c := newTestClient(t, 0, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	if _, err := c.GetUser("gone"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestGetRepository(t *testing.T) {
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/o/r":
+			_, _ = fmt.Fprint(w, `{"name":"r","default_branch":"trunk"}`) //nolint:errcheck
+		case "/repos/o/r/readme":
+			if ref := r.URL.Query().Get("ref"); ref != "trunk" {
+				t.Errorf("Expected the README to be read from trunk, got ref %q", ref)
+			}
+			_, _ = fmt.Fprint(w, `{"type":"file","name":"README.rst","path":"docs/README.rst"}`) //nolint:errcheck
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	repo, err := c.GetRepository("o", "r")
+	if err != nil {
+		t.Fatalf("GetRepository failed: %v", err)
+	}
+	if repo.DefaultBranch != "trunk" || repo.ReadmePath != "docs/README.rst" {
+		t.Errorf("Expected trunk and docs/README.rst, got %+v", repo)
+	}
+}
+
+func TestGetRepository_NoReadme(t *testing.T) {
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/o/r/readme" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"name":"r","default_branch":"main"}`) //nolint:errcheck
+	})
+
+	repo, err := c.GetRepository("o", "r")
+	if err != nil {
+		t.Fatalf("Expected a missing README not to be an error, got %v", err)
+	}
+	if repo.DefaultBranch != "main" || repo.ReadmePath != "" {
+		t.Errorf("Expected main with no README path, got %+v", repo)
+	}
+}
+
+func TestListPullRequestFiles_FollowsPages(t *testing.T) {
+	var c *Client
+	c = newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%srepos/o/r/pulls/1/files?page=2>; rel="next"`, c.client.BaseURL))
+			_, _ = fmt.Fprint(w, `[{"filename":"README.md","patch":"+hello"}]`) //nolint:errcheck
+			return
+		}
+		_, _ = fmt.Fprint(w, `[{"filename":"logo.png"}]`) //nolint:errcheck
+	})
+
+	files, err := c.ListPullRequestFiles("o", "r", 1)
+	if err != nil {
+		t.Fatalf("ListPullRequestFiles failed: %v", err)
+	}
+	if len(files) != 2 || files[0].Patch != "+hello" || files[1].Filename != "logo.png" || files[1].Patch != "" {
+		t.Errorf("Unexpected files: %+v %+v", files[0], files[len(files)-1])
+	}
+}
+
+func TestGetIssueEvents(t *testing.T) {
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/o/r/issues/7/events" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `[{"event":"closed","actor":{"login":"maintainer"},"created_at":"2025-01-01T00:00:00Z"},{"event":"reopened","actor":{"login":"spammer"},"created_at":"2025-01-02T00:00:00Z"}]`) //nolint:errcheck
+	})
+
+	events, err := c.GetIssueEvents("o", "r", 7)
+	if err != nil {
+		t.Fatalf("GetIssueEvents failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[1].Event != "reopened" || events[1].Actor != "spammer" || !events[1].CreatedAt.Equal(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected event: %+v", events[1])
+	}
+}
+
+func TestEnsureLabel(t *testing.T) {
+	var created []string
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/o/r/labels/spam":
+			_, _ = fmt.Fprint(w, `{"name":"spam","color":"ffffff"}`) //nolint:errcheck
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/o/r/labels":
+			var label struct{ Name, Color string }
+			if err := json.NewDecoder(r.Body).Decode(&label); err != nil {
+				t.Errorf("failed to decode label: %v", err)
+			}
+			created = append(created, label.Name+"#"+label.Color)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprint(w, `{}`) //nolint:errcheck
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	if err := c.EnsureLabel("o", "r", "spam", "d73a4a"); err != nil {
+		t.Fatalf("EnsureLabel(spam) failed: %v", err)
+	}
+	if err := c.EnsureLabel("o", "r", "invalid", "cccccc"); err != nil {
+		t.Fatalf("EnsureLabel(invalid) failed: %v", err)
+	}
+	if len(created) != 1 || created[0] != "invalid#cccccc" {
+		t.Errorf("Expected only the missing label to be created, got %v", created)
+	}
+}
+
+func TestIsOrgMember(t *testing.T) {
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/acme/members/insider":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	member, err := c.IsOrgMember("acme", "insider")
+	if err != nil || !member {
+		t.Errorf("IsOrgMember(insider) = %v, %v; want true", member, err)
+	}
+	member, err = c.IsOrgMember("acme", "outsider")
+	if err != nil || member {
+		t.Errorf("IsOrgMember(outsider) = %v, %v; want false", member, err)
+	}
+}
+
+func TestListUserRepositories(t *testing.T) {
+	c := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/someone/repos" {
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("type"); got != "owner" {
+			t.Errorf("Expected owned repositories, got type %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `[{"name":"react","fork":true,"size":5000},{"name":"tool","fork":false,"size":12}]`) //nolint:errcheck
+	})
+
+	repos, err := c.ListUserRepositories("someone")
+	if err != nil {
+		t.Fatalf("ListUserRepositories failed: %v", err)
+	}
+	if len(repos) != 2 {
+		t.Fatalf("Expected 2 repositories, got %d", len(repos))
+	}
+	if !repos[0].Fork || repos[0].Name != "react" {
+		t.Errorf("Expected react to be a fork, got %+v", repos[0])
+	}
+	if repos[1].Fork || repos[1].Size != 12 {
+		t.Errorf("Expected tool to be an original 12 KB repository, got %+v", repos[1])
+	}
+}
@@ -14,15 +14,48 @@
 
 package github
 
+import (
+	"context"
+	"time"
+)
+
 // GitHubClient defines the interface for GitHub operations needed by commands
 type GitHubClient interface {
+	// WithContext returns a client whose calls are cancelled when ctx is done
+	WithContext(ctx context.Context) GitHubClient
+
 	// PR operations
 	GetPullRequests(owner, repo string) ([]*PullRequest, error)
+	StreamPullRequests(owner, repo string, fn func(*PullRequest) error) error
+	ListPullRequestNumbers(owner, repo string) ([]int, error)
+	ListPullRequestNumbersSince(owner, repo string, since time.Time) ([]int, error)
+	ListPullRequestNumbersByAuthor(owner, repo, author string, since time.Time) ([]int, error)
+	GetPullRequest(owner, repo string, number int) (*PullRequest, error)
+	ListPullRequestFiles(owner, repo string, number int) ([]*PullRequestFile, error)
 	ClosePullRequest(owner, repo string, number int, comment string) error
+	ReopenPullRequest(owner, repo string, number int, comment string) error
 	AddLabel(owner, repo string, number int, label string) error
+	EnsureLabel(owner, repo, name, color string) error
+	GetIssueEvents(owner, repo string, number int) ([]*IssueEvent, error)
+
+	// Repository operations
+	GetRepository(owner, repo string) (*Repository, error)
+
+	// Issue operations
+	GetIssues(owner, repo string) ([]*Issue, error)
+	CloseIssue(owner, repo string, number int, comment string) error
 
 	// User operations
 	GetUser(username string) (*User, error)
+	GetAuthenticatedUser() (*User, []string, error)
+	HasPriorContribution(owner, repo, username string) (bool, error)
+	IsOrgMember(org, username string) (bool, error)
+	ListUserRepositories(username string) ([]*UserRepository, error)
 	BlockUserOrg(org, username string) error
 	BlockUserPersonal(username string) error
+	UnblockUserOrg(org, username string) error
+	UnblockUserPersonal(username string) error
+
+	// API budget
+	GetRateLimit() (*RateLimits, error)
 }
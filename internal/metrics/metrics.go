@@ -0,0 +1,73 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics exposes Prometheus counters for long-running scans.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+// Metrics holds the counters updated by periodic scans, labeled by repository
+type Metrics struct {
+	registry     *prometheus.Registry
+	prsScanned   *prometheus.CounterVec
+	spamDetected *prometheus.CounterVec
+	apiErrors    *prometheus.CounterVec
+}
+
+// New creates the counters and registers them with a dedicated registry
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		prsScanned: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prguard_prs_scanned_total",
+			Help: "Pull requests scanned.",
+		}, []string{"repository"}),
+		spamDetected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prguard_spam_detected_total",
+			Help: "Pull requests classified as spam.",
+		}, []string{"repository"}),
+		apiErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prguard_github_api_errors_total",
+			Help: "GitHub API failures while listing or scanning pull requests.",
+		}, []string{"repository"}),
+	}
+
+	m.registry.MustRegister(m.prsScanned, m.spamDetected, m.apiErrors)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveScan records the outcome of a completed repository scan.
+// PRs that could not be fetched count as API errors.
+func (m *Metrics) ObserveScan(repository string, results *scanner.ScanResults) {
+	m.prsScanned.WithLabelValues(repository).Add(float64(results.Total))
+	m.spamDetected.WithLabelValues(repository).Add(float64(len(results.Spam)))
+	m.apiErrors.WithLabelValues(repository).Add(float64(len(results.Errors)))
+}
+
+// ObserveError records a repository scan that failed outright
+func (m *Metrics) ObserveError(repository string) {
+	m.apiErrors.WithLabelValues(repository).Inc()
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/internal/metrics"
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("failed to read metrics: %v", err)
+	}
+	return string(body)
+}
+
+func TestObserveScan(t *testing.T) {
+	m := metrics.New()
+	results := &scanner.ScanResults{
+		Total:  3,
+		Spam:   []*scanner.ScanResult{{}},
+		Errors: []*scanner.ScanError{{Number: 7, Err: errors.New("not found")}},
+	}
+
+	m.ObserveScan("org/repo", results)
+	m.ObserveScan("org/repo", results)
+	m.ObserveError("org/other")
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`prguard_prs_scanned_total{repository="org/repo"} 6`,
+		`prguard_spam_detected_total{repository="org/repo"} 2`,
+		`prguard_github_api_errors_total{repository="org/repo"} 2`,
+		`prguard_github_api_errors_total{repository="org/other"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in metrics output:\n%s", want, body)
+		}
+	}
+}
@@ -14,26 +14,69 @@
 
 package mocks
 
-import "github.com/prguard/prguard/pkg/models"
+import (
+	"io"
+
+	"github.com/prguard/prguard/internal/blocklist"
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/pkg/models"
+)
 
 // MockBlocklistManager is a mock implementation of blocklist.BlocklistManager for testing
 type MockBlocklistManager struct {
-	BlockFn             func(username, reason, evidenceURL, blockedBy, severity, source string) (*models.BlocklistEntry, error)
-	UnblockFn           func(username string) error
-	IsBlockedFn         func(username string) (bool, error)
-	ListFn              func() ([]*models.BlocklistEntry, error)
-	GetByUsernameFn     func(username string) ([]*models.BlocklistEntry, error)
-	ExportJSONFn        func(path string) error
-	ExportCSVFn         func(path string) error
-	ImportJSONFn        func(path string) (int, error)
-	ImportJSONFromURLFn func(url string) (int, error)
-}
-
-func (m *MockBlocklistManager) Block(username, reason, evidenceURL, blockedBy, severity, source string) (*models.BlocklistEntry, error) {
+	BlockFn                  func(req blocklist.BlockRequest) (*blocklist.BlockResult, error)
+	BlockManyFn              func(usernames []string, req blocklist.BlockRequest) (int, int, error)
+	UnblockFn                func(username string) error
+	RemoveByIDFn             func(id string) (*models.BlocklistEntry, error)
+	IsBlockedFn              func(username string) (bool, error)
+	MarkGitHubBlockedFn      func(username string) error
+	IsGitHubBlockedFn        func(username string) (bool, error)
+	PurgeExpiredFn           func() (int64, error)
+	FindDuplicateUsernamesFn func() ([]string, error)
+	PruneDuplicatesFn        func() (int64, error)
+	ListFn                   func() ([]*models.BlocklistEntry, error)
+	ListFilteredFn           func(filter models.EntryFilter) ([]*models.BlocklistEntry, error)
+	ListPagedFn              func(filter models.EntryFilter) ([]*models.BlocklistEntry, int, error)
+	ListSortedFn             func(sortField string, reverse bool) ([]*models.BlocklistEntry, error)
+	ListByTagFn              func(tag string) ([]*models.BlocklistEntry, error)
+	GetByUsernameFn          func(username string) ([]*models.BlocklistEntry, error)
+	SearchFn                 func(term, field, severity string) ([]*models.BlocklistEntry, error)
+	CountFn                  func(severity string) (int, int, error)
+	ExportJSONFn             func(path string, filter models.EntryFilter) error
+	ExportCSVFn              func(path string, filter models.EntryFilter) error
+	ExportYAMLFn             func(path string, filter models.EntryFilter) error
+	ExportRegistryFn         func(path string, filter models.EntryFilter) error
+	ExportJSONLFn            func(w io.Writer, filter models.EntryFilter) error
+	ExportSignedJSONFn       func(path, privKeyPath string, filter models.EntryFilter) error
+	ImportJSONFn             func(path string) (int, error)
+	ImportYAMLFn             func(path string) (int, error)
+	ImportCSVFn              func(path string) (int, error)
+	ImportJSONFromURLFn      func(url string) (int, error)
+	ImportSignedJSONFn       func(path, pubKeyPath string) (int, error)
+	DiffFn                   func(entries []*models.BlocklistEntry) ([]*models.BlocklistEntry, []blocklist.Upgrade, []*models.BlocklistEntry, error)
+	SyncSourcesFn            func(sources []config.BlocklistSource) []*blocklist.SyncResult
+	MergeStagedFn            func(entries []*models.BlocklistEntry) (int, error)
+}
+
+func (m *MockBlocklistManager) Block(req blocklist.BlockRequest) (*blocklist.BlockResult, error) {
 	if m.BlockFn != nil {
-		return m.BlockFn(username, reason, evidenceURL, blockedBy, severity, source)
+		return m.BlockFn(req)
+	}
+	entry := models.NewBlocklistEntry(req.Username, req.Reason, req.EvidenceURL, req.BlockedBy, req.Severity, req.Source)
+	entry.ExpiresAt = req.ExpiresAt
+	entry.Tags = req.Tags
+	result := &blocklist.BlockResult{Entry: entry}
+	if req.Escalate {
+		result.Offense = 1
+	}
+	return result, nil
+}
+
+func (m *MockBlocklistManager) BlockMany(usernames []string, req blocklist.BlockRequest) (added, skipped int, err error) {
+	if m.BlockManyFn != nil {
+		return m.BlockManyFn(usernames, req)
 	}
-	return models.NewBlocklistEntry(username, reason, evidenceURL, blockedBy, severity, source), nil
+	return len(usernames), 0, nil
 }
 
 func (m *MockBlocklistManager) Unblock(username string) error {
@@ -43,6 +86,13 @@ func (m *MockBlocklistManager) Unblock(username string) error {
 	return nil
 }
 
+func (m *MockBlocklistManager) RemoveByID(id string) (*models.BlocklistEntry, error) {
+	if m.RemoveByIDFn != nil {
+		return m.RemoveByIDFn(id)
+	}
+	return &models.BlocklistEntry{ID: id}, nil
+}
+
 func (m *MockBlocklistManager) IsBlocked(username string) (bool, error) {
 	if m.IsBlockedFn != nil {
 		return m.IsBlockedFn(username)
@@ -50,6 +100,41 @@ func (m *MockBlocklistManager) IsBlocked(username string) (bool, error) {
 	return false, nil
 }
 
+func (m *MockBlocklistManager) MarkGitHubBlocked(username string) error {
+	if m.MarkGitHubBlockedFn != nil {
+		return m.MarkGitHubBlockedFn(username)
+	}
+	return nil
+}
+
+func (m *MockBlocklistManager) IsGitHubBlocked(username string) (bool, error) {
+	if m.IsGitHubBlockedFn != nil {
+		return m.IsGitHubBlockedFn(username)
+	}
+	return false, nil
+}
+
+func (m *MockBlocklistManager) PurgeExpired() (int64, error) {
+	if m.PurgeExpiredFn != nil {
+		return m.PurgeExpiredFn()
+	}
+	return 0, nil
+}
+
+func (m *MockBlocklistManager) FindDuplicateUsernames() ([]string, error) {
+	if m.FindDuplicateUsernamesFn != nil {
+		return m.FindDuplicateUsernamesFn()
+	}
+	return nil, nil
+}
+
+func (m *MockBlocklistManager) PruneDuplicates() (int64, error) {
+	if m.PruneDuplicatesFn != nil {
+		return m.PruneDuplicatesFn()
+	}
+	return 0, nil
+}
+
 func (m *MockBlocklistManager) List() ([]*models.BlocklistEntry, error) {
 	if m.ListFn != nil {
 		return m.ListFn()
@@ -57,6 +142,34 @@ func (m *MockBlocklistManager) List() ([]*models.BlocklistEntry, error) {
 	return []*models.BlocklistEntry{}, nil
 }
 
+func (m *MockBlocklistManager) ListFiltered(filter models.EntryFilter) ([]*models.BlocklistEntry, error) {
+	if m.ListFilteredFn != nil {
+		return m.ListFilteredFn(filter)
+	}
+	return []*models.BlocklistEntry{}, nil
+}
+
+func (m *MockBlocklistManager) ListPaged(filter models.EntryFilter) ([]*models.BlocklistEntry, int, error) {
+	if m.ListPagedFn != nil {
+		return m.ListPagedFn(filter)
+	}
+	return []*models.BlocklistEntry{}, 0, nil
+}
+
+func (m *MockBlocklistManager) ListSorted(sortField string, reverse bool) ([]*models.BlocklistEntry, error) {
+	if m.ListSortedFn != nil {
+		return m.ListSortedFn(sortField, reverse)
+	}
+	return []*models.BlocklistEntry{}, nil
+}
+
+func (m *MockBlocklistManager) ListByTag(tag string) ([]*models.BlocklistEntry, error) {
+	if m.ListByTagFn != nil {
+		return m.ListByTagFn(tag)
+	}
+	return nil, nil
+}
+
 func (m *MockBlocklistManager) GetByUsername(username string) ([]*models.BlocklistEntry, error) {
 	if m.GetByUsernameFn != nil {
 		return m.GetByUsernameFn(username)
@@ -64,16 +177,58 @@ func (m *MockBlocklistManager) GetByUsername(username string) ([]*models.Blockli
 	return []*models.BlocklistEntry{}, nil
 }
 
-func (m *MockBlocklistManager) ExportJSON(path string) error {
+func (m *MockBlocklistManager) Search(term, field, severity string) ([]*models.BlocklistEntry, error) {
+	if m.SearchFn != nil {
+		return m.SearchFn(term, field, severity)
+	}
+	return []*models.BlocklistEntry{}, nil
+}
+
+func (m *MockBlocklistManager) Count(severity string) (int, int, error) {
+	if m.CountFn != nil {
+		return m.CountFn(severity)
+	}
+	return 0, 0, nil
+}
+
+func (m *MockBlocklistManager) ExportJSON(path string, filter models.EntryFilter) error {
 	if m.ExportJSONFn != nil {
-		return m.ExportJSONFn(path)
+		return m.ExportJSONFn(path, filter)
 	}
 	return nil
 }
 
-func (m *MockBlocklistManager) ExportCSV(path string) error {
+func (m *MockBlocklistManager) ExportCSV(path string, filter models.EntryFilter) error {
 	if m.ExportCSVFn != nil {
-		return m.ExportCSVFn(path)
+		return m.ExportCSVFn(path, filter)
+	}
+	return nil
+}
+
+func (m *MockBlocklistManager) ExportYAML(path string, filter models.EntryFilter) error {
+	if m.ExportYAMLFn != nil {
+		return m.ExportYAMLFn(path, filter)
+	}
+	return nil
+}
+
+func (m *MockBlocklistManager) ExportRegistry(path string, filter models.EntryFilter) error {
+	if m.ExportRegistryFn != nil {
+		return m.ExportRegistryFn(path, filter)
+	}
+	return nil
+}
+
+func (m *MockBlocklistManager) ExportJSONL(w io.Writer, filter models.EntryFilter) error {
+	if m.ExportJSONLFn != nil {
+		return m.ExportJSONLFn(w, filter)
+	}
+	return nil
+}
+
+func (m *MockBlocklistManager) ExportSignedJSON(path, privKeyPath string, filter models.EntryFilter) error {
+	if m.ExportSignedJSONFn != nil {
+		return m.ExportSignedJSONFn(path, privKeyPath, filter)
 	}
 	return nil
 }
@@ -85,9 +240,51 @@ func (m *MockBlocklistManager) ImportJSON(path string) (int, error) {
 	return 0, nil
 }
 
+func (m *MockBlocklistManager) ImportYAML(path string) (int, error) {
+	if m.ImportYAMLFn != nil {
+		return m.ImportYAMLFn(path)
+	}
+	return 0, nil
+}
+
+func (m *MockBlocklistManager) ImportCSV(path string) (int, error) {
+	if m.ImportCSVFn != nil {
+		return m.ImportCSVFn(path)
+	}
+	return 0, nil
+}
+
 func (m *MockBlocklistManager) ImportJSONFromURL(url string) (int, error) {
 	if m.ImportJSONFromURLFn != nil {
 		return m.ImportJSONFromURLFn(url)
 	}
 	return 0, nil
 }
+
+func (m *MockBlocklistManager) ImportSignedJSON(path, pubKeyPath string) (int, error) {
+	if m.ImportSignedJSONFn != nil {
+		return m.ImportSignedJSONFn(path, pubKeyPath)
+	}
+	return 0, nil
+}
+
+func (m *MockBlocklistManager) Diff(entries []*models.BlocklistEntry) (added []*models.BlocklistEntry, upgraded []blocklist.Upgrade, localOnly []*models.BlocklistEntry, err error) {
+	if m.DiffFn != nil {
+		return m.DiffFn(entries)
+	}
+	return entries, nil, nil, nil
+}
+
+func (m *MockBlocklistManager) SyncSources(sources []config.BlocklistSource) []*blocklist.SyncResult {
+	if m.SyncSourcesFn != nil {
+		return m.SyncSourcesFn(sources)
+	}
+	return nil
+}
+
+func (m *MockBlocklistManager) MergeStaged(entries []*models.BlocklistEntry) (int, error) {
+	if m.MergeStagedFn != nil {
+		return m.MergeStagedFn(entries)
+	}
+	return 0, nil
+}
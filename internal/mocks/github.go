@@ -14,16 +14,48 @@
 
 package mocks
 
-import "github.com/prguard/prguard/internal/github"
+import (
+	"context"
+	"time"
+
+	"github.com/prguard/prguard/internal/github"
+)
 
 // MockGitHubClient is a mock implementation of github.GitHubClient for testing
 type MockGitHubClient struct {
-	GetPullRequestsFn   func(owner, repo string) ([]*github.PullRequest, error)
-	ClosePullRequestFn  func(owner, repo string, number int, comment string) error
-	AddLabelFn          func(owner, repo string, number int, label string) error
-	GetUserFn           func(username string) (*github.User, error)
-	BlockUserOrgFn      func(org, username string) error
-	BlockUserPersonalFn func(username string) error
+	WithContextFn                    func(ctx context.Context) github.GitHubClient
+	GetPullRequestsFn                func(owner, repo string) ([]*github.PullRequest, error)
+	StreamPullRequestsFn             func(owner, repo string, fn func(*github.PullRequest) error) error
+	ListPullRequestNumbersFn         func(owner, repo string) ([]int, error)
+	ListPullRequestNumbersSinceFn    func(owner, repo string, since time.Time) ([]int, error)
+	ListPullRequestNumbersByAuthorFn func(owner, repo, author string, since time.Time) ([]int, error)
+	GetPullRequestFn                 func(owner, repo string, number int) (*github.PullRequest, error)
+	ListPullRequestFilesFn           func(owner, repo string, number int) ([]*github.PullRequestFile, error)
+	ClosePullRequestFn               func(owner, repo string, number int, comment string) error
+	ReopenPullRequestFn              func(owner, repo string, number int, comment string) error
+	AddLabelFn                       func(owner, repo string, number int, label string) error
+	EnsureLabelFn                    func(owner, repo, name, color string) error
+	GetIssueEventsFn                 func(owner, repo string, number int) ([]*github.IssueEvent, error)
+	GetIssuesFn                      func(owner, repo string) ([]*github.Issue, error)
+	CloseIssueFn                     func(owner, repo string, number int, comment string) error
+	GetUserFn                        func(username string) (*github.User, error)
+	GetRepositoryFn                  func(owner, repo string) (*github.Repository, error)
+	HasPriorContributionFn           func(owner, repo, username string) (bool, error)
+	IsOrgMemberFn                    func(org, username string) (bool, error)
+	ListUserRepositoriesFn           func(username string) ([]*github.UserRepository, error)
+	BlockUserOrgFn                   func(org, username string) error
+	BlockUserPersonalFn              func(username string) error
+	UnblockUserOrgFn                 func(org, username string) error
+	UnblockUserPersonalFn            func(username string) error
+	GetRateLimitFn                   func() (*github.RateLimits, error)
+	GetAuthenticatedUserFn           func() (*github.User, []string, error)
+}
+
+func (m *MockGitHubClient) WithContext(ctx context.Context) github.GitHubClient {
+	if m.WithContextFn != nil {
+		return m.WithContextFn(ctx)
+	}
+	return m
 }
 
 func (m *MockGitHubClient) GetPullRequests(owner, repo string) ([]*github.PullRequest, error) {
@@ -33,6 +65,48 @@ func (m *MockGitHubClient) GetPullRequests(owner, repo string) ([]*github.PullRe
 	return nil, nil
 }
 
+func (m *MockGitHubClient) StreamPullRequests(owner, repo string, fn func(*github.PullRequest) error) error {
+	if m.StreamPullRequestsFn != nil {
+		return m.StreamPullRequestsFn(owner, repo, fn)
+	}
+	return nil
+}
+
+func (m *MockGitHubClient) ListPullRequestNumbers(owner, repo string) ([]int, error) {
+	if m.ListPullRequestNumbersFn != nil {
+		return m.ListPullRequestNumbersFn(owner, repo)
+	}
+	return nil, nil
+}
+
+func (m *MockGitHubClient) ListPullRequestNumbersSince(owner, repo string, since time.Time) ([]int, error) {
+	if m.ListPullRequestNumbersSinceFn != nil {
+		return m.ListPullRequestNumbersSinceFn(owner, repo, since)
+	}
+	return m.ListPullRequestNumbers(owner, repo)
+}
+
+func (m *MockGitHubClient) ListPullRequestNumbersByAuthor(owner, repo, author string, since time.Time) ([]int, error) {
+	if m.ListPullRequestNumbersByAuthorFn != nil {
+		return m.ListPullRequestNumbersByAuthorFn(owner, repo, author, since)
+	}
+	return nil, nil
+}
+
+func (m *MockGitHubClient) GetPullRequest(owner, repo string, number int) (*github.PullRequest, error) {
+	if m.GetPullRequestFn != nil {
+		return m.GetPullRequestFn(owner, repo, number)
+	}
+	return nil, nil
+}
+
+func (m *MockGitHubClient) ListPullRequestFiles(owner, repo string, number int) ([]*github.PullRequestFile, error) {
+	if m.ListPullRequestFilesFn != nil {
+		return m.ListPullRequestFilesFn(owner, repo, number)
+	}
+	return nil, nil
+}
+
 func (m *MockGitHubClient) ClosePullRequest(owner, repo string, number int, comment string) error {
 	if m.ClosePullRequestFn != nil {
 		return m.ClosePullRequestFn(owner, repo, number, comment)
@@ -40,6 +114,13 @@ func (m *MockGitHubClient) ClosePullRequest(owner, repo string, number int, comm
 	return nil
 }
 
+func (m *MockGitHubClient) ReopenPullRequest(owner, repo string, number int, comment string) error {
+	if m.ReopenPullRequestFn != nil {
+		return m.ReopenPullRequestFn(owner, repo, number, comment)
+	}
+	return nil
+}
+
 func (m *MockGitHubClient) AddLabel(owner, repo string, number int, label string) error {
 	if m.AddLabelFn != nil {
 		return m.AddLabelFn(owner, repo, number, label)
@@ -47,6 +128,41 @@ func (m *MockGitHubClient) AddLabel(owner, repo string, number int, label string
 	return nil
 }
 
+func (m *MockGitHubClient) EnsureLabel(owner, repo, name, color string) error {
+	if m.EnsureLabelFn != nil {
+		return m.EnsureLabelFn(owner, repo, name, color)
+	}
+	return nil
+}
+
+func (m *MockGitHubClient) GetIssueEvents(owner, repo string, number int) ([]*github.IssueEvent, error) {
+	if m.GetIssueEventsFn != nil {
+		return m.GetIssueEventsFn(owner, repo, number)
+	}
+	return nil, nil
+}
+
+func (m *MockGitHubClient) GetIssues(owner, repo string) ([]*github.Issue, error) {
+	if m.GetIssuesFn != nil {
+		return m.GetIssuesFn(owner, repo)
+	}
+	return nil, nil
+}
+
+func (m *MockGitHubClient) CloseIssue(owner, repo string, number int, comment string) error {
+	if m.CloseIssueFn != nil {
+		return m.CloseIssueFn(owner, repo, number, comment)
+	}
+	return nil
+}
+
+func (m *MockGitHubClient) GetRepository(owner, repo string) (*github.Repository, error) {
+	if m.GetRepositoryFn != nil {
+		return m.GetRepositoryFn(owner, repo)
+	}
+	return &github.Repository{}, nil
+}
+
 func (m *MockGitHubClient) GetUser(username string) (*github.User, error) {
 	if m.GetUserFn != nil {
 		return m.GetUserFn(username)
@@ -54,6 +170,34 @@ func (m *MockGitHubClient) GetUser(username string) (*github.User, error) {
 	return nil, nil
 }
 
+func (m *MockGitHubClient) GetAuthenticatedUser() (*github.User, []string, error) {
+	if m.GetAuthenticatedUserFn != nil {
+		return m.GetAuthenticatedUserFn()
+	}
+	return &github.User{}, nil, nil
+}
+
+func (m *MockGitHubClient) HasPriorContribution(owner, repo, username string) (bool, error) {
+	if m.HasPriorContributionFn != nil {
+		return m.HasPriorContributionFn(owner, repo, username)
+	}
+	return false, nil
+}
+
+func (m *MockGitHubClient) IsOrgMember(org, username string) (bool, error) {
+	if m.IsOrgMemberFn != nil {
+		return m.IsOrgMemberFn(org, username)
+	}
+	return false, nil
+}
+
+func (m *MockGitHubClient) ListUserRepositories(username string) ([]*github.UserRepository, error) {
+	if m.ListUserRepositoriesFn != nil {
+		return m.ListUserRepositoriesFn(username)
+	}
+	return nil, nil
+}
+
 func (m *MockGitHubClient) BlockUserOrg(org, username string) error {
 	if m.BlockUserOrgFn != nil {
 		return m.BlockUserOrgFn(org, username)
@@ -67,3 +211,24 @@ func (m *MockGitHubClient) BlockUserPersonal(username string) error {
 	}
 	return nil
 }
+
+func (m *MockGitHubClient) UnblockUserOrg(org, username string) error {
+	if m.UnblockUserOrgFn != nil {
+		return m.UnblockUserOrgFn(org, username)
+	}
+	return nil
+}
+
+func (m *MockGitHubClient) UnblockUserPersonal(username string) error {
+	if m.UnblockUserPersonalFn != nil {
+		return m.UnblockUserPersonalFn(username)
+	}
+	return nil
+}
+
+func (m *MockGitHubClient) GetRateLimit() (*github.RateLimits, error) {
+	if m.GetRateLimitFn != nil {
+		return m.GetRateLimitFn()
+	}
+	return &github.RateLimits{}, nil
+}
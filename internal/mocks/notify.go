@@ -0,0 +1,29 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mocks
+
+import "github.com/prguard/prguard/internal/notify"
+
+// MockNotifier is a mock implementation of notify.Notifier for testing
+type MockNotifier struct {
+	NotifySpamFn func(event *notify.SpamEvent) error
+}
+
+func (m *MockNotifier) NotifySpam(event *notify.SpamEvent) error {
+	if m.NotifySpamFn != nil {
+		return m.NotifySpamFn(event)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notify delivers scan notifications to external services.
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of the payload body
+const SignatureHeader = "X-PRGuard-Signature"
+
+// Notifier delivers spam notifications
+type Notifier interface {
+	NotifySpam(event *SpamEvent) error
+}
+
+// SpamEvent is the payload sent when a scan detects spam
+type SpamEvent struct {
+	Repository     string    `json:"repository"`
+	ScannedAt      time.Time `json:"scanned_at"`
+	Total          int       `json:"total"`
+	SpamCount      int       `json:"spam_count"`
+	UncertainCount int       `json:"uncertain_count"`
+	CleanCount     int       `json:"clean_count"`
+	PullRequests   []SpamPR  `json:"pull_requests"`
+}
+
+// SpamPR describes a single PR classified as spam
+type SpamPR struct {
+	Number  int      `json:"number"`
+	Author  string   `json:"author"`
+	URL     string   `json:"url"`
+	Reasons []string `json:"reasons"`
+}
+
+// NewSpamEvent builds a spam event from scan results
+func NewSpamEvent(owner, repo string, results *scanner.ScanResults) *SpamEvent {
+	event := &SpamEvent{
+		Repository:     owner + "/" + repo,
+		ScannedAt:      time.Now().UTC(),
+		Total:          results.Total,
+		SpamCount:      len(results.Spam),
+		UncertainCount: len(results.Uncertain),
+		CleanCount:     len(results.Clean),
+		PullRequests:   make([]SpamPR, 0, len(results.Spam)),
+	}
+	for _, result := range results.Spam {
+		event.PullRequests = append(event.PullRequests, SpamPR{
+			Number:  result.PR.Number,
+			Author:  result.PR.Author,
+			URL:     result.PR.HTMLURL,
+			Reasons: result.Reasons,
+		})
+	}
+	return event
+}
+
+// WebhookNotifier POSTs spam events as JSON to a webhook URL
+type WebhookNotifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a webhook notifier; an empty secret disables signing
+func NewWebhookNotifier(url, secret string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NotifySpam POSTs the event to the webhook
+func (n *WebhookNotifier) NotifySpam(event *SpamEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	headers := map[string]string{}
+	if n.secret != "" {
+		headers[SignatureHeader] = Sign(n.secret, body)
+	}
+	return postJSON(n.client, n.url, body, headers)
+}
+
+// postJSON POSTs a JSON body and treats any non-2xx response as an error
+func postJSON(client *http.Client, url string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Multi delivers events to several notifiers, collecting every failure
+type Multi []Notifier
+
+// NotifySpam sends the event to each notifier in turn
+func (m Multi) NotifySpam(event *SpamEvent) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.NotifySpam(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FromConfig builds the notifiers enabled in the configuration; the result is empty when none are
+func FromConfig(cfg config.NotificationsConfig) Multi {
+	var notifiers Multi
+	if cfg.WebhookURL != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(cfg.WebhookURL, cfg.Secret))
+	}
+	if cfg.SlackWebhookURL != "" {
+		notifiers = append(notifiers, NewSlackNotifier(cfg.SlackWebhookURL, cfg.SlackMaxPRs))
+	}
+	return notifiers
+}
+
+// Sign returns the signature header value for body: "sha256=" followed by the hex HMAC
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+func testResults() *scanner.ScanResults {
+	return &scanner.ScanResults{
+		Total: 2,
+		Spam: []*scanner.ScanResult{
+			{
+				PR: &github.PullRequest{
+					Number:  7,
+					Author:  "spammer",
+					HTMLURL: "https://github.com/org/repo/pull/7",
+				},
+				IsSpam:  true,
+				Reasons: []string{"Contains spam phrases"},
+			},
+		},
+	}
+}
+
+func TestWebhookNotifier_PayloadAndSignature(t *testing.T) {
+	var gotBody []byte
+	var gotSignature, gotContentType string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(SignatureHeader)
+		gotContentType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, "s3cret")
+	if err := notifier.NotifySpam(NewSpamEvent("org", "repo", testResults())); err != nil {
+		t.Fatalf("NotifySpam failed: %v", err)
+	}
+
+	if gotContentType != "application/json" {
+		t.Errorf("expected JSON content type, got %q", gotContentType)
+	}
+	if gotSignature != Sign("s3cret", gotBody) {
+		t.Errorf("signature mismatch: got %q", gotSignature)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(gotBody, &payload); err != nil {
+		t.Fatalf("invalid JSON payload: %v", err)
+	}
+	if payload["repository"] != "org/repo" {
+		t.Errorf("expected repository org/repo, got %v", payload["repository"])
+	}
+	if _, ok := payload["scanned_at"]; !ok {
+		t.Error("expected scanned_at in payload")
+	}
+	prs, ok := payload["pull_requests"].([]any)
+	if !ok || len(prs) != 1 {
+		t.Fatalf("expected 1 pull request, got %v", payload["pull_requests"])
+	}
+	pr := prs[0].(map[string]any)
+	if pr["number"] != float64(7) || pr["author"] != "spammer" || pr["url"] != "https://github.com/org/repo/pull/7" {
+		t.Errorf("unexpected pull request payload: %v", pr)
+	}
+	if reasons, ok := pr["reasons"].([]any); !ok || len(reasons) != 1 {
+		t.Errorf("expected 1 reason, got %v", pr["reasons"])
+	}
+}
+
+func TestWebhookNotifier_NoSecret(t *testing.T) {
+	var hasSignature bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, hasSignature = r.Header[http.CanonicalHeaderKey(SignatureHeader)]
+	}))
+	defer server.Close()
+
+	if err := NewWebhookNotifier(server.URL, "").NotifySpam(NewSpamEvent("org", "repo", testResults())); err != nil {
+		t.Fatalf("NotifySpam failed: %v", err)
+	}
+	if hasSignature {
+		t.Error("expected no signature header without a secret")
+	}
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookNotifier(server.URL, "").NotifySpam(NewSpamEvent("org", "repo", testResults())); err == nil {
+		t.Error("expected error for non-2xx response")
+	}
+}
+
+func TestSign(t *testing.T) {
+	// Reference value computed with: printf 'hello' | openssl dgst -sha256 -hmac key
+	expected := "sha256=9307b3b915efb5171ff14d8cb55fbcc798c6c0ef1456d66ded1a6aa723a58b7b"
+	if got := Sign("key", []byte("hello")); got != expected {
+		t.Errorf("Sign() = %q, expected %q", got, expected)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	if got := FromConfig(config.NotificationsConfig{}); len(got) != 0 {
+		t.Errorf("expected no notifiers, got %d", len(got))
+	}
+
+	got := FromConfig(config.NotificationsConfig{
+		WebhookURL:      "https://hooks.example.com",
+		SlackWebhookURL: "https://hooks.slack.com/services/x",
+	})
+	if len(got) != 2 {
+		t.Fatalf("expected 2 notifiers, got %d", len(got))
+	}
+	if _, ok := got[0].(*WebhookNotifier); !ok {
+		t.Errorf("expected webhook notifier first, got %T", got[0])
+	}
+	if _, ok := got[1].(*SlackNotifier); !ok {
+		t.Errorf("expected Slack notifier second, got %T", got[1])
+	}
+}
+
+func TestMulti_DeliversToAll(t *testing.T) {
+	var hits int
+	ok := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { hits++ }))
+	defer ok.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	multi := Multi{
+		NewWebhookNotifier(failing.URL, ""),
+		NewSlackNotifier(ok.URL, 5),
+	}
+	if err := multi.NotifySpam(NewSpamEvent("org", "repo", testResults())); err == nil {
+		t.Error("expected the failing notifier's error")
+	}
+	if hits != 1 {
+		t.Errorf("expected the remaining notifier to still be called, got %d hits", hits)
+	}
+}
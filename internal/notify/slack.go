@@ -0,0 +1,115 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultSlackMaxPRs is used when no positive PR limit is configured
+const defaultSlackMaxPRs = 10
+
+// SlackNotifier posts spam events to a Slack incoming webhook as Block Kit messages
+type SlackNotifier struct {
+	url    string
+	maxPRs int
+	client *http.Client
+}
+
+// NewSlackNotifier creates a Slack notifier listing at most maxPRs spam PRs per message
+func NewSlackNotifier(url string, maxPRs int) *SlackNotifier {
+	if maxPRs <= 0 {
+		maxPRs = defaultSlackMaxPRs
+	}
+	return &SlackNotifier{
+		url:    url,
+		maxPRs: maxPRs,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SlackMessage is a Slack incoming-webhook payload
+type SlackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// SlackBlock is a Block Kit layout block
+type SlackBlock struct {
+	Type     string      `json:"type"`
+	Text     *SlackText  `json:"text,omitempty"`
+	Fields   []SlackText `json:"fields,omitempty"`
+	Elements []SlackText `json:"elements,omitempty"`
+}
+
+// SlackText is a Block Kit text object
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// NotifySpam posts the event to Slack
+func (n *SlackNotifier) NotifySpam(event *SpamEvent) error {
+	body, err := json.Marshal(n.Message(event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal Slack message: %w", err)
+	}
+	return postJSON(n.client, n.url, body, nil)
+}
+
+// Message formats an event as a Block Kit message
+func (n *SlackNotifier) Message(event *SpamEvent) *SlackMessage {
+	title := fmt.Sprintf("PRGuard detected %d spam PR(s) in %s", event.SpamCount, event.Repository)
+
+	blocks := []SlackBlock{
+		{Type: "header", Text: &SlackText{Type: "plain_text", Text: title}},
+		{Type: "section", Fields: []SlackText{
+			{Type: "mrkdwn", Text: fmt.Sprintf("*Total:* %d", event.Total)},
+			{Type: "mrkdwn", Text: fmt.Sprintf("*Spam:* %d", event.SpamCount)},
+			{Type: "mrkdwn", Text: fmt.Sprintf("*Uncertain:* %d", event.UncertainCount)},
+			{Type: "mrkdwn", Text: fmt.Sprintf("*Clean:* %d", event.CleanCount)},
+		}},
+		{Type: "divider"},
+	}
+
+	shown := event.PullRequests
+	if len(shown) > n.maxPRs {
+		shown = shown[:n.maxPRs]
+	}
+	for _, pr := range shown {
+		text := fmt.Sprintf("<%s|#%d> by *%s*", pr.URL, pr.Number, slackEscape(pr.Author))
+		if len(pr.Reasons) > 0 {
+			text += "\n" + slackEscape(strings.Join(pr.Reasons, ", "))
+		}
+		blocks = append(blocks, SlackBlock{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: text}})
+	}
+
+	if hidden := len(event.PullRequests) - len(shown); hidden > 0 {
+		blocks = append(blocks, SlackBlock{Type: "context", Elements: []SlackText{
+			{Type: "mrkdwn", Text: fmt.Sprintf("…and %d more spam PR(s)", hidden)},
+		}})
+	}
+
+	return &SlackMessage{Text: title, Blocks: blocks}
+}
+
+// slackEscape escapes the control characters Slack reserves in mrkdwn text
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func slackTestEvent(numSpam int) *SpamEvent {
+	event := &SpamEvent{
+		Repository: "org/repo",
+		Total:      numSpam + 3,
+		SpamCount:  numSpam,
+		CleanCount: 3,
+	}
+	for i := 1; i <= numSpam; i++ {
+		event.PullRequests = append(event.PullRequests, SpamPR{
+			Number:  i,
+			Author:  fmt.Sprintf("spammer%d", i),
+			URL:     fmt.Sprintf("https://github.com/org/repo/pull/%d", i),
+			Reasons: []string{"Contains spam phrases"},
+		})
+	}
+	return event
+}
+
+func TestSlackNotifier_BlockKitStructure(t *testing.T) {
+	var message map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("invalid JSON body: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	if err := NewSlackNotifier(server.URL, 5).NotifySpam(slackTestEvent(2)); err != nil {
+		t.Fatalf("NotifySpam failed: %v", err)
+	}
+
+	if !strings.Contains(message["text"].(string), "org/repo") {
+		t.Errorf("expected fallback text to name the repository, got %v", message["text"])
+	}
+
+	blocks := message["blocks"].([]any)
+	wantTypes := []string{"header", "section", "divider", "section", "section"}
+	if len(blocks) != len(wantTypes) {
+		t.Fatalf("expected %d blocks, got %d", len(wantTypes), len(blocks))
+	}
+	for i, want := range wantTypes {
+		if got := blocks[i].(map[string]any)["type"]; got != want {
+			t.Errorf("block %d: expected type %s, got %v", i, want, got)
+		}
+	}
+
+	fields := blocks[1].(map[string]any)["fields"].([]any)
+	if len(fields) != 4 {
+		t.Errorf("expected 4 summary fields, got %d", len(fields))
+	}
+
+	prText := blocks[3].(map[string]any)["text"].(map[string]any)
+	if prText["type"] != "mrkdwn" || !strings.Contains(prText["text"].(string), "<https://github.com/org/repo/pull/1|#1>") {
+		t.Errorf("unexpected PR block: %v", prText)
+	}
+}
+
+func TestSlackNotifier_Truncation(t *testing.T) {
+	message := NewSlackNotifier("http://unused", 3).Message(slackTestEvent(8))
+
+	var prBlocks int
+	for _, block := range message.Blocks[3:] {
+		if block.Type == "section" {
+			prBlocks++
+		}
+	}
+	if prBlocks != 3 {
+		t.Errorf("expected 3 PR blocks, got %d", prBlocks)
+	}
+
+	last := message.Blocks[len(message.Blocks)-1]
+	if last.Type != "context" || len(last.Elements) != 1 || !strings.Contains(last.Elements[0].Text, "5 more") {
+		t.Errorf("expected truncation note for 5 more PRs, got %+v", last)
+	}
+
+	// No truncation note when everything fits
+	message = NewSlackNotifier("http://unused", 3).Message(slackTestEvent(3))
+	if message.Blocks[len(message.Blocks)-1].Type == "context" {
+		t.Error("unexpected truncation note")
+	}
+}
+
+func TestSlackEscape(t *testing.T) {
+	if got := slackEscape("<a> & b"); got != "&lt;a&gt; &amp; b" {
+		t.Errorf("slackEscape() = %q", got)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+// maxTopAuthors caps the repeated spam authors listed in an aggregate report
+const maxTopAuthors = 20
+
+// Aggregate combines the reports of several repositories scanned together
+type Aggregate struct {
+	GeneratedAt    time.Time
+	Repositories   []*document
+	Total          int
+	SpamCount      int
+	UncertainCount int
+	CleanCount     int
+	ErrorCount     int
+	TopAuthors     []AuthorCount // Authors of spam PRs, most spam PRs first
+}
+
+// AuthorCount is how much spam an author opened across the scanned repositories
+type AuthorCount struct {
+	Author       string
+	SpamPRs      int
+	Repositories []string
+}
+
+// NewAggregate sums the reports' counts and ranks spam authors across them.
+// Authors are matched ignoring case, as GitHub logins are.
+func NewAggregate(reports []*scanner.Report) *Aggregate {
+	agg := &Aggregate{GeneratedAt: time.Now().UTC()}
+	authors := make(map[string]*AuthorCount)
+
+	for _, r := range reports {
+		agg.Repositories = append(agg.Repositories, newDocument(r))
+		agg.Total += r.Total
+		agg.SpamCount += r.SpamCount
+		agg.UncertainCount += r.UncertainCount
+		agg.CleanCount += r.CleanCount
+		agg.ErrorCount += len(r.Errors)
+
+		for _, pr := range r.PullRequests {
+			if pr.Classification != scanner.ClassificationSpam || pr.Author == "" {
+				continue
+			}
+			key := strings.ToLower(pr.Author)
+			count, ok := authors[key]
+			if !ok {
+				count = &AuthorCount{Author: pr.Author}
+				authors[key] = count
+			}
+			count.SpamPRs++
+			if !slices.Contains(count.Repositories, r.Repository) {
+				count.Repositories = append(count.Repositories, r.Repository)
+			}
+		}
+	}
+
+	for _, count := range authors {
+		agg.TopAuthors = append(agg.TopAuthors, *count)
+	}
+	slices.SortFunc(agg.TopAuthors, func(a, b AuthorCount) int {
+		if a.SpamPRs != b.SpamPRs {
+			return b.SpamPRs - a.SpamPRs
+		}
+		if len(a.Repositories) != len(b.Repositories) {
+			return len(b.Repositories) - len(a.Repositories)
+		}
+		return strings.Compare(strings.ToLower(a.Author), strings.ToLower(b.Author))
+	})
+	if len(agg.TopAuthors) > maxTopAuthors {
+		agg.TopAuthors = agg.TopAuthors[:maxTopAuthors]
+	}
+
+	return agg
+}
+
+// RenderAggregateHTML writes an HTML report with an overall summary, the top
+// spam authors, and one section per repository
+func RenderAggregateHTML(w io.Writer, agg *Aggregate) error {
+	tmpl, err := htmltemplate.New("aggregate.html.tmpl").Funcs(htmltemplate.FuncMap{
+		"join": strings.Join,
+	}).ParseFS(templates, "templates/aggregate.html.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to parse html template: %w", err)
+	}
+	if err := tmpl.Execute(w, agg); err != nil {
+		return fmt.Errorf("failed to render html report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+func otherTestReport() *scanner.Report {
+	return &scanner.Report{
+		Repository: "org/other",
+		Total:      2,
+		SpamCount:  2,
+		PullRequests: []scanner.ReportPR{
+			{Number: 7, Title: "Add link", Author: "Spammer", URL: "https://github.com/org/other/pull/7", Classification: scanner.ClassificationSpam, Severity: "high", Reasons: []string{"Contains spam phrases"}},
+			{Number: 8, Title: "Update docs", Author: "drive-by", URL: "https://github.com/org/other/pull/8", Classification: scanner.ClassificationSpam, Severity: "medium", Reasons: []string{"Single-file README-only edit"}},
+		},
+	}
+}
+
+func TestNewAggregate(t *testing.T) {
+	agg := NewAggregate([]*scanner.Report{testReport(), otherTestReport()})
+
+	if agg.Total != 5 || agg.SpamCount != 3 || agg.UncertainCount != 1 || agg.CleanCount != 1 || agg.ErrorCount != 1 {
+		t.Errorf("unexpected totals: %+v", agg)
+	}
+	if len(agg.TopAuthors) != 2 {
+		t.Fatalf("expected 2 spam authors, got %+v", agg.TopAuthors)
+	}
+	top := agg.TopAuthors[0]
+	if !strings.EqualFold(top.Author, "spammer") || top.SpamPRs != 2 || len(top.Repositories) != 2 {
+		t.Errorf("expected spammer with 2 spam PRs across 2 repositories first, got %+v", top)
+	}
+}
+
+func TestRenderAggregateHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderAggregateHTML(&buf, NewAggregate([]*scanner.Report{testReport(), otherTestReport()})); err != nil {
+		t.Fatalf("RenderAggregateHTML failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"<h1>PRGuard Report: 2 repositories</h1>",
+		"<h2>org/repo</h2>",
+		"<h2>org/other</h2>",
+		`<a href="https://github.com/org/repo/pull/101">#101</a>`,
+		`<a href="https://github.com/org/other/pull/7">#7</a>`,
+		"<tr><td>spammer</td><td>2</td><td>org/repo, org/other</td></tr>",
+		"<tr><th>All repositories</th><th>5</th><th>3</th><th>1</th><th>1</th><th>1</th></tr>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("aggregate report missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package report renders scan reports as shareable Markdown or HTML documents.
+package report
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+// Supported output formats
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// document is the data passed to the report templates
+type document struct {
+	*scanner.Report
+	Spam      []scanner.ReportPR
+	Uncertain []scanner.ReportPR
+}
+
+// newDocument splits the report's PRs into the sections shown in the document
+func newDocument(report *scanner.Report) *document {
+	doc := &document{Report: report}
+	for _, pr := range report.PullRequests {
+		switch pr.Classification {
+		case scanner.ClassificationSpam:
+			doc.Spam = append(doc.Spam, pr)
+		case scanner.ClassificationUncertain:
+			doc.Uncertain = append(doc.Uncertain, pr)
+		}
+	}
+	return doc
+}
+
+// Render writes the report to w in the given format (markdown or html)
+func Render(w io.Writer, format string, report *scanner.Report) error {
+	doc := newDocument(report)
+
+	switch format {
+	case FormatMarkdown:
+		tmpl, err := texttemplate.New("report.md.tmpl").Funcs(texttemplate.FuncMap{
+			"cell": markdownCell,
+			"join": strings.Join,
+		}).ParseFS(templates, "templates/report.md.tmpl")
+		if err != nil {
+			return fmt.Errorf("failed to parse markdown template: %w", err)
+		}
+		if err := tmpl.Execute(w, doc); err != nil {
+			return fmt.Errorf("failed to render markdown report: %w", err)
+		}
+	case FormatHTML:
+		tmpl, err := htmltemplate.New("report.html.tmpl").ParseFS(templates, "templates/report.html.tmpl")
+		if err != nil {
+			return fmt.Errorf("failed to parse html template: %w", err)
+		}
+		if err := tmpl.Execute(w, doc); err != nil {
+			return fmt.Errorf("failed to render html report: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported report format: %s (must be '%s' or '%s')", format, FormatMarkdown, FormatHTML)
+	}
+
+	return nil
+}
+
+// markdownCell escapes text for use inside a Markdown table cell
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+func testReport() *scanner.Report {
+	return &scanner.Report{
+		Repository:     "org/repo",
+		ScannedAt:      time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Total:          3,
+		SpamCount:      1,
+		UncertainCount: 1,
+		CleanCount:     1,
+		PullRequests: []scanner.ReportPR{
+			{Number: 101, Title: "Update README | typo", Author: "spammer", URL: "https://github.com/org/repo/pull/101", Classification: scanner.ClassificationSpam, Severity: "high", Reasons: []string{"Single-file README-only edit", "Contains spam phrases"}},
+			{Number: 102, Title: "Tweak <docs>", Author: "newbie", URL: "https://github.com/org/repo/pull/102", Classification: scanner.ClassificationUncertain, Severity: "low", Reasons: []string{"Account created recently (suspicious but not definitive)"}},
+			{Number: 103, Title: "Fix bug", Author: "regular", URL: "https://github.com/org/repo/pull/103", Classification: scanner.ClassificationClean, Severity: "low"},
+		},
+		Errors: []scanner.ReportPRError{{Number: 104, Error: "not found"}},
+	}
+}
+
+func TestRender_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, FormatMarkdown, testReport()); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"# PRGuard Report: org/repo",
+		"| 3 | 1 | 1 | 1 | 1 |",
+		"[#101](https://github.com/org/repo/pull/101)",
+		`Update README \| typo`,
+		"@spammer",
+		"Single-file README-only edit; Contains spam phrases",
+		"[#102](https://github.com/org/repo/pull/102)",
+		"@newbie",
+		"#104: not found",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown report missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "#103") {
+		t.Error("clean PRs should not be listed")
+	}
+}
+
+func TestRender_HTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, FormatHTML, testReport()); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"<h1>PRGuard Report: org/repo</h1>",
+		`<a href="https://github.com/org/repo/pull/101">#101</a>`,
+		"spammer",
+		`<a href="https://github.com/org/repo/pull/102">#102</a>`,
+		"newbie",
+		"Tweak &lt;docs&gt;",
+		"<li>Contains spam phrases</li>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("html report missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRender_EmptySections(t *testing.T) {
+	var buf bytes.Buffer
+	report := &scanner.Report{Repository: "org/quiet"}
+	if err := Render(&buf, FormatMarkdown, report); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No spam PRs detected.") || !strings.Contains(buf.String(), "No PRs need manual review.") {
+		t.Errorf("expected empty-section messages:\n%s", buf.String())
+	}
+}
+
+func TestRender_UnsupportedFormat(t *testing.T) {
+	if err := Render(&bytes.Buffer{}, "pdf", testReport()); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/prguard/prguard/internal/scanner"
+	"github.com/prguard/prguard/pkg/models"
+)
+
+// FormatSARIF renders a report as a SARIF 2.1.0 log for code scanning tools
+const FormatSARIF = "sarif"
+
+// SARIF constants
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	ruleSpam      = "prguard/spam-pr"
+	ruleUncertain = "prguard/uncertain-pr"
+)
+
+// sarifLog is the top-level SARIF document
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// sarifRun holds the results of a single scan
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// sarifResult describes one flagged pull request
+type sarifResult struct {
+	RuleID     string          `json:"ruleId"`
+	Level      string          `json:"level"`
+	Message    sarifMessage    `json:"message"`
+	Locations  []sarifLocation `json:"locations"`
+	Properties sarifProperties `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifProperties carries PRGuard specific details of a result
+type sarifProperties struct {
+	Number   int      `json:"number"`
+	Author   string   `json:"author"`
+	Severity string   `json:"severity"`
+	Signals  []string `json:"signals,omitempty"`
+}
+
+// newSARIF converts a scan report into a SARIF log with one result per spam or
+// uncertain PR, located at the PR URL
+func newSARIF(report *scanner.Report) *sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "PRGuard",
+			InformationURI: "https://github.com/prguard/prguard",
+			Rules: []sarifRule{
+				{ID: ruleSpam, ShortDescription: sarifMessage{Text: "Pull request detected as spam"}},
+				{ID: ruleUncertain, ShortDescription: sarifMessage{Text: "Pull request needs manual review"}},
+			},
+		}},
+		Results: []sarifResult{},
+	}
+
+	for _, pr := range report.PullRequests {
+		var ruleID, level string
+		switch pr.Classification {
+		case scanner.ClassificationSpam:
+			ruleID, level = ruleSpam, sarifLevel(pr.Severity)
+		case scanner.ClassificationUncertain:
+			ruleID, level = ruleUncertain, "note"
+		default:
+			continue
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:  ruleID,
+			Level:   level,
+			Message: sarifMessage{Text: fmt.Sprintf("PR #%d by @%s: %s", pr.Number, pr.Author, strings.Join(pr.Reasons, "; "))},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: pr.URL}},
+			}},
+			Properties: sarifProperties{
+				Number:   pr.Number,
+				Author:   pr.Author,
+				Severity: pr.Severity,
+				Signals:  pr.Signals,
+			},
+		})
+	}
+
+	return &sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}
+}
+
+// sarifLevel maps a spam severity to a SARIF result level
+func sarifLevel(severity string) string {
+	switch severity {
+	case models.SeverityHigh:
+		return "error"
+	case models.SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// WriteSARIF writes the report to w as an indented SARIF log
+func WriteSARIF(w io.Writer, report *scanner.Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(newSARIF(report)); err != nil {
+		return fmt.Errorf("failed to encode SARIF report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, testReport()); err != nil {
+		t.Fatalf("WriteSARIF failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("SARIF output is not valid JSON: %v", err)
+	}
+	if doc["version"] != "2.1.0" {
+		t.Errorf("Expected version 2.1.0, got %v", doc["version"])
+	}
+	if _, ok := doc["$schema"].(string); !ok {
+		t.Error("Expected $schema to be set")
+	}
+
+	runs, ok := doc["runs"].([]any)
+	if !ok || len(runs) != 1 {
+		t.Fatalf("Expected one run, got %v", doc["runs"])
+	}
+	run := runs[0].(map[string]any)
+	driver := run["tool"].(map[string]any)["driver"].(map[string]any)
+	if driver["name"] != "PRGuard" {
+		t.Errorf("Expected tool name PRGuard, got %v", driver["name"])
+	}
+
+	results, ok := run["results"].([]any)
+	if !ok {
+		t.Fatalf("Expected results array, got %v", run["results"])
+	}
+	// The clean PR is not reported
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+}
+
+func TestNewSARIF_Results(t *testing.T) {
+	log := newSARIF(testReport())
+	results := log.Runs[0].Results
+
+	spam := results[0]
+	if spam.RuleID != ruleSpam || spam.Level != "error" {
+		t.Errorf("Expected high severity spam to be %s/error, got %s/%s", ruleSpam, spam.RuleID, spam.Level)
+	}
+	if want := "PR #101 by @spammer: Single-file README-only edit; Contains spam phrases"; spam.Message.Text != want {
+		t.Errorf("Expected message %q, got %q", want, spam.Message.Text)
+	}
+	if uri := spam.Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "https://github.com/org/repo/pull/101" {
+		t.Errorf("Expected PR URL as location, got %q", uri)
+	}
+
+	uncertain := results[1]
+	if uncertain.RuleID != ruleUncertain || uncertain.Level != "note" {
+		t.Errorf("Expected uncertain PR to be %s/note, got %s/%s", ruleUncertain, uncertain.RuleID, uncertain.Level)
+	}
+}
+
+func TestSARIFLevel(t *testing.T) {
+	for severity, want := range map[string]string{"high": "error", "medium": "warning", "low": "note", "": "note"} {
+		if got := sarifLevel(severity); got != want {
+			t.Errorf("sarifLevel(%q) = %q, want %q", severity, got, want)
+		}
+	}
+}
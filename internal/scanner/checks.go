@@ -0,0 +1,92 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"slices"
+
+	"github.com/prguard/prguard/internal/github"
+)
+
+// signalOrder lists every signal in the order checks are reported
+var signalOrder = []string{
+	SignalReadmeOnly,
+	SignalSensitiveFiles,
+	SignalNewAccount,
+	SignalFirstTime,
+	SignalMinimalChanges,
+	SignalNoNetChange,
+	SignalGeneratedOnly,
+	SignalNonDefaultBase,
+	SignalSuspiciousEncoding,
+	SignalLowEffortBody,
+	SignalSpamPhrase,
+	SignalSpamPattern,
+	SignalLowReputation,
+	SignalHollowProfile,
+	SignalDuplicateTitles,
+	SignalSpamLink,
+	SignalReopened,
+	SignalAuthorGone,
+}
+
+// markChecked records that the heuristic identified by signal ran for the result
+func (r *ScanResult) markChecked(signal string) {
+	r.checked = append(r.checked, signal)
+}
+
+// Checks returns the codes of the heuristics that ran for the result, so
+// callers can show which checks a PR passed. Heuristics that are disabled, or
+// that were skipped because the data they need could not be fetched, are left
+// out. It returns nil when the author is whitelisted or a trusted organization
+// member, as heuristics are skipped.
+func (r *ScanResult) Checks() []string {
+	var checks []string
+	for _, signal := range signalOrder {
+		if slices.Contains(r.checked, signal) {
+			checks = append(checks, signal)
+		}
+	}
+	return checks
+}
+
+// markPRChecks records the per-PR heuristics scanPR runs for result under the
+// effective filters; user is nil when the author could not be fetched
+func (s *Scanner) markPRChecks(result *ScanResult, user *github.User) {
+	pr := result.PR
+	enabled := []struct {
+		signal string
+		on     bool
+	}{
+		{SignalReadmeOnly, s.filters.ReadmeOnlyBlock},
+		{SignalSensitiveFiles, len(s.filters.SensitiveFiles) > 0},
+		{SignalNewAccount, user != nil && s.filters.AccountAgeDays > 0},
+		{SignalMinimalChanges, s.filters.MinFiles > 0 || s.filters.MinLines > 0},
+		{SignalNoNetChange, true},
+		{SignalGeneratedOnly, len(s.filters.GeneratedFilePatterns) > 0},
+		// Without both branches the base cannot be compared
+		{SignalNonDefaultBase, s.filters.FlagNonDefaultBase && pr.BaseRef != "" && s.defaultBranch(pr) != ""},
+		{SignalSuspiciousEncoding, s.filters.SuspiciousEncoding},
+		{SignalLowEffortBody, s.filters.MinBodyLength > 0},
+		{SignalSpamPhrase, len(s.filters.SpamPhrases) > 0},
+		{SignalSpamPattern, len(s.spamRegexes) > 0},
+		{SignalLowReputation, user != nil && (s.filters.MinFollowers > 0 || s.filters.MinPublicRepos > 0)},
+	}
+	for _, check := range enabled {
+		if check.on {
+			result.markChecked(check.signal)
+		}
+	}
+}
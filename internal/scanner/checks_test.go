@@ -0,0 +1,60 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+)
+
+func TestChecks(t *testing.T) {
+	readmeOff := false
+	cfg := getTestConfig()
+	cfg.Filters.MinBodyLength = 20
+	cfg.Repositories = []config.Repository{{Owner: "org", Name: "docs", Filters: &config.RepositoryFilters{ReadmeOnlyBlock: &readmeOff}}}
+	s := NewScanner(cfg)
+
+	pr := &github.PullRequest{Author: "regular", FilesCount: 3, Additions: 40, Body: "Fixes the parser crash on empty input"}
+	user := &github.User{Login: "regular", CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+
+	got := s.forRepository("org", "repo").ScanPR(pr, user).Checks()
+	for _, want := range []string{SignalReadmeOnly, SignalNewAccount, SignalMinimalChanges, SignalSpamPhrase, SignalLowEffortBody} {
+		if !slices.Contains(got, want) {
+			t.Errorf("expected %s to be checked, got %v", want, got)
+		}
+	}
+	for _, disabled := range []string{SignalFirstTime, SignalSuspiciousEncoding, SignalSpamPattern, SignalReopened, SignalSpamLink, SignalDuplicateTitles} {
+		if slices.Contains(got, disabled) {
+			t.Errorf("expected %s not to be checked, got %v", disabled, got)
+		}
+	}
+
+	if slices.Contains(s.forRepository("org", "docs").ScanPR(pr, user).Checks(), SignalReadmeOnly) {
+		t.Error("expected the per-repository override to disable the README check")
+	}
+
+	// Account checks cannot run when the author could not be fetched
+	if got := s.ScanPR(pr, nil).Checks(); slices.Contains(got, SignalNewAccount) {
+		t.Errorf("expected the account age check to be skipped without the author, got %v", got)
+	}
+
+	if got := s.ScanPR(&github.PullRequest{Author: "dependabot[bot]"}, user).Checks(); got != nil {
+		t.Errorf("expected no checks for a whitelisted author, got %v", got)
+	}
+}
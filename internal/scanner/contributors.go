@@ -0,0 +1,77 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"sync"
+
+	"github.com/prguard/prguard/internal/github"
+)
+
+// contributionCache remembers, for the duration of one repository scan, whether
+// each author has prior commits so the lookup runs once per username
+type contributionCache struct {
+	ghClient    github.GitHubClient
+	owner, repo string
+
+	mu      sync.Mutex
+	lookups map[string]*contributionLookup
+}
+
+// contributionLookup holds the result of a single HasPriorContribution call
+type contributionLookup struct {
+	once      sync.Once
+	firstTime bool
+	ok        bool // The lookup succeeded
+}
+
+func newContributionCache(ghClient github.GitHubClient, owner, repo string) *contributionCache {
+	return &contributionCache{
+		ghClient: ghClient,
+		owner:    owner,
+		repo:     repo,
+		lookups:  make(map[string]*contributionLookup),
+	}
+}
+
+// isFirstTime reports whether username has never committed to the repository,
+// and whether the lookup succeeded. Lookup failures are treated as not
+// first-time so an API error never flags a PR.
+func (c *contributionCache) isFirstTime(username string) (firstTime, ok bool) {
+	c.mu.Lock()
+	lookup, ok := c.lookups[username]
+	if !ok {
+		lookup = &contributionLookup{}
+		c.lookups[username] = lookup
+	}
+	c.mu.Unlock()
+
+	lookup.once.Do(func() {
+		prior, err := c.ghClient.HasPriorContribution(c.owner, c.repo, username)
+		lookup.firstTime = err == nil && !prior
+		lookup.ok = err == nil
+	})
+	return lookup.firstTime, lookup.ok
+}
+
+// isFirstTimeContributor checks whether the PR author has no prior commits to the
+// repository, and whether that was checked; it is a no-op unless the
+// first_time_contributors filter is enabled
+func (s *Scanner) isFirstTimeContributor(pr *github.PullRequest, contributions *contributionCache) (firstTime, checked bool) {
+	if !s.filters.FirstTimeContributors || contributions == nil || s.isWhitelisted(pr.Author) {
+		return false, false
+	}
+	return contributions.isFirstTime(pr.Author)
+}
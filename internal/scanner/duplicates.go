@@ -0,0 +1,139 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxTitleDistance is the largest edit distance at which two normalized titles
+// are considered duplicates
+const maxTitleDistance = 2
+
+// titleRunesPerEdit scales the allowed edit distance to title length: titles
+// may differ by one edit per this many runes of the shorter title, up to
+// maxTitleDistance, so short titles such as "fix typo" must match closely
+const titleRunesPerEdit = 6
+
+// flagDuplicateTitles marks every PR in a cluster of near-identical titles as spam
+// when the cluster reaches the configured minimum size
+func (s *Scanner) flagDuplicateTitles(results []*ScanResult) {
+	minSize := s.filters.MinDuplicateTitles
+	if minSize < 2 {
+		return
+	}
+
+	// Whitelisted authors (e.g. bots) and trusted org members routinely open
+	// similarly titled PRs
+	var candidates []*ScanResult
+	var titles []string
+	for _, result := range results {
+		if result == nil || result.trusted || s.isWhitelisted(result.PR.Author) {
+			continue
+		}
+		result.markChecked(SignalDuplicateTitles)
+		candidates = append(candidates, result)
+		titles = append(titles, normalizeTitle(result.PR.Title))
+	}
+
+	// Union-find over pairwise similar titles
+	parent := make([]int, len(candidates))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range candidates {
+		for j := i + 1; j < len(candidates); j++ {
+			if similarTitles(titles[i], titles[j]) {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	clusters := make(map[int][]*ScanResult)
+	for i, result := range candidates {
+		root := find(i)
+		clusters[root] = append(clusters[root], result)
+	}
+
+	for _, cluster := range clusters {
+		if len(cluster) < minSize {
+			continue
+		}
+		reason := fmt.Sprintf("Part of a duplicate-title cluster (%d PRs)", len(cluster))
+		for _, result := range cluster {
+			result.IsSpam = true
+			result.addSignal(SignalDuplicateTitles, reason)
+			if result.Severity == "low" {
+				result.Severity = "medium"
+			}
+			result.RecommendAction = "Block user and close PR"
+		}
+	}
+}
+
+// normalizeTitle lowercases a title, strips punctuation, and collapses whitespace
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// similarTitles reports whether two normalized titles are near-identical
+func similarTitles(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	shorter := min(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	return levenshtein(a, b) <= min(shorter/titleRunesPerEdit, maxTitleDistance)
+}
+
+// levenshtein computes the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
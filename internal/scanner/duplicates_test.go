@@ -0,0 +1,100 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"testing"
+
+	"github.com/prguard/prguard/internal/github"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := map[string]string{
+		"Update README":         "update readme",
+		"Update Readme!":        "update readme",
+		"  update   readme.md ": "update readmemd",
+		"Fix: typo (docs)":      "fix typo docs",
+	}
+
+	for input, expected := range tests {
+		if got := normalizeTitle(input); got != expected {
+			t.Errorf("normalizeTitle(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"readme", "", 6},
+		{"update readme", "update readme", 0},
+		{"update readme", "update readmes", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.expected {
+			t.Errorf("levenshtein(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestSimilarTitles(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"update readme", "update readmes", true},
+		{"update readme", "update readmemd", true},
+		{"fix typo", "fix typos", true},
+		{"fix typo", "fix tests", false},
+		{"v124", "v125", false},
+		{"docs", "dots", false},
+		{"docs", "docs", true},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := similarTitles(tt.a, tt.b); got != tt.expected {
+			t.Errorf("similarTitles(%q, %q) = %v, expected %v", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestFlagDuplicateTitles_BelowMinimum(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.Filters.MinDuplicateTitles = 3
+	scanner := NewScanner(cfg)
+
+	results := []*ScanResult{
+		{PR: newTitledPR(1, "Update README", "a"), Severity: "low"},
+		{PR: newTitledPR(2, "update readme", "b"), Severity: "low"},
+		{PR: newTitledPR(3, "Bump lodash", "dependabot[bot]"), Severity: "low"},
+	}
+	scanner.flagDuplicateTitles(results)
+
+	for _, result := range results {
+		if result.IsSpam {
+			t.Errorf("PR #%d should not be flagged below the cluster minimum", result.PR.Number)
+		}
+	}
+}
+
+func newTitledPR(number int, title, author string) *github.PullRequest {
+	return &github.PullRequest{Number: number, Title: title, Author: author}
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prguard/prguard/internal/github"
+)
+
+// IssueScanResult represents the result of scanning an issue
+type IssueScanResult struct {
+	Issue           *github.Issue
+	IsSpam          bool
+	IsUncertain     bool
+	Reasons         []string
+	Severity        string
+	RecommendAction string
+}
+
+// IssueScanResults holds the results of scanning a repository's issues
+type IssueScanResults struct {
+	Total     int
+	Spam      []*IssueScanResult
+	Uncertain []*IssueScanResult
+	Clean     []*IssueScanResult
+}
+
+// ScanIssue analyzes an issue for spam indicators
+func (s *Scanner) ScanIssue(issue *github.Issue, user *github.User) *IssueScanResult {
+	return s.isSpamIssue(issue, user)
+}
+
+// isSpamIssue applies the text and account heuristics shared with pull
+// requests; file and line count checks have no equivalent for issues
+func (s *Scanner) isSpamIssue(issue *github.Issue, user *github.User) *IssueScanResult {
+	result := &IssueScanResult{
+		Issue:    issue,
+		Reasons:  []string{},
+		Severity: "low",
+	}
+
+	// Check if user is whitelisted
+	if s.isWhitelisted(issue.Author) {
+		result.RecommendAction = "No action needed"
+		return result
+	}
+
+	// Check for spam phrases
+	if s.textContainsSpamPhrases(issue.Title, issue.Body) {
+		result.IsSpam = true
+		result.Reasons = append(result.Reasons, "Contains spam phrases")
+		result.Severity = "high"
+	}
+
+	// Check for spam regex patterns
+	if s.textMatchesSpamRegex(issue.Title, issue.Body) {
+		result.IsSpam = true
+		result.Reasons = append(result.Reasons, "Matches spam pattern")
+		result.Severity = "high"
+	}
+
+	// Check account age
+	if user != nil && s.isNewAccount(user) {
+		if result.IsSpam {
+			result.Reasons = append(result.Reasons, "Account created recently")
+		} else {
+			result.IsUncertain = true
+			result.Reasons = append(result.Reasons, "Account created recently (suspicious but not definitive)")
+		}
+	}
+
+	// Check account reputation; this only corroborates other signals
+	if user != nil && s.isLowReputation(user) {
+		result.Reasons = append(result.Reasons, "Low reputation account (few followers and public repos)")
+		if result.IsSpam || result.IsUncertain {
+			result.IsSpam = true
+			if result.Severity == "low" {
+				result.Severity = "medium"
+			}
+		} else {
+			result.IsUncertain = true
+		}
+	}
+
+	// Determine recommended action
+	//nolint:gocritic // if-else is more readable here than switch
+	if result.IsSpam {
+		result.RecommendAction = "Close issue"
+	} else if result.IsUncertain {
+		result.RecommendAction = "Manual review recommended"
+	} else {
+		result.RecommendAction = "No action needed"
+	}
+
+	return result
+}
+
+// ScanIssues scans all open issues in a repository
+func (s *Scanner) ScanIssues(ghClient github.GitHubClient, owner, repo string) (*IssueScanResults, error) {
+	issues, err := ghClient.GetIssues(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use per-repo filter overrides when configured
+	repoScanner := s.forRepository(owner, repo)
+
+	results := &IssueScanResults{
+		Spam:      []*IssueScanResult{},
+		Uncertain: []*IssueScanResult{},
+		Clean:     []*IssueScanResult{},
+	}
+
+	for _, issue := range issues {
+		user, err := ghClient.GetUser(issue.Author)
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+		if err != nil {
+			// If we can't fetch user info, continue with nil
+			user = nil
+		}
+
+		result := repoScanner.isSpamIssue(issue, user)
+		results.Total++
+
+		//nolint:gocritic // if-else is more readable here than switch
+		if result.IsSpam {
+			results.Spam = append(results.Spam, result)
+		} else if result.IsUncertain {
+			results.Uncertain = append(results.Uncertain, result)
+		} else {
+			results.Clean = append(results.Clean, result)
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/mocks"
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+func issueScanConfig() *config.Config {
+	cfg := &config.Config{
+		Filters: config.FiltersConfig{
+			AccountAgeDays: 7,
+			SpamPhrases:    []string{"buy followers", "crypto giveaway"},
+			Whitelist:      []string{"*[bot]"},
+		},
+	}
+	cfg.SetDefaults()
+	return cfg
+}
+
+func TestScanIssues(t *testing.T) {
+	newAccount := time.Now().Add(-24 * time.Hour)
+	oldAccount := time.Now().Add(-365 * 24 * time.Hour)
+	authors := map[string]time.Time{
+		"spammer":       newAccount,
+		"newcomer":      newAccount,
+		"veteran":       oldAccount,
+		"old-spammer":   oldAccount,
+		"renovate[bot]": newAccount,
+	}
+
+	ghClient := &mocks.MockGitHubClient{
+		GetIssuesFn: func(_, _ string) ([]*github.Issue, error) {
+			return []*github.Issue{
+				{Number: 1, Title: "Crypto giveaway!!!", Body: "Claim now", Author: "spammer"},
+				{Number: 2, Title: "Crash on startup", Body: "Stack trace attached", Author: "newcomer"},
+				{Number: 3, Title: "Feature request", Body: "Please add X", Author: "veteran"},
+				{Number: 4, Title: "Cheap deals", Body: "Buy followers here", Author: "old-spammer"},
+				{Number: 5, Title: "Crypto giveaway", Author: "renovate[bot]"},
+			}, nil
+		},
+		GetUserFn: func(username string) (*github.User, error) {
+			return &github.User{Login: username, CreatedAt: authors[username]}, nil
+		},
+	}
+
+	results, err := scanner.NewScanner(issueScanConfig()).ScanIssues(ghClient, "org", "repo")
+	if err != nil {
+		t.Fatalf("ScanIssues() error = %v", err)
+	}
+
+	if results.Total != 5 {
+		t.Errorf("Total = %d, want 5", results.Total)
+	}
+	if len(results.Spam) != 2 || results.Spam[0].Issue.Number != 1 || results.Spam[1].Issue.Number != 4 {
+		t.Fatalf("expected issues #1 and #4 to be spam, got %+v", results.Spam)
+	}
+	if len(results.Uncertain) != 1 || results.Uncertain[0].Issue.Number != 2 {
+		t.Errorf("expected issue #2 from a new account to be uncertain, got %+v", results.Uncertain)
+	}
+	if len(results.Clean) != 2 {
+		t.Errorf("expected veteran and whitelisted bot issues to be clean, got %d", len(results.Clean))
+	}
+
+	spam := results.Spam[0]
+	if spam.Severity != "high" || spam.RecommendAction != "Close issue" {
+		t.Errorf("unexpected spam result: severity %q, action %q", spam.Severity, spam.RecommendAction)
+	}
+	if len(spam.Reasons) != 2 || spam.Reasons[0] != "Contains spam phrases" || spam.Reasons[1] != "Account created recently" {
+		t.Errorf("unexpected reasons: %v", spam.Reasons)
+	}
+}
+
+func TestScanIssue_UserLookupFailure(t *testing.T) {
+	s := scanner.NewScanner(issueScanConfig())
+
+	result := s.ScanIssue(&github.Issue{Number: 1, Title: "Buy followers", Author: "ghost"}, nil)
+	if !result.IsSpam {
+		t.Error("spam phrases should be detected without user information")
+	}
+
+	result = s.ScanIssue(&github.Issue{Number: 2, Title: "Docs typo", Author: "ghost"}, nil)
+	if result.IsSpam || result.IsUncertain {
+		t.Errorf("expected a clean result, got %+v", result)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/prguard/prguard/internal/github"
+)
+
+// membershipCache remembers, for the duration of one repository scan, whether
+// each author belongs to a trusted organization so the lookups run once per username
+type membershipCache struct {
+	ghClient github.GitHubClient
+	orgs     []string
+
+	mu      sync.Mutex
+	lookups map[string]*membershipLookup
+}
+
+// membershipLookup holds the result of the IsOrgMember calls for one username
+type membershipLookup struct {
+	once   sync.Once
+	member bool
+}
+
+func newMembershipCache(ghClient github.GitHubClient, orgs []string) *membershipCache {
+	return &membershipCache{
+		ghClient: ghClient,
+		orgs:     orgs,
+		lookups:  make(map[string]*membershipLookup),
+	}
+}
+
+// isMember reports whether username belongs to any trusted organization.
+// Lookup failures are treated as not a member so an API error never trusts a PR.
+func (c *membershipCache) isMember(username string) bool {
+	key := strings.ToLower(username)
+	c.mu.Lock()
+	lookup, ok := c.lookups[key]
+	if !ok {
+		lookup = &membershipLookup{}
+		c.lookups[key] = lookup
+	}
+	c.mu.Unlock()
+
+	lookup.once.Do(func() {
+		for _, org := range c.orgs {
+			if member, err := c.ghClient.IsOrgMember(org, username); err == nil && member {
+				lookup.member = true
+				return
+			}
+		}
+	})
+	return lookup.member
+}
+
+// isTrustedMember checks whether the PR author belongs to one of the trusted
+// organizations; it is a no-op unless trusted_orgs is configured
+func (s *Scanner) isTrustedMember(pr *github.PullRequest, members *membershipCache) bool {
+	if len(s.filters.TrustedOrgs) == 0 || members == nil || s.isWhitelisted(pr.Author) {
+		return false
+	}
+	return members.isMember(pr.Author)
+}
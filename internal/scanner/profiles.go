@@ -0,0 +1,106 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/prguard/prguard/internal/github"
+)
+
+// profileCache remembers, for the duration of one repository scan, the public
+// repositories of each author so the listing runs once per username
+type profileCache struct {
+	ghClient github.GitHubClient
+
+	mu      sync.Mutex
+	lookups map[string]*profileLookup
+}
+
+// profileLookup holds the result of a single ListUserRepositories call
+type profileLookup struct {
+	once  sync.Once
+	repos []*github.UserRepository
+	err   error
+}
+
+func newProfileCache(ghClient github.GitHubClient) *profileCache {
+	return &profileCache{
+		ghClient: ghClient,
+		lookups:  make(map[string]*profileLookup),
+	}
+}
+
+// repositories returns the public repositories owned by username
+func (c *profileCache) repositories(username string) ([]*github.UserRepository, error) {
+	c.mu.Lock()
+	lookup, ok := c.lookups[username]
+	if !ok {
+		lookup = &profileLookup{}
+		c.lookups[username] = lookup
+	}
+	c.mu.Unlock()
+
+	lookup.once.Do(func() {
+		lookup.repos, lookup.err = c.ghClient.ListUserRepositories(username)
+	})
+	return lookup.repos, lookup.err
+}
+
+// originalRepos counts the repositories that are neither forks nor empty
+func originalRepos(repos []*github.UserRepository) int {
+	original := 0
+	for _, repo := range repos {
+		if !repo.Fork && repo.Size > 0 {
+			original++
+		}
+	}
+	return original
+}
+
+// isHollowProfile reports whether the share of original repositories among
+// repos is below min_original_repo_ratio. Authors without public repositories
+// are left to the low reputation check.
+func (s *Scanner) isHollowProfile(repos []*github.UserRepository) bool {
+	if s.filters.MinOriginalRepoRatio <= 0 || len(repos) == 0 {
+		return false
+	}
+	return float64(originalRepos(repos))/float64(len(repos)) < s.filters.MinOriginalRepoRatio
+}
+
+// checkHollowProfile marks a PR for review when its author's public
+// repositories are mostly forks or empty, a common trait of spam accounts
+// with no original work. It is a no-op unless min_original_repo_ratio is set.
+func (s *Scanner) checkHollowProfile(result *ScanResult, profiles *profileCache) {
+	if s.filters.MinOriginalRepoRatio <= 0 || profiles == nil || s.isWhitelisted(result.PR.Author) {
+		return
+	}
+
+	repos, err := profiles.repositories(result.PR.Author)
+	if err != nil {
+		// Without the repository list the PR keeps the verdict of the other heuristics
+		return
+	}
+	result.markChecked(SignalHollowProfile)
+	if !s.isHollowProfile(repos) {
+		return
+	}
+	result.addSignal(SignalHollowProfile, fmt.Sprintf("Profile has little original work (%d of %d repositories are not forks or empty)", originalRepos(repos), len(repos)))
+	if !result.IsSpam && !result.IsUncertain {
+		result.IsUncertain = true
+		result.RecommendAction = "Manual review recommended"
+	}
+}
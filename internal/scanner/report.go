@@ -0,0 +1,103 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import "time"
+
+// Classification constants used in scan reports
+const (
+	ClassificationSpam      = "spam"
+	ClassificationUncertain = "uncertain"
+	ClassificationClean     = "clean"
+)
+
+// Report is the stable, machine-readable form of a repository scan.
+// Field names and JSON keys are part of the public output format; add new
+// fields rather than renaming or removing existing ones.
+type Report struct {
+	Repository     string          `json:"repository"`      // owner/repo
+	ScannedAt      time.Time       `json:"scanned_at"`      // When the scan completed
+	Total          int             `json:"total"`           // Open PRs found
+	SpamCount      int             `json:"spam_count"`      // PRs classified as spam
+	UncertainCount int             `json:"uncertain_count"` // PRs needing manual review
+	CleanCount     int             `json:"clean_count"`     // PRs with no findings
+	PullRequests   []ReportPR      `json:"pull_requests"`   // Scanned PRs: spam, then uncertain, then clean
+	Errors         []ReportPRError `json:"errors"`          // PRs that could not be scanned
+}
+
+// ReportPR describes a single scanned pull request
+type ReportPR struct {
+	Number            int      `json:"number"`
+	Title             string   `json:"title"`
+	Author            string   `json:"author"`
+	URL               string   `json:"url"`
+	BaseRef           string   `json:"base_ref,omitempty"` // Branch the PR targets
+	Classification    string   `json:"classification"`     // spam/uncertain/clean
+	Severity          string   `json:"severity"`           // low/medium/high
+	Reasons           []string `json:"reasons"`
+	Signals           []string `json:"signals"`
+	RecommendedAction string   `json:"recommended_action"`
+}
+
+// ReportPRError describes a pull request that failed to scan
+type ReportPRError struct {
+	Number int    `json:"number"`
+	Error  string `json:"error"`
+}
+
+// NewReport builds a Report from scan results
+func NewReport(owner, repo string, results *ScanResults) *Report {
+	report := &Report{
+		Repository:     owner + "/" + repo,
+		ScannedAt:      time.Now().UTC(),
+		Total:          results.Total,
+		SpamCount:      len(results.Spam),
+		UncertainCount: len(results.Uncertain),
+		CleanCount:     len(results.Clean),
+		PullRequests:   []ReportPR{},
+		Errors:         []ReportPRError{},
+	}
+
+	report.addResults(results.Spam, ClassificationSpam)
+	report.addResults(results.Uncertain, ClassificationUncertain)
+	report.addResults(results.Clean, ClassificationClean)
+
+	for _, scanErr := range results.Errors {
+		report.Errors = append(report.Errors, ReportPRError{
+			Number: scanErr.Number,
+			Error:  scanErr.Err.Error(),
+		})
+	}
+
+	return report
+}
+
+// addResults appends scan results with the given classification
+func (r *Report) addResults(results []*ScanResult, classification string) {
+	for _, result := range results {
+		r.PullRequests = append(r.PullRequests, ReportPR{
+			Number:            result.PR.Number,
+			Title:             result.PR.Title,
+			Author:            result.PR.Author,
+			URL:               result.PR.HTMLURL,
+			BaseRef:           result.PR.BaseRef,
+			Classification:    classification,
+			Severity:          result.Severity,
+			Reasons:           result.Reasons,
+			Signals:           result.Signals,
+			RecommendedAction: result.RecommendAction,
+		})
+	}
+}
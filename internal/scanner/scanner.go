@@ -15,39 +15,149 @@
 package scanner
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/prguard/prguard/internal/config"
 	"github.com/prguard/prguard/internal/github"
 )
 
+// maxNoOpChangeLines is the largest equal add/delete count treated as a no-op change
+const maxNoOpChangeLines = 5
+
+// Thresholds for isSuspiciousEncoding: the share of letters outside the Latin
+// script, and the fewest letters a body needs before the share is meaningful
+const (
+	suspiciousScriptRatio = 0.6
+	minSuspiciousLetters  = 20
+)
+
+// maxReasonLineLength caps how much of an offending line is quoted in a reason
+const maxReasonLineLength = 100
+
+// urlPattern matches links in PR bodies and patches
+var urlPattern = regexp.MustCompile(`(?i)\bhttps?://\S+|\bwww\.\S+`)
+
 // ScanResult represents the result of scanning a PR
 type ScanResult struct {
 	PR              *github.PullRequest
 	IsSpam          bool
 	IsUncertain     bool
-	Reasons         []string
+	Reasons         []string // Human-readable explanations for display
+	Signals         []string // Stable Signal* codes, one per heuristic that fired
 	Severity        string
 	RecommendAction string
+
+	trusted bool     // Author belongs to a trusted organization
+	checked []string // Signal codes of the heuristics that ran, in no particular order
+}
+
+// Signal codes identify which heuristic produced a reason. They are stable so
+// they can be stored and grouped on, unlike the free-text reasons.
+const (
+	SignalReadmeOnly         = "readme_only"
+	SignalSensitiveFiles     = "sensitive_files"
+	SignalNewAccount         = "new_account"
+	SignalFirstTime          = "first_time_contributor"
+	SignalMinimalChanges     = "minimal_changes"
+	SignalNoNetChange        = "no_net_change"
+	SignalGeneratedOnly      = "generated_files_only"
+	SignalSpamPhrase         = "spam_phrase"
+	SignalSpamPattern        = "spam_pattern"
+	SignalLowReputation      = "low_reputation"
+	SignalDuplicateTitles    = "duplicate_title"
+	SignalNonDefaultBase     = "non_default_base"
+	SignalSuspiciousEncoding = "suspicious_encoding"
+	SignalSpamLink           = "spam_link"
+	SignalReopened           = "reopened"
+	SignalAuthorGone         = "author_gone"
+	SignalLowEffortBody      = "low_effort_description"
+	SignalHollowProfile      = "hollow_profile"
+)
+
+// addSignal records a heuristic that fired with its code and display reason
+func (r *ScanResult) addSignal(signal, reason string) {
+	r.Signals = append(r.Signals, signal)
+	r.Reasons = append(r.Reasons, reason)
 }
 
 // Scanner analyzes pull requests for spam indicators
 type Scanner struct {
-	config *config.Config
+	config      *config.Config
+	filters     config.FiltersConfig // Effective filters (global or per-repo)
+	spamRegexes []*regexp.Regexp
+	since       time.Time          // Only scan PRs created after this time (zero scans all)
+	author      string             // Only scan PRs opened by this login (empty scans all)
+	repository  *github.Repository // Details of the repository being scanned, nil when unknown
 }
 
-// NewScanner creates a new PR scanner
+// NewScanner creates a new PR scanner.
+// It panics if a configured spam regex is invalid; use NewScannerE to handle the error.
 func NewScanner(cfg *config.Config) *Scanner {
-	return &Scanner{config: cfg}
+	s, err := NewScannerE(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// NewScannerE creates a new PR scanner, returning an error if a configured
+// spam regex fails to compile
+func NewScannerE(cfg *config.Config) (*Scanner, error) {
+	regexes := make([]*regexp.Regexp, 0, len(cfg.Filters.SpamRegexes))
+	for _, pattern := range cfg.Filters.SpamRegexes {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid spam regex %q: %w", pattern, err)
+		}
+		regexes = append(regexes, re)
+	}
+	return &Scanner{config: cfg, filters: cfg.Filters, spamRegexes: regexes}, nil
+}
+
+// SetSince limits ScanRepository to PRs created after since; a zero time scans every open PR
+func (s *Scanner) SetSince(since time.Time) {
+	s.since = since
+}
+
+// SetAuthor limits ScanRepository to PRs opened by author; an empty author scans every PR
+func (s *Scanner) SetAuthor(author string) {
+	s.author = author
+}
+
+// forRepository returns a scanner using the effective filters for a repository
+func (s *Scanner) forRepository(owner, repo string) *Scanner {
+	return &Scanner{
+		config:      s.config,
+		filters:     s.config.FiltersFor(owner, repo),
+		spamRegexes: s.spamRegexes,
+		since:       s.since,
+		author:      s.author,
+		repository:  s.repository,
+	}
 }
 
 // ScanPR analyzes a pull request for spam indicators
 func (s *Scanner) ScanPR(pr *github.PullRequest, user *github.User) *ScanResult {
+	return s.scanPR(pr, user, false)
+}
+
+// scanPR analyzes a pull request; firstTime marks an author with no prior commits to the repository
+func (s *Scanner) scanPR(pr *github.PullRequest, user *github.User, firstTime bool) *ScanResult {
 	result := &ScanResult{
 		PR:       pr,
 		IsSpam:   false,
 		Reasons:  []string{},
+		Signals:  []string{},
 		Severity: "low",
 	}
 
@@ -55,41 +165,130 @@ func (s *Scanner) ScanPR(pr *github.PullRequest, user *github.User) *ScanResult
 	if s.isWhitelisted(pr.Author) {
 		return result
 	}
+	s.markPRChecks(result, user)
 
-	// Check for single-file README edits
+	// Check for single-file README edits; substantial rewrites are often
+	// legitimate, so they are only marked for review
 	if s.isSingleFileReadmeEdit(pr) {
-		result.IsSpam = true
-		result.Reasons = append(result.Reasons, "Single-file README-only edit")
-		result.Severity = "high"
+		if s.isSubstantialReadmeEdit(pr) {
+			result.IsUncertain = true
+			result.addSignal(SignalReadmeOnly, fmt.Sprintf("Single-file README-only edit (%d lines added)", pr.Additions))
+		} else {
+			result.IsSpam = true
+			result.addSignal(SignalReadmeOnly, "Single-file README-only edit")
+			result.Severity = "high"
+		}
+	}
+
+	// Check for edits to LICENSE, SECURITY.md, CODEOWNERS and similar files;
+	// from a new account this is treated as an attack rather than a mistake
+	if files := s.sensitiveFileEdits(pr); len(files) > 0 {
+		result.addSignal(SignalSensitiveFiles, fmt.Sprintf("Modifies sensitive files: %s", strings.Join(files, ", ")))
+		if user != nil && s.isNewAccount(user) {
+			result.IsSpam = true
+			result.Severity = "high"
+		} else if !result.IsSpam {
+			result.IsUncertain = true
+		}
 	}
 
 	// Check account age
 	if user != nil && s.isNewAccount(user) {
 		if result.IsSpam {
-			result.Reasons = append(result.Reasons, "Account created recently")
+			result.addSignal(SignalNewAccount, "Account created recently")
 		} else {
 			result.IsUncertain = true
-			result.Reasons = append(result.Reasons, "Account created recently (suspicious but not definitive)")
+			result.addSignal(SignalNewAccount, "Account created recently (suspicious but not definitive)")
+		}
+	}
+
+	// Check for authors who have never contributed to the repository
+	if firstTime {
+		if result.IsSpam {
+			result.addSignal(SignalFirstTime, "First-time contributor")
+		} else {
+			result.IsUncertain = true
+			result.addSignal(SignalFirstTime, "First-time contributor (no prior commits to the repository)")
 		}
 	}
 
 	// Check for minimal changes
 	if s.isMinimalChanges(pr) {
 		if result.IsSpam {
-			result.Reasons = append(result.Reasons, "Minimal changes (below threshold)")
+			result.addSignal(SignalMinimalChanges, "Minimal changes (below threshold)")
 		} else {
 			result.IsUncertain = true
-			result.Reasons = append(result.Reasons, "Minimal changes (below threshold)")
+			result.addSignal(SignalMinimalChanges, "Minimal changes (below threshold)")
+		}
+	}
+
+	// Check for changes that cancel out (e.g. whitespace churn)
+	if s.isNoOpChange(pr) {
+		if !result.IsSpam {
+			result.IsUncertain = true
+		}
+		result.addSignal(SignalNoNetChange, "No net content change")
+	}
+
+	// Check for generated/lock file only changes
+	if s.isGeneratedFileOnly(pr) {
+		if !result.IsSpam {
+			result.IsUncertain = true
+		}
+		result.addSignal(SignalGeneratedOnly, "Only modifies generated/lock files")
+	}
+
+	// Check for PRs aimed at a branch other than the default
+	if s.targetsNonDefaultBranch(pr) {
+		if !result.IsSpam {
+			result.IsUncertain = true
+		}
+		result.addSignal(SignalNonDefaultBase, fmt.Sprintf("Targets non-default branch: %s", pr.BaseRef))
+	}
+
+	// Check for promotional bodies written mostly in another script
+	if s.isSuspiciousEncoding(pr) {
+		if !result.IsSpam {
+			result.IsUncertain = true
+		}
+		result.addSignal(SignalSuspiciousEncoding, "Body is mostly non-Latin text with links")
+	}
+
+	// Check for empty or one-word descriptions
+	if s.isLowEffortDescription(pr) {
+		if !result.IsSpam {
+			result.IsUncertain = true
 		}
+		result.addSignal(SignalLowEffortBody, "Empty or very short description")
 	}
 
 	// Check for spam phrases
 	if s.containsSpamPhrases(pr) {
 		result.IsSpam = true
-		result.Reasons = append(result.Reasons, "Contains spam phrases")
+		result.addSignal(SignalSpamPhrase, "Contains spam phrases")
+		result.Severity = "high"
+	}
+
+	// Check for spam regex patterns
+	if s.matchesSpamRegex(pr) {
+		result.IsSpam = true
+		result.addSignal(SignalSpamPattern, "Matches spam pattern")
 		result.Severity = "high"
 	}
 
+	// Check account reputation; this only corroborates other signals
+	if user != nil && s.isLowReputation(user) {
+		result.addSignal(SignalLowReputation, "Low reputation account (few followers and public repos)")
+		if result.IsSpam || result.IsUncertain {
+			result.IsSpam = true
+			if result.Severity == "low" {
+				result.Severity = "medium"
+			}
+		} else {
+			result.IsUncertain = true
+		}
+	}
+
 	// Determine recommended action
 	//nolint:gocritic // if-else is more readable here than switch
 	if result.IsSpam {
@@ -103,19 +302,83 @@ func (s *Scanner) ScanPR(pr *github.PullRequest, user *github.User) *ScanResult
 	return result
 }
 
-// isWhitelisted checks if a user is in the whitelist
+// isWhitelisted checks if a user is in the whitelist, ignoring case as GitHub
+// does. Entries containing * or ? are glob patterns, so "*[bot]" matches every
+// bot account.
 func (s *Scanner) isWhitelisted(username string) bool {
-	for _, whitelisted := range s.config.Filters.Whitelist {
-		if whitelisted == username {
+	for _, whitelisted := range s.filters.Whitelist {
+		if strings.EqualFold(whitelisted, username) || matchesUsernameGlob(whitelisted, username) {
 			return true
 		}
 	}
 	return false
 }
 
+// matchesUsernameGlob matches username case-insensitively against a pattern where
+// only * and ? are wildcards. Brackets are literal so "[bot]" suffixes match as written.
+func matchesUsernameGlob(pattern, username string) bool {
+	if !strings.ContainsAny(pattern, "*?") {
+		return false
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`).Replace(strings.ToLower(pattern))
+	matched, _ := path.Match(escaped, strings.ToLower(username))
+	return matched
+}
+
+// targetsNonDefaultBranch checks if a PR targets a branch other than the
+// repository's default; PRs with an unknown base or default are not flagged
+func (s *Scanner) targetsNonDefaultBranch(pr *github.PullRequest) bool {
+	defaultBranch := s.defaultBranch(pr)
+	if !s.filters.FlagNonDefaultBase || pr.BaseRef == "" || defaultBranch == "" {
+		return false
+	}
+	return pr.BaseRef != defaultBranch
+}
+
+// defaultBranch returns the default branch of the PR's repository, or "" when unknown
+func (s *Scanner) defaultBranch(pr *github.PullRequest) string {
+	if pr.DefaultBranch == "" && s.repository != nil {
+		return s.repository.DefaultBranch
+	}
+	return pr.DefaultBranch
+}
+
+// isSuspiciousEncoding checks if a PR body containing links is written mostly
+// in non-Latin script. PRs that change at least min_lines are not flagged so
+// genuine translations and contributions from non-English speakers are spared.
+func (s *Scanner) isSuspiciousEncoding(pr *github.PullRequest) bool {
+	if !s.filters.SuspiciousEncoding || pr.Additions+pr.Deletions >= s.filters.MinLines {
+		return false
+	}
+	if !urlPattern.MatchString(pr.Body) {
+		return false
+	}
+
+	letters, nonLatin := 0, 0
+	for _, r := range urlPattern.ReplaceAllString(pr.Body, " ") {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if !unicode.Is(unicode.Latin, r) {
+			nonLatin++
+		}
+	}
+	return letters >= minSuspiciousLetters && float64(nonLatin)/float64(letters) >= suspiciousScriptRatio
+}
+
+// isLowEffortDescription checks if the trimmed PR body is shorter than
+// min_body_length characters
+func (s *Scanner) isLowEffortDescription(pr *github.PullRequest) bool {
+	if s.filters.MinBodyLength <= 0 {
+		return false
+	}
+	return utf8.RuneCountInString(strings.TrimSpace(pr.Body)) < s.filters.MinBodyLength
+}
+
 // isSingleFileReadmeEdit checks if PR only modifies a single README file
 func (s *Scanner) isSingleFileReadmeEdit(pr *github.PullRequest) bool {
-	if !s.config.Filters.ReadmeOnlyBlock {
+	if !s.filters.ReadmeOnlyBlock {
 		return false
 	}
 
@@ -126,7 +389,7 @@ func (s *Scanner) isSingleFileReadmeEdit(pr *github.PullRequest) bool {
 
 	// Check if that file is a README
 	for _, file := range pr.Files {
-		if config.IsReadmeFile(file) {
+		if s.isReadme(file) {
 			return true
 		}
 	}
@@ -134,27 +397,131 @@ func (s *Scanner) isSingleFileReadmeEdit(pr *github.PullRequest) bool {
 	return false
 }
 
+// isSubstantialReadmeEdit checks if a README edit adds more lines than
+// readme_min_lines; a threshold of zero disables the exception
+func (s *Scanner) isSubstantialReadmeEdit(pr *github.PullRequest) bool {
+	return s.filters.ReadmeMinLines > 0 && pr.Additions > s.filters.ReadmeMinLines
+}
+
+// isReadme checks if file is the repository's README. When the README path is
+// known only that file counts; otherwise any readme-named file does.
+func (s *Scanner) isReadme(file string) bool {
+	if s.repository != nil && s.repository.ReadmePath != "" {
+		return file == s.repository.ReadmePath
+	}
+	return config.IsReadmeFile(file)
+}
+
 // isNewAccount checks if the account was created recently
 func (s *Scanner) isNewAccount(user *github.User) bool {
-	threshold := time.Duration(s.config.Filters.AccountAgeDays) * 24 * time.Hour
+	threshold := time.Duration(s.filters.AccountAgeDays) * 24 * time.Hour
 	accountAge := time.Since(user.CreatedAt)
 	return accountAge < threshold
 }
 
+// isLowReputation checks if the account falls below every configured reputation threshold
+func (s *Scanner) isLowReputation(user *github.User) bool {
+	if s.filters.MinFollowers == 0 && s.filters.MinPublicRepos == 0 {
+		return false
+	}
+	if s.filters.MinFollowers > 0 && user.Followers >= s.filters.MinFollowers {
+		return false
+	}
+	if s.filters.MinPublicRepos > 0 && user.PublicRepos >= s.filters.MinPublicRepos {
+		return false
+	}
+	return true
+}
+
 // isMinimalChanges checks if the PR has minimal changes
 func (s *Scanner) isMinimalChanges(pr *github.PullRequest) bool {
 	totalLines := pr.Additions + pr.Deletions
-	return pr.FilesCount < s.config.Filters.MinFiles || totalLines < s.config.Filters.MinLines
+	return pr.FilesCount < s.filters.MinFiles || totalLines < s.filters.MinLines
+}
+
+// isNoOpChange checks if the PR changes nothing, or adds and removes the same small number of lines
+func (s *Scanner) isNoOpChange(pr *github.PullRequest) bool {
+	if pr.Additions+pr.Deletions == 0 {
+		return true
+	}
+	return pr.Additions == pr.Deletions && pr.Additions <= maxNoOpChangeLines
+}
+
+// isGeneratedFileOnly checks if every file in the PR is a generated or lock file
+func (s *Scanner) isGeneratedFileOnly(pr *github.PullRequest) bool {
+	if len(pr.Files) == 0 || len(s.filters.GeneratedFilePatterns) == 0 {
+		return false
+	}
+
+	for _, file := range pr.Files {
+		if !s.isGeneratedFile(file) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isGeneratedFile checks a file's full path and basename against the generated file patterns
+func (s *Scanner) isGeneratedFile(file string) bool {
+	base := filepath.Base(file)
+	for _, pattern := range s.filters.GeneratedFilePatterns {
+		if matched, _ := filepath.Match(pattern, file); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, base); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// isSensitiveFileEdit checks if the PR touches any configured sensitive file
+func (s *Scanner) isSensitiveFileEdit(pr *github.PullRequest) bool {
+	return len(s.sensitiveFileEdits(pr)) > 0
+}
+
+// sensitiveFileEdits returns the files in the PR matching the sensitive file patterns
+func (s *Scanner) sensitiveFileEdits(pr *github.PullRequest) []string {
+	var matched []string
+	for _, file := range pr.Files {
+		if s.isSensitiveFile(file) {
+			matched = append(matched, file)
+		}
+	}
+	return matched
+}
+
+// isSensitiveFile checks a file's full path and basename against the sensitive
+// file patterns, ignoring case so "License.md" matches "LICENSE*"
+func (s *Scanner) isSensitiveFile(file string) bool {
+	lower := strings.ToLower(file)
+	base := filepath.Base(lower)
+	for _, pattern := range s.filters.SensitiveFiles {
+		pattern = strings.ToLower(pattern)
+		if matched, _ := filepath.Match(pattern, lower); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, base); matched {
+			return true
+		}
+	}
+	return false
 }
 
 // containsSpamPhrases checks if PR title or body contains spam phrases
 func (s *Scanner) containsSpamPhrases(pr *github.PullRequest) bool {
-	if len(s.config.Filters.SpamPhrases) == 0 {
+	return s.textContainsSpamPhrases(pr.Title, pr.Body)
+}
+
+// textContainsSpamPhrases checks if a title or body contains spam phrases
+func (s *Scanner) textContainsSpamPhrases(title, body string) bool {
+	if len(s.filters.SpamPhrases) == 0 {
 		return false
 	}
 
-	text := strings.ToLower(pr.Title + " " + pr.Body)
-	for _, phrase := range s.config.Filters.SpamPhrases {
+	text := strings.ToLower(title + " " + body)
+	for _, phrase := range s.filters.SpamPhrases {
 		if strings.Contains(text, strings.ToLower(phrase)) {
 			return true
 		}
@@ -163,37 +530,143 @@ func (s *Scanner) containsSpamPhrases(pr *github.PullRequest) bool {
 	return false
 }
 
+// matchesSpamRegex checks if PR title or body matches a configured spam regex
+func (s *Scanner) matchesSpamRegex(pr *github.PullRequest) bool {
+	return s.textMatchesSpamRegex(pr.Title, pr.Body)
+}
+
+// textMatchesSpamRegex checks if a title or body matches a configured spam regex
+func (s *Scanner) textMatchesSpamRegex(title, body string) bool {
+	if len(s.spamRegexes) == 0 {
+		return false
+	}
+
+	text := title + " " + body
+	for _, re := range s.spamRegexes {
+		if re.MatchString(text) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ScanResults holds multiple scan results
 type ScanResults struct {
 	Total     int
 	Spam      []*ScanResult
 	Uncertain []*ScanResult
 	Clean     []*ScanResult
+	Errors    []*ScanError
+}
+
+// ScanError records a PR that could not be scanned. Number is 0 when listing
+// the open PRs failed part way, leaving later pages unscanned.
+type ScanError struct {
+	Number int
+	Err    error
 }
 
-// ScanRepository scans all open PRs in a repository
+func (e *ScanError) Error() string {
+	if e.Number == 0 {
+		return fmt.Sprintf("listing PRs: %v", e.Err)
+	}
+	return fmt.Sprintf("PR #%d: %v", e.Number, e.Err)
+}
+
+// ScanRepository scans all open PRs in a repository.
+// PRs are fetched and scanned concurrently using a bounded worker pool; failures
+// for individual PRs are collected in ScanResults.Errors rather than aborting the scan.
+// If listing fails after some pages, the PRs already listed are still scanned and
+// the listing error is recorded in ScanResults.Errors.
 func (s *Scanner) ScanRepository(ghClient github.GitHubClient, owner, repo string) (*ScanResults, error) {
-	prs, err := ghClient.GetPullRequests(owner, repo)
-	if err != nil {
-		return nil, err
+	var numbers []int
+	var listErr error
+	switch {
+	case s.author != "":
+		// Let the client drop other authors' PRs so their details are never fetched
+		numbers, listErr = ghClient.ListPullRequestNumbersByAuthor(owner, repo, s.author, s.since)
+	case s.since.IsZero():
+		numbers, listErr = ghClient.ListPullRequestNumbers(owner, repo)
+	default:
+		// Let the client skip old PRs so their details are never fetched
+		numbers, listErr = ghClient.ListPullRequestNumbersSince(owner, repo, s.since)
+	}
+	if listErr != nil && (len(numbers) == 0 || errors.Is(listErr, context.Canceled) || errors.Is(listErr, context.DeadlineExceeded)) {
+		return nil, listErr
+	}
+
+	// Use per-repo filter overrides when configured
+	repoScanner := s.forRepository(owner, repo)
+
+	// Knowing the canonical README lets README detection ignore other
+	// readme-named files; without it the filename heuristic is used
+	if repository, err := ghClient.GetRepository(owner, repo); err == nil {
+		repoScanner.repository = repository
+	}
+
+	workers := repoScanner.filters.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	// Prior-contribution, membership, and profile lookups are shared across workers, once per author
+	contributions := newContributionCache(ghClient, owner, repo)
+	members := newMembershipCache(ghClient, repoScanner.filters.TrustedOrgs)
+	profiles := newProfileCache(ghClient)
+
+	// Results are stored by index so partitioning is independent of completion order
+	scanned := make([]*ScanResult, len(numbers))
+	errs := make([]error, len(numbers))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				scanned[i], errs[i] = repoScanner.scanPullRequest(ghClient, contributions, members, profiles, owner, repo, numbers[i])
+			}
+		}()
+	}
+	for i := range numbers {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	// A cancelled or timed-out context aborts the scan instead of failing every PR
+	for _, err := range errs {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
 	}
 
+	// Cross-PR heuristics run once every PR has been scanned
+	repoScanner.flagDuplicateTitles(scanned)
+
 	results := &ScanResults{
-		Total:     len(prs),
 		Spam:      []*ScanResult{},
 		Uncertain: []*ScanResult{},
 		Clean:     []*ScanResult{},
+		Errors:    []*ScanError{},
+	}
+	if listErr != nil {
+		results.Errors = append(results.Errors, &ScanError{Err: listErr})
 	}
 
-	for _, pr := range prs {
-		// Fetch user information
-		user, err := ghClient.GetUser(pr.Author)
-		if err != nil {
-			// If we can't fetch user info, continue with nil
-			user = nil
+	for i, scanResult := range scanned {
+		if errs[i] != nil {
+			results.Total++
+			results.Errors = append(results.Errors, &ScanError{Number: numbers[i], Err: errs[i]})
+			continue
 		}
-
-		scanResult := s.ScanPR(pr, user)
+		if scanResult == nil {
+			// Skipped as older than the --since cutoff or by another author
+			continue
+		}
+		results.Total++
 
 		//nolint:gocritic // if-else is more readable here than switch
 		if scanResult.IsSpam {
@@ -207,3 +680,184 @@ func (s *Scanner) ScanRepository(ghClient github.GitHubClient, owner, repo strin
 
 	return results, nil
 }
+
+// ScanPullRequest fetches and scans a single PR using the repository's
+// effective filters. It runs the same per-PR checks as ScanRepository; the
+// duplicate title check is skipped since it needs the other open PRs.
+func (s *Scanner) ScanPullRequest(ghClient github.GitHubClient, owner, repo string, number int) (*ScanResult, error) {
+	repoScanner := s.forRepository(owner, repo)
+	// The PR was asked for by number, so the since and author filters do not apply
+	repoScanner.since = time.Time{}
+	repoScanner.author = ""
+	if repository, err := ghClient.GetRepository(owner, repo); err == nil {
+		repoScanner.repository = repository
+	}
+
+	contributions := newContributionCache(ghClient, owner, repo)
+	members := newMembershipCache(ghClient, repoScanner.filters.TrustedOrgs)
+	profiles := newProfileCache(ghClient)
+	return repoScanner.scanPullRequest(ghClient, contributions, members, profiles, owner, repo, number)
+}
+
+// scanPullRequest fetches a single PR and its author and scans it
+func (s *Scanner) scanPullRequest(ghClient github.GitHubClient, contributions *contributionCache, members *membershipCache, profiles *profileCache, owner, repo string, number int) (*ScanResult, error) {
+	pr, err := ghClient.GetPullRequest(owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	// Listing may not honor the cutoff or author filter, so check again before fetching the author
+	if !s.since.IsZero() && pr.CreatedAt.Before(s.since) {
+		return nil, nil
+	}
+	if s.author != "" && !strings.EqualFold(pr.Author, s.author) {
+		return nil, nil
+	}
+
+	// Members of trusted organizations bypass spam detection like whitelisted authors
+	if s.isTrustedMember(pr, members) {
+		return &ScanResult{
+			PR:              pr,
+			Reasons:         []string{},
+			Signals:         []string{},
+			Severity:        "low",
+			RecommendAction: "No action needed",
+			trusted:         true,
+		}, nil
+	}
+
+	// Fetch user information
+	user, err := ghClient.GetUser(pr.Author)
+	if err != nil {
+		// If we can't fetch user info, continue with nil
+		user = nil
+	}
+
+	firstTime, checkedFirstTime := s.isFirstTimeContributor(pr, contributions)
+	result := s.scanPR(pr, user, firstTime)
+	if checkedFirstTime {
+		result.markChecked(SignalFirstTime)
+	}
+	if errors.Is(err, github.ErrUserNotFound) {
+		s.markAuthorGone(result)
+	} else {
+		if err == nil && !s.isWhitelisted(pr.Author) {
+			// The author is known to exist
+			result.markChecked(SignalAuthorGone)
+		}
+		s.checkHollowProfile(result, profiles)
+	}
+	s.checkPatchLinks(ghClient, owner, repo, result)
+	s.checkReopened(ghClient, owner, repo, result)
+	return result, nil
+}
+
+// markAuthorGone marks a PR whose author account was deleted or suspended as
+// spam. GitHub suspends accounts it has identified as abusive, so a missing
+// author usually means the account was already banned.
+func (s *Scanner) markAuthorGone(result *ScanResult) {
+	if s.isWhitelisted(result.PR.Author) {
+		return
+	}
+	result.markChecked(SignalAuthorGone)
+	result.IsSpam = true
+	result.addSignal(SignalAuthorGone, "Author account no longer exists")
+	if result.Severity == "low" {
+		result.Severity = "medium"
+	}
+	result.RecommendAction = "Block user and close PR"
+}
+
+// checkPatchLinks fetches the patches of a PR that other heuristics already
+// flagged and marks it as spam when an added line links to a URL matching a
+// spam regex. Clean PRs are skipped to avoid an extra API call for each one.
+func (s *Scanner) checkPatchLinks(ghClient github.GitHubClient, owner, repo string, result *ScanResult) {
+	if !s.filters.ScanPatchLinks || len(s.spamRegexes) == 0 || (!result.IsSpam && !result.IsUncertain) {
+		return
+	}
+
+	files, err := ghClient.ListPullRequestFiles(owner, repo, result.PR.Number)
+	if err != nil {
+		// Without patches the PR keeps the verdict of the other heuristics
+		return
+	}
+	result.markChecked(SignalSpamLink)
+
+	filename, line := s.spamLinkInPatches(files)
+	if line == "" {
+		return
+	}
+	result.IsSpam = true
+	result.addSignal(SignalSpamLink, fmt.Sprintf("Adds spam link in %s: %s", filename, shortenLine(line)))
+	result.Severity = "high"
+}
+
+// checkReopened marks a PR as high-severity spam when its author reopened it
+// after it was closed, an escalation after a maintainer rejected it. Reopens
+// by anyone else, such as a maintainer undoing a close, are not flagged.
+func (s *Scanner) checkReopened(ghClient github.GitHubClient, owner, repo string, result *ScanResult) {
+	if !s.filters.FlagReopened || s.isWhitelisted(result.PR.Author) {
+		return
+	}
+
+	events, err := ghClient.GetIssueEvents(owner, repo, result.PR.Number)
+	if err != nil {
+		// Without the timeline the PR keeps the verdict of the other heuristics
+		return
+	}
+	result.markChecked(SignalReopened)
+	if !isReopened(result.PR, events) {
+		return
+	}
+	result.IsSpam = true
+	result.addSignal(SignalReopened, "Reopened after being closed")
+	result.Severity = "high"
+	result.RecommendAction = "Block user and close PR"
+}
+
+// isReopened reports whether the PR author reopened the PR after it was closed
+func isReopened(pr *github.PullRequest, events []*github.IssueEvent) bool {
+	closed := false
+	for _, event := range events {
+		switch event.Event {
+		case "closed":
+			closed = true
+		case "reopened":
+			if closed && strings.EqualFold(event.Actor, pr.Author) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// spamLinkInPatches returns the file and first added line containing a URL
+// that matches a spam regex
+func (s *Scanner) spamLinkInPatches(files []*github.PullRequestFile) (filename, line string) {
+	for _, file := range files {
+		for _, patchLine := range strings.Split(file.Patch, "\n") {
+			added, ok := strings.CutPrefix(patchLine, "+")
+			if !ok || strings.HasPrefix(added, "++") {
+				continue
+			}
+			for _, url := range urlPattern.FindAllString(added, -1) {
+				for _, re := range s.spamRegexes {
+					if re.MatchString(url) {
+						return file.Filename, strings.TrimSpace(added)
+					}
+				}
+			}
+		}
+	}
+	return "", ""
+}
+
+// shortenLine collapses whitespace in line and cuts it to maxReasonLineLength runes
+func shortenLine(line string) string {
+	line = strings.Join(strings.Fields(line), " ")
+	runes := []rune(line)
+	if len(runes) <= maxReasonLineLength {
+		return line
+	}
+	return string(runes[:maxReasonLineLength-1]) + "…"
+}
@@ -0,0 +1,1078 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prguard/prguard/internal/config"
+	"github.com/prguard/prguard/internal/github"
+	"github.com/prguard/prguard/internal/mocks"
+	"github.com/prguard/prguard/internal/scanner"
+)
+
+func readmePRClient() *mocks.MockGitHubClient {
+	return &mocks.MockGitHubClient{
+		ListPullRequestNumbersFn: func(_, _ string) ([]int, error) {
+			return []int{1}, nil
+		},
+		GetPullRequestFn: func(_, _ string, number int) (*github.PullRequest, error) {
+			return &github.PullRequest{
+				Number:     number,
+				Title:      "Update README",
+				Author:     "contributor",
+				FilesCount: 1,
+				Files:      []string{"README.md"},
+				Additions:  20,
+			}, nil
+		},
+		GetUserFn: func(username string) (*github.User, error) {
+			return &github.User{
+				Login:     username,
+				CreatedAt: time.Now().Add(-365 * 24 * time.Hour),
+			}, nil
+		},
+	}
+}
+
+func hasReason(result *scanner.ScanResult, reason string) bool {
+	for _, r := range result.Reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func TestScanRepository_PerRepoFilterOverride(t *testing.T) {
+	readmeOnly := false
+	cfg := &config.Config{
+		Filters: config.FiltersConfig{
+			MinFiles:        2,
+			MinLines:        10,
+			AccountAgeDays:  7,
+			ReadmeOnlyBlock: true,
+		},
+		Repositories: []config.Repository{
+			{Owner: "org", Name: "docs", Filters: &config.RepositoryFilters{ReadmeOnlyBlock: &readmeOnly}},
+			{Owner: "org", Name: "code"},
+		},
+	}
+	cfg.SetDefaults()
+
+	s := scanner.NewScanner(cfg)
+	client := readmePRClient()
+
+	// Repo-level override suppresses the README-only reason
+	docsResults, err := s.ScanRepository(client, "org", "docs")
+	if err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+	if len(docsResults.Spam) != 0 {
+		t.Errorf("Expected no spam in org/docs, got %d", len(docsResults.Spam))
+	}
+	for _, result := range append(docsResults.Uncertain, docsResults.Clean...) {
+		if hasReason(result, "Single-file README-only edit") {
+			t.Errorf("README-only reason should be suppressed for org/docs: %v", result.Reasons)
+		}
+	}
+
+	// Global default still flags other repos
+	codeResults, err := s.ScanRepository(client, "org", "code")
+	if err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+	if len(codeResults.Spam) != 1 {
+		t.Fatalf("Expected 1 spam PR in org/code, got %d", len(codeResults.Spam))
+	}
+	if !hasReason(codeResults.Spam[0], "Single-file README-only edit") {
+		t.Errorf("Expected README-only reason for org/code, got %v", codeResults.Spam[0].Reasons)
+	}
+}
+
+func TestScanRepository_UsesCanonicalReadmePath(t *testing.T) {
+	cfg := &config.Config{
+		Filters: config.FiltersConfig{
+			MinFiles:        2,
+			MinLines:        10,
+			AccountAgeDays:  7,
+			ReadmeOnlyBlock: true,
+		},
+	}
+	cfg.SetDefaults()
+
+	tests := []struct {
+		name     string
+		repo     *github.Repository
+		repoErr  error
+		file     string
+		wantSpam bool
+	}{
+		{"canonical README", &github.Repository{DefaultBranch: "main", ReadmePath: "README.md"}, nil, "README.md", true},
+		{"other readme-named file", &github.Repository{DefaultBranch: "main", ReadmePath: "README.md"}, nil, "readme-ja.md", false},
+		{"README outside the root", &github.Repository{DefaultBranch: "main", ReadmePath: ".github/README.md"}, nil, ".github/README.md", true},
+		{"no README in the repository", &github.Repository{DefaultBranch: "main"}, nil, "readme-ja.md", true},
+		{"lookup failed", nil, errors.New("forbidden"), "readme-ja.md", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := readmePRClient()
+			getPR := client.GetPullRequestFn
+			client.GetPullRequestFn = func(owner, repo string, number int) (*github.PullRequest, error) {
+				pr, err := getPR(owner, repo, number)
+				pr.Files = []string{tt.file}
+				return pr, err
+			}
+			client.GetRepositoryFn = func(_, _ string) (*github.Repository, error) {
+				return tt.repo, tt.repoErr
+			}
+
+			results, err := scanner.NewScanner(cfg).ScanRepository(client, "org", "repo")
+			if err != nil {
+				t.Fatalf("ScanRepository failed: %v", err)
+			}
+			if got := len(results.Spam) == 1; got != tt.wantSpam {
+				t.Errorf("Expected spam %v for %s, got spam %d uncertain %d clean %d",
+					tt.wantSpam, tt.file, len(results.Spam), len(results.Uncertain), len(results.Clean))
+			}
+		})
+	}
+}
+
+func TestScanRepository_Concurrency(t *testing.T) {
+	const numPRs = 50
+
+	for _, concurrency := range []int{1, 8} {
+		t.Run(fmt.Sprintf("concurrency=%d", concurrency), func(t *testing.T) {
+			cfg := &config.Config{
+				Filters: config.FiltersConfig{
+					ReadmeOnlyBlock: true,
+					Concurrency:     concurrency,
+				},
+			}
+			cfg.SetDefaults()
+
+			var fetched int32
+			client := &mocks.MockGitHubClient{
+				ListPullRequestNumbersFn: func(_, _ string) ([]int, error) {
+					numbers := make([]int, numPRs)
+					for i := range numbers {
+						numbers[i] = i + 1
+					}
+					return numbers, nil
+				},
+				GetPullRequestFn: func(_, _ string, number int) (*github.PullRequest, error) {
+					atomic.AddInt32(&fetched, 1)
+					if number%10 == 0 {
+						return nil, fmt.Errorf("not found")
+					}
+					pr := &github.PullRequest{
+						Number:     number,
+						Title:      "Add feature",
+						Author:     fmt.Sprintf("user%d", number),
+						FilesCount: 5,
+						Files:      []string{"a.go", "b.go", "c.go", "d.go", "e.go"},
+						Additions:  100,
+					}
+					if number%2 == 1 {
+						pr.FilesCount = 1
+						pr.Files = []string{"README.md"}
+					}
+					return pr, nil
+				},
+				GetUserFn: func(username string) (*github.User, error) {
+					return &github.User{Login: username, CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}, nil
+				},
+			}
+
+			results, err := scanner.NewScanner(cfg).ScanRepository(client, "org", "repo")
+			if err != nil {
+				t.Fatalf("ScanRepository failed: %v", err)
+			}
+
+			if fetched != numPRs {
+				t.Errorf("Expected %d PRs fetched, got %d", numPRs, fetched)
+			}
+			if results.Total != numPRs {
+				t.Errorf("Expected total %d, got %d", numPRs, results.Total)
+			}
+			if len(results.Errors) != 5 {
+				t.Errorf("Expected 5 errors, got %d", len(results.Errors))
+			}
+			if len(results.Spam) != 25 {
+				t.Errorf("Expected 25 spam PRs, got %d", len(results.Spam))
+			}
+			if len(results.Clean) != 20 {
+				t.Errorf("Expected 20 clean PRs, got %d", len(results.Clean))
+			}
+
+			// Partitions preserve listing order regardless of completion order
+			for i := 1; i < len(results.Spam); i++ {
+				if results.Spam[i-1].PR.Number > results.Spam[i].PR.Number {
+					t.Fatalf("Spam results out of order: #%d before #%d", results.Spam[i-1].PR.Number, results.Spam[i].PR.Number)
+				}
+			}
+			for i := 1; i < len(results.Errors); i++ {
+				if results.Errors[i-1].Number > results.Errors[i].Number {
+					t.Fatalf("Errors out of order: #%d before #%d", results.Errors[i-1].Number, results.Errors[i].Number)
+				}
+			}
+		})
+	}
+}
+
+func TestScanRepository_DuplicateTitleCluster(t *testing.T) {
+	titles := map[int]string{
+		1: "Update README",
+		2: "Update Readme!",
+		3: "update readme",
+		4: "Update README.md",
+		5: "Update READMEs",
+		6: "Add retry support to the HTTP client",
+	}
+
+	cfg := &config.Config{
+		Filters: config.FiltersConfig{
+			MinDuplicateTitles: 3,
+		},
+	}
+	cfg.SetDefaults()
+
+	client := &mocks.MockGitHubClient{
+		ListPullRequestNumbersFn: func(_, _ string) ([]int, error) {
+			return []int{1, 2, 3, 4, 5, 6}, nil
+		},
+		GetPullRequestFn: func(_, _ string, number int) (*github.PullRequest, error) {
+			return &github.PullRequest{
+				Number:     number,
+				Title:      titles[number],
+				Author:     fmt.Sprintf("user%d", number),
+				FilesCount: 3,
+				Files:      []string{"README.md", "docs/a.md", "docs/b.md"},
+				Additions:  50,
+			}, nil
+		},
+		GetUserFn: func(username string) (*github.User, error) {
+			return &github.User{Login: username, CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}, nil
+		},
+	}
+
+	results, err := scanner.NewScanner(cfg).ScanRepository(client, "org", "repo")
+	if err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+
+	if len(results.Spam) != 5 {
+		t.Fatalf("Expected 5 duplicate-title PRs flagged as spam, got %d", len(results.Spam))
+	}
+	for _, result := range results.Spam {
+		if !hasReason(result, "Part of a duplicate-title cluster (5 PRs)") {
+			t.Errorf("PR #%d missing cluster reason: %v", result.PR.Number, result.Reasons)
+		}
+	}
+	if len(results.Clean) != 1 || results.Clean[0].PR.Number != 6 {
+		t.Errorf("Expected PR #6 to stay clean, got %d clean", len(results.Clean))
+	}
+}
+
+func sinceTestClient(created map[int]time.Time, fetched *sync.Map) *mocks.MockGitHubClient {
+	return &mocks.MockGitHubClient{
+		ListPullRequestNumbersFn: func(_, _ string) ([]int, error) {
+			return []int{1, 2, 3, 4}, nil
+		},
+		GetPullRequestFn: func(_, _ string, number int) (*github.PullRequest, error) {
+			return &github.PullRequest{
+				Number:     number,
+				Title:      fmt.Sprintf("Feature %d", number),
+				Author:     fmt.Sprintf("user%d", number),
+				CreatedAt:  created[number],
+				FilesCount: 3,
+				Files:      []string{"a.go", "b.go", "c.go"},
+				Additions:  50,
+			}, nil
+		},
+		GetUserFn: func(username string) (*github.User, error) {
+			fetched.Store(username, true)
+			return &github.User{Login: username, CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}, nil
+		},
+	}
+}
+
+func TestScanRepository_Since(t *testing.T) {
+	now := time.Now()
+	created := map[int]time.Time{
+		1: now.Add(-1 * time.Hour),
+		2: now.Add(-3 * 24 * time.Hour),
+		3: now.Add(-30 * 24 * time.Hour),
+		4: now.Add(-365 * 24 * time.Hour),
+	}
+
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+
+	var fetched sync.Map
+	s := scanner.NewScanner(cfg)
+	s.SetSince(now.Add(-7 * 24 * time.Hour))
+
+	results, err := s.ScanRepository(sinceTestClient(created, &fetched), "org", "repo")
+	if err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+
+	if results.Total != 2 {
+		t.Errorf("Expected 2 PRs scanned, got %d", results.Total)
+	}
+	for _, result := range results.Clean {
+		if result.PR.Number != 1 && result.PR.Number != 2 {
+			t.Errorf("PR #%d is older than the cutoff but was scanned", result.PR.Number)
+		}
+	}
+	for _, old := range []string{"user3", "user4"} {
+		if _, ok := fetched.Load(old); ok {
+			t.Errorf("Author %s of an old PR should not be fetched", old)
+		}
+	}
+}
+
+func TestScanRepository_SincePassedToClient(t *testing.T) {
+	cutoff := time.Now().Add(-48 * time.Hour)
+
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+
+	var gotSince time.Time
+	var details atomic.Int32
+	client := &mocks.MockGitHubClient{
+		ListPullRequestNumbersSinceFn: func(_, _ string, since time.Time) ([]int, error) {
+			gotSince = since
+			return []int{7}, nil
+		},
+		GetPullRequestFn: func(_, _ string, number int) (*github.PullRequest, error) {
+			details.Add(1)
+			return &github.PullRequest{Number: number, Author: "someone", CreatedAt: time.Now(), FilesCount: 3, Additions: 50}, nil
+		},
+		GetUserFn: func(username string) (*github.User, error) {
+			return &github.User{Login: username, CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}, nil
+		},
+	}
+
+	s := scanner.NewScanner(cfg)
+	s.SetSince(cutoff)
+	results, err := s.ScanRepository(client, "org", "repo")
+	if err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+
+	if !gotSince.Equal(cutoff) {
+		t.Errorf("Expected cutoff %v passed to client, got %v", cutoff, gotSince)
+	}
+	if details.Load() != 1 || results.Total != 1 {
+		t.Errorf("Expected only listed PR to be fetched, got %d fetches and total %d", details.Load(), results.Total)
+	}
+}
+
+func TestScanRepository_FirstTimeContributor(t *testing.T) {
+	// PRs 1 and 2 share an author to exercise the per-scan cache
+	authors := map[int]string{1: "newcomer", 2: "newcomer", 3: "regular", 4: "maintainer"}
+	prior := map[string]bool{"newcomer": false, "regular": true, "maintainer": false}
+
+	cfg := &config.Config{
+		Filters: config.FiltersConfig{
+			FirstTimeContributors: true,
+			Whitelist:             []string{"maintainer"},
+			Concurrency:           4,
+		},
+	}
+	cfg.SetDefaults()
+
+	var lookups sync.Map
+	var calls atomic.Int32
+	client := &mocks.MockGitHubClient{
+		ListPullRequestNumbersFn: func(_, _ string) ([]int, error) {
+			return []int{1, 2, 3, 4}, nil
+		},
+		GetPullRequestFn: func(_, _ string, number int) (*github.PullRequest, error) {
+			return &github.PullRequest{
+				Number:     number,
+				Title:      fmt.Sprintf("Feature %d", number),
+				Author:     authors[number],
+				FilesCount: 3,
+				Files:      []string{"a.go", "b.go", "c.go"},
+				Additions:  50,
+			}, nil
+		},
+		GetUserFn: func(username string) (*github.User, error) {
+			return &github.User{Login: username, CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}, nil
+		},
+		HasPriorContributionFn: func(owner, repo, username string) (bool, error) {
+			if owner != "org" || repo != "repo" {
+				t.Errorf("unexpected repository %s/%s", owner, repo)
+			}
+			calls.Add(1)
+			if _, loaded := lookups.LoadOrStore(username, true); loaded {
+				t.Errorf("prior contribution for %s looked up more than once", username)
+			}
+			return prior[username], nil
+		},
+	}
+
+	results, err := scanner.NewScanner(cfg).ScanRepository(client, "org", "repo")
+	if err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+
+	if len(results.Uncertain) != 2 {
+		t.Fatalf("Expected 2 uncertain PRs from the first-time contributor, got %d", len(results.Uncertain))
+	}
+	for _, result := range results.Uncertain {
+		if result.PR.Author != "newcomer" {
+			t.Errorf("PR #%d by %s should not be uncertain", result.PR.Number, result.PR.Author)
+		}
+		if !hasReason(result, "First-time contributor (no prior commits to the repository)") {
+			t.Errorf("PR #%d missing first-time reason: %v", result.PR.Number, result.Reasons)
+		}
+	}
+	if len(results.Clean) != 2 {
+		t.Errorf("Expected existing and whitelisted contributors to stay clean, got %d clean", len(results.Clean))
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 lookups (newcomer, regular), got %d", calls.Load())
+	}
+}
+
+func TestScanRepository_FirstTimeContributorDisabled(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+
+	client := readmePRClient()
+	client.HasPriorContributionFn = func(_, _, _ string) (bool, error) {
+		t.Error("prior contribution should not be looked up when the filter is disabled")
+		return false, nil
+	}
+
+	if _, err := scanner.NewScanner(cfg).ScanRepository(client, "org", "repo"); err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+}
+
+func TestScanRepository_FirstTimeContributorLookupError(t *testing.T) {
+	cfg := &config.Config{Filters: config.FiltersConfig{FirstTimeContributors: true, MinFiles: 1, MinLines: 1}}
+	cfg.SetDefaults()
+
+	client := readmePRClient()
+	client.GetPullRequestFn = func(_, _ string, number int) (*github.PullRequest, error) {
+		return &github.PullRequest{Number: number, Author: "someone", FilesCount: 2, Files: []string{"a.go", "b.go"}, Additions: 20}, nil
+	}
+	client.HasPriorContributionFn = func(_, _, _ string) (bool, error) {
+		return false, fmt.Errorf("rate limited")
+	}
+
+	results, err := scanner.NewScanner(cfg).ScanRepository(client, "org", "repo")
+	if err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+	if len(results.Clean) != 1 {
+		t.Errorf("Expected lookup errors not to flag the PR, got %d clean", len(results.Clean))
+	}
+}
+
+func TestScanRepository_TrustedOrgMembers(t *testing.T) {
+	// PRs 1 and 2 share an author to exercise the per-scan cache
+	authors := map[int]string{1: "member", 2: "member", 3: "outsider"}
+
+	cfg := &config.Config{Filters: config.FiltersConfig{TrustedOrgs: []string{"acme"}, Concurrency: 3}}
+	cfg.SetDefaults()
+
+	var calls sync.Map
+	client := readmePRClient()
+	client.ListPullRequestNumbersFn = func(_, _ string) ([]int, error) {
+		return []int{1, 2, 3}, nil
+	}
+	client.GetPullRequestFn = func(_, _ string, number int) (*github.PullRequest, error) {
+		return &github.PullRequest{
+			Number:     number,
+			Title:      "Update README",
+			Author:     authors[number],
+			FilesCount: 1,
+			Files:      []string{"README.md"},
+			Additions:  20,
+		}, nil
+	}
+	client.IsOrgMemberFn = func(org, username string) (bool, error) {
+		if org != "acme" {
+			t.Errorf("unexpected organization %s", org)
+		}
+		if _, loaded := calls.LoadOrStore(username, true); loaded {
+			t.Errorf("membership for %s looked up more than once", username)
+		}
+		return username == "member", nil
+	}
+	client.GetUserFn = func(username string) (*github.User, error) {
+		if username == "member" {
+			t.Error("trusted members should not be looked up")
+		}
+		return &github.User{Login: username, CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}, nil
+	}
+
+	results, err := scanner.NewScanner(cfg).ScanRepository(client, "org", "repo")
+	if err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+
+	if len(results.Clean) != 2 {
+		t.Errorf("Expected both PRs from the trusted member to be clean, got %d", len(results.Clean))
+	}
+	for _, result := range results.Clean {
+		if result.PR.Author != "member" {
+			t.Errorf("PR #%d by %s should not be clean", result.PR.Number, result.PR.Author)
+		}
+	}
+	if len(results.Spam)+len(results.Uncertain) != 1 {
+		t.Errorf("Expected the outsider's PR to be flagged, got %d spam and %d uncertain", len(results.Spam), len(results.Uncertain))
+	}
+}
+
+func TestScanRepository_TrustedOrgLookupError(t *testing.T) {
+	cfg := &config.Config{Filters: config.FiltersConfig{TrustedOrgs: []string{"acme"}}}
+	cfg.SetDefaults()
+
+	client := readmePRClient()
+	client.IsOrgMemberFn = func(_, _ string) (bool, error) {
+		return false, fmt.Errorf("rate limited")
+	}
+
+	results, err := scanner.NewScanner(cfg).ScanRepository(client, "org", "repo")
+	if err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+	if len(results.Clean) != 0 {
+		t.Errorf("Expected lookup errors not to trust the author, got %d clean", len(results.Clean))
+	}
+}
+
+func TestScanRepository_CancelledContextAborts(t *testing.T) {
+	client := readmePRClient()
+	client.GetPullRequestFn = func(_, _ string, _ int) (*github.PullRequest, error) {
+		return nil, fmt.Errorf("failed to get pull request: %w", context.Canceled)
+	}
+
+	s := scanner.NewScanner(&config.Config{})
+	if _, err := s.ScanRepository(client, "org", "repo"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestScanRepository_ListFailsOnLastPage(t *testing.T) {
+	client := readmePRClient()
+	listErr := errors.New("failed to list pull requests: 502 Bad Gateway")
+	client.ListPullRequestNumbersFn = func(_, _ string) ([]int, error) {
+		// PRs from the pages listed before the failure come back with the error
+		return []int{1, 2}, listErr
+	}
+
+	s := scanner.NewScanner(&config.Config{})
+	results, err := s.ScanRepository(client, "org", "repo")
+	if err != nil {
+		t.Fatalf("Expected the listed PRs to be scanned, got error %v", err)
+	}
+	if results.Total != 2 || len(results.Spam)+len(results.Uncertain)+len(results.Clean) != 2 {
+		t.Errorf("Expected both listed PRs scanned, got %+v", results)
+	}
+	if len(results.Errors) != 1 || !errors.Is(results.Errors[0].Err, listErr) || results.Errors[0].Number != 0 {
+		t.Fatalf("Expected the listing error recorded, got %v", results.Errors)
+	}
+	if got := results.Errors[0].Error(); got != "listing PRs: "+listErr.Error() {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestScanRepository_ListFailsOnFirstPage(t *testing.T) {
+	client := readmePRClient()
+	client.ListPullRequestNumbersFn = func(_, _ string) ([]int, error) {
+		return nil, errors.New("failed to list pull requests: 502 Bad Gateway")
+	}
+
+	s := scanner.NewScanner(&config.Config{})
+	if _, err := s.ScanRepository(client, "org", "repo"); err == nil {
+		t.Error("Expected an error when no PRs could be listed")
+	}
+}
+
+func TestScanRepository_Author(t *testing.T) {
+	authors := map[int]string{1: "target", 2: "someone", 3: "Target"}
+
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+
+	var gotAuthor string
+	var fetched sync.Map
+	client := &mocks.MockGitHubClient{
+		ListPullRequestNumbersFn: func(_, _ string) ([]int, error) {
+			t.Error("ListPullRequestNumbers should not be called when filtering by author")
+			return nil, nil
+		},
+		// Return every PR to exercise the post-fetch author guard
+		ListPullRequestNumbersByAuthorFn: func(_, _, author string, _ time.Time) ([]int, error) {
+			gotAuthor = author
+			return []int{1, 2, 3}, nil
+		},
+		GetPullRequestFn: func(_, _ string, number int) (*github.PullRequest, error) {
+			return &github.PullRequest{Number: number, Author: authors[number], CreatedAt: time.Now(), FilesCount: 3, Additions: 50}, nil
+		},
+		GetUserFn: func(username string) (*github.User, error) {
+			fetched.Store(username, true)
+			return &github.User{Login: username, CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}, nil
+		},
+	}
+
+	s := scanner.NewScanner(cfg)
+	s.SetAuthor("target")
+
+	results, err := s.ScanRepository(client, "org", "repo")
+	if err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+	if gotAuthor != "target" {
+		t.Errorf("Expected author %q passed to client, got %q", "target", gotAuthor)
+	}
+	if results.Total != 2 {
+		t.Errorf("Expected 2 PRs scanned, got %d", results.Total)
+	}
+	if _, ok := fetched.Load("someone"); ok {
+		t.Error("Author of another user's PR should not be fetched")
+	}
+}
+
+func patchLinkClient(patch string, fetched *int32) *mocks.MockGitHubClient {
+	client := readmePRClient()
+	client.ListPullRequestFilesFn = func(_, _ string, number int) ([]*github.PullRequestFile, error) {
+		atomic.AddInt32(fetched, 1)
+		if number != 1 {
+			return nil, fmt.Errorf("unexpected PR #%d", number)
+		}
+		return []*github.PullRequestFile{{Filename: "README.md", Patch: patch}}, nil
+	}
+	return client
+}
+
+func TestScanRepository_SpamLinkInPatch(t *testing.T) {
+	cfg := &config.Config{
+		Filters: config.FiltersConfig{
+			MinFiles:       2,
+			MinLines:       10,
+			AccountAgeDays: 7,
+			SpamRegexes:    []string{`(?i)casino`},
+			ScanPatchLinks: true,
+		},
+	}
+	cfg.SetDefaults()
+
+	patch := "@@ -1,2 +1,3 @@\n # Project\n-Old link https://casino.example.com/old\n+Visit https://best-casino.example.com/promo for bonuses\n"
+	var fetched int32
+	results, err := scanner.NewScanner(cfg).ScanRepository(patchLinkClient(patch, &fetched), "org", "repo")
+	if err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+	if fetched != 1 {
+		t.Errorf("Expected the flagged PR's files to be fetched once, got %d", fetched)
+	}
+	if len(results.Spam) != 1 {
+		t.Fatalf("Expected the PR to be spam, got spam %d uncertain %d", len(results.Spam), len(results.Uncertain))
+	}
+	want := "Adds spam link in README.md: Visit https://best-casino.example.com/promo for bonuses"
+	if !hasReason(results.Spam[0], want) {
+		t.Errorf("Expected reason %q, got %v", want, results.Spam[0].Reasons)
+	}
+	if results.Spam[0].Severity != "high" {
+		t.Errorf("Expected high severity, got %s", results.Spam[0].Severity)
+	}
+}
+
+func TestScanRepository_SpamLinkInPatch_IgnoresRemovedLines(t *testing.T) {
+	cfg := &config.Config{
+		Filters: config.FiltersConfig{
+			MinFiles:       2,
+			MinLines:       10,
+			AccountAgeDays: 7,
+			SpamRegexes:    []string{`(?i)casino`},
+			ScanPatchLinks: true,
+		},
+	}
+	cfg.SetDefaults()
+
+	patch := "@@ -1,2 +1,2 @@\n-See https://casino.example.com\n+See https://example.org/docs\n"
+	var fetched int32
+	results, err := scanner.NewScanner(cfg).ScanRepository(patchLinkClient(patch, &fetched), "org", "repo")
+	if err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+	for _, result := range append(results.Spam, results.Uncertain...) {
+		for _, signal := range result.Signals {
+			if signal == scanner.SignalSpamLink {
+				t.Errorf("Expected removed links to be ignored, got %v", result.Reasons)
+			}
+		}
+	}
+}
+
+func TestScanRepository_SpamLinkInPatch_Disabled(t *testing.T) {
+	cfg := &config.Config{
+		Filters: config.FiltersConfig{
+			MinFiles:       2,
+			MinLines:       10,
+			AccountAgeDays: 7,
+			SpamRegexes:    []string{`(?i)casino`},
+		},
+	}
+	cfg.SetDefaults()
+
+	var fetched int32
+	if _, err := scanner.NewScanner(cfg).ScanRepository(patchLinkClient("+https://casino.example.com\n", &fetched), "org", "repo"); err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+	if fetched != 0 {
+		t.Errorf("Expected no file fetches with scan_patch_links off, got %d", fetched)
+	}
+}
+
+// reopenedClient returns a client for one clean-looking PR by "author" whose
+// timeline holds events
+func reopenedClient(events []*github.IssueEvent) *mocks.MockGitHubClient {
+	client := readmePRClient()
+	client.GetPullRequestFn = func(_, _ string, number int) (*github.PullRequest, error) {
+		return &github.PullRequest{
+			Number:     number,
+			Title:      "Add feature",
+			Author:     "author",
+			FilesCount: 3,
+			Files:      []string{"a.go", "b.go", "c.go"},
+			Additions:  50,
+		}, nil
+	}
+	client.GetIssueEventsFn = func(owner, repo string, number int) ([]*github.IssueEvent, error) {
+		if owner != "org" || repo != "repo" || number != 1 {
+			return nil, fmt.Errorf("unexpected PR %s/%s#%d", owner, repo, number)
+		}
+		return events, nil
+	}
+	return client
+}
+
+func TestScanRepository_Reopened(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		events   []*github.IssueEvent
+		wantSpam bool
+	}{
+		{
+			name: "author reopened after close",
+			events: []*github.IssueEvent{
+				{Event: "closed", Actor: "maintainer", CreatedAt: now.Add(-2 * time.Hour)},
+				{Event: "reopened", Actor: "Author", CreatedAt: now.Add(-time.Hour)},
+			},
+			wantSpam: true,
+		},
+		{
+			name: "maintainer reopened after close",
+			events: []*github.IssueEvent{
+				{Event: "closed", Actor: "maintainer", CreatedAt: now.Add(-2 * time.Hour)},
+				{Event: "reopened", Actor: "maintainer", CreatedAt: now.Add(-time.Hour)},
+			},
+		},
+		{
+			name:   "never closed",
+			events: []*github.IssueEvent{{Event: "labeled", Actor: "maintainer", CreatedAt: now}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Filters: config.FiltersConfig{FlagReopened: true}}
+			cfg.SetDefaults()
+
+			results, err := scanner.NewScanner(cfg).ScanRepository(reopenedClient(tt.events), "org", "repo")
+			if err != nil {
+				t.Fatalf("ScanRepository failed: %v", err)
+			}
+
+			if !tt.wantSpam {
+				if len(results.Clean) != 1 {
+					t.Errorf("Expected the PR to stay clean, got %d spam and %d uncertain", len(results.Spam), len(results.Uncertain))
+				}
+				return
+			}
+			if len(results.Spam) != 1 {
+				t.Fatalf("Expected the reopened PR to be spam, got %d spam", len(results.Spam))
+			}
+			result := results.Spam[0]
+			if result.Severity != "high" {
+				t.Errorf("Expected high severity, got %s", result.Severity)
+			}
+			if !hasReason(result, "Reopened after being closed") {
+				t.Errorf("Expected reopened reason, got %v", result.Reasons)
+			}
+		})
+	}
+}
+
+func TestScanRepository_ReopenedDisabled(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+
+	client := reopenedClient(nil)
+	client.GetIssueEventsFn = func(_, _ string, _ int) ([]*github.IssueEvent, error) {
+		t.Error("events should not be fetched when flag_reopened is off")
+		return nil, nil
+	}
+
+	if _, err := scanner.NewScanner(cfg).ScanRepository(client, "org", "repo"); err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+}
+
+func TestScanRepository_AuthorGone(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantGone bool
+	}{
+		{name: "deleted or suspended", err: fmt.Errorf("failed to get user contributor: %w", github.ErrUserNotFound), wantGone: true},
+		{name: "transient failure", err: fmt.Errorf("failed to get user: rate limited")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.SetDefaults()
+
+			client := readmePRClient()
+			client.GetUserFn = func(_ string) (*github.User, error) {
+				return nil, tt.err
+			}
+
+			results, err := scanner.NewScanner(cfg).ScanRepository(client, "org", "repo")
+			if err != nil {
+				t.Fatalf("ScanRepository failed: %v", err)
+			}
+
+			if !tt.wantGone {
+				for _, result := range append(results.Uncertain, results.Clean...) {
+					if hasReason(result, "Author account no longer exists") {
+						t.Errorf("Expected only a missing account to be flagged, got %v", result.Reasons)
+					}
+				}
+				if len(results.Spam) != 0 {
+					t.Errorf("Expected a lookup failure not to mark spam, got %d spam", len(results.Spam))
+				}
+				return
+			}
+			if len(results.Spam) != 1 {
+				t.Fatalf("Expected the PR to be spam, got %d spam", len(results.Spam))
+			}
+			result := results.Spam[0]
+			if !hasReason(result, "Author account no longer exists") {
+				t.Errorf("Expected missing account reason, got %v", result.Reasons)
+			}
+			if result.Severity != "medium" {
+				t.Errorf("Expected medium severity, got %s", result.Severity)
+			}
+		})
+	}
+}
+
+func TestScanPullRequest(t *testing.T) {
+	cfg := &config.Config{Filters: config.FiltersConfig{ReadmeOnlyBlock: true}}
+	cfg.SetDefaults()
+
+	client := readmePRClient()
+	client.ListPullRequestNumbersFn = func(_, _ string) ([]int, error) {
+		t.Error("a single PR scan should not list the repository's PRs")
+		return nil, nil
+	}
+
+	s := scanner.NewScanner(cfg)
+	// Filters meant for repository scans do not hide a PR asked for by number
+	s.SetSince(time.Now())
+	s.SetAuthor("someone-else")
+
+	result, err := s.ScanPullRequest(client, "org", "repo", 7)
+	if err != nil {
+		t.Fatalf("ScanPullRequest failed: %v", err)
+	}
+	if result == nil || result.PR.Number != 7 {
+		t.Fatalf("Expected a result for PR #7, got %+v", result)
+	}
+	if !result.IsSpam {
+		t.Errorf("Expected the README-only PR to be spam, got reasons %v", result.Reasons)
+	}
+}
+
+func TestScanPullRequest_ChecksOmitSkippedHeuristics(t *testing.T) {
+	cfg := &config.Config{Filters: config.FiltersConfig{FlagReopened: true, MinDuplicateTitles: 2, FirstTimeContributors: true}}
+	cfg.SetDefaults()
+
+	client := readmePRClient()
+	client.GetIssueEventsFn = func(_, _ string, _ int) ([]*github.IssueEvent, error) {
+		return nil, errors.New("timeline unavailable")
+	}
+	client.HasPriorContributionFn = func(_, _, _ string) (bool, error) {
+		return true, nil
+	}
+
+	result, err := scanner.NewScanner(cfg).ScanPullRequest(client, "org", "repo", 7)
+	if err != nil {
+		t.Fatalf("ScanPullRequest failed: %v", err)
+	}
+
+	checks := result.Checks()
+	for _, want := range []string{scanner.SignalFirstTime, scanner.SignalAuthorGone} {
+		if !slices.Contains(checks, want) {
+			t.Errorf("Expected %s to be checked, got %v", want, checks)
+		}
+	}
+	// The timeline could not be fetched and duplicates need the other open PRs
+	for _, skipped := range []string{scanner.SignalReopened, scanner.SignalDuplicateTitles} {
+		if slices.Contains(checks, skipped) {
+			t.Errorf("Expected %s not to be checked, got %v", skipped, checks)
+		}
+	}
+}
+
+func TestScanRepository_HollowProfile(t *testing.T) {
+	tests := []struct {
+		name       string
+		repos      []*github.UserRepository
+		err        error
+		wantHollow bool
+		wantReason string
+	}{
+		{
+			name: "all forked",
+			repos: []*github.UserRepository{
+				{Name: "react", Fork: true, Size: 5000},
+				{Name: "awesome-list", Fork: true, Size: 300},
+				{Name: "first-contributions", Fork: true, Size: 80},
+			},
+			wantHollow: true,
+			wantReason: "Profile has little original work (0 of 3 repositories are not forks or empty)",
+		},
+		{
+			name: "forks and empty repos",
+			repos: []*github.UserRepository{
+				{Name: "react", Fork: true, Size: 5000},
+				{Name: "my-project", Size: 0},
+			},
+			wantHollow: true,
+			wantReason: "Profile has little original work (0 of 2 repositories are not forks or empty)",
+		},
+		{
+			name: "mixed",
+			repos: []*github.UserRepository{
+				{Name: "react", Fork: true, Size: 5000},
+				{Name: "dotfiles", Size: 40},
+				{Name: "tool", Size: 900},
+			},
+		},
+		{name: "no repositories"},
+		{name: "lookup error", err: errors.New("rate limited")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Filters: config.FiltersConfig{MinOriginalRepoRatio: 0.5}}
+			cfg.SetDefaults()
+
+			client := reopenedClient(nil)
+			client.ListUserRepositoriesFn = func(username string) ([]*github.UserRepository, error) {
+				if username != "author" {
+					return nil, fmt.Errorf("unexpected user %s", username)
+				}
+				return tt.repos, tt.err
+			}
+
+			results, err := scanner.NewScanner(cfg).ScanRepository(client, "org", "repo")
+			if err != nil {
+				t.Fatalf("ScanRepository failed: %v", err)
+			}
+
+			if !tt.wantHollow {
+				if len(results.Clean) != 1 {
+					t.Errorf("Expected the PR to stay clean, got %d spam and %d uncertain", len(results.Spam), len(results.Uncertain))
+				}
+				return
+			}
+			if len(results.Uncertain) != 1 {
+				t.Fatalf("Expected the PR to be marked for review, got %d uncertain", len(results.Uncertain))
+			}
+			result := results.Uncertain[0]
+			if !hasReason(result, tt.wantReason) {
+				t.Errorf("Expected hollow profile reason %q, got %v", tt.wantReason, result.Reasons)
+			}
+			if result.RecommendAction != "Manual review recommended" {
+				t.Errorf("Expected manual review, got %s", result.RecommendAction)
+			}
+		})
+	}
+}
+
+func TestScanRepository_HollowProfileCachedPerScan(t *testing.T) {
+	cfg := &config.Config{Filters: config.FiltersConfig{MinOriginalRepoRatio: 0.5}}
+	cfg.SetDefaults()
+
+	client := reopenedClient(nil)
+	client.ListPullRequestNumbersFn = func(_, _ string) ([]int, error) {
+		return []int{1, 2, 3}, nil
+	}
+	var lookups atomic.Int32
+	client.ListUserRepositoriesFn = func(_ string) ([]*github.UserRepository, error) {
+		lookups.Add(1)
+		return []*github.UserRepository{{Name: "react", Fork: true, Size: 5000}}, nil
+	}
+
+	results, err := scanner.NewScanner(cfg).ScanRepository(client, "org", "repo")
+	if err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+	if len(results.Uncertain) != 3 {
+		t.Errorf("Expected all 3 PRs to be marked for review, got %d", len(results.Uncertain))
+	}
+	if got := lookups.Load(); got != 1 {
+		t.Errorf("Expected one repository lookup for the author, got %d", got)
+	}
+}
+
+func TestScanRepository_HollowProfileDisabled(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+
+	client := reopenedClient(nil)
+	client.ListUserRepositoriesFn = func(_ string) ([]*github.UserRepository, error) {
+		t.Error("repositories should not be listed when min_original_repo_ratio is 0")
+		return nil, nil
+	}
+
+	if _, err := scanner.NewScanner(cfg).ScanRepository(client, "org", "repo"); err != nil {
+		t.Fatalf("ScanRepository failed: %v", err)
+	}
+}
@@ -15,6 +15,7 @@
 package scanner
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -314,6 +315,37 @@ func TestIsWhitelisted(t *testing.T) {
 	}
 }
 
+func TestIsWhitelisted_Patterns(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.Filters.Whitelist = []string{"*[bot]", "trusted-user", "acme-?"}
+	scanner := NewScanner(cfg)
+
+	tests := []struct {
+		name     string
+		username string
+		expected bool
+	}{
+		{"Bot glob matches dependabot", "dependabot[bot]", true},
+		{"Bot glob matches renovate", "renovate[bot]", true},
+		{"Literal username matches", "trusted-user", true},
+		{"Literal username ignores case", "Trusted-User", true},
+		{"Glob ignores case", "Dependabot[BOT]", true},
+		{"Question mark matches one character", "acme-1", true},
+		{"Brackets are not a character class", "robot", false},
+		{"Literal does not match prefix", "trusted-user2", false},
+		{"Question mark does not match two characters", "acme-12", false},
+		{"Unlisted user", "regular-user", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := scanner.isWhitelisted(tt.username); result != tt.expected {
+				t.Errorf("isWhitelisted(%q) = %v, want %v", tt.username, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestScanPR_DefiniteSpam(t *testing.T) {
 	scanner := NewScanner(getTestConfig())
 
@@ -474,3 +506,598 @@ func TestScanPR_UncertainCases(t *testing.T) {
 		})
 	}
 }
+
+func TestNewScannerE_InvalidRegex(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.Filters.SpamRegexes = []string{"(unclosed"}
+
+	if _, err := NewScannerE(cfg); err == nil {
+		t.Error("Expected error for invalid spam regex")
+	}
+}
+
+func TestMatchesSpamRegex(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.Filters.SpamRegexes = []string{`(?i)https?://\S+\.(ru|tk)`, `(?i)c\s*l\s*i\s*c\s*k\s+h\s*e\s*r\s*e`}
+	scanner, err := NewScannerE(cfg)
+	if err != nil {
+		t.Fatalf("NewScannerE failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		pr       *github.PullRequest
+		expected bool
+	}{
+		{
+			name: "Crypto link not caught by phrases",
+			pr: &github.PullRequest{
+				Title: "Improve docs",
+				Body:  "Get free coins at https://crypto-airdrop.tk/claim",
+			},
+			expected: true,
+		},
+		{
+			name: "Obfuscated phrase",
+			pr: &github.PullRequest{
+				Title: "c l i c k   h e r e",
+			},
+			expected: true,
+		},
+		{
+			name: "Legitimate link",
+			pr: &github.PullRequest{
+				Title: "Fix typo",
+				Body:  "See https://go.dev/doc for details",
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := scanner.matchesSpamRegex(tt.pr)
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	// The crypto-link PR slips past plain substring matching but is flagged by ScanPR
+	crypto := tests[0].pr
+	if scanner.containsSpamPhrases(crypto) {
+		t.Error("Expected substring spam phrases not to match crypto-link PR")
+	}
+	result := scanner.ScanPR(crypto, nil)
+	if !result.IsSpam {
+		t.Errorf("Expected crypto-link PR to be spam, reasons: %v", result.Reasons)
+	}
+}
+
+func TestIsGeneratedFileOnly(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.Filters.GeneratedFilePatterns = config.DefaultGeneratedFilePatterns
+	scanner := NewScanner(cfg)
+
+	tests := []struct {
+		name     string
+		pr       *github.PullRequest
+		expected bool
+	}{
+		{
+			name: "Pure lockfile PR",
+			pr: &github.PullRequest{
+				FilesCount: 2,
+				Files:      []string{"package-lock.json", "web/yarn.lock"},
+			},
+			expected: true,
+		},
+		{
+			name: "Minified asset",
+			pr: &github.PullRequest{
+				FilesCount: 1,
+				Files:      []string{"static/js/app.min.js"},
+			},
+			expected: true,
+		},
+		{
+			name: "Mixed PR",
+			pr: &github.PullRequest{
+				FilesCount: 2,
+				Files:      []string{"go.sum", "main.go"},
+			},
+			expected: false,
+		},
+		{
+			name: "Empty file list",
+			pr: &github.PullRequest{
+				FilesCount: 0,
+				Files:      []string{},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := scanner.isGeneratedFileOnly(tt.pr)
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestScanPR_GeneratedFileOnlyIsUncertain(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.Filters.GeneratedFilePatterns = config.DefaultGeneratedFilePatterns
+	scanner := NewScanner(cfg)
+
+	pr := &github.PullRequest{
+		Number:     1,
+		Title:      "Bump lockfile",
+		Author:     "farmer",
+		FilesCount: 2,
+		Files:      []string{"package-lock.json", "go.sum"},
+		Additions:  40,
+	}
+	user := &github.User{Login: "farmer", CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+
+	result := scanner.ScanPR(pr, user)
+	if result.IsSpam {
+		t.Errorf("Generated-file-only PR should not be spam by itself: %v", result.Reasons)
+	}
+	if !result.IsUncertain {
+		t.Errorf("Expected generated-file-only PR to be uncertain: %v", result.Reasons)
+	}
+}
+
+func TestScanPR_ReadmeMinLines(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.Filters.ReadmeMinLines = 50
+	scanner := NewScanner(cfg)
+	user := &github.User{Login: "newcomer", CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+
+	tweak := &github.PullRequest{
+		Number:     1,
+		Title:      "Update README",
+		Author:     "newcomer",
+		FilesCount: 1,
+		Files:      []string{"README.md"},
+		Additions:  2,
+	}
+	result := scanner.ScanPR(tweak, user)
+	if !result.IsSpam || result.Severity != "high" {
+		t.Errorf("Expected a 2-line README tweak to be high severity spam, got spam=%v severity=%s", result.IsSpam, result.Severity)
+	}
+
+	rewrite := &github.PullRequest{
+		Number:     2,
+		Title:      "Rewrite README with setup guide",
+		Author:     "newcomer",
+		FilesCount: 1,
+		Files:      []string{"README.md"},
+		Additions:  200,
+		Deletions:  40,
+	}
+	result = scanner.ScanPR(rewrite, user)
+	if result.IsSpam {
+		t.Errorf("Expected a 200-line README rewrite not to be definite spam: %v", result.Reasons)
+	}
+	if !result.IsUncertain || !slices.Contains(result.Signals, SignalReadmeOnly) {
+		t.Errorf("Expected a 200-line README rewrite to be marked for review, got %v", result.Reasons)
+	}
+	if !slices.Contains(result.Reasons, "Single-file README-only edit (200 lines added)") {
+		t.Errorf("Expected reason to mention the lines added, got %v", result.Reasons)
+	}
+
+	// Without a threshold every README-only edit is spam
+	if result := NewScanner(getTestConfig()).ScanPR(rewrite, user); !result.IsSpam {
+		t.Error("Expected README rewrite to be spam when readme_min_lines is unset")
+	}
+}
+
+func TestIsLowReputation(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.Filters.MinFollowers = 1
+	cfg.Filters.MinPublicRepos = 1
+	scanner := NewScanner(cfg)
+
+	tests := []struct {
+		name     string
+		user     *github.User
+		expected bool
+	}{
+		{"zero followers and repos", &github.User{Login: "throwaway"}, true},
+		{"has followers", &github.User{Login: "social", Followers: 5}, false},
+		{"has public repos", &github.User{Login: "builder", PublicRepos: 3}, false},
+		{"established", &github.User{Login: "veteran", Followers: 120, PublicRepos: 45}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scanner.isLowReputation(tt.user); got != tt.expected {
+				t.Errorf("isLowReputation() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+
+	// Thresholds of zero disable the check
+	if NewScanner(getTestConfig()).isLowReputation(&github.User{Login: "throwaway"}) {
+		t.Error("isLowReputation should be disabled without thresholds")
+	}
+}
+
+func TestScanPR_LowReputation(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.Filters.MinFollowers = 1
+	cfg.Filters.MinPublicRepos = 1
+	scanner := NewScanner(cfg)
+
+	substantialPR := &github.PullRequest{
+		Number:     1,
+		Title:      "Add caching layer",
+		Author:     "someone",
+		FilesCount: 4,
+		Files:      []string{"cache.go", "cache_test.go", "server.go", "README.md"},
+		Additions:  120,
+		Deletions:  10,
+	}
+	oldAccount := time.Now().Add(-365 * 24 * time.Hour)
+
+	// Low reputation alone is only uncertain
+	result := scanner.ScanPR(substantialPR, &github.User{Login: "someone", CreatedAt: oldAccount})
+	if result.IsSpam {
+		t.Errorf("Low reputation alone should not be spam: %v", result.Reasons)
+	}
+	if !result.IsUncertain {
+		t.Errorf("Low reputation alone should be uncertain: %v", result.Reasons)
+	}
+
+	// An established account with many repos is clean
+	result = scanner.ScanPR(substantialPR, &github.User{Login: "someone", CreatedAt: oldAccount, Followers: 80, PublicRepos: 30})
+	if result.IsSpam || result.IsUncertain {
+		t.Errorf("Established account should be clean: %v", result.Reasons)
+	}
+
+	// A zero-follower new account pushes toward spam
+	newAccount := &github.User{Login: "someone", CreatedAt: time.Now().Add(-24 * time.Hour)}
+	result = scanner.ScanPR(substantialPR, newAccount)
+	if !result.IsSpam {
+		t.Errorf("New low-reputation account should be spam: %v", result.Reasons)
+	}
+	if result.Severity != "medium" {
+		t.Errorf("Expected medium severity, got %s", result.Severity)
+	}
+}
+
+func TestIsNoOpChange(t *testing.T) {
+	scanner := NewScanner(getTestConfig())
+
+	tests := []struct {
+		name      string
+		additions int
+		deletions int
+		expected  bool
+	}{
+		{"empty diff", 0, 0, true},
+		{"3 added, 3 deleted", 3, 3, true},
+		{"equal but large", 40, 40, false},
+		{"net addition", 5, 2, false},
+		{"pure deletion", 0, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &github.PullRequest{Additions: tt.additions, Deletions: tt.deletions}
+			if got := scanner.isNoOpChange(pr); got != tt.expected {
+				t.Errorf("isNoOpChange() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScanPR_NoOpChangeIsUncertain(t *testing.T) {
+	scanner := NewScanner(getTestConfig())
+	user := &github.User{Login: "someone", CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+
+	for _, pr := range []*github.PullRequest{
+		{Number: 1, Title: "Fix formatting", Author: "someone", FilesCount: 3, Files: []string{"a.go", "b.go", "c.go"}, Additions: 3, Deletions: 3},
+		{Number: 2, Title: "Empty change", Author: "someone", FilesCount: 3, Files: []string{"a.go", "b.go", "c.go"}},
+	} {
+		result := scanner.ScanPR(pr, user)
+		if result.IsSpam {
+			t.Errorf("PR #%d: no-op change should not be spam by itself: %v", pr.Number, result.Reasons)
+		}
+		if !result.IsUncertain {
+			t.Errorf("PR #%d: expected no-op change to be uncertain", pr.Number)
+		}
+		found := false
+		for _, reason := range result.Reasons {
+			if reason == "No net content change" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("PR #%d: expected no-op reason, got %v", pr.Number, result.Reasons)
+		}
+	}
+}
+
+func TestIsSensitiveFileEdit(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.Filters.SensitiveFiles = config.DefaultSensitiveFiles
+	scanner := NewScanner(cfg)
+
+	tests := []struct {
+		name     string
+		files    []string
+		expected bool
+	}{
+		{"License only", []string{"LICENSE"}, true},
+		{"License with extension, different case", []string{"License.md"}, true},
+		{"CODEOWNERS in .github", []string{"src/main.go", ".github/CODEOWNERS"}, true},
+		{"Security policy", []string{"SECURITY.md"}, true},
+		{"Ordinary files", []string{"README.md", "docs/licensing.md"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &github.PullRequest{FilesCount: len(tt.files), Files: tt.files}
+			if got := scanner.isSensitiveFileEdit(pr); got != tt.expected {
+				t.Errorf("isSensitiveFileEdit(%v) = %v, want %v", tt.files, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScanPR_SensitiveFileEdit(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.Filters.SensitiveFiles = config.DefaultSensitiveFiles
+	scanner := NewScanner(cfg)
+
+	newUser := &github.User{Login: "newbie", CreatedAt: time.Now().Add(-24 * time.Hour)}
+	oldUser := &github.User{Login: "veteran", CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+
+	licenseOnly := &github.PullRequest{
+		Number:     1,
+		Title:      "Update license",
+		Author:     "newbie",
+		FilesCount: 1,
+		Files:      []string{"LICENSE"},
+		Additions:  30,
+		Deletions:  200,
+	}
+
+	result := scanner.ScanPR(licenseOnly, newUser)
+	if !result.IsSpam || result.Severity != "high" {
+		t.Errorf("LICENSE edit from a new account should be high-severity spam, got spam=%v severity=%s", result.IsSpam, result.Severity)
+	}
+	if !slices.Contains(result.Reasons, "Modifies sensitive files: LICENSE") {
+		t.Errorf("expected reason naming LICENSE, got %v", result.Reasons)
+	}
+
+	licenseOnly.Author = "veteran"
+	result = scanner.ScanPR(licenseOnly, oldUser)
+	if result.IsSpam || !result.IsUncertain {
+		t.Errorf("LICENSE edit from an established account should need review, got spam=%v uncertain=%v", result.IsSpam, result.IsUncertain)
+	}
+
+	mixed := &github.PullRequest{
+		Number:     2,
+		Title:      "Refactor handlers",
+		Author:     "veteran",
+		FilesCount: 3,
+		Files:      []string{"internal/handler.go", "internal/handler_test.go", ".github/CODEOWNERS"},
+		Additions:  120,
+		Deletions:  40,
+	}
+
+	result = scanner.ScanPR(mixed, oldUser)
+	if !result.IsUncertain {
+		t.Error("PR touching CODEOWNERS should be flagged for review")
+	}
+	if !slices.Contains(result.Reasons, "Modifies sensitive files: .github/CODEOWNERS") {
+		t.Errorf("expected reason naming CODEOWNERS, got %v", result.Reasons)
+	}
+}
+
+func TestScanPR_Signals(t *testing.T) {
+	scanner := NewScanner(getTestConfig())
+	newUser := &github.User{Login: "newbie", CreatedAt: time.Now().Add(-2 * 24 * time.Hour)}
+	oldUser := &github.User{Login: "veteran", CreatedAt: time.Now().Add(-365 * 24 * time.Hour), Followers: 50, PublicRepos: 20}
+
+	tests := []struct {
+		name    string
+		pr      *github.PullRequest
+		user    *github.User
+		signals []string
+	}{
+		{
+			name:    "README edit by new account",
+			pr:      &github.PullRequest{Author: "newbie", FilesCount: 1, Files: []string{"README.md"}, Additions: 5},
+			user:    newUser,
+			signals: []string{SignalReadmeOnly, SignalNewAccount, SignalMinimalChanges},
+		},
+		{
+			name:    "Spam phrase from established account",
+			pr:      &github.PullRequest{Title: "Please click here", Author: "veteran", FilesCount: 3, Files: []string{"a.go", "b.go", "c.go"}, Additions: 40},
+			user:    oldUser,
+			signals: []string{SignalSpamPhrase},
+		},
+		{
+			name:    "Clean PR",
+			pr:      &github.PullRequest{Title: "Add feature", Author: "veteran", FilesCount: 3, Files: []string{"a.go", "b.go", "c.go"}, Additions: 40},
+			user:    oldUser,
+			signals: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := scanner.ScanPR(tt.pr, tt.user)
+			if !slices.Equal(result.Signals, tt.signals) {
+				t.Errorf("Expected signals %v, got %v", tt.signals, result.Signals)
+			}
+			if len(result.Signals) != len(result.Reasons) {
+				t.Errorf("Expected one signal per reason, got %d signals and %d reasons", len(result.Signals), len(result.Reasons))
+			}
+		})
+	}
+}
+
+func TestScanPR_NonDefaultBase(t *testing.T) {
+	oldUser := &github.User{Login: "veteran", CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+	pr := func(base string) *github.PullRequest {
+		return &github.PullRequest{Author: "veteran", FilesCount: 3, Files: []string{"a.go", "b.go", "c.go"}, Additions: 40, BaseRef: base, DefaultBranch: "main"}
+	}
+
+	cfg := getTestConfig()
+	if result := NewScanner(cfg).ScanPR(pr("gh-pages"), oldUser); result.IsUncertain {
+		t.Error("Non-default base should not be flagged unless enabled")
+	}
+
+	cfg.Filters.FlagNonDefaultBase = true
+	scanner := NewScanner(cfg)
+
+	result := scanner.ScanPR(pr("gh-pages"), oldUser)
+	if !result.IsUncertain || result.IsSpam {
+		t.Errorf("Expected uncertain result, got spam=%v uncertain=%v", result.IsSpam, result.IsUncertain)
+	}
+	if !slices.Contains(result.Signals, SignalNonDefaultBase) || !slices.Contains(result.Reasons, "Targets non-default branch: gh-pages") {
+		t.Errorf("Expected non-default base signal, got %v %v", result.Signals, result.Reasons)
+	}
+
+	if result := scanner.ScanPR(pr("main"), oldUser); result.IsUncertain {
+		t.Errorf("PR against the default branch should be clean, got %v", result.Reasons)
+	}
+}
+
+func TestIsSuspiciousEncoding(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.Filters.SuspiciousEncoding = true
+	scanner := NewScanner(cfg)
+
+	promo := "Лучшие онлайн казино с бонусами для новых игроков! Регистрируйтесь прямо сейчас и получайте фриспины: https://casino.example/ru"
+	translation := "Перевод документации на русский язык. Исходный текст: https://example.com/docs/en"
+
+	tests := []struct {
+		name string
+		pr   *github.PullRequest
+		want bool
+	}{
+		{
+			name: "promotional Cyrillic with link",
+			pr:   &github.PullRequest{Body: promo, FilesCount: 1, Additions: 1},
+			want: true,
+		},
+		{
+			name: "genuine translation with a real change",
+			pr:   &github.PullRequest{Body: translation, FilesCount: 4, Files: []string{"docs/ru/index.md"}, Additions: 320, Deletions: 2},
+			want: false,
+		},
+		{
+			name: "CJK description of a code change",
+			pr:   &github.PullRequest{Body: "修复了解析器在处理空输入时崩溃的问题，并添加了测试用例。参考 https://example.com/issues/42", FilesCount: 2, Additions: 45, Deletions: 3},
+			want: false,
+		},
+		{
+			name: "Cyrillic without links",
+			pr:   &github.PullRequest{Body: "Исправлена опечатка в описании функции обработки запросов", FilesCount: 1, Additions: 1, Deletions: 1},
+			want: false,
+		},
+		{
+			name: "English with link",
+			pr:   &github.PullRequest{Body: "Fixes a typo, see https://example.com/style-guide for details on wording", FilesCount: 1, Additions: 1, Deletions: 1},
+			want: false,
+		},
+		{
+			name: "too little text to judge",
+			pr:   &github.PullRequest{Body: "см. https://example.com", FilesCount: 1, Additions: 1},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scanner.isSuspiciousEncoding(tt.pr); got != tt.want {
+				t.Errorf("isSuspiciousEncoding() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsLowEffortDescription(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.Filters.MinBodyLength = 20
+	scanner := NewScanner(cfg)
+
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{name: "empty body", body: "", want: true},
+		{name: "whitespace only", body: " \n\t ", want: true},
+		{name: "one word", body: "  fix  ", want: true},
+		{name: "detailed body", body: "Fixes a crash in the parser when the input is empty and adds a regression test.", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scanner.isLowEffortDescription(&github.PullRequest{Body: tt.body}); got != tt.want {
+				t.Errorf("isLowEffortDescription(%q) = %v, want %v", tt.body, got, tt.want)
+			}
+		})
+	}
+
+	if NewScanner(getTestConfig()).isLowEffortDescription(&github.PullRequest{}) {
+		t.Error("An empty body should not be flagged when min_body_length is 0")
+	}
+}
+
+func TestScanPR_LowEffortDescription(t *testing.T) {
+	oldUser := &github.User{Login: "veteran", CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+	pr := &github.PullRequest{
+		Author:     "veteran",
+		Body:       "update",
+		FilesCount: 3,
+		Files:      []string{"main.go", "parser.go", "parser_test.go"},
+		Additions:  40,
+	}
+
+	cfg := getTestConfig()
+	cfg.Filters.MinBodyLength = 20
+	result := NewScanner(cfg).ScanPR(pr, oldUser)
+	if !result.IsUncertain || result.IsSpam {
+		t.Errorf("Expected uncertain result, got spam=%v uncertain=%v", result.IsSpam, result.IsUncertain)
+	}
+	if !slices.Contains(result.Signals, SignalLowEffortBody) {
+		t.Errorf("Expected low effort description signal, got %v", result.Signals)
+	}
+}
+
+func TestScanPR_SuspiciousEncoding(t *testing.T) {
+	oldUser := &github.User{Login: "veteran", CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+	pr := &github.PullRequest{
+		Author:     "veteran",
+		Body:       "Лучшие онлайн казино с бонусами для новых игроков! Регистрируйтесь прямо сейчас: https://casino.example/ru",
+		FilesCount: 1,
+		Files:      []string{"docs/guide.md"},
+		Additions:  2,
+	}
+
+	cfg := getTestConfig()
+	if result := NewScanner(cfg).ScanPR(pr, oldUser); slices.Contains(result.Signals, SignalSuspiciousEncoding) {
+		t.Error("Suspicious encoding should not be flagged unless enabled")
+	}
+
+	cfg.Filters.SuspiciousEncoding = true
+	result := NewScanner(cfg).ScanPR(pr, oldUser)
+	if !result.IsUncertain {
+		t.Errorf("Expected uncertain result, got spam=%v uncertain=%v", result.IsSpam, result.IsUncertain)
+	}
+	if !slices.Contains(result.Signals, SignalSuspiciousEncoding) {
+		t.Errorf("Expected suspicious encoding signal, got %v", result.Signals)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Action log action types
+const (
+	ActionBlock   = "block"
+	ActionClosePR = "close_pr"
+)
+
+// ActionLogEntry records one automated action so its batch can be undone
+type ActionLogEntry struct {
+	ID         string    `json:"id" db:"id"`                 // UUID
+	BatchID    string    `json:"batch_id" db:"batch_id"`     // Groups the actions taken by one run
+	Action     string    `json:"action" db:"action"`         // block/close_pr
+	EntryID    string    `json:"entry_id" db:"entry_id"`     // Blocklist entry added (block only)
+	Username   string    `json:"username" db:"username"`     // Blocked user (block only)
+	Repository string    `json:"repository" db:"repository"` // owner/repo (close_pr only)
+	PRNumber   int       `json:"pr_number" db:"pr_number"`   // Closed PR (close_pr only)
+	CreatedAt  time.Time `json:"created_at" db:"created_at"` // When the action was taken
+}
+
+// NewBlockAction records that entry was added to the blocklist in batchID
+func NewBlockAction(batchID string, entry *BlocklistEntry) *ActionLogEntry {
+	return &ActionLogEntry{
+		ID:        uuid.New().String(),
+		BatchID:   batchID,
+		Action:    ActionBlock,
+		EntryID:   entry.ID,
+		Username:  entry.Username,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewClosePRAction records that a pull request was closed in batchID
+func NewClosePRAction(batchID, repository string, number int) *ActionLogEntry {
+	return &ActionLogEntry{
+		ID:         uuid.New().String(),
+		BatchID:    batchID,
+		Action:     ActionClosePR,
+		Repository: repository,
+		PRNumber:   number,
+		CreatedAt:  time.Now(),
+	}
+}
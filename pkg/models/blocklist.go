@@ -16,6 +16,8 @@
 package models
 
 import (
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,15 +25,50 @@ import (
 
 // BlocklistEntry represents a blocked user in the database
 type BlocklistEntry struct {
-	ID          string    `json:"id" db:"id"`                     // UUID
-	Username    string    `json:"username" db:"username"`         // GitHub username
-	Reason      string    `json:"reason" db:"reason"`             // Reason for blocking
-	EvidenceURL string    `json:"evidence_url" db:"evidence_url"` // Link to problematic PR/issue
-	Timestamp   time.Time `json:"timestamp" db:"timestamp"`       // When entry was created
-	BlockedBy   string    `json:"blocked_by" db:"blocked_by"`     // Maintainer who added entry
-	Severity    string    `json:"severity" db:"severity"`         // low/medium/high
-	Source      string    `json:"source" db:"source"`             // manual/imported/auto-detected
-	Metadata    string    `json:"metadata" db:"metadata"`         // JSON field for extensibility
+	ID          string     `json:"id" yaml:"id" db:"id"`                                             // UUID
+	Username    string     `json:"username" yaml:"username" db:"username"`                           // GitHub username
+	Reason      string     `json:"reason" yaml:"reason" db:"reason"`                                 // Reason for blocking
+	EvidenceURL string     `json:"evidence_url" yaml:"evidence_url" db:"evidence_url"`               // Link to problematic PR/issue
+	Timestamp   time.Time  `json:"timestamp" yaml:"timestamp" db:"timestamp"`                        // When entry was created
+	BlockedBy   string     `json:"blocked_by" yaml:"blocked_by" db:"blocked_by"`                     // Maintainer who added entry
+	Severity    string     `json:"severity" yaml:"severity" db:"severity"`                           // low/medium/high
+	Source      string     `json:"source" yaml:"source" db:"source"`                                 // manual/imported/auto-detected
+	Metadata    string     `json:"metadata" yaml:"metadata" db:"metadata"`                           // JSON field for extensibility
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" yaml:"expires_at,omitempty" db:"expires_at"` // Optional expiry for temporary blocks
+	Tags        []string   `json:"tags,omitempty" yaml:"tags,omitempty" db:"tags"`                   // Reason codes such as crypto-spam
+}
+
+// EntryMetadata is the structured content stored in BlocklistEntry.Metadata
+type EntryMetadata struct {
+	Signals []string         `json:"signals,omitempty"` // Scanner heuristic codes that led to an auto-block
+	Account *AccountSnapshot `json:"account,omitempty"` // The blocked account as it looked at block time
+
+	GitHubBlockedAt *time.Time `json:"github_blocked_at,omitempty"` // When the user was blocked via the GitHub API
+}
+
+// AccountSnapshot records a GitHub account's public profile when it was blocked
+type AccountSnapshot struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Followers   int       `json:"followers"`
+	PublicRepos int       `json:"public_repos"`
+	CapturedAt  time.Time `json:"captured_at"`
+}
+
+// ParseMetadata decodes the entry's metadata; empty or "{}" metadata yields a zero value
+func (e *BlocklistEntry) ParseMetadata() (*EntryMetadata, error) {
+	var metadata EntryMetadata
+	if e.Metadata == "" {
+		return &metadata, nil
+	}
+	if err := json.Unmarshal([]byte(e.Metadata), &metadata); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+// IsExpired reports whether the entry has an expiry that has passed
+func (e *BlocklistEntry) IsExpired() bool {
+	return e.ExpiresAt != nil && !e.ExpiresAt.After(time.Now())
 }
 
 // NewBlocklistEntry creates a new blocklist entry with a generated UUID
@@ -49,6 +86,21 @@ func NewBlocklistEntry(username, reason, evidenceURL, blockedBy, severity, sourc
 	}
 }
 
+// NormalizeTags lowercases and trims tags, dropping empty and duplicate values
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
 // Severity constants
 const (
 	SeverityLow    = "low"
@@ -56,6 +108,30 @@ const (
 	SeverityHigh   = "high"
 )
 
+// SeverityRank orders severities from low (1) to high (3); unknown values rank 0
+func SeverityRank(severity string) int {
+	switch severity {
+	case SeverityLow:
+		return 1
+	case SeverityMedium:
+		return 2
+	case SeverityHigh:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// EntryFilter narrows blocklist queries. Zero values disable each condition.
+type EntryFilter struct {
+	MinSeverity string    // Only entries at or above this severity
+	Tag         string    // Only entries carrying this normalized tag
+	Since       time.Time // Only entries created at or after this time
+	Until       time.Time // Only entries created before this time
+	Limit       int       // At most this many entries; 0 means no limit
+	Offset      int       // Number of matching entries to skip
+}
+
 // Source constants
 const (
 	SourceManual       = "manual"
@@ -0,0 +1,47 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ValidateEvidenceURL checks that raw links to a GitHub pull request or issue,
+// e.g. https://github.com/owner/repo/pull/123 or https://github.com/owner/repo/issues/45.
+// Trailing path segments such as /files, query strings and fragments are allowed.
+func ValidateEvidenceURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid evidence URL %q: %w", raw, err)
+	}
+
+	if u.Scheme != "https" || (u.Host != "github.com" && u.Host != "www.github.com") {
+		return fmt.Errorf("invalid evidence URL %q: must be an https://github.com link", raw)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] == "" || parts[1] == "" || (parts[2] != "pull" && parts[2] != "issues") {
+		return fmt.Errorf("invalid evidence URL %q: expected https://github.com/<owner>/<repo>/pull/<number> or /issues/<number>", raw)
+	}
+
+	if n, err := strconv.Atoi(parts[3]); err != nil || n <= 0 {
+		return fmt.Errorf("invalid evidence URL %q: %q is not a pull request or issue number", raw, parts[3])
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import "testing"
+
+func TestValidateEvidenceURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{"pull request", "https://github.com/owner/repo/pull/123", false},
+		{"pull request files tab", "https://github.com/owner/repo/pull/123/files", false},
+		{"pull request comment anchor", "https://github.com/owner/repo/pull/7#issuecomment-1", false},
+		{"issue", "https://github.com/owner/repo/issues/45", false},
+		{"www host", "https://www.github.com/owner/repo/issues/45", false},
+		{"empty", "", true},
+		{"not a url", "see slack", true},
+		{"http scheme", "http://github.com/owner/repo/pull/1", true},
+		{"other host", "https://example.com/owner/repo/pull/1", true},
+		{"profile link", "https://github.com/spammer", true},
+		{"repository link", "https://github.com/owner/repo", true},
+		{"commit link", "https://github.com/owner/repo/commit/abc123", true},
+		{"missing number", "https://github.com/owner/repo/pull/", true},
+		{"non-numeric number", "https://github.com/owner/repo/issues/new", true},
+		{"zero number", "https://github.com/owner/repo/pull/0", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEvidenceURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEvidenceURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScanHistoryEntry records the outcome of a single repository scan
+type ScanHistoryEntry struct {
+	ID             string    `json:"id" db:"id"`                           // UUID
+	Repository     string    `json:"repository" db:"repository"`           // owner/repo
+	ScannedAt      time.Time `json:"scanned_at" db:"scanned_at"`           // When the scan ran
+	TotalPRs       int       `json:"total_prs" db:"total_prs"`             // Open PRs scanned
+	SpamCount      int       `json:"spam_count" db:"spam_count"`           // PRs detected as spam
+	UncertainCount int       `json:"uncertain_count" db:"uncertain_count"` // PRs needing review
+	CleanCount     int       `json:"clean_count" db:"clean_count"`         // PRs with no findings
+}
+
+// NewScanHistoryEntry creates a new scan history entry with a generated UUID
+func NewScanHistoryEntry(repository string, total, spam, uncertain, clean int) *ScanHistoryEntry {
+	return &ScanHistoryEntry{
+		ID:             uuid.New().String(),
+		Repository:     repository,
+		ScannedAt:      time.Now(),
+		TotalPRs:       total,
+		SpamCount:      spam,
+		UncertainCount: uncertain,
+		CleanCount:     clean,
+	}
+}
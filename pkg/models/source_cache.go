@@ -0,0 +1,26 @@
+// Copyright 2025 Logan Lindquist Land
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import "time"
+
+// SourceCache holds the HTTP validators from the last successful import of a
+// remote blocklist, used to make the next fetch conditional
+type SourceCache struct {
+	URL          string    `json:"url" db:"url"`                     // Remote blocklist URL
+	ETag         string    `json:"etag" db:"etag"`                   // ETag response header
+	LastModified string    `json:"last_modified" db:"last_modified"` // Last-Modified response header
+	FetchedAt    time.Time `json:"fetched_at" db:"fetched_at"`       // When the list was last downloaded
+}